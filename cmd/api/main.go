@@ -2,53 +2,203 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/stevenmed26/AutoFarm/internal/api"
+	"github.com/stevenmed26/AutoFarm/internal/audit"
+	"github.com/stevenmed26/AutoFarm/internal/gatewayauth"
+	"github.com/stevenmed26/AutoFarm/internal/logging"
 	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/store"
+	"github.com/stevenmed26/AutoFarm/internal/tracing"
 )
 
 func main() {
 	httpAddr := getEnv("API_HTTP_ADDR", ":8080")
 	orchestratorAddr := getEnv("ORCHESTRATOR_GRPC_ADDR", "localhost:50051")
 
-	// Set up gRPC client to orchestrator.
+	if _, err := logging.Setup("api"); err != nil {
+		log.Fatal(err)
+	}
+	shutdownTracing, err := tracing.Setup(context.Background(), "api")
+	if err != nil {
+		logging.Fatal("failed to set up tracing", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	// Set up gRPC client to orchestrator. The shared token makes the
+	// orchestrator trust the caller each call names.
+	token := os.Getenv(gatewayauth.TokenEnv)
+	if token == "" {
+		slog.Warn("the orchestrator will not trust the callers the gateway names; set " + gatewayauth.TokenEnv)
+	}
 	conn, err := grpc.Dial(
 		orchestratorAddr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(gatewayauth.Credentials(token)),
 		grpc.WithBlock(),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		// Every call acts for the request's caller.
+		grpc.WithChainUnaryInterceptor(api.CallerUnaryInterceptor),
+		grpc.WithChainStreamInterceptor(api.CallerStreamInterceptor),
 	)
 	if err != nil {
-		log.Fatalf("failed to connect to orchestrator at %s: %v", orchestratorAddr, err)
+		logging.Fatal("failed to connect to orchestrator", "addr", orchestratorAddr, "error", err)
 	}
 	defer conn.Close()
 
 	simClient := simulationpb.NewSimulationServiceClient(conn)
 
+	auditLog, err := audit.Open("api", os.Getenv("AUDIT_LOG_FILE"))
+	if err != nil {
+		logging.Fatal("failed to open audit log", "error", err)
+	}
+
+	opts := []api.Option{
+		api.WithAllowedOrigins(splitList(os.Getenv("WS_ALLOWED_ORIGINS"))),
+		api.WithAuditLog(auditLog),
+		api.WithLimits(loadLimits()),
+	}
+	if auth, keys := setupAuth(); auth != nil {
+		opts = append(opts, api.WithAuth(auth, keys))
+	}
+
 	// Set up API server + WebSocket hub.
-	server := api.NewServer(simClient)
+	server := api.NewServer(simClient, opts...)
 
 	mux := http.NewServeMux()
 	server.RegisterRoutes(mux)
 
+	// Every request gets a span and an ID first, so its log line carries
+	// them.
+	handler := otelhttp.NewHandler(
+		api.Chain(mux, api.RequestIDMiddleware, api.LoggingMiddleware), "api",
+		otelhttp.WithSpanNameFormatter(httpSpanName),
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return r.URL.Path != "/healthz" && r.URL.Path != "/metrics"
+		}),
+	)
+
 	srv := &http.Server{
 		Addr:         httpAddr,
-		Handler:      mux,
+		Handler:      handler,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
-	log.Printf("API server listening on %s (orchestrator: %s)", httpAddr, orchestratorAddr)
+	slog.Info("API server listening", "addr", httpAddr, "orchestrator", orchestratorAddr)
 	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("HTTP server failed: %v", err)
+		logging.Fatal("HTTP server failed", "error", err)
+	}
+}
+
+// setupAuth builds the authenticator from AUTH_KEYS_FILE, a static file of
+// API keys, and AUTH_JWKS_FILE, the HMAC keys bearer tokens are signed
+// with. Without either, authentication is disabled.
+func setupAuth() (*api.Authenticator, store.KeyStore) {
+	keysFile := os.Getenv("AUTH_KEYS_FILE")
+	jwksFile := os.Getenv("AUTH_JWKS_FILE")
+	if keysFile == "" && jwksFile == "" {
+		slog.Warn("authentication is disabled; set AUTH_KEYS_FILE or AUTH_JWKS_FILE to require it")
+		return nil, nil
+	}
+
+	keys := store.NewMemoryStore()
+	if keysFile != "" {
+		n, err := api.LoadKeyFile(context.Background(), keysFile, keys)
+		if err != nil {
+			logging.Fatal("failed to load API keys", "error", err)
+		}
+		slog.Info("loaded API keys", "keys", n, "path", keysFile)
 	}
+
+	cfg := api.AuthConfig{
+		Keys:     keys,
+		Issuer:   os.Getenv("AUTH_JWT_ISSUER"),
+		Audience: os.Getenv("AUTH_JWT_AUDIENCE"),
+	}
+	if jwksFile != "" {
+		jwks, err := api.LoadJWKS(jwksFile)
+		if err != nil {
+			logging.Fatal("failed to load JWKS", "error", err)
+		}
+		cfg.JWKS = jwks
+	}
+	return api.NewAuthenticator(cfg), keys
+}
+
+// loadLimits reads the per-client limits from the environment, starting
+// from api.DefaultLimits. Zero disables a limit.
+func loadLimits() api.Limits {
+	l := api.DefaultLimits
+	l.ReadRate = getEnvFloat("RATE_LIMIT_READ_RPS", l.ReadRate)
+	l.ReadBurst = getEnvInt("RATE_LIMIT_READ_BURST", l.ReadBurst)
+	l.WriteRate = getEnvFloat("RATE_LIMIT_WRITE_RPS", l.WriteRate)
+	l.WriteBurst = getEnvInt("RATE_LIMIT_WRITE_BURST", l.WriteBurst)
+	l.AuthFailureRate = getEnvFloat("RATE_LIMIT_AUTH_FAILURE_RPS", l.AuthFailureRate)
+	l.AuthFailureBurst = getEnvInt("RATE_LIMIT_AUTH_FAILURE_BURST", l.AuthFailureBurst)
+	l.MaxWebSockets = getEnvInt("WS_MAX_CONNECTIONS_PER_CLIENT", l.MaxWebSockets)
+	l.MaxBodyBytes = int64(getEnvInt("API_MAX_BODY_BYTES", int(l.MaxBodyBytes)))
+	slog.Info("limits per client",
+		"read_rps", l.ReadRate, "read_burst", l.ReadBurst,
+		"write_rps", l.WriteRate, "write_burst", l.WriteBurst,
+		"auth_failure_rps", l.AuthFailureRate, "auth_failure_burst", l.AuthFailureBurst,
+		"websockets", l.MaxWebSockets, "max_body_bytes", l.MaxBodyBytes)
+	return l
+}
+
+func getEnvFloat(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		logging.Fatal(key+" must be a number >= 0", "value", v)
+	}
+	return f
+}
+
+func getEnvInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		logging.Fatal(key+" must be an integer >= 0", "value", v)
+	}
+	return n
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// httpSpanName names a request's span by its method and the first segment
+// of its path, e.g. "POST /simulations", so IDs stay out of span names.
+func httpSpanName(_ string, r *http.Request) string {
+	first, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	return r.Method + " /" + first
 }
 
 func getEnv(key, def string) string {
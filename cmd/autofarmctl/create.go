@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+var taskAllocations = map[string]simulationpb.TaskAllocationStrategy{
+	"":               simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_UNSPECIFIED,
+	"greedy_nearest": simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST,
+	"auction":        simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_AUCTION,
+	"hungarian":      simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_HUNGARIAN,
+}
+
+// create creates a simulation. The config comes from flags, or from a
+// scenario file (-f), a library scenario (-scenario) or a template
+// (-template); with one of those, -name, -entities and -seed override it.
+func (c *cli) create(args []string) error {
+	fs := newFlags("create", "[-f file | -scenario name | -template name] [flags]")
+	file := fs.String("f", "", "scenario document to create from (YAML or JSON; - reads stdin)")
+	scenarioName := fs.String("scenario", "", "library scenario to create from")
+	template := fs.String("template", "", "template to create from")
+	name := fs.String("name", "", "simulation name")
+	entities := fs.Uint("entities", 0, "number of entities")
+	tickRate := fs.Uint("tick-rate-ms", 50, "tick interval in milliseconds")
+	scenarioType := fs.String("scenario-type", "", "scenario type label")
+	seed := fs.Uint64("seed", 0, "world and weather seed (0 picks one)")
+	allocation := fs.String("task-allocation", "", "greedy_nearest, auction or hungarian")
+	maxTicks := fs.Uint64("max-ticks", 0, "complete after this many ticks")
+	maxSimTime := fs.Duration("max-sim-time", 0, "complete after this much simulated time")
+	start := fs.Bool("start", false, "start the simulation once created")
+	quiet := fs.Bool("q", false, "print only the simulation id")
+	if err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	req := &simulationpb.CreateSimulationRequest{
+		ScenarioName: *scenarioName,
+		TemplateName: *template,
+	}
+	if *file != "" {
+		doc, err := readFile(*file)
+		if err != nil {
+			return err
+		}
+		req.ScenarioDocument = doc
+	}
+
+	if *file == "" && *scenarioName == "" && *template == "" {
+		a, ok := taskAllocations[*allocation]
+		if !ok {
+			return fmt.Errorf("unknown task allocation %q (want greedy_nearest, auction or hungarian)", *allocation)
+		}
+		if *name == "" || *entities == 0 {
+			return errors.New("-name and -entities are required without -f, -scenario or -template")
+		}
+		req.Config = &simulationpb.SimulationConfig{
+			Name:           *name,
+			EntityCount:    uint32(*entities),
+			TickRateMs:     uint32(*tickRate),
+			ScenarioType:   *scenarioType,
+			Seed:           *seed,
+			TaskAllocation: a,
+		}
+		if *maxTicks > 0 || *maxSimTime > 0 {
+			req.Config.Termination = &simulationpb.Termination{
+				MaxTicks:     *maxTicks,
+				MaxSimTimeMs: uint64(maxSimTime.Milliseconds()),
+			}
+		}
+	} else {
+		// The source brings its own config; only the basics can be
+		// overridden from here.
+		set := map[string]bool{}
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		for _, f := range []string{"tick-rate-ms", "scenario-type", "task-allocation", "max-ticks", "max-sim-time"} {
+			if set[f] {
+				return fmt.Errorf("-%s cannot be combined with -f, -scenario or -template", f)
+			}
+		}
+		req.Overrides = &simulationpb.ConfigOverrides{
+			Name:        *name,
+			EntityCount: uint32(*entities),
+			Seed:        *seed,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.CreateSimulation(ctx, req)
+	if err != nil {
+		return fmt.Errorf("create simulation: %w", err)
+	}
+	sim := resp.GetSimulation()
+
+	if *start {
+		started, err := c.client.StartSimulation(ctx, &simulationpb.StartSimulationRequest{Id: sim.GetId()})
+		if err != nil {
+			return fmt.Errorf("start simulation %s: %w", sim.GetId().GetValue(), err)
+		}
+		sim = started.GetSimulation()
+	}
+
+	if *quiet {
+		_, err := fmt.Fprintln(c.stdout, sim.GetId().GetValue())
+		return err
+	}
+	return c.printJSON(sim)
+}
+
+// readFile reads name, or stdin for "-".
+func readFile(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(name)
+}
@@ -0,0 +1,284 @@
+// cmd/autofarmctl/main.go
+//
+// autofarmctl drives simulations over the orchestrator's gRPC API, for
+// scripts and CI pipelines.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stevenmed26/AutoFarm/internal/gatewayauth"
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/tenant"
+)
+
+const usage = `usage: autofarmctl [-addr host:port] [-tenant t] [-timeout d] <command> [flags] [args]
+
+Commands:
+  create [flags]          create a simulation from flags, a scenario file,
+                          a library scenario or a template
+  start <id>              start or resume a simulation
+  pause <id>              pause a running simulation
+  stop <id>               stop a simulation
+  delete <id>             stop a simulation if needed and delete it
+  get <id>                print a simulation
+  list [-status s]        list simulations
+  tail <id> [flags]       stream a simulation's ticks
+  export <id> -o file     record a run's ticks until it ends and write them
+                          to a file; the orchestrator keeps no tick history,
+                          so the run must not have ended yet
+
+Run "autofarmctl <command> -h" for the flags of a command.
+`
+
+// errUsage makes main exit with status 2 after the command printed its
+// usage.
+var errUsage = errors.New("usage")
+
+// cli holds what every command needs.
+type cli struct {
+	client  simulationpb.SimulationServiceClient
+	timeout time.Duration
+	stdout  io.Writer
+}
+
+func main() {
+	flags := flag.NewFlagSet("autofarmctl", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	addr := flags.String("addr", getEnv("ORCHESTRATOR_GRPC_ADDR", "localhost:50051"), "orchestrator gRPC address")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of each call, except streams")
+	tenantName := flags.String("tenant", getEnv("AUTOFARM_TENANT", tenant.Default), "tenant (project) to act for")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	if err := tenant.Validate(*tenantName); err != nil {
+		fmt.Fprintf(os.Stderr, "autofarmctl: %v\n", err)
+		os.Exit(2)
+	}
+	// With the orchestrator's token, calls are admin and may name a tenant;
+	// without it, they get the orchestrator's RBAC_DEFAULT_ROLE in the
+	// default tenant.
+	conn, err := grpc.Dial(*addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(gatewayauth.Credentials(os.Getenv(gatewayauth.TokenEnv))),
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any,
+			cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(tenant.NewOutgoingContext(ctx, *tenantName), method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+			method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(tenant.NewOutgoingContext(ctx, *tenantName), desc, cc, method, opts...)
+		}),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "autofarmctl: connect to %s: %v\n", *addr, err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	c := &cli{
+		client:  simulationpb.NewSimulationServiceClient(conn),
+		timeout: *timeout,
+		stdout:  os.Stdout,
+	}
+
+	if err := c.run(flags.Arg(0), flags.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "autofarmctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (c *cli) run(cmd string, args []string) error {
+	switch cmd {
+	case "create":
+		return c.create(args)
+	case "start", "pause", "stop", "delete", "get":
+		return c.lifecycle(cmd, args)
+	case "list":
+		return c.list(args)
+	case "tail":
+		return c.tail(args)
+	case "export":
+		return c.export(args)
+	case "help":
+		fmt.Fprint(c.stdout, usage)
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "autofarmctl: unknown command %q\n\n%s", cmd, usage)
+	return errUsage
+}
+
+// newFlags returns the flag set of a command; its errors come back from
+// parse as errUsage.
+func newFlags(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: autofarmctl %s %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses a command's flags, which may come before or after its
+// arguments, and checks it got exactly nargs arguments. The arguments are
+// left in fs.Args.
+func parse(fs *flag.FlagSet, args []string, nargs int) error {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return errUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != nargs {
+		fs.Usage()
+		return errUsage
+	}
+	// Parse the arguments alone so fs.Arg returns them.
+	return fs.Parse(append([]string{"--"}, positional...))
+}
+
+func (c *cli) lifecycle(cmd string, args []string) error {
+	fs := newFlags(cmd, "<id>")
+	if err := parse(fs, args, 1); err != nil {
+		return err
+	}
+	id := &commonpb.SimulationId{Value: fs.Arg(0)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var sim *simulationpb.Simulation
+	switch cmd {
+	case "start":
+		resp, err := c.client.StartSimulation(ctx, &simulationpb.StartSimulationRequest{Id: id})
+		if err != nil {
+			return fmt.Errorf("start simulation: %w", err)
+		}
+		sim = resp.GetSimulation()
+	case "pause":
+		resp, err := c.client.PauseSimulation(ctx, &simulationpb.PauseSimulationRequest{Id: id})
+		if err != nil {
+			return fmt.Errorf("pause simulation: %w", err)
+		}
+		sim = resp.GetSimulation()
+	case "stop":
+		resp, err := c.client.StopSimulation(ctx, &simulationpb.StopSimulationRequest{Id: id})
+		if err != nil {
+			return fmt.Errorf("stop simulation: %w", err)
+		}
+		sim = resp.GetSimulation()
+	case "delete":
+		if _, err := c.client.DeleteSimulation(ctx, &simulationpb.DeleteSimulationRequest{Id: id}); err != nil {
+			return fmt.Errorf("delete simulation: %w", err)
+		}
+		return nil
+	case "get":
+		resp, err := c.client.GetSimulation(ctx, &simulationpb.GetSimulationRequest{Id: id})
+		if err != nil {
+			return fmt.Errorf("get simulation: %w", err)
+		}
+		sim = resp.GetSimulation()
+	}
+	return c.printJSON(sim)
+}
+
+func (c *cli) list(args []string) error {
+	fs := newFlags("list", "[-status s] [-o table|json]")
+	status := fs.String("status", "", "only simulations in this status, e.g. running")
+	output := fs.String("o", "table", "output format: table or json")
+	if err := parse(fs, args, 0); err != nil {
+		return err
+	}
+	st, err := parseStatus(*status)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.ListSimulations(ctx, &simulationpb.ListSimulationsRequest{Status: st})
+	if err != nil {
+		return fmt.Errorf("list simulations: %w", err)
+	}
+
+	switch *output {
+	case "json":
+		return c.printJSON(resp)
+	case "table":
+		tw := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tSTATUS\tENTITIES\tCREATED\tEND_REASON")
+		for _, sim := range resp.GetSimulations() {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n",
+				sim.GetId().GetValue(),
+				sim.GetConfig().GetName(),
+				statusName(sim.GetStatus()),
+				sim.GetConfig().GetEntityCount(),
+				sim.GetCreatedAt().AsTime().Local().Format(time.DateTime),
+				sim.GetEndReason(),
+			)
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("unknown output format %q (want table or json)", *output)
+}
+
+// printJSON prints m as indented JSON with the proto field names.
+func (c *cli) printJSON(m proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, UseProtoNames: true}.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.stdout, "%s\n", data)
+	return err
+}
+
+// parseStatus reads a status as "running" or "SIMULATION_STATUS_RUNNING".
+func parseStatus(name string) (commonpb.SimulationStatus, error) {
+	if name == "" {
+		return commonpb.SimulationStatus_SIMULATION_STATUS_UNSPECIFIED, nil
+	}
+	v, ok := commonpb.SimulationStatus_value["SIMULATION_STATUS_"+strings.TrimPrefix(strings.ToUpper(name), "SIMULATION_STATUS_")]
+	if !ok {
+		return 0, fmt.Errorf("unknown status %q", name)
+	}
+	return commonpb.SimulationStatus(v), nil
+}
+
+func statusName(st commonpb.SimulationStatus) string {
+	return strings.ToLower(strings.TrimPrefix(st.String(), "SIMULATION_STATUS_"))
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
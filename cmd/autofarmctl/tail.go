@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// drainIdle is how long to keep reading ticks after the simulation ended,
+// for those still on their way.
+const drainIdle = 250 * time.Millisecond
+
+// tickJSON marshals ticks as single-line JSON with the proto field names.
+var tickJSON = protojson.MarshalOptions{UseProtoNames: true}
+
+// followOptions says how to follow a simulation's ticks.
+type followOptions struct {
+	// start starts the simulation once subscribed, so no tick is missed.
+	start bool
+
+	// untilDone stops following when the simulation completes or is
+	// stopped; otherwise following ends with the stream or on interrupt.
+	untilDone bool
+}
+
+// follow streams the ticks of simulation id to handle. It returns the
+// simulation as it ended when following stopped because it did, or nil.
+func (c *cli) follow(id string, opts followOptions, handle func(*simulationpb.AggregatedTick) error) (*simulationpb.Simulation, error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	simID := &commonpb.SimulationId{Value: id}
+	stream, err := c.client.StreamAggregatedTicks(ctx, &simulationpb.StreamAggregatedTicksRequest{Id: simID})
+	if err != nil {
+		return nil, fmt.Errorf("stream ticks: %w", err)
+	}
+	// The orchestrator sends headers once subscribed.
+	if _, err := stream.Header(); err != nil {
+		return nil, fmt.Errorf("stream ticks: %w", err)
+	}
+
+	if opts.start {
+		callCtx, callCancel := context.WithTimeout(ctx, c.timeout)
+		_, err := c.client.StartSimulation(callCtx, &simulationpb.StartSimulationRequest{Id: simID})
+		callCancel()
+		if err != nil {
+			return nil, fmt.Errorf("start simulation: %w", err)
+		}
+	}
+
+	type received struct {
+		tick *simulationpb.AggregatedTick
+		err  error
+	}
+	ticks := make(chan received)
+	go func() {
+		for {
+			tick, err := stream.Recv()
+			select {
+			case ticks <- received{tick, err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	done := make(chan *simulationpb.Simulation, 1)
+	if opts.untilDone {
+		go c.waitDone(ctx, simID, done)
+	}
+
+	var ended *simulationpb.Simulation
+	var idle <-chan time.Time
+	for {
+		select {
+		case r := <-ticks:
+			if r.err != nil {
+				if ctx.Err() != nil || errors.Is(r.err, io.EOF) {
+					return ended, nil
+				}
+				return ended, fmt.Errorf("stream ticks: %w", r.err)
+			}
+			if err := handle(r.tick); err != nil {
+				return ended, err
+			}
+			if ended != nil {
+				idle = time.After(drainIdle)
+			}
+		case sim := <-done:
+			if sim == nil {
+				if ctx.Err() != nil {
+					// Interrupted.
+					return nil, nil
+				}
+				return nil, errors.New("lost track of the simulation's status")
+			}
+			ended = sim
+			idle = time.After(drainIdle)
+		case <-idle:
+			return ended, nil
+		case <-ctx.Done():
+			return ended, nil
+		}
+	}
+}
+
+// waitDone watches the simulation's status until it is completed, stopped
+// or failed and sends it on done, or sends nil if ctx ends first.
+func (c *cli) waitDone(ctx context.Context, id *commonpb.SimulationId, done chan<- *simulationpb.Simulation) {
+	stream, err := c.client.WatchSimulationStatus(ctx, &simulationpb.WatchSimulationStatusRequest{Id: id})
+	for err == nil {
+		var e *simulationpb.SimulationStatusEvent
+		if e, err = stream.Recv(); err == nil && ended(e.GetStatus()) {
+			done <- e.GetSimulation()
+			return
+		}
+	}
+	if ctx.Err() == nil {
+		fmt.Fprintf(os.Stderr, "autofarmctl: watch simulation status: %v\n", err)
+	}
+	done <- nil
+}
+
+func ended(st commonpb.SimulationStatus) bool {
+	return st == commonpb.SimulationStatus_SIMULATION_STATUS_COMPLETED ||
+		st == commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED ||
+		st == commonpb.SimulationStatus_SIMULATION_STATUS_FAILED
+}
+
+func (c *cli) tail(args []string) error {
+	fs := newFlags("tail", "<id> [-o json|table] [-start] [-until-done] [-n ticks]")
+	output := fs.String("o", "json", "output format: json (one tick per line) or table")
+	start := fs.Bool("start", false, "start the simulation once subscribed")
+	untilDone := fs.Bool("until-done", false, "exit once the simulation completes or is stopped")
+	limit := fs.Uint64("n", 0, "exit after this many ticks (0 for no limit)")
+	if err := parse(fs, args, 1); err != nil {
+		return err
+	}
+
+	var printTick func(*simulationpb.AggregatedTick) error
+	switch *output {
+	case "json":
+		printTick = c.printTickJSON
+	case "table":
+		fmt.Fprintf(c.stdout, "%8s %10s %8s %8s %7s %6s %5s %9s\n",
+			"TICK", "SIM_TIME", "ENTITIES", "OFFLINE", "BATTERY", "TASKS", "LATE", "YIELD")
+		printTick = c.printTickRow
+	default:
+		return fmt.Errorf("unknown output format %q (want json or table)", *output)
+	}
+
+	// errLimit ends following once -n ticks were printed.
+	errLimit := errors.New("limit reached")
+	var n uint64
+	_, err := c.follow(fs.Arg(0), followOptions{start: *start, untilDone: *untilDone}, func(tick *simulationpb.AggregatedTick) error {
+		if err := printTick(tick); err != nil {
+			return err
+		}
+		if n++; *limit > 0 && n >= *limit {
+			return errLimit
+		}
+		return nil
+	})
+	if errors.Is(err, errLimit) {
+		return nil
+	}
+	return err
+}
+
+func (c *cli) printTickJSON(tick *simulationpb.AggregatedTick) error {
+	data, err := tickJSON.Marshal(tick)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.stdout, "%s\n", data)
+	return err
+}
+
+// printTickRow prints a compact summary of tick: entity counts, average
+// battery, task events, whether it was late, and the crop yield so far.
+func (c *cli) printTickRow(tick *simulationpb.AggregatedTick) error {
+	var offline int
+	var battery float64
+	for _, e := range tick.GetEntities() {
+		if e.GetStatus() == "offline" {
+			offline++
+		}
+		battery += e.GetBattery()
+	}
+	if n := len(tick.GetEntities()); n > 0 {
+		battery /= float64(n)
+	}
+
+	late := ""
+	if tick.GetLate() {
+		late = "late"
+	}
+	simTime := time.Duration(tick.GetSimTimeMs()) * time.Millisecond
+
+	_, err := fmt.Fprintf(c.stdout, "%8d %10s %8d %8d %7.1f %6d %5s %9.1f\n",
+		tick.GetTick(), simTime, len(tick.GetEntities()), offline, battery,
+		len(tick.GetTaskEvents()), late, tick.GetCrops().GetTotalYield())
+	return err
+}
+
+// export records a run: it follows the simulation until it completes or is
+// stopped and writes the simulation and every tick received to a file.
+// The orchestrator keeps no history of ticks, so only a simulation that
+// has not ended can be exported, and only the ticks from the subscription
+// on; -start subscribes before the first tick.
+func (c *cli) export(args []string) error {
+	fs := newFlags("export", "<id> -o file [-start]\n\nRecords ticks as they happen, so the simulation must not have ended yet.")
+	out := fs.String("o", "", "file to write the run to (- for stdout)")
+	start := fs.Bool("start", false, "start the simulation once subscribed")
+	if err := parse(fs, args, 1); err != nil {
+		return err
+	}
+	if *out == "" {
+		fs.Usage()
+		return errUsage
+	}
+	id := fs.Arg(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	resp, err := c.client.GetSimulation(ctx, &simulationpb.GetSimulationRequest{Id: &commonpb.SimulationId{Value: id}})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("get simulation: %w", err)
+	}
+	if st := resp.GetSimulation().GetStatus(); ended(st) {
+		return fmt.Errorf("simulation %s has already ended (%s); export follows a run as it happens", id, statusName(st))
+	}
+
+	var ticks []json.RawMessage
+	var prev, gaps uint64
+	sim, err := c.follow(id, followOptions{start: *start, untilDone: true}, func(tick *simulationpb.AggregatedTick) error {
+		data, err := tickJSON.Marshal(tick)
+		if err != nil {
+			return err
+		}
+		ticks = append(ticks, data)
+		if prev != 0 && tick.GetTick() != prev+1 {
+			gaps += tick.GetTick() - prev - 1
+		}
+		prev = tick.GetTick()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if sim == nil {
+		return errors.New("interrupted before the simulation ended; nothing written")
+	}
+	if gaps > 0 {
+		fmt.Fprintf(os.Stderr, "autofarmctl: warning: %d ticks missing from the export (the stream fell behind)\n", gaps)
+	}
+
+	simJSON, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(sim)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(struct {
+		Simulation json.RawMessage   `json:"simulation"`
+		Ticks      []json.RawMessage `json:"ticks"`
+	}{simJSON, ticks})
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if *out == "-" {
+		_, err = c.stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "autofarmctl: wrote %d ticks of simulation %s (%s) to %s\n",
+		len(ticks), id, statusName(sim.GetStatus()), *out)
+	return nil
+}
@@ -2,31 +2,50 @@
 package main
 
 import (
+    "context"
     "log"
+    "log/slog"
     "net"
 
+    "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
     "google.golang.org/grpc"
 
+    "github.com/stevenmed26/AutoFarm/internal/logging"
     "github.com/stevenmed26/AutoFarm/internal/node"
     nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
+    "github.com/stevenmed26/AutoFarm/internal/tracing"
 )
 
 func main() {
     addr := ":50052"
 
+    logger, err := logging.Setup("node")
+    if err != nil {
+        log.Fatal(err)
+    }
+    shutdownTracing, err := tracing.Setup(context.Background(), "node")
+    if err != nil {
+        logging.Fatal("failed to set up tracing", "error", err)
+    }
+    defer shutdownTracing(context.Background())
+
     lis, err := net.Listen("tcp", addr)
     if err != nil {
-        log.Fatalf("failed to listen on %s: %v", addr, err)
+        logging.Fatal("failed to listen", "addr", addr, "error", err)
     }
 
-    grpcServer := grpc.NewServer()
+    grpcServer := grpc.NewServer(
+        grpc.StatsHandler(otelgrpc.NewServerHandler()),
+        grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(logger)),
+        grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(logger)),
+    )
 
     workerServer := node.NewWorkerServer()
     nodepb.RegisterNodeWorkerServiceServer(grpcServer, workerServer)
 
-    log.Printf("Node Worker gRPC server listening on %s", addr)
+    slog.Info("Node Worker gRPC server listening", "addr", addr)
 
     if err := grpcServer.Serve(lis); err != nil {
-        log.Fatalf("failed to serve gRPC: %v", err)
+        logging.Fatal("failed to serve gRPC", "error", err)
     }
 }
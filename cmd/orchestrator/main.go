@@ -2,31 +2,83 @@
 package main
 
 import (
+    "context"
     "log"
+    "log/slog"
     "net"
+    "os"
 
+    "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
     "google.golang.org/grpc"
 
+    "github.com/stevenmed26/AutoFarm/internal/audit"
+    "github.com/stevenmed26/AutoFarm/internal/gatewayauth"
+    "github.com/stevenmed26/AutoFarm/internal/logging"
     "github.com/stevenmed26/AutoFarm/internal/orchestrator"
     simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+    "github.com/stevenmed26/AutoFarm/internal/rbac"
+    "github.com/stevenmed26/AutoFarm/internal/tenant"
+    "github.com/stevenmed26/AutoFarm/internal/tracing"
 )
 
 func main() {
     addr := ":50051"
 
+    logger, err := logging.Setup("orchestrator")
+    if err != nil {
+        log.Fatal(err)
+    }
+    shutdownTracing, err := tracing.Setup(context.Background(), "orchestrator")
+    if err != nil {
+        logging.Fatal("failed to set up tracing", "error", err)
+    }
+    defer shutdownTracing(context.Background())
+
     lis, err := net.Listen("tcp", addr)
     if err != nil {
-        log.Fatalf("failed to listen on %s: %v", addr, err)
+        logging.Fatal("failed to listen", "addr", addr, "error", err)
     }
 
-    grpcServer := grpc.NewServer()
+    // Only clients with the shared token, like the API gateway, may name
+    // the caller's role and tenant. Everyone else gets the default role.
+    token := os.Getenv(gatewayauth.TokenEnv)
+    if token == "" {
+        slog.Warn("no client is trusted; set " + gatewayauth.TokenEnv + " here and in the API gateway")
+    }
+    defaultRole := rbac.Viewer
+    if v := os.Getenv("RBAC_DEFAULT_ROLE"); v != "" {
+        if defaultRole, err = rbac.Parse(v); err != nil {
+            logging.Fatal("invalid RBAC_DEFAULT_ROLE", "error", err)
+        }
+    }
+    auditLog, err := audit.Open("orchestrator", os.Getenv("AUDIT_LOG_FILE"))
+    if err != nil {
+        logging.Fatal("failed to open audit log", "error", err)
+    }
+    authz := orchestrator.NewAuthorizer(token, defaultRole, auditLog)
+
+    // Logging comes first, so denied calls are logged with their request
+    // ID too. Spans start before either, so both see them.
+    grpcServer := grpc.NewServer(
+        grpc.StatsHandler(otelgrpc.NewServerHandler()),
+        grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(logger), authz.UnaryInterceptor),
+        grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(logger), authz.StreamInterceptor),
+    )
 
     simServer := orchestrator.NewSimulationServer()
+    if path := os.Getenv("TENANT_QUOTAS_FILE"); path != "" {
+        quotas, err := tenant.LoadQuotas(path)
+        if err != nil {
+            logging.Fatal("failed to load tenant quotas", "error", err)
+        }
+        simServer.SetQuotas(quotas)
+        slog.Info("loaded tenant quotas", "tenants", len(quotas.Tenants), "path", path)
+    }
     simulationpb.RegisterSimulationServiceServer(grpcServer, simServer)
 
-    log.Printf("Orchestrator gRPC server listening on %s", addr)
+    slog.Info("Orchestrator gRPC server listening", "addr", addr)
 
     if err := grpcServer.Serve(lis); err != nil {
-        log.Fatalf("failed to serve gRPC: %v", err)
+        logging.Fatal("failed to serve gRPC", "error", err)
     }
 }
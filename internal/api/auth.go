@@ -0,0 +1,441 @@
+// internal/api/auth.go
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/stevenmed26/AutoFarm/internal/rbac"
+	"github.com/stevenmed26/AutoFarm/internal/store"
+	"github.com/stevenmed26/AutoFarm/internal/tenant"
+)
+
+// apiKeyPrefix starts every API key secret, which tells keys apart from
+// JWTs in an Authorization header.
+const apiKeyPrefix = "afk_"
+
+// jwtLeeway is the clock skew allowed when checking exp and nbf.
+const jwtLeeway = 30 * time.Second
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject string `json:"subject"`
+
+	// Method is "api_key" or "jwt".
+	Method string `json:"method"`
+
+	// KeyID is the API key's ID, or the kid of the JWT's signing key.
+	KeyID string `json:"key_id,omitempty"`
+
+	// Tenant is the tenant (project) the caller acts for; every call is
+	// scoped to it.
+	Tenant string `json:"tenant"`
+
+	// Role decides what the caller may do.
+	Role rbac.Role `json:"role"`
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the caller authenticated by the auth
+// middleware, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// AuthConfig configures an Authenticator.
+type AuthConfig struct {
+	// Keys holds the API keys.
+	Keys store.KeyStore
+
+	// JWKS holds the HMAC keys JWT bearer tokens are signed with; nil
+	// disables JWTs.
+	JWKS *JWKS
+
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+}
+
+// Authenticator checks the API keys and JWT bearer tokens of requests.
+type Authenticator struct {
+	cfg AuthConfig
+	now func() time.Time
+}
+
+// NewAuthenticator creates an Authenticator.
+func NewAuthenticator(cfg AuthConfig) *Authenticator {
+	return &Authenticator{cfg: cfg, now: time.Now}
+}
+
+// Middleware rejects requests without valid credentials with 401 and puts
+// the caller of the others into the request context.
+//
+// Credentials come as "Authorization: Bearer <key or JWT>" or
+// "X-API-Key: <key>". Browsers cannot set headers on WebSocket requests,
+// so those may pass the credential as the access_token query parameter.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, err := a.authenticate(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="autofarm"`)
+			writeError(w, "unauthorized: "+err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, p)))
+	})
+}
+
+func (a *Authenticator) authenticate(r *http.Request) (*Principal, error) {
+	credential := r.Header.Get("X-API-Key")
+	if credential == "" {
+		if h := r.Header.Get("Authorization"); h != "" {
+			scheme, token, ok := strings.Cut(h, " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") {
+				return nil, errors.New("Authorization must use the Bearer scheme")
+			}
+			credential = strings.TrimSpace(token)
+		}
+	}
+	if credential == "" && isWebSocketUpgrade(r) {
+		credential = r.URL.Query().Get("access_token")
+	}
+	if credential == "" {
+		return nil, errors.New("missing API key or bearer token")
+	}
+
+	if strings.HasPrefix(credential, apiKeyPrefix) {
+		return a.checkKey(r.Context(), credential)
+	}
+	return a.checkJWT(credential)
+}
+
+func (a *Authenticator) checkKey(ctx context.Context, secret string) (*Principal, error) {
+	if a.cfg.Keys == nil {
+		return nil, errors.New("API keys are not accepted")
+	}
+	k, err := a.cfg.Keys.GetKeyByHash(ctx, hashKey(secret))
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, errors.New("unknown API key")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if k.Expired(a.now()) {
+		return nil, errors.New("API key expired")
+	}
+	return &Principal{Subject: k.Subject, Method: "api_key", KeyID: k.ID, Tenant: tenantOrDefault(k.Tenant), Role: rbac.Role(k.Role)}, nil
+}
+
+// jwtClaims are the claims AutoFarm reads. Audience is a string or a list.
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *int64          `json:"exp"`
+	NotBefore *int64          `json:"nbf"`
+	Tenant    string          `json:"tenant"`
+	Role      string          `json:"role"`
+	Admin     bool            `json:"admin"`
+}
+
+// checkJWT verifies an HS256, HS384 or HS512 token against the JWKS. The
+// token must carry sub and exp.
+func (a *Authenticator) checkJWT(token string) (*Principal, error) {
+	if a.cfg.JWKS == nil {
+		return nil, errors.New("bearer tokens are not accepted")
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("token header: %w", err)
+	}
+	newHash, ok := hmacAlgs[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("token algorithm %q is not supported", header.Alg)
+	}
+	key, err := a.cfg.JWKS.key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if key.Alg != "" && key.Alg != header.Alg {
+		return nil, fmt.Errorf("key %q is for %s, not %s", key.Kid, key.Alg, header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed token signature")
+	}
+	mac := hmac.New(newHash, key.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, errors.New("invalid token signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("token claims: %w", err)
+	}
+	now := a.now()
+	switch {
+	case claims.Subject == "":
+		return nil, errors.New("token has no sub claim")
+	case claims.ExpiresAt == nil:
+		return nil, errors.New("token has no exp claim")
+	case now.After(time.Unix(*claims.ExpiresAt, 0).Add(jwtLeeway)):
+		return nil, errors.New("token expired")
+	case claims.NotBefore != nil && now.Add(jwtLeeway).Before(time.Unix(*claims.NotBefore, 0)):
+		return nil, errors.New("token not valid yet")
+	case a.cfg.Issuer != "" && claims.Issuer != a.cfg.Issuer:
+		return nil, fmt.Errorf("token issuer %q is not accepted", claims.Issuer)
+	case a.cfg.Audience != "" && !hasAudience(claims.Audience, a.cfg.Audience):
+		return nil, errors.New("token is not meant for this audience")
+	}
+	owner := tenantOrDefault(claims.Tenant)
+	if err := tenant.Validate(owner); err != nil {
+		return nil, fmt.Errorf("token claims: %w", err)
+	}
+	role, err := resolveRole(claims.Role, claims.Admin)
+	if err != nil {
+		return nil, fmt.Errorf("token claims: %w", err)
+	}
+	return &Principal{Subject: claims.Subject, Method: "jwt", KeyID: key.Kid, Tenant: owner, Role: role}, nil
+}
+
+var hmacAlgs = map[string]func() hash.Hash{
+	"HS256": sha256.New,
+	"HS384": sha512.New384,
+	"HS512": sha512.New,
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return errors.New("not base64url")
+	}
+	return json.Unmarshal(data, v)
+}
+
+func hasAudience(raw json.RawMessage, want string) bool {
+	var one string
+	if json.Unmarshal(raw, &one) == nil {
+		return one == want
+	}
+	var many []string
+	if json.Unmarshal(raw, &many) == nil {
+		for _, aud := range many {
+			if aud == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// JWKS is a set of symmetric JSON Web Keys (RFC 7517, kty "oct").
+type JWKS struct {
+	keys map[string]*jwk
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	K   string `json:"k"`
+
+	secret []byte
+}
+
+// LoadJWKS reads a JWKS file:
+//
+//	{"keys": [{"kty": "oct", "kid": "2026-10", "alg": "HS256", "k": "<base64url secret>"}]}
+//
+// Keys other than "oct" are ignored; alg is optional.
+func LoadJWKS(path string) (*JWKS, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Keys []*jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	set := &JWKS{keys: make(map[string]*jwk)}
+	for i, k := range doc.Keys {
+		if k.Kty != "oct" {
+			continue
+		}
+		if _, ok := hmacAlgs[k.Alg]; k.Alg != "" && !ok {
+			return nil, fmt.Errorf("%s: keys[%d]: alg %q is not supported", path, i, k.Alg)
+		}
+		k.secret, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(k.K, "="))
+		if err != nil || len(k.secret) < 32 {
+			return nil, fmt.Errorf("%s: keys[%d]: k must be a base64url secret of at least 32 bytes", path, i)
+		}
+		if _, ok := set.keys[k.Kid]; ok {
+			return nil, fmt.Errorf("%s: keys[%d]: duplicate kid %q", path, i, k.Kid)
+		}
+		set.keys[k.Kid] = k
+	}
+	if len(set.keys) == 0 {
+		return nil, fmt.Errorf("%s: no oct keys", path)
+	}
+	return set, nil
+}
+
+// key returns the key with the given kid. Tokens without a kid may only be
+// used with a single-key set.
+func (s *JWKS) key(kid string) (*jwk, error) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, k := range s.keys {
+			return k, nil
+		}
+	}
+	k, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return k, nil
+}
+
+// keyFileEntry is an API key in a static key file. The secret is given as
+// key_sha256 or, for development only, in plain text as key.
+type keyFileEntry struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Subject   string    `json:"subject"`
+	Tenant    string    `json:"tenant"`
+	Role      string    `json:"role"`
+	Admin     bool      `json:"admin"`
+	Key       string    `json:"key"`
+	KeySHA256 string    `json:"key_sha256"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LoadKeyFile adds the API keys of a static key file to ks and returns how
+// many it added:
+//
+//	{"keys": [{"id": "ci", "subject": "ci", "tenant": "team-a", "role": "operator", "key_sha256": "<hex>"}]}
+//
+// Keys without a tenant act for the default tenant; keys without a role
+// are operators.
+func LoadKeyFile(ctx context.Context, path string, ks store.KeyStore) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var doc struct {
+		Keys []keyFileEntry `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for i, e := range doc.Keys {
+		k := &store.APIKey{
+			ID:        e.ID,
+			Name:      e.Name,
+			Subject:   e.Subject,
+			Tenant:    tenantOrDefault(e.Tenant),
+			Hash:      strings.ToLower(e.KeySHA256),
+			ExpiresAt: e.ExpiresAt,
+		}
+		switch {
+		case e.Key != "" && e.KeySHA256 != "":
+			return i, fmt.Errorf("%s: keys[%d]: set key or key_sha256, not both", path, i)
+		case e.Key != "":
+			if !strings.HasPrefix(e.Key, apiKeyPrefix) {
+				return i, fmt.Errorf("%s: keys[%d]: key must start with %q", path, i, apiKeyPrefix)
+			}
+			k.Hash = hashKey(e.Key)
+		case len(k.Hash) != sha256.Size*2:
+			return i, fmt.Errorf("%s: keys[%d]: key_sha256 must be a hex SHA-256", path, i)
+		}
+		if k.ID == "" {
+			return i, fmt.Errorf("%s: keys[%d]: id is required", path, i)
+		}
+		if k.Subject == "" {
+			k.Subject = k.ID
+		}
+		if err := tenant.Validate(k.Tenant); err != nil {
+			return i, fmt.Errorf("%s: keys[%d]: %w", path, i, err)
+		}
+		role, err := resolveRole(e.Role, e.Admin)
+		if err != nil {
+			return i, fmt.Errorf("%s: keys[%d]: %w", path, i, err)
+		}
+		k.Role = string(role)
+		if _, err := ks.CreateKey(ctx, k); err != nil {
+			return i, fmt.Errorf("%s: keys[%d]: %w", path, i, err)
+		}
+	}
+	return len(doc.Keys), nil
+}
+
+// newKeySecret returns a random API key secret.
+func newKeySecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// resolveRole returns the role named role. The older "admin": true still
+// grants the admin role; credentials naming neither are operators.
+func resolveRole(role string, admin bool) (rbac.Role, error) {
+	if role == "" {
+		if admin {
+			return rbac.Admin, nil
+		}
+		return rbac.Operator, nil
+	}
+	r, err := rbac.Parse(role)
+	if err != nil {
+		return "", err
+	}
+	if admin && r != rbac.Admin {
+		return "", fmt.Errorf("admin conflicts with role %q", role)
+	}
+	return r, nil
+}
+
+func tenantOrDefault(name string) string {
+	if name == "" {
+		return tenant.Default
+	}
+	return name
+}
+
+func hashKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
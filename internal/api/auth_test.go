@@ -0,0 +1,167 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stevenmed26/AutoFarm/internal/rbac"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// signJWT returns an HS256 token of claims under header, signed with
+// secret.
+func signJWT(t *testing.T, header, claims map[string]any, secret []byte) string {
+	t.Helper()
+	seg := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := seg(header) + "." + seg(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestCheckJWT(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	header := map[string]any{"alg": "HS256", "kid": "k1"}
+	claims := func(extra map[string]any) map[string]any {
+		c := map[string]any{"sub": "ada", "exp": now.Add(time.Hour).Unix()}
+		for k, v := range extra {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		issuer   string
+		audience string
+		token    string
+		want     *Principal
+		wantErr  string
+	}{
+		{
+			name:  "valid",
+			token: signJWT(t, header, claims(nil), testSecret),
+			want:  &Principal{Subject: "ada", Method: "jwt", KeyID: "k1", Tenant: "default", Role: rbac.Operator},
+		},
+		{
+			name:  "tenant and role claims",
+			token: signJWT(t, header, claims(map[string]any{"tenant": "acme", "role": "viewer"}), testSecret),
+			want:  &Principal{Subject: "ada", Method: "jwt", KeyID: "k1", Tenant: "acme", Role: rbac.Viewer},
+		},
+		{
+			name:     "audience list",
+			issuer:   "idp",
+			audience: "autofarm",
+			token:    signJWT(t, header, claims(map[string]any{"aud": []string{"other", "autofarm"}, "iss": "idp"}), testSecret),
+			want:     &Principal{Subject: "ada", Method: "jwt", KeyID: "k1", Tenant: "default", Role: rbac.Operator},
+		},
+		{
+			name:  "expired within leeway",
+			token: signJWT(t, header, claims(map[string]any{"exp": now.Add(-jwtLeeway / 2).Unix()}), testSecret),
+			want:  &Principal{Subject: "ada", Method: "jwt", KeyID: "k1", Tenant: "default", Role: rbac.Operator},
+		},
+		{
+			name:    "expired",
+			token:   signJWT(t, header, claims(map[string]any{"exp": now.Add(-time.Minute).Unix()}), testSecret),
+			wantErr: "token expired",
+		},
+		{
+			name:    "not valid yet",
+			token:   signJWT(t, header, claims(map[string]any{"nbf": now.Add(time.Minute).Unix()}), testSecret),
+			wantErr: "not valid yet",
+		},
+		{
+			name:    "no sub",
+			token:   signJWT(t, header, claims(map[string]any{"sub": nil}), testSecret),
+			wantErr: "no sub claim",
+		},
+		{
+			name:    "no exp",
+			token:   signJWT(t, header, claims(map[string]any{"exp": nil}), testSecret),
+			wantErr: "no exp claim",
+		},
+		{
+			name:    "wrong secret",
+			token:   signJWT(t, header, claims(nil), []byte("fedcba9876543210fedcba9876543210")),
+			wantErr: "invalid token signature",
+		},
+		{
+			name:    "unsupported alg",
+			token:   signJWT(t, map[string]any{"alg": "none", "kid": "k1"}, claims(nil), testSecret),
+			wantErr: `algorithm "none"`,
+		},
+		{
+			name:    "alg other than the key's",
+			token:   signJWT(t, map[string]any{"alg": "HS512", "kid": "k1"}, claims(nil), testSecret),
+			wantErr: "is for HS256",
+		},
+		{
+			name:    "unknown kid",
+			token:   signJWT(t, map[string]any{"alg": "HS256", "kid": "k2"}, claims(nil), testSecret),
+			wantErr: "unknown signing key",
+		},
+		{
+			name:    "other issuer",
+			issuer:  "idp",
+			token:   signJWT(t, header, claims(map[string]any{"iss": "elsewhere"}), testSecret),
+			wantErr: "issuer",
+		},
+		{
+			name:     "other audience",
+			audience: "autofarm",
+			token:    signJWT(t, header, claims(map[string]any{"aud": "other"}), testSecret),
+			wantErr:  "audience",
+		},
+		{
+			name:    "admin conflicting with role",
+			token:   signJWT(t, header, claims(map[string]any{"admin": true, "role": "viewer"}), testSecret),
+			wantErr: "admin conflicts",
+		},
+		{
+			name:    "malformed",
+			token:   "a.b",
+			wantErr: "malformed token",
+		},
+	}
+
+	jwks := &JWKS{keys: map[string]*jwk{
+		"k1": {Kty: "oct", Kid: "k1", Alg: "HS256", secret: testSecret},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthenticator(AuthConfig{JWKS: jwks, Issuer: tt.issuer, Audience: tt.audience})
+			a.now = func() time.Time { return now }
+
+			got, err := a.checkJWT(tt.token)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("checkJWT() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkJWT() error = %v", err)
+			}
+			if *got != *tt.want {
+				t.Errorf("checkJWT() = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// internal/api/caller.go
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/stevenmed26/AutoFarm/internal/audit"
+	"github.com/stevenmed26/AutoFarm/internal/rbac"
+	"github.com/stevenmed26/AutoFarm/internal/requestid"
+	"github.com/stevenmed26/AutoFarm/internal/tenant"
+)
+
+// CallerUnaryInterceptor passes the request's caller to the orchestrator:
+// their tenant, which it scopes the call to, and their role and subject,
+// which it authorizes the call with. Calls without an authenticated caller
+// act for the default tenant with the orchestrator's default role. The
+// request's ID and the address it came from go along for the audit trail.
+func CallerUnaryInterceptor(ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withCaller(ctx), method, req, reply, cc, opts...)
+}
+
+// CallerStreamInterceptor is CallerUnaryInterceptor for streams.
+func CallerStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn,
+	method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withCaller(ctx), desc, cc, method, opts...)
+}
+
+func withCaller(ctx context.Context) context.Context {
+	if id := requestid.FromContext(ctx); id != "" {
+		ctx = requestid.NewOutgoingContext(ctx, id)
+	}
+	if ip, ok := ctx.Value(sourceIPKey{}).(string); ok && ip != "" {
+		ctx = audit.NewOutgoingContext(ctx, ip)
+	}
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	ctx = tenant.NewOutgoingContext(ctx, p.Tenant)
+	return rbac.NewOutgoingContext(ctx, rbac.Caller{Subject: p.Subject, Role: p.Role})
+}
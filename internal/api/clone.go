@@ -0,0 +1,97 @@
+// internal/api/clone.go
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// overridesJSON changes a config taken from a template, scenario or another
+// simulation; zero fields keep the original value.
+type overridesJSON struct {
+	Name           string           `json:"name"`
+	Seed           uint64           `json:"seed"`
+	RandomizeSeed  bool             `json:"randomize_seed"`
+	EntityCount    uint32           `json:"entities"`
+	Fleet          []fleetGroupJSON `json:"fleet"`
+	TickRateMs     uint32           `json:"tick_rate_ms"`
+	Scenario       string           `json:"scenario_type"`
+	TaskAllocation string           `json:"task_allocation"`
+}
+
+// cloneRequest is the body of POST /simulations/{id}/clone. With fork the
+// new simulation starts from the source's latest snapshot at or before
+// fork_tick, or its latest snapshot when fork_tick is 0.
+type cloneRequest struct {
+	Overrides *overridesJSON `json:"overrides"`
+	Fork      bool           `json:"fork"`
+	ForkTick  uint64         `json:"fork_tick"`
+}
+
+func overridesFromJSON(oj *overridesJSON) (*simulationpb.ConfigOverrides, error) {
+	if oj == nil {
+		return nil, nil
+	}
+
+	fleetMix, _, err := fleetFromJSON(oj.Fleet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	allocation, err := parseTaskAllocation(oj.TaskAllocation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &simulationpb.ConfigOverrides{
+		Name:           oj.Name,
+		Seed:           oj.Seed,
+		RandomizeSeed:  oj.RandomizeSeed,
+		EntityCount:    oj.EntityCount,
+		Fleet:          fleetMix,
+		TickRateMs:     oj.TickRateMs,
+		ScenarioType:   oj.Scenario,
+		TaskAllocation: allocation,
+	}, nil
+}
+
+func (s *Server) handleCloneSimulation(w http.ResponseWriter, r *http.Request, id string) {
+	// The body is optional: a plain clone needs none.
+	var reqBody cloneRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSONBody(r, &reqBody); err != nil {
+			writeError(w, "invalid JSON: "+err.Error(), bodyErrorStatus(err))
+			return
+		}
+	}
+	if reqBody.ForkTick != 0 && !reqBody.Fork {
+		writeError(w, "fork_tick needs fork", http.StatusBadRequest)
+		return
+	}
+
+	overrides, err := overridesFromJSON(reqBody.Overrides)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.CloneSimulation(ctx, &simulationpb.CloneSimulationRequest{
+		Id:        &commonpb.SimulationId{Value: id},
+		Overrides: overrides,
+		Fork:      reqBody.Fork,
+		ForkTick:  reqBody.ForkTick,
+	})
+	if err != nil {
+		writeRPCError(w, "failed to clone simulation", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toSimulationResponse(resp.GetSimulation()))
+}
@@ -0,0 +1,170 @@
+// internal/api/crops.go
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// cropConfigJSON sets up crop growth; zero fields use the defaults. Durations
+// are in simulated days.
+type cropConfigJSON struct {
+	Fields        []cropPatchJSON `json:"fields,omitempty"`
+	CellSize      float64         `json:"cell_size,omitempty"`
+	DaysToRipe    float64         `json:"days_to_ripe,omitempty"`
+	RipeDays      float64         `json:"ripe_days,omitempty"`
+	SpoilDays     float64         `json:"spoil_days,omitempty"`
+	YieldPerCell  float64         `json:"yield_per_cell,omitempty"`
+	ManualHarvest bool            `json:"manual_harvest,omitempty"`
+}
+
+type cropPatchJSON struct {
+	MinX  float64 `json:"min_x"`
+	MinY  float64 `json:"min_y"`
+	MaxX  float64 `json:"max_x"`
+	MaxY  float64 `json:"max_y"`
+	Label string  `json:"label,omitempty"`
+}
+
+// cropSummaryJSON is the crop totals after a tick.
+type cropSummaryJSON struct {
+	TotalYield       float64 `json:"total_yield"`
+	PlantedCells     uint32  `json:"planted_cells"`
+	HarvestedCells   uint32  `json:"harvested_cells"`
+	RipeCells        uint32  `json:"ripe_cells"`
+	SpoiledCells     uint32  `json:"spoiled_cells"`
+	HarvestableCells uint32  `json:"harvestable_cells"`
+	HarvestableArea  float64 `json:"harvestable_area"`
+}
+
+type cropCellJSON struct {
+	Col   uint32  `json:"col"`
+	Row   uint32  `json:"row"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Stage string  `json:"stage"`
+	Yield float64 `json:"yield"`
+}
+
+type cropsResponse struct {
+	Summary  *cropSummaryJSON `json:"summary"`
+	CellSize float64          `json:"cell_size"`
+	Cells    []cropCellJSON   `json:"cells"`
+}
+
+// cropStages maps the REST names of crop stages to the proto enum.
+var cropStages = map[string]simulationpb.CropStage{
+	"seedling":   simulationpb.CropStage_CROP_STAGE_SEEDLING,
+	"vegetative": simulationpb.CropStage_CROP_STAGE_VEGETATIVE,
+	"flowering":  simulationpb.CropStage_CROP_STAGE_FLOWERING,
+	"ripe":       simulationpb.CropStage_CROP_STAGE_RIPE,
+	"overripe":   simulationpb.CropStage_CROP_STAGE_OVERRIPE,
+	"spoiled":    simulationpb.CropStage_CROP_STAGE_SPOILED,
+	"harvested":  simulationpb.CropStage_CROP_STAGE_HARVESTED,
+}
+
+func cropStageName(s simulationpb.CropStage) string {
+	for name, v := range cropStages {
+		if v == s {
+			return name
+		}
+	}
+	return ""
+}
+
+func (s *Server) handleGetCrops(w http.ResponseWriter, r *http.Request, id string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.GetCrops(ctx, &simulationpb.GetCropsRequest{
+		Id: &commonpb.SimulationId{Value: id},
+	})
+	if err != nil {
+		writeRPCError(w, "failed to get crops", err)
+		return
+	}
+
+	out := cropsResponse{
+		Summary:  cropSummaryToJSON(resp.GetSummary()),
+		CellSize: resp.GetCellSize(),
+		Cells:    make([]cropCellJSON, 0, len(resp.GetCells())),
+	}
+	for _, c := range resp.GetCells() {
+		out.Cells = append(out.Cells, cropCellJSON{
+			Col:   c.GetCol(),
+			Row:   c.GetRow(),
+			X:     c.GetX(),
+			Y:     c.GetY(),
+			Stage: cropStageName(c.GetStage()),
+			Yield: c.GetYield(),
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func cropSummaryToJSON(s *simulationpb.CropSummary) *cropSummaryJSON {
+	if s == nil {
+		return nil
+	}
+	return &cropSummaryJSON{
+		TotalYield:       s.GetTotalYield(),
+		PlantedCells:     s.GetPlantedCells(),
+		HarvestedCells:   s.GetHarvestedCells(),
+		RipeCells:        s.GetRipeCells(),
+		SpoiledCells:     s.GetSpoiledCells(),
+		HarvestableCells: s.GetHarvestableCells(),
+		HarvestableArea:  s.GetHarvestableArea(),
+	}
+}
+
+func cropConfigFromJSON(cj *cropConfigJSON) *simulationpb.CropConfig {
+	if cj == nil {
+		return nil
+	}
+	cfg := &simulationpb.CropConfig{
+		CellSize:      cj.CellSize,
+		DaysToRipe:    cj.DaysToRipe,
+		RipeDays:      cj.RipeDays,
+		SpoilDays:     cj.SpoilDays,
+		YieldPerCell:  cj.YieldPerCell,
+		ManualHarvest: cj.ManualHarvest,
+	}
+	for _, f := range cj.Fields {
+		cfg.Fields = append(cfg.Fields, &simulationpb.CropPatch{
+			MinX:  f.MinX,
+			MinY:  f.MinY,
+			MaxX:  f.MaxX,
+			MaxY:  f.MaxY,
+			Label: f.Label,
+		})
+	}
+	return cfg
+}
+
+func cropConfigToJSON(cfg *simulationpb.CropConfig) *cropConfigJSON {
+	if cfg == nil {
+		return nil
+	}
+	cj := &cropConfigJSON{
+		CellSize:      cfg.GetCellSize(),
+		DaysToRipe:    cfg.GetDaysToRipe(),
+		RipeDays:      cfg.GetRipeDays(),
+		SpoilDays:     cfg.GetSpoilDays(),
+		YieldPerCell:  cfg.GetYieldPerCell(),
+		ManualHarvest: cfg.GetManualHarvest(),
+	}
+	for _, f := range cfg.GetFields() {
+		cj.Fields = append(cj.Fields, cropPatchJSON{
+			MinX:  f.GetMinX(),
+			MinY:  f.GetMinY(),
+			MaxX:  f.GetMaxX(),
+			MaxY:  f.GetMaxY(),
+			Label: f.GetLabel(),
+		})
+	}
+	return cj
+}
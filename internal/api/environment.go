@@ -0,0 +1,205 @@
+// internal/api/environment.go
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// environmentConfigJSON sets the day cycle; zero fields use the defaults
+// (start at 06:00, 10 simulated minutes per day).
+type environmentConfigJSON struct {
+	StartHour   float64 `json:"start_hour,omitempty"`
+	DayLengthMs uint64  `json:"day_length_ms,omitempty"`
+}
+
+type weatherJSON struct {
+	Condition        string  `json:"condition,omitempty"`
+	CloudCover       float64 `json:"cloud_cover"`
+	RainIntensity    float64 `json:"rain_intensity"`
+	WindSpeed        float64 `json:"wind_speed"`
+	WindDirectionDeg float64 `json:"wind_direction_deg"`
+}
+
+// environmentJSON is the time of day and weather during a tick.
+type environmentJSON struct {
+	TimeOfDayHours float64      `json:"time_of_day_hours"`
+	Day            uint64       `json:"day"`
+	LightLevel     float64      `json:"light_level"`
+	Dark           bool         `json:"dark"`
+	Weather        *weatherJSON `json:"weather,omitempty"`
+	Overridden     bool         `json:"overridden,omitempty"`
+}
+
+// setEnvironmentRequest overrides the weather and/or the time of day. The
+// clock stays where it is unless time_of_day_hours is given.
+type setEnvironmentRequest struct {
+	Weather        *weatherJSON `json:"weather"`
+	TimeOfDayHours *float64     `json:"time_of_day_hours"`
+	Clear          bool         `json:"clear"`
+}
+
+// environmentChangeJSON is one entry of a config's weather script, applied
+// like a setEnvironmentRequest once at_ms of simulated time has passed.
+type environmentChangeJSON struct {
+	AtMs           uint64       `json:"at_ms"`
+	Weather        *weatherJSON `json:"weather,omitempty"`
+	TimeOfDayHours *float64     `json:"time_of_day_hours,omitempty"`
+	Clear          bool         `json:"clear,omitempty"`
+}
+
+// weatherConditions maps the REST names of weather conditions to the proto enum.
+var weatherConditions = map[string]simulationpb.WeatherCondition{
+	"":       simulationpb.WeatherCondition_WEATHER_CONDITION_UNSPECIFIED,
+	"clear":  simulationpb.WeatherCondition_WEATHER_CONDITION_CLEAR,
+	"cloudy": simulationpb.WeatherCondition_WEATHER_CONDITION_CLOUDY,
+	"rain":   simulationpb.WeatherCondition_WEATHER_CONDITION_RAIN,
+	"storm":  simulationpb.WeatherCondition_WEATHER_CONDITION_STORM,
+}
+
+func weatherConditionName(c simulationpb.WeatherCondition) string {
+	for name, v := range weatherConditions {
+		if v == c && name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+func (s *Server) handleSetEnvironment(w http.ResponseWriter, r *http.Request, id string) {
+	var reqBody setEnvironmentRequest
+	if err := decodeJSONBody(r, &reqBody); err != nil {
+		writeError(w, "invalid JSON: "+err.Error(), bodyErrorStatus(err))
+		return
+	}
+
+	if !reqBody.Clear && reqBody.Weather == nil && reqBody.TimeOfDayHours == nil {
+		writeError(w, "weather, time_of_day_hours or clear is required", http.StatusBadRequest)
+		return
+	}
+
+	req := &simulationpb.SetEnvironmentRequest{
+		Id:    &commonpb.SimulationId{Value: id},
+		Clear: reqBody.Clear,
+	}
+	if reqBody.Weather != nil {
+		weather, err := weatherFromJSON(reqBody.Weather)
+		if err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.Weather = weather
+	}
+	if reqBody.TimeOfDayHours != nil {
+		req.SetTimeOfDay = true
+		req.TimeOfDayHours = *reqBody.TimeOfDayHours
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.SetEnvironment(ctx, req)
+	if err != nil {
+		writeRPCError(w, "failed to set environment", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, environmentToJSON(resp.GetEnvironment()))
+}
+
+func weatherFromJSON(wj *weatherJSON) (*simulationpb.Weather, error) {
+	condition, ok := weatherConditions[strings.ToLower(wj.Condition)]
+	if !ok {
+		return nil, fmt.Errorf("unknown weather.condition %q (want clear, cloudy, rain or storm)", wj.Condition)
+	}
+	return &simulationpb.Weather{
+		Condition:        condition,
+		CloudCover:       wj.CloudCover,
+		RainIntensity:    wj.RainIntensity,
+		WindSpeed:        wj.WindSpeed,
+		WindDirectionDeg: wj.WindDirectionDeg,
+	}, nil
+}
+
+func weatherToJSON(w *simulationpb.Weather) *weatherJSON {
+	if w == nil {
+		return nil
+	}
+	return &weatherJSON{
+		Condition:        weatherConditionName(w.GetCondition()),
+		CloudCover:       w.GetCloudCover(),
+		RainIntensity:    w.GetRainIntensity(),
+		WindSpeed:        w.GetWindSpeed(),
+		WindDirectionDeg: w.GetWindDirectionDeg(),
+	}
+}
+
+func environmentChangeFromJSON(cj environmentChangeJSON) (*simulationpb.EnvironmentChange, error) {
+	c := &simulationpb.EnvironmentChange{AtMs: cj.AtMs, Clear: cj.Clear}
+	if cj.Weather != nil {
+		w, err := weatherFromJSON(cj.Weather)
+		if err != nil {
+			return nil, err
+		}
+		c.Weather = w
+	}
+	if cj.TimeOfDayHours != nil {
+		c.SetTimeOfDay = true
+		c.TimeOfDayHours = *cj.TimeOfDayHours
+	}
+	return c, nil
+}
+
+func environmentChangeToJSON(c *simulationpb.EnvironmentChange) environmentChangeJSON {
+	cj := environmentChangeJSON{
+		AtMs:    c.GetAtMs(),
+		Weather: weatherToJSON(c.GetWeather()),
+		Clear:   c.GetClear(),
+	}
+	if c.GetSetTimeOfDay() {
+		h := c.GetTimeOfDayHours()
+		cj.TimeOfDayHours = &h
+	}
+	return cj
+}
+
+func environmentToJSON(env *simulationpb.Environment) *environmentJSON {
+	if env == nil {
+		return nil
+	}
+	out := &environmentJSON{
+		TimeOfDayHours: env.GetTimeOfDayHours(),
+		Day:            env.GetDay(),
+		LightLevel:     env.GetLightLevel(),
+		Dark:           env.GetDark(),
+		Overridden:     env.GetOverridden(),
+	}
+	out.Weather = weatherToJSON(env.GetWeather())
+	return out
+}
+
+func environmentConfigFromJSON(ej *environmentConfigJSON) *simulationpb.EnvironmentConfig {
+	if ej == nil {
+		return nil
+	}
+	return &simulationpb.EnvironmentConfig{
+		StartHour:   ej.StartHour,
+		DayLengthMs: ej.DayLengthMs,
+	}
+}
+
+func environmentConfigToJSON(cfg *simulationpb.EnvironmentConfig) *environmentConfigJSON {
+	if cfg == nil {
+		return nil
+	}
+	return &environmentConfigJSON{
+		StartHour:   cfg.GetStartHour(),
+		DayLengthMs: cfg.GetDayLengthMs(),
+	}
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stevenmed26/AutoFarm/internal/requestid"
+)
+
+// errorResponse is the body of every error response:
+//
+//	{"error": {"code": "NOT_FOUND", "message": "...", "request_id": "...", ...}}
+//
+// code is the gRPC status code of the error, in upper snake case, or for
+// errors of the gateway itself the one matching the HTTP status. The other
+// fields come from the details of the orchestrator's error.
+type errorResponse struct {
+	Error apiError `json:"error"`
+}
+
+type apiError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+
+	// Reason and Metadata are from the error's ErrorInfo. Reasons are
+	// stable; messages are not.
+	Reason   string            `json:"reason,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	Fields        []fieldViolationJSON `json:"fields,omitempty"`
+	Resource      *resourceInfoJSON    `json:"resource,omitempty"`
+	Preconditions []preconditionJSON   `json:"preconditions,omitempty"`
+}
+
+type fieldViolationJSON struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+type resourceInfoJSON struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type preconditionJSON struct {
+	Type        string `json:"type"`
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+}
+
+// statusCodes are the gRPC codes of errors of the gateway itself, by HTTP
+// status.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusInternalServerError: codes.Internal,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+}
+
+// httpStatus returns the HTTP status for errors with code.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeError is http.Error with a JSON body.
+func writeError(w http.ResponseWriter, msg string, httpCode int) {
+	name := strings.ToUpper(strings.ReplaceAll(http.StatusText(httpCode), " ", "_"))
+	if code, ok := statusCodes[httpCode]; ok {
+		name = codeName(code)
+	}
+	writeAPIError(w, httpCode, apiError{Code: name, Message: msg})
+}
+
+// writeRPCError reports err, from a call to the orchestrator, with the HTTP
+// status matching its code and the details it carries.
+func writeRPCError(w http.ResponseWriter, msg string, err error) {
+	st := status.Convert(err)
+	e := apiError{
+		Code:    codeName(st.Code()),
+		Message: msg + ": " + st.Message(),
+	}
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			e.Reason = d.GetReason()
+			e.Metadata = d.GetMetadata()
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				e.Fields = append(e.Fields, fieldViolationJSON{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		case *errdetails.ResourceInfo:
+			e.Resource = &resourceInfoJSON{Type: d.GetResourceType(), Name: d.GetResourceName()}
+		case *errdetails.PreconditionFailure:
+			for _, v := range d.GetViolations() {
+				e.Preconditions = append(e.Preconditions, preconditionJSON{
+					Type: v.GetType(), Subject: v.GetSubject(), Description: v.GetDescription(),
+				})
+			}
+		}
+	}
+	writeAPIError(w, httpStatus(st.Code()), e)
+}
+
+func writeAPIError(w http.ResponseWriter, httpCode int, e apiError) {
+	e.RequestID = w.Header().Get(requestid.Header)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	writeJSON(w, httpCode, errorResponse{Error: e})
+}
+
+// codeName returns code in upper snake case, e.g. NOT_FOUND.
+func codeName(code codes.Code) string {
+	var b strings.Builder
+	for i, r := range code.String() {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,157 @@
+// internal/api/events.go
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// auditEventJSON is an entry of the audit trail.
+type auditEventJSON struct {
+	ID           uint64    `json:"id"`
+	Time         time.Time `json:"time"`
+	Tenant       string    `json:"tenant"`
+	SimulationID string    `json:"simulation_id"`
+	Action       string    `json:"action"`
+	Actor        string    `json:"actor,omitempty"`
+	SourceIP     string    `json:"source_ip,omitempty"`
+	RequestID    string    `json:"request_id,omitempty"`
+	StatusBefore string    `json:"status_before,omitempty"`
+	StatusAfter  string    `json:"status_after"`
+	Detail       string    `json:"detail,omitempty"`
+}
+
+type listEventsResponse struct {
+	Events []*auditEventJSON `json:"events"`
+}
+
+// eventQuery holds the query parameters both event endpoints take.
+type eventQuery struct {
+	since, until *timestamppb.Timestamp
+	limit        uint32
+}
+
+// handleSimulationEvents returns the audit trail of one simulation:
+// GET /simulations/{id}/events?since=&until=&limit=
+func (s *Server) handleSimulationEvents(w http.ResponseWriter, r *http.Request, id string) {
+	q, err := parseEventQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.ListSimulationEvents(ctx, &simulationpb.ListSimulationEventsRequest{
+		Id:    &commonpb.SimulationId{Value: id},
+		Since: q.since,
+		Until: q.until,
+		Limit: q.limit,
+	})
+	if err != nil {
+		writeRPCError(w, "failed to list simulation events", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, eventsToJSON(resp.GetEvents()))
+}
+
+// handleAuditEvents returns the audit trail of the caller's tenant:
+// GET /audit/events?since=&until=&action=&actor=&limit=
+func (s *Server) handleAuditEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	params := r.URL.Query()
+	q, err := parseEventQuery(params)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.ListAuditEvents(ctx, &simulationpb.ListAuditEventsRequest{
+		Since:  q.since,
+		Until:  q.until,
+		Action: params.Get("action"),
+		Actor:  params.Get("actor"),
+		Limit:  q.limit,
+	})
+	if err != nil {
+		writeRPCError(w, "failed to list audit events", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, eventsToJSON(resp.GetEvents()))
+}
+
+// parseEventQuery reads since and until, each an RFC 3339 time or a
+// duration before now such as "1h", and limit.
+func parseEventQuery(params url.Values) (eventQuery, error) {
+	var q eventQuery
+	now := time.Now()
+	for _, p := range []struct {
+		name string
+		dst  **timestamppb.Timestamp
+	}{{"since", &q.since}, {"until", &q.until}} {
+		v := params.Get(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			d, derr := time.ParseDuration(v)
+			if derr != nil || d < 0 {
+				return q, fmt.Errorf("%s must be an RFC 3339 time or a duration such as 1h", p.name)
+			}
+			t = now.Add(-d)
+		}
+		*p.dst = timestamppb.New(t)
+	}
+	if q.since != nil && q.until != nil && !q.since.AsTime().Before(q.until.AsTime()) {
+		return q, errors.New("since must be before until")
+	}
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || n == 0 || n > 1000 {
+			return q, errors.New("limit must be between 1 and 1000")
+		}
+		q.limit = uint32(n)
+	}
+	return q, nil
+}
+
+func eventsToJSON(events []*simulationpb.AuditEvent) listEventsResponse {
+	out := listEventsResponse{Events: make([]*auditEventJSON, 0, len(events))}
+	for _, e := range events {
+		j := &auditEventJSON{
+			ID:           e.GetId(),
+			Time:         e.GetTime().AsTime(),
+			Tenant:       e.GetTenant(),
+			SimulationID: e.GetSimulationId().GetValue(),
+			Action:       e.GetAction(),
+			Actor:        e.GetActor(),
+			SourceIP:     e.GetSourceIp(),
+			RequestID:    e.GetRequestId(),
+			StatusAfter:  e.GetStatusAfter().String(),
+			Detail:       e.GetDetail(),
+		}
+		if e.GetStatusBefore() != commonpb.SimulationStatus_SIMULATION_STATUS_UNSPECIFIED {
+			j.StatusBefore = e.GetStatusBefore().String()
+		}
+		out.Events = append(out.Events, j)
+	}
+	return out
+}
@@ -0,0 +1,526 @@
+// internal/api/experiments.go
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// experimentParameterJSON is one parameter an experiment varies: a list of
+// values, a min/max range (stepped on a grid, drawn from when sampling at
+// random), or the choices of task_allocation.
+type experimentParameterJSON struct {
+	Name    string    `json:"name"`
+	Values  []float64 `json:"values,omitempty"`
+	Min     float64   `json:"min,omitempty"`
+	Max     float64   `json:"max,omitempty"`
+	Step    float64   `json:"step,omitempty"`
+	Choices []string  `json:"choices,omitempty"`
+}
+
+// createExperimentRequest is the body of POST /experiments. The runs start
+// from base, which has the shape of a create body, or from a template.
+type createExperimentRequest struct {
+	Name         string                    `json:"name"`
+	Base         *createSimulationRequest  `json:"base"`
+	Template     string                    `json:"template"`
+	Parameters   []experimentParameterJSON `json:"parameters"`
+	Sampling     string                    `json:"sampling"`
+	Samples      uint32                    `json:"samples"`
+	SamplingSeed uint64                    `json:"sampling_seed"`
+	Concurrency  uint32                    `json:"concurrency"`
+}
+
+type experimentProgressJSON struct {
+	Total     uint32 `json:"total"`
+	Queued    uint32 `json:"queued"`
+	Running   uint32 `json:"running"`
+	Completed uint32 `json:"completed"`
+	Failed    uint32 `json:"failed"`
+	Canceled  uint32 `json:"canceled"`
+}
+
+type experimentJSON struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	State     string                 `json:"state"`
+	CreatedAt time.Time              `json:"created_at"`
+	EndedAt   *time.Time             `json:"ended_at,omitempty"`
+	Progress  experimentProgressJSON `json:"progress"`
+
+	Base         *createSimulationRequest  `json:"base"`
+	Template     string                    `json:"template,omitempty"`
+	Parameters   []experimentParameterJSON `json:"parameters"`
+	Sampling     string                    `json:"sampling"`
+	Samples      uint32                    `json:"samples,omitempty"`
+	SamplingSeed uint64                    `json:"sampling_seed,omitempty"`
+	Concurrency  uint32                    `json:"concurrency"`
+
+	// Runs is left out of the list.
+	Runs []experimentRunJSON `json:"runs,omitempty"`
+}
+
+type experimentRunJSON struct {
+	Index        uint32                 `json:"index"`
+	Parameters   map[string]any         `json:"parameters"`
+	State        string                 `json:"state"`
+	SimulationID string                 `json:"simulation_id,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+	Summary      *experimentSummaryJSON `json:"summary,omitempty"`
+}
+
+// experimentSummaryJSON is what a completed run achieved, at the tick it
+// completed.
+type experimentSummaryJSON struct {
+	Ticks          uint64  `json:"ticks"`
+	SimTimeMs      uint64  `json:"sim_time_ms"`
+	EndReason      string  `json:"end_reason"`
+	TotalYield     float64 `json:"total_yield"`
+	HarvestedCells uint32  `json:"harvested_cells"`
+	SpoiledCells   uint32  `json:"spoiled_cells"`
+	TasksTotal     uint32  `json:"tasks_total"`
+	TasksCompleted uint32  `json:"tasks_completed"`
+	Entities       uint32  `json:"final_entities"`
+	AvgBattery     float64 `json:"avg_battery"`
+	Offline        uint32  `json:"offline_entities"`
+	WallTimeMs     float64 `json:"wall_time_ms"`
+}
+
+type listExperimentsResponse struct {
+	Experiments []*experimentJSON `json:"experiments"`
+}
+
+// experimentResultsJSON is the comparison table of an experiment's runs,
+// one row per run, parameters in the order the experiment lists them.
+type experimentResultsJSON struct {
+	ExperimentID string                 `json:"experiment_id"`
+	State        string                 `json:"state"`
+	Parameters   []string               `json:"parameters"`
+	Progress     experimentProgressJSON `json:"progress"`
+	Rows         []experimentRunJSON    `json:"rows"`
+}
+
+// experimentSamplings maps the REST names of sampling methods to the proto
+// enum.
+var experimentSamplings = map[string]simulationpb.ExperimentSampling{
+	"":       simulationpb.ExperimentSampling_EXPERIMENT_SAMPLING_UNSPECIFIED,
+	"grid":   simulationpb.ExperimentSampling_EXPERIMENT_SAMPLING_GRID,
+	"random": simulationpb.ExperimentSampling_EXPERIMENT_SAMPLING_RANDOM,
+}
+
+func parseExperimentSampling(name string) (simulationpb.ExperimentSampling, error) {
+	v, ok := experimentSamplings[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown sampling %q (want grid or random)", name)
+	}
+	return v, nil
+}
+
+func experimentSamplingName(v simulationpb.ExperimentSampling) string {
+	if v == simulationpb.ExperimentSampling_EXPERIMENT_SAMPLING_RANDOM {
+		return "random"
+	}
+	return "grid"
+}
+
+// experimentStates and experimentRunStates map the proto enums to their
+// REST names.
+var experimentStates = map[simulationpb.ExperimentState]string{
+	simulationpb.ExperimentState_EXPERIMENT_STATE_RUNNING:   "running",
+	simulationpb.ExperimentState_EXPERIMENT_STATE_COMPLETED: "completed",
+	simulationpb.ExperimentState_EXPERIMENT_STATE_CANCELED:  "canceled",
+}
+
+var experimentRunStates = map[simulationpb.ExperimentRunState]string{
+	simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_QUEUED:    "queued",
+	simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_RUNNING:   "running",
+	simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_COMPLETED: "completed",
+	simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_FAILED:    "failed",
+	simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_CANCELED:  "canceled",
+}
+
+func experimentStateName(st simulationpb.ExperimentState) string {
+	if name, ok := experimentStates[st]; ok {
+		return name
+	}
+	return st.String()
+}
+
+func experimentRunStateName(st simulationpb.ExperimentRunState) string {
+	if name, ok := experimentRunStates[st]; ok {
+		return name
+	}
+	return st.String()
+}
+
+func (s *Server) handleExperiments(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.handleListExperiments(w, r)
+	case http.MethodPost:
+		s.handleCreateExperiment(w, r)
+	default:
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleExperimentByID(w http.ResponseWriter, r *http.Request) {
+	// Path format: /experiments/{id} or /experiments/{id}/action
+	path := strings.TrimPrefix(r.URL.Path, "/experiments/")
+	if path == "" {
+		writeError(w, "not found", http.StatusNotFound)
+		return
+	}
+	parts := strings.Split(path, "/")
+	id := parts[0]
+
+	if len(parts) == 1 {
+		// /experiments/{id}
+		switch r.Method {
+		case http.MethodGet:
+			s.handleGetExperiment(w, r, id)
+		default:
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	if len(parts) > 2 {
+		writeError(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	// /experiments/{id}/{action}
+	switch parts[1] {
+	case "results":
+		if r.Method != http.MethodGet {
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleExperimentResults(w, r, id)
+	case "cancel":
+		if r.Method != http.MethodPost {
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleCancelExperiment(w, r, id)
+	default:
+		writeError(w, "not found", http.StatusNotFound)
+	}
+}
+
+func (s *Server) handleCreateExperiment(w http.ResponseWriter, r *http.Request) {
+	var reqBody createExperimentRequest
+	if err := decodeJSONBody(r, &reqBody); err != nil {
+		writeError(w, "invalid JSON: "+err.Error(), bodyErrorStatus(err))
+		return
+	}
+
+	spec, err := experimentSpecFromJSON(&reqBody)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.CreateExperiment(ctx, &simulationpb.CreateExperimentRequest{Spec: spec})
+	if err != nil {
+		writeRPCError(w, "failed to create experiment", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, experimentToJSON(resp.GetExperiment()))
+}
+
+func (s *Server) handleListExperiments(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.ListExperiments(ctx, &simulationpb.ListExperimentsRequest{})
+	if err != nil {
+		writeRPCError(w, "failed to list experiments", err)
+		return
+	}
+
+	out := listExperimentsResponse{Experiments: make([]*experimentJSON, 0, len(resp.GetExperiments()))}
+	for _, exp := range resp.GetExperiments() {
+		out.Experiments = append(out.Experiments, experimentToJSON(exp))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleGetExperiment(w http.ResponseWriter, r *http.Request, id string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.GetExperiment(ctx, &simulationpb.GetExperimentRequest{Id: id})
+	if err != nil {
+		writeRPCError(w, "failed to get experiment", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, experimentToJSON(resp.GetExperiment()))
+}
+
+func (s *Server) handleCancelExperiment(w http.ResponseWriter, r *http.Request, id string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.CancelExperiment(ctx, &simulationpb.CancelExperimentRequest{Id: id})
+	if err != nil {
+		writeRPCError(w, "failed to cancel experiment", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, experimentToJSON(resp.GetExperiment()))
+}
+
+// handleExperimentResults serves the comparison table of an experiment's
+// runs as JSON, or as CSV with ?format=csv or Accept: text/csv. Runs that
+// have not completed are listed without metrics.
+func (s *Server) handleExperimentResults(w http.ResponseWriter, r *http.Request, id string) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	switch format {
+	case "":
+		if strings.Contains(r.Header.Get("Accept"), "text/csv") {
+			format = "csv"
+		}
+	case "json", "csv":
+	default:
+		writeError(w, fmt.Sprintf("unknown format %q (want json or csv)", format), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.GetExperiment(ctx, &simulationpb.GetExperimentRequest{Id: id})
+	if err != nil {
+		writeRPCError(w, "failed to get experiment", err)
+		return
+	}
+	exp := resp.GetExperiment()
+
+	names := make([]string, 0, len(exp.GetSpec().GetParameters()))
+	for _, p := range exp.GetSpec().GetParameters() {
+		names = append(names, p.GetName())
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="experiment-`+exp.GetId()+`.csv"`)
+		writeExperimentCSV(w, names, exp.GetRuns())
+		return
+	}
+
+	out := experimentResultsJSON{
+		ExperimentID: exp.GetId(),
+		State:        experimentStateName(exp.GetState()),
+		Parameters:   names,
+		Progress:     experimentProgressToJSON(exp),
+		Rows:         make([]experimentRunJSON, 0, len(exp.GetRuns())),
+	}
+	for _, run := range exp.GetRuns() {
+		out.Rows = append(out.Rows, experimentRunToJSON(run))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// experimentCSVMetrics are the metric columns of the CSV table, after the
+// run, its state and its parameters.
+var experimentCSVMetrics = []string{
+	"ticks", "sim_time_ms", "end_reason",
+	"total_yield", "harvested_cells", "spoiled_cells",
+	"tasks_total", "tasks_completed",
+	"final_entities", "avg_battery", "offline_entities",
+	"wall_time_ms",
+}
+
+func writeExperimentCSV(w http.ResponseWriter, names []string, runs []*simulationpb.ExperimentRun) {
+	cw := csv.NewWriter(w)
+
+	header := append([]string{"run", "simulation_id", "state"}, names...)
+	header = append(header, experimentCSVMetrics...)
+	_ = cw.Write(append(header, "error"))
+
+	for _, run := range runs {
+		row := []string{
+			strconv.FormatUint(uint64(run.GetIndex()), 10),
+			run.GetSimulationId(),
+			experimentRunStateName(run.GetState()),
+		}
+		for _, name := range names {
+			if c, ok := run.GetChoices()[name]; ok {
+				row = append(row, c)
+			} else {
+				row = append(row, formatFloat(run.GetValues()[name]))
+			}
+		}
+
+		if sum := run.GetSummary(); sum != nil {
+			row = append(row,
+				strconv.FormatUint(sum.GetTicks(), 10),
+				strconv.FormatUint(sum.GetSimTimeMs(), 10),
+				sum.GetEndReason(),
+				formatFloat(sum.GetTotalYield()),
+				strconv.FormatUint(uint64(sum.GetHarvestedCells()), 10),
+				strconv.FormatUint(uint64(sum.GetSpoiledCells()), 10),
+				strconv.FormatUint(uint64(sum.GetTasksTotal()), 10),
+				strconv.FormatUint(uint64(sum.GetTasksCompleted()), 10),
+				strconv.FormatUint(uint64(sum.GetEntities()), 10),
+				formatFloat(sum.GetAvgBattery()),
+				strconv.FormatUint(uint64(sum.GetOfflineEntities()), 10),
+				formatFloat(sum.GetWallTimeMs()),
+			)
+		} else {
+			row = append(row, make([]string, len(experimentCSVMetrics))...)
+		}
+		_ = cw.Write(append(row, run.GetError()))
+	}
+	cw.Flush()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func experimentSpecFromJSON(reqBody *createExperimentRequest) (*simulationpb.ExperimentSpec, error) {
+	sampling, err := parseExperimentSampling(reqBody.Sampling)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &simulationpb.ExperimentSpec{
+		Name:         reqBody.Name,
+		BaseTemplate: reqBody.Template,
+		Sampling:     sampling,
+		Samples:      reqBody.Samples,
+		SamplingSeed: reqBody.SamplingSeed,
+		Concurrency:  reqBody.Concurrency,
+	}
+
+	switch {
+	case reqBody.Base != nil && reqBody.Template != "":
+		return nil, errors.New("set base or template, not both")
+	case reqBody.Base != nil:
+		// The base's name defaults to the experiment's.
+		if reqBody.Base.Name == "" {
+			reqBody.Base.Name = reqBody.Name
+		}
+		cfg, err := configFromJSON(reqBody.Base)
+		if err != nil {
+			return nil, errors.New("base: " + err.Error())
+		}
+		spec.BaseConfig = cfg
+	case reqBody.Template == "":
+		return nil, errors.New("base or template is required")
+	}
+
+	for _, pj := range reqBody.Parameters {
+		spec.Parameters = append(spec.Parameters, &simulationpb.ExperimentParameter{
+			Name:    pj.Name,
+			Values:  pj.Values,
+			Min:     pj.Min,
+			Max:     pj.Max,
+			Step:    pj.Step,
+			Choices: pj.Choices,
+		})
+	}
+	return spec, nil
+}
+
+func experimentToJSON(exp *simulationpb.Experiment) *experimentJSON {
+	if exp == nil {
+		return nil
+	}
+	spec := exp.GetSpec()
+	out := &experimentJSON{
+		ID:        exp.GetId(),
+		Name:      spec.GetName(),
+		State:     experimentStateName(exp.GetState()),
+		CreatedAt: exp.GetCreatedAt().AsTime(),
+		Progress:  experimentProgressToJSON(exp),
+
+		Base:         configToJSON(spec.GetBaseConfig()),
+		Template:     spec.GetBaseTemplate(),
+		Parameters:   make([]experimentParameterJSON, 0, len(spec.GetParameters())),
+		Sampling:     experimentSamplingName(spec.GetSampling()),
+		Samples:      spec.GetSamples(),
+		SamplingSeed: spec.GetSamplingSeed(),
+		Concurrency:  spec.GetConcurrency(),
+	}
+	if exp.GetEndedAt() != nil {
+		t := exp.GetEndedAt().AsTime()
+		out.EndedAt = &t
+	}
+	for _, p := range spec.GetParameters() {
+		out.Parameters = append(out.Parameters, experimentParameterJSON{
+			Name:    p.GetName(),
+			Values:  p.GetValues(),
+			Min:     p.GetMin(),
+			Max:     p.GetMax(),
+			Step:    p.GetStep(),
+			Choices: p.GetChoices(),
+		})
+	}
+	for _, run := range exp.GetRuns() {
+		out.Runs = append(out.Runs, experimentRunToJSON(run))
+	}
+	return out
+}
+
+func experimentProgressToJSON(exp *simulationpb.Experiment) experimentProgressJSON {
+	return experimentProgressJSON{
+		Total:     exp.GetTotalRuns(),
+		Queued:    exp.GetQueuedRuns(),
+		Running:   exp.GetRunningRuns(),
+		Completed: exp.GetCompletedRuns(),
+		Failed:    exp.GetFailedRuns(),
+		Canceled:  exp.GetCanceledRuns(),
+	}
+}
+
+func experimentRunToJSON(run *simulationpb.ExperimentRun) experimentRunJSON {
+	params := make(map[string]any, len(run.GetValues())+len(run.GetChoices()))
+	for name, v := range run.GetValues() {
+		params[name] = v
+	}
+	for name, c := range run.GetChoices() {
+		params[name] = c
+	}
+
+	out := experimentRunJSON{
+		Index:        run.GetIndex(),
+		Parameters:   params,
+		State:        experimentRunStateName(run.GetState()),
+		SimulationID: run.GetSimulationId(),
+		Error:        run.GetError(),
+	}
+	if sum := run.GetSummary(); sum != nil {
+		out.Summary = &experimentSummaryJSON{
+			Ticks:          sum.GetTicks(),
+			SimTimeMs:      sum.GetSimTimeMs(),
+			EndReason:      sum.GetEndReason(),
+			TotalYield:     sum.GetTotalYield(),
+			HarvestedCells: sum.GetHarvestedCells(),
+			SpoiledCells:   sum.GetSpoiledCells(),
+			TasksTotal:     sum.GetTasksTotal(),
+			TasksCompleted: sum.GetTasksCompleted(),
+			Entities:       sum.GetEntities(),
+			AvgBattery:     sum.GetAvgBattery(),
+			Offline:        sum.GetOfflineEntities(),
+			WallTimeMs:     sum.GetWallTimeMs(),
+		}
+	}
+	return out
+}
@@ -5,65 +5,289 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/stevenmed26/AutoFarm/internal/fleet"
 	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
 	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
 )
 
 type createSimulationRequest struct {
-	Name        string `json:"name"`
-	EntityCount uint32 `json:"entities"`
-	TickRateMs  uint32 `json:"tick_rate_ms"`
-	Scenario    string `json:"scenario_type"`
+	Name           string `json:"name"`
+	EntityCount    uint32 `json:"entities"`
+	TickRateMs     uint32 `json:"tick_rate_ms"`
+	Scenario       string `json:"scenario_type"`
+	TickDeadlineMs uint32 `json:"tick_deadline_ms"`
+	OverrunPolicy  string `json:"overrun_policy"`
+	PipelineDepth  uint32 `json:"pipeline_depth"`
+	TaskAllocation string `json:"task_allocation"`
+
+	Fleet       []fleetGroupJSON `json:"fleet,omitempty"`
+	EntityTypes []entityTypeJSON `json:"entity_types,omitempty"`
+	World       *worldJSON       `json:"world,omitempty"`
+
+	// Seed drives the weather; 0 lets the orchestrator pick one.
+	Seed        uint64                 `json:"seed"`
+	Environment *environmentConfigJSON `json:"environment,omitempty"`
+	Crops       *cropConfigJSON        `json:"crops,omitempty"`
+
+	// Tasks are queued when the simulation is created.
+	Tasks         []taskJSON              `json:"tasks,omitempty"`
+	WeatherScript []environmentChangeJSON `json:"weather_script,omitempty"`
+	Termination   *terminationJSON        `json:"termination,omitempty"`
+
+	// SnapshotIntervalTicks is how often a snapshot is kept for forks; 0
+	// means every 20 ticks.
+	SnapshotIntervalTicks uint32 `json:"snapshot_interval_ticks,omitempty"`
+}
+
+// fleetGroupJSON is one part of a fleet mix, e.g. {"type": "drone", "count": 50}.
+type fleetGroupJSON struct {
+	Type  string `json:"type"`
+	Count uint32 `json:"count"`
+
+	// Placements are the starting positions of the group's first entities.
+	Placements []placementJSON `json:"placements,omitempty"`
+}
+
+type placementJSON struct {
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	Battery float64 `json:"battery,omitempty"`
+}
+
+// entityTypeJSON carries per-type parameters; zero fields use the defaults.
+type entityTypeJSON struct {
+	Type                   string  `json:"type"`
+	MaxSpeed               float64 `json:"max_speed"`
+	BatteryCapacity        float64 `json:"battery_capacity"`
+	DrainRate              float64 `json:"drain_rate"`
+	PayloadCapacity        float64 `json:"payload_capacity"`
+	IgnoresGroundObstacles bool    `json:"ignores_ground_obstacles"`
+}
+
+// worldJSON is the static world layout: obstacles ground entities plan
+// paths around, and terrain that slows them down.
+type worldJSON struct {
+	CellSize      float64        `json:"cell_size,omitempty"`
+	PathAlgorithm string         `json:"path_algorithm,omitempty"`
+	Obstacles     []obstacleJSON `json:"obstacles,omitempty"`
+	Terrain       []terrainJSON  `json:"terrain,omitempty"`
+}
+
+type obstacleJSON struct {
+	MinX  float64 `json:"min_x"`
+	MinY  float64 `json:"min_y"`
+	MaxX  float64 `json:"max_x"`
+	MaxY  float64 `json:"max_y"`
+	Label string  `json:"label,omitempty"`
+}
+
+type terrainJSON struct {
+	MinX  float64 `json:"min_x"`
+	MinY  float64 `json:"min_y"`
+	MaxX  float64 `json:"max_x"`
+	MaxY  float64 `json:"max_y"`
+	Cost  float64 `json:"cost"`
+	Label string  `json:"label,omitempty"`
+}
+
+// pathAlgorithms maps the REST names of path planners to the proto enum.
+var pathAlgorithms = map[string]simulationpb.PathAlgorithm{
+	"":      simulationpb.PathAlgorithm_PATH_ALGORITHM_UNSPECIFIED,
+	"jps":   simulationpb.PathAlgorithm_PATH_ALGORITHM_JPS,
+	"astar": simulationpb.PathAlgorithm_PATH_ALGORITHM_ASTAR,
 }
 
 type simulationResponse struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Status      string `json:"status"`
-	EntityCount uint32 `json:"entities"`
-	TickRateMs  uint32 `json:"tick_rate_ms"`
-	Scenario    string `json:"scenario_type"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Tenant         string `json:"tenant"`
+	Status         string `json:"status"`
+	EntityCount    uint32 `json:"entities"`
+	TickRateMs     uint32 `json:"tick_rate_ms"`
+	Scenario       string `json:"scenario_type"`
+	TickDeadlineMs uint32 `json:"tick_deadline_ms"`
+	OverrunPolicy  string `json:"overrun_policy"`
+	PipelineDepth  uint32 `json:"pipeline_depth"`
+	TaskAllocation string `json:"task_allocation"`
+
+	SpeedMultiplier float64 `json:"speed_multiplier"`
+	FastForward     bool    `json:"fast_forward"`
+
+	// EndReason says which termination condition completed the run.
+	EndReason string `json:"end_reason,omitempty"`
+
+	// Where the simulation came from: a template, or the simulation it was
+	// cloned or forked from and the tick it was forked at, or the experiment
+	// it is a run of.
+	TemplateName       string `json:"template,omitempty"`
+	SourceSimulationID string `json:"source_simulation_id,omitempty"`
+	ForkedFromTick     uint64 `json:"forked_from_tick,omitempty"`
+	ExperimentID       string `json:"experiment_id,omitempty"`
+
+	Fleet       []fleetGroupJSON `json:"fleet,omitempty"`
+	EntityTypes []entityTypeJSON `json:"entity_types,omitempty"`
+	World       *worldJSON       `json:"world,omitempty"`
+
+	Seed        uint64                 `json:"seed"`
+	Environment *environmentConfigJSON `json:"environment,omitempty"`
+	Crops       *cropConfigJSON        `json:"crops,omitempty"`
+	Termination *terminationJSON       `json:"termination,omitempty"`
+}
+
+type stepSimulationResponse struct {
+	Simulation *simulationResponse `json:"simulation"`
+	Ticks      []*DashboardUpdate  `json:"ticks"`
+}
+
+type entitySpawnRequest struct {
+	Type    string  `json:"type"`
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	Vx      float64 `json:"vx"`
+	Vy      float64 `json:"vy"`
+	Battery float64 `json:"battery"`
+}
+
+type scaleEntitiesRequest struct {
+	Spawn      []entitySpawnRequest `json:"spawn"`
+	SpawnCount uint32               `json:"spawn_count"`
+	SpawnType  string               `json:"spawn_type"`
+	Retire     []uint64             `json:"retire"`
+}
+
+type scaleEntitiesResponse struct {
+	Simulation *simulationResponse `json:"simulation"`
+	SpawnedIDs []uint64            `json:"spawned_ids"`
+	RetiredIDs []uint64            `json:"retired_ids"`
+}
+
+type entityCommandRequest struct {
+	Type string  `json:"type"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	Vx   float64 `json:"vx"`
+	Vy   float64 `json:"vy"`
+}
+
+type entityCommandResponse struct {
+	CommandID uint64  `json:"command_id"`
+	EntityID  uint64  `json:"entity_id"`
+	Type      string  `json:"type"`
+	X         float64 `json:"x,omitempty"`
+	Y         float64 `json:"y,omitempty"`
+	Vx        float64 `json:"vx,omitempty"`
+	Vy        float64 `json:"vy,omitempty"`
+}
+
+// entityCommandTypes maps the REST names of entity commands to the proto enum.
+var entityCommandTypes = map[string]simulationpb.EntityCommandType{
+	"move_to":        simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_MOVE_TO,
+	"set_velocity":   simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_SET_VELOCITY,
+	"return_to_base": simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_RETURN_TO_BASE,
+	"disable":        simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_DISABLE,
+	"enable":         simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_ENABLE,
+}
+
+func entityCommandTypeName(t simulationpb.EntityCommandType) string {
+	for name, v := range entityCommandTypes {
+		if v == t {
+			return name
+		}
+	}
+	return t.String()
+}
+
+type setSpeedRequest struct {
+	Multiplier  float64 `json:"multiplier"`
+	FastForward bool    `json:"fast_forward"`
+}
+
+// overrunPolicies maps the REST names of tick overrun policies to the proto enum.
+var overrunPolicies = map[string]simulationpb.TickOverrunPolicy{
+	"":          simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_UNSPECIFIED,
+	"mark_late": simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_MARK_LATE,
+	"skip":      simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_SKIP,
+	"stretch":   simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_STRETCH,
+}
+
+func parseOverrunPolicy(name string) (simulationpb.TickOverrunPolicy, error) {
+	p, ok := overrunPolicies[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown overrun_policy %q (want mark_late, skip or stretch)", name)
+	}
+	return p, nil
+}
+
+func overrunPolicyName(p simulationpb.TickOverrunPolicy) string {
+	for name, v := range overrunPolicies {
+		if v == p && name != "" {
+			return name
+		}
+	}
+	return "mark_late"
 }
 
 func (s *Server) handleSimulations(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
+	case http.MethodGet:
+		s.handleListSimulations(w, r)
 	case http.MethodPost:
 		s.handleCreateSimulation(w, r)
 	default:
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
 	}
 }
 
 func (s *Server) handleCreateSimulation(w http.ResponseWriter, r *http.Request) {
+	data, err := readBody(r)
+	if err != nil {
+		writeError(w, "invalid body: "+err.Error(), bodyErrorStatus(err))
+		return
+	}
+
+	// Scenario documents come as a YAML body, or inline or by reference in
+	// a JSON body; templates by name in a JSON body.
+	if isYAML(r.Header.Get("Content-Type")) {
+		s.createSimulation(w, r, &simulationpb.CreateSimulationRequest{ScenarioDocument: data})
+		return
+	}
+	if req, ok, err := sourceRequestFromJSON(data); err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	} else if ok {
+		s.createSimulation(w, r, req)
+		return
+	}
+
 	var reqBody createSimulationRequest
-	if err := decodeJSONBody(r, &reqBody); err != nil {
-		http.Error(w, "invalid JSON: "+err.Error(), http.StatusBadRequest)
+	if err := json.Unmarshal(data, &reqBody); err != nil {
+		writeError(w, "invalid JSON: "+err.Error(), bodyErrorStatus(err))
 		return
 	}
 
-	if reqBody.Name == "" || reqBody.EntityCount == 0 || reqBody.TickRateMs == 0 {
-		http.Error(w, "name, entities, and tick_rate_ms are required", http.StatusBadRequest)
+	cfg, err := configFromJSON(&reqBody)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
+	s.createSimulation(w, r, &simulationpb.CreateSimulationRequest{Config: cfg})
+}
+
+func (s *Server) createSimulation(w http.ResponseWriter, r *http.Request, req *simulationpb.CreateSimulationRequest) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	resp, err := s.simClient.CreateSimulation(ctx, &simulationpb.CreateSimulationRequest{
-		Config: &simulationpb.SimulationConfig{
-			Name:         reqBody.Name,
-			EntityCount:  reqBody.EntityCount,
-			TickRateMs:   reqBody.TickRateMs,
-			ScenarioType: reqBody.Scenario,
-		},
-	})
+	resp, err := s.simClient.CreateSimulation(ctx, req)
 	if err != nil {
-		http.Error(w, "failed to create simulation: "+err.Error(), http.StatusInternalServerError)
+		writeRPCError(w, "failed to create simulation", err)
 		return
 	}
 
@@ -74,7 +298,7 @@ func (s *Server) handleSimulationByID(w http.ResponseWriter, r *http.Request) {
 	// Path format: /simulations/{id} or /simulations/{id}/action
 	path := strings.TrimPrefix(r.URL.Path, "/simulations/")
 	if path == "" {
-		http.NotFound(w, r)
+		writeError(w, "not found", http.StatusNotFound)
 		return
 	}
 	parts := strings.Split(path, "/")
@@ -85,8 +309,10 @@ func (s *Server) handleSimulationByID(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			s.handleGetSimulation(w, r, id)
+		case http.MethodDelete:
+			s.handleDeleteSimulation(w, r, id)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 		return
 	}
@@ -96,27 +322,142 @@ func (s *Server) handleSimulationByID(w http.ResponseWriter, r *http.Request) {
 	switch action {
 	case "start":
 		if r.Method != http.MethodPost {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		s.handleStartSimulation(w, r, id)
 	case "pause":
 		if r.Method != http.MethodPost {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		s.handlePauseSimulation(w, r, id)
 	case "stop":
 		if r.Method != http.MethodPost {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		s.handleStopSimulation(w, r, id)
+	case "speed":
+		if r.Method != http.MethodPost {
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleSetSimulationSpeed(w, r, id)
+	case "step":
+		if r.Method != http.MethodPost {
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleStepSimulation(w, r, id)
+	case "entities":
+		if len(parts) == 2 {
+			// /simulations/{id}/entities
+			if r.Method != http.MethodPost {
+				writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			s.handleScaleEntities(w, r, id)
+			return
+		}
+
+		// /simulations/{id}/entities/{eid}/commands
+		if len(parts) != 4 || parts[3] != "commands" {
+			writeError(w, "not found", http.StatusNotFound)
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		eid, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil || eid == 0 {
+			writeError(w, "invalid entity id", http.StatusBadRequest)
+			return
+		}
+		s.handleSendEntityCommand(w, r, id, eid)
+	case "tasks":
+		switch r.Method {
+		case http.MethodPost:
+			s.handleSubmitTasks(w, r, id)
+		case http.MethodGet:
+			s.handleListTasks(w, r, id)
+		default:
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	case "crops":
+		if r.Method != http.MethodGet {
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleGetCrops(w, r, id)
+	case "events":
+		if r.Method != http.MethodGet {
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleSimulationEvents(w, r, id)
+	case "clone":
+		if r.Method != http.MethodPost {
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleCloneSimulation(w, r, id)
+	case "environment":
+		if r.Method != http.MethodPost {
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleSetEnvironment(w, r, id)
 	default:
-		http.NotFound(w, r)
+		writeError(w, "not found", http.StatusNotFound)
 	}
 }
 
+type listSimulationsResponse struct {
+	Simulations []*simulationResponse `json:"simulations"`
+}
+
+// handleListSimulations lists simulations, optionally filtered by
+// ?status=running (or SIMULATION_STATUS_RUNNING).
+func (s *Server) handleListSimulations(w http.ResponseWriter, r *http.Request) {
+	status, err := parseSimulationStatus(r.URL.Query().Get("status"))
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.ListSimulations(ctx, &simulationpb.ListSimulationsRequest{Status: status})
+	if err != nil {
+		writeRPCError(w, "failed to list simulations", err)
+		return
+	}
+
+	out := listSimulationsResponse{Simulations: make([]*simulationResponse, 0, len(resp.GetSimulations()))}
+	for _, sim := range resp.GetSimulations() {
+		out.Simulations = append(out.Simulations, toSimulationResponse(sim))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func parseSimulationStatus(name string) (commonpb.SimulationStatus, error) {
+	if name == "" {
+		return commonpb.SimulationStatus_SIMULATION_STATUS_UNSPECIFIED, nil
+	}
+	name = strings.ToUpper(name)
+	if !strings.HasPrefix(name, "SIMULATION_STATUS_") {
+		name = "SIMULATION_STATUS_" + name
+	}
+	v, ok := commonpb.SimulationStatus_value[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown status %q", strings.ToLower(strings.TrimPrefix(name, "SIMULATION_STATUS_")))
+	}
+	return commonpb.SimulationStatus(v), nil
+}
+
 func (s *Server) handleGetSimulation(w http.ResponseWriter, r *http.Request, id string) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
@@ -125,7 +466,7 @@ func (s *Server) handleGetSimulation(w http.ResponseWriter, r *http.Request, id
 		Id: &commonpb.SimulationId{Value: id},
 	})
 	if err != nil {
-		http.Error(w, "failed to get simulation: "+err.Error(), http.StatusInternalServerError)
+		writeRPCError(w, "failed to get simulation", err)
 		return
 	}
 
@@ -140,7 +481,7 @@ func (s *Server) handleStartSimulation(w http.ResponseWriter, r *http.Request, i
 		Id: &commonpb.SimulationId{Value: id},
 	})
 	if err != nil {
-		http.Error(w, "failed to start simulation: "+err.Error(), http.StatusInternalServerError)
+		writeRPCError(w, "failed to start simulation", err)
 		return
 	}
 
@@ -155,7 +496,7 @@ func (s *Server) handlePauseSimulation(w http.ResponseWriter, r *http.Request, i
 		Id: &commonpb.SimulationId{Value: id},
 	})
 	if err != nil {
-		http.Error(w, "failed to pause simulation: "+err.Error(), http.StatusInternalServerError)
+		writeRPCError(w, "failed to pause simulation", err)
 		return
 	}
 
@@ -170,7 +511,187 @@ func (s *Server) handleStopSimulation(w http.ResponseWriter, r *http.Request, id
 		Id: &commonpb.SimulationId{Value: id},
 	})
 	if err != nil {
-		http.Error(w, "failed to stop simulation: "+err.Error(), http.StatusInternalServerError)
+		writeRPCError(w, "failed to stop simulation", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSimulationResponse(resp.GetSimulation()))
+}
+
+// handleDeleteSimulation stops the simulation if it is still active and
+// deletes it.
+func (s *Server) handleDeleteSimulation(w http.ResponseWriter, r *http.Request, id string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	_, err := s.simClient.DeleteSimulation(ctx, &simulationpb.DeleteSimulationRequest{
+		Id: &commonpb.SimulationId{Value: id},
+	})
+	if err != nil {
+		writeRPCError(w, "failed to delete simulation", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleStepSimulation(w http.ResponseWriter, r *http.Request, id string) {
+	ticks := uint64(1)
+	if v := r.URL.Query().Get("ticks"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil || n == 0 {
+			writeError(w, "ticks must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		ticks = n
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.StepSimulation(ctx, &simulationpb.StepSimulationRequest{
+		Id:    &commonpb.SimulationId{Value: id},
+		Ticks: uint32(ticks),
+	})
+	if err != nil {
+		writeRPCError(w, "failed to step simulation", err)
+		return
+	}
+
+	out := &stepSimulationResponse{
+		Simulation: toSimulationResponse(resp.GetSimulation()),
+		Ticks:      make([]*DashboardUpdate, 0, len(resp.GetTicks())),
+	}
+	for _, tick := range resp.GetTicks() {
+		out.Ticks = append(out.Ticks, dashboardUpdateFromProto(tick))
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleScaleEntities(w http.ResponseWriter, r *http.Request, id string) {
+	var reqBody scaleEntitiesRequest
+	if err := decodeJSONBody(r, &reqBody); err != nil {
+		writeError(w, "invalid JSON: "+err.Error(), bodyErrorStatus(err))
+		return
+	}
+
+	if len(reqBody.Spawn) == 0 && reqBody.SpawnCount == 0 && len(reqBody.Retire) == 0 {
+		writeError(w, "spawn, spawn_count or retire is required", http.StatusBadRequest)
+		return
+	}
+
+	spawnType, err := fleet.ParseType(reqBody.SpawnType)
+	if err != nil {
+		writeError(w, "spawn_type: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	spawn := make([]*simulationpb.EntityState, 0, len(reqBody.Spawn))
+	for _, sp := range reqBody.Spawn {
+		t, err := fleet.ParseType(sp.Type)
+		if err != nil {
+			writeError(w, "spawn: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		spawn = append(spawn, &simulationpb.EntityState{
+			Type:    t,
+			X:       sp.X,
+			Y:       sp.Y,
+			Vx:      sp.Vx,
+			Vy:      sp.Vy,
+			Battery: sp.Battery,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.ScaleEntities(ctx, &simulationpb.ScaleEntitiesRequest{
+		Id:         &commonpb.SimulationId{Value: id},
+		Spawn:      spawn,
+		SpawnCount: reqBody.SpawnCount,
+		SpawnType:  spawnType,
+		Retire:     reqBody.Retire,
+	})
+	if err != nil {
+		writeRPCError(w, "failed to scale entities", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &scaleEntitiesResponse{
+		Simulation: toSimulationResponse(resp.GetSimulation()),
+		SpawnedIDs: resp.GetSpawnedIds(),
+		RetiredIDs: resp.GetRetiredIds(),
+	})
+}
+
+func (s *Server) handleSendEntityCommand(w http.ResponseWriter, r *http.Request, id string, eid uint64) {
+	var reqBody entityCommandRequest
+	if err := decodeJSONBody(r, &reqBody); err != nil {
+		writeError(w, "invalid JSON: "+err.Error(), bodyErrorStatus(err))
+		return
+	}
+
+	cmdType, ok := entityCommandTypes[strings.ToLower(reqBody.Type)]
+	if !ok {
+		writeError(w, fmt.Sprintf("unknown command type %q", reqBody.Type), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.SendEntityCommand(ctx, &simulationpb.SendEntityCommandRequest{
+		Id: &commonpb.SimulationId{Value: id},
+		Command: &simulationpb.EntityCommand{
+			EntityId: eid,
+			Type:     cmdType,
+			X:        reqBody.X,
+			Y:        reqBody.Y,
+			Vx:       reqBody.Vx,
+			Vy:       reqBody.Vy,
+		},
+	})
+	if err != nil {
+		writeRPCError(w, "failed to send entity command", err)
+		return
+	}
+
+	cmd := resp.GetCommand()
+	writeJSON(w, http.StatusAccepted, &entityCommandResponse{
+		CommandID: cmd.GetCommandId(),
+		EntityID:  cmd.GetEntityId(),
+		Type:      entityCommandTypeName(cmd.GetType()),
+		X:         cmd.GetX(),
+		Y:         cmd.GetY(),
+		Vx:        cmd.GetVx(),
+		Vy:        cmd.GetVy(),
+	})
+}
+
+func (s *Server) handleSetSimulationSpeed(w http.ResponseWriter, r *http.Request, id string) {
+	var reqBody setSpeedRequest
+	if err := decodeJSONBody(r, &reqBody); err != nil {
+		writeError(w, "invalid JSON: "+err.Error(), bodyErrorStatus(err))
+		return
+	}
+
+	if !reqBody.FastForward && reqBody.Multiplier <= 0 {
+		writeError(w, "multiplier must be > 0 unless fast_forward is set", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.SetSimulationSpeed(ctx, &simulationpb.SetSimulationSpeedRequest{
+		Id:          &commonpb.SimulationId{Value: id},
+		Multiplier:  reqBody.Multiplier,
+		FastForward: reqBody.FastForward,
+	})
+	if err != nil {
+		writeRPCError(w, "failed to set simulation speed", err)
 		return
 	}
 
@@ -182,31 +703,287 @@ func toSimulationResponse(sim *simulationpb.Simulation) *simulationResponse {
 		return nil
 	}
 	return &simulationResponse{
-		ID:          sim.GetId().GetValue(),
-		Name:        sim.Config.GetName(),
-		Status:      sim.GetStatus().String(),
-		EntityCount: sim.Config.GetEntityCount(),
-		TickRateMs:  sim.Config.GetTickRateMs(),
-		Scenario:    sim.Config.GetScenarioType(),
+		ID:             sim.GetId().GetValue(),
+		Name:           sim.Config.GetName(),
+		Tenant:         sim.GetTenant(),
+		Status:         sim.GetStatus().String(),
+		EntityCount:    sim.Config.GetEntityCount(),
+		TickRateMs:     sim.Config.GetTickRateMs(),
+		Scenario:       sim.Config.GetScenarioType(),
+		TickDeadlineMs: sim.Config.GetTickDeadlineMs(),
+		OverrunPolicy:  overrunPolicyName(sim.Config.GetOverrunPolicy()),
+		PipelineDepth:  sim.Config.GetPipelineDepth(),
+		TaskAllocation: taskAllocationName(sim.Config.GetTaskAllocation()),
+
+		SpeedMultiplier: sim.GetSpeedMultiplier(),
+		FastForward:     sim.GetFastForward(),
+		EndReason:       sim.GetEndReason(),
+
+		TemplateName:       sim.GetTemplateName(),
+		SourceSimulationID: sim.GetSourceSimulationId(),
+		ForkedFromTick:     sim.GetForkedFromTick(),
+		ExperimentID:       sim.GetExperimentId(),
+
+		Fleet:       fleetToJSON(sim.Config.GetFleet()),
+		EntityTypes: entityTypesToJSON(sim.Config.GetEntityTypes()),
+		World:       worldToJSON(sim.Config.GetWorld()),
+
+		Seed:        sim.Config.GetSeed(),
+		Environment: environmentConfigToJSON(sim.Config.GetEnvironment()),
+		Crops:       cropConfigToJSON(sim.Config.GetCrops()),
+		Termination: terminationToJSON(sim.Config.GetTermination()),
+	}
+}
+
+// configFromJSON checks the required fields of a create body and turns it
+// into a simulation config.
+func configFromJSON(reqBody *createSimulationRequest) (*simulationpb.SimulationConfig, error) {
+	if reqBody.Name == "" || (reqBody.EntityCount == 0 && len(reqBody.Fleet) == 0) || reqBody.TickRateMs == 0 {
+		return nil, errors.New("name, entities (or fleet), and tick_rate_ms are required")
+	}
+
+	fleetMix, entityTypes, err := fleetFromJSON(reqBody.Fleet, reqBody.EntityTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := parseOverrunPolicy(reqBody.OverrunPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	allocation, err := parseTaskAllocation(reqBody.TaskAllocation)
+	if err != nil {
+		return nil, err
+	}
+
+	worldDef, err := worldFromJSON(reqBody.World)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &simulationpb.SimulationConfig{
+		Name:                  reqBody.Name,
+		EntityCount:           reqBody.EntityCount,
+		TickRateMs:            reqBody.TickRateMs,
+		ScenarioType:          reqBody.Scenario,
+		TickDeadlineMs:        reqBody.TickDeadlineMs,
+		OverrunPolicy:         policy,
+		PipelineDepth:         reqBody.PipelineDepth,
+		TaskAllocation:        allocation,
+		Fleet:                 fleetMix,
+		EntityTypes:           entityTypes,
+		World:                 worldDef,
+		Seed:                  reqBody.Seed,
+		Environment:           environmentConfigFromJSON(reqBody.Environment),
+		Crops:                 cropConfigFromJSON(reqBody.Crops),
+		Termination:           terminationFromJSON(reqBody.Termination),
+		SnapshotIntervalTicks: reqBody.SnapshotIntervalTicks,
 	}
+	for i, tj := range reqBody.Tasks {
+		t, err := taskFromJSON(tj)
+		if err != nil {
+			return nil, fmt.Errorf("tasks[%d]: %w", i, err)
+		}
+		cfg.Tasks = append(cfg.Tasks, t)
+	}
+	for i, cj := range reqBody.WeatherScript {
+		c, err := environmentChangeFromJSON(cj)
+		if err != nil {
+			return nil, fmt.Errorf("weather_script[%d]: %w", i, err)
+		}
+		cfg.WeatherScript = append(cfg.WeatherScript, c)
+	}
+	return cfg, nil
+}
+
+// configToJSON is the inverse of configFromJSON.
+func configToJSON(cfg *simulationpb.SimulationConfig) *createSimulationRequest {
+	if cfg == nil {
+		return nil
+	}
+	out := &createSimulationRequest{
+		Name:                  cfg.GetName(),
+		EntityCount:           cfg.GetEntityCount(),
+		TickRateMs:            cfg.GetTickRateMs(),
+		Scenario:              cfg.GetScenarioType(),
+		TickDeadlineMs:        cfg.GetTickDeadlineMs(),
+		OverrunPolicy:         overrunPolicyName(cfg.GetOverrunPolicy()),
+		PipelineDepth:         cfg.GetPipelineDepth(),
+		TaskAllocation:        taskAllocationName(cfg.GetTaskAllocation()),
+		World:                 worldToJSON(cfg.GetWorld()),
+		Seed:                  cfg.GetSeed(),
+		Environment:           environmentConfigToJSON(cfg.GetEnvironment()),
+		Crops:                 cropConfigToJSON(cfg.GetCrops()),
+		Termination:           terminationToJSON(cfg.GetTermination()),
+		SnapshotIntervalTicks: cfg.GetSnapshotIntervalTicks(),
+	}
+	if len(cfg.GetFleet()) > 0 {
+		out.Fleet = fleetToJSON(cfg.GetFleet())
+	}
+	if len(cfg.GetEntityTypes()) > 0 {
+		out.EntityTypes = entityTypesToJSON(cfg.GetEntityTypes())
+	}
+	if len(cfg.GetTasks()) > 0 {
+		out.Tasks = tasksToJSON(cfg.GetTasks()).Tasks
+	}
+	for _, c := range cfg.GetWeatherScript() {
+		out.WeatherScript = append(out.WeatherScript, environmentChangeToJSON(c))
+	}
+	return out
+}
+
+func fleetFromJSON(groups []fleetGroupJSON, types []entityTypeJSON) ([]*simulationpb.FleetGroup, []*simulationpb.EntityTypeParams, error) {
+	fleetMix := make([]*simulationpb.FleetGroup, 0, len(groups))
+	for _, g := range groups {
+		t, err := fleet.ParseType(g.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fleet: %w", err)
+		}
+		group := &simulationpb.FleetGroup{Type: t, Count: g.Count}
+		for _, p := range g.Placements {
+			group.Placements = append(group.Placements, &simulationpb.EntityPlacement{X: p.X, Y: p.Y, Battery: p.Battery})
+		}
+		fleetMix = append(fleetMix, group)
+	}
+
+	params := make([]*simulationpb.EntityTypeParams, 0, len(types))
+	for _, p := range types {
+		t, err := fleet.ParseType(p.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("entity_types: %w", err)
+		}
+		params = append(params, &simulationpb.EntityTypeParams{
+			Type:                   t,
+			MaxSpeed:               p.MaxSpeed,
+			BatteryCapacity:        p.BatteryCapacity,
+			DrainRate:              p.DrainRate,
+			PayloadCapacity:        p.PayloadCapacity,
+			IgnoresGroundObstacles: p.IgnoresGroundObstacles,
+		})
+	}
+
+	return fleetMix, params, nil
+}
+
+func fleetToJSON(groups []*simulationpb.FleetGroup) []fleetGroupJSON {
+	out := make([]fleetGroupJSON, 0, len(groups))
+	for _, g := range groups {
+		group := fleetGroupJSON{Type: fleet.TypeName(g.GetType()), Count: g.GetCount()}
+		for _, p := range g.GetPlacements() {
+			group.Placements = append(group.Placements, placementJSON{X: p.GetX(), Y: p.GetY(), Battery: p.GetBattery()})
+		}
+		out = append(out, group)
+	}
+	return out
+}
+
+func entityTypesToJSON(types []*simulationpb.EntityTypeParams) []entityTypeJSON {
+	out := make([]entityTypeJSON, 0, len(types))
+	for _, p := range types {
+		out = append(out, entityTypeJSON{
+			Type:                   fleet.TypeName(p.GetType()),
+			MaxSpeed:               p.GetMaxSpeed(),
+			BatteryCapacity:        p.GetBatteryCapacity(),
+			DrainRate:              p.GetDrainRate(),
+			PayloadCapacity:        p.GetPayloadCapacity(),
+			IgnoresGroundObstacles: p.GetIgnoresGroundObstacles(),
+		})
+	}
+	return out
+}
+
+func worldFromJSON(wj *worldJSON) (*simulationpb.WorldDefinition, error) {
+	if wj == nil {
+		return nil, nil
+	}
+	algorithm, ok := pathAlgorithms[strings.ToLower(wj.PathAlgorithm)]
+	if !ok {
+		return nil, fmt.Errorf("unknown world.path_algorithm %q (want jps or astar)", wj.PathAlgorithm)
+	}
+
+	def := &simulationpb.WorldDefinition{
+		CellSize:      wj.CellSize,
+		PathAlgorithm: algorithm,
+	}
+	for _, o := range wj.Obstacles {
+		def.Obstacles = append(def.Obstacles, &simulationpb.Obstacle{
+			MinX:  o.MinX,
+			MinY:  o.MinY,
+			MaxX:  o.MaxX,
+			MaxY:  o.MaxY,
+			Label: o.Label,
+		})
+	}
+	for _, t := range wj.Terrain {
+		def.Terrain = append(def.Terrain, &simulationpb.TerrainPatch{
+			MinX:  t.MinX,
+			MinY:  t.MinY,
+			MaxX:  t.MaxX,
+			MaxY:  t.MaxY,
+			Cost:  t.Cost,
+			Label: t.Label,
+		})
+	}
+	return def, nil
+}
+
+func worldToJSON(def *simulationpb.WorldDefinition) *worldJSON {
+	if def == nil {
+		return nil
+	}
+	wj := &worldJSON{CellSize: def.GetCellSize()}
+	for name, v := range pathAlgorithms {
+		if v == def.GetPathAlgorithm() && name != "" {
+			wj.PathAlgorithm = name
+		}
+	}
+	for _, o := range def.GetObstacles() {
+		wj.Obstacles = append(wj.Obstacles, obstacleJSON{
+			MinX:  o.GetMinX(),
+			MinY:  o.GetMinY(),
+			MaxX:  o.GetMaxX(),
+			MaxY:  o.GetMaxY(),
+			Label: o.GetLabel(),
+		})
+	}
+	for _, t := range def.GetTerrain() {
+		wj.Terrain = append(wj.Terrain, terrainJSON{
+			MinX:  t.GetMinX(),
+			MinY:  t.GetMinY(),
+			MaxX:  t.GetMaxX(),
+			MaxY:  t.GetMaxY(),
+			Cost:  t.GetCost(),
+			Label: t.GetLabel(),
+		})
+	}
+	return wj
 }
 
 // helpers
 
 func decodeJSONBody(r *http.Request, dst any) error {
+	data, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+func readBody(r *http.Request) ([]byte, error) {
 	if r.Body == nil {
-		return errors.New("empty body")
+		return nil, errors.New("empty body")
 	}
 	defer r.Body.Close()
 
-	data, err := io.ReadAll(io.LimitReader(r.Body, 1<<20)) // 1MB limit
+	data, err := io.ReadAll(r.Body) // capped by limitBody
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(data) == 0 {
-		return errors.New("empty body")
+		return nil, errors.New("empty body")
 	}
-	return json.Unmarshal(data, dst)
+	return data, nil
 }
 
 func writeJSON(w http.ResponseWriter, status int, v any) {
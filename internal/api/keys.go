@@ -0,0 +1,221 @@
+// internal/api/keys.go
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/stevenmed26/AutoFarm/internal/store"
+	"github.com/stevenmed26/AutoFarm/internal/tenant"
+)
+
+// apiKeyJSON is an API key without its secret.
+type apiKeyJSON struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name,omitempty"`
+	Subject   string     `json:"subject"`
+	Tenant    string     `json:"tenant"`
+	Role      string     `json:"role"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// createdKeyJSON is a new key with its secret, which is never shown again.
+type createdKeyJSON struct {
+	apiKeyJSON
+	Key string `json:"key"`
+}
+
+type createKeyRequest struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+
+	// Role defaults to operator; "admin": true is the older way to ask
+	// for the admin role.
+	Role  string `json:"role"`
+	Admin bool   `json:"admin"`
+
+	// Tenant defaults to the tenant of the admin creating the key, and
+	// may not name another.
+	Tenant string `json:"tenant"`
+
+	// ExpiresIn is a Go duration such as "720h"; empty keys do not expire.
+	ExpiresIn string `json:"expires_in"`
+}
+
+type listKeysResponse struct {
+	Keys []*apiKeyJSON `json:"keys"`
+}
+
+// handleWhoAmI returns the caller of the request.
+func (s *Server) handleWhoAmI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	p, ok := PrincipalFromContext(r.Context())
+	if !ok {
+		writeError(w, "authentication is not enabled", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handleKeys(w http.ResponseWriter, r *http.Request) {
+	if !s.requireKeys(w) {
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s.handleListKeys(w, r)
+	case http.MethodPost:
+		s.handleCreateKey(w, r)
+	default:
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleKeyByID(w http.ResponseWriter, r *http.Request) {
+	// Path format: /auth/keys/{id}
+	id := strings.TrimPrefix(r.URL.Path, "/auth/keys/")
+	if id == "" || strings.Contains(id, "/") {
+		writeError(w, "not found", http.StatusNotFound)
+		return
+	}
+	if !s.requireKeys(w) {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		k, err := s.keys.GetKey(r.Context(), callerTenant(r), id)
+		if err != nil {
+			writeKeyError(w, "failed to get key", err)
+			return
+		}
+		writeJSON(w, http.StatusOK, keyToJSON(k))
+	case http.MethodDelete:
+		if err := s.keys.DeleteKey(r.Context(), callerTenant(r), id); err != nil {
+			writeKeyError(w, "failed to delete key", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// requireKeys reports whether there are keys to manage, and writes the
+// error response if not. Only admins get this far; see RegisterRoutes.
+func (s *Server) requireKeys(w http.ResponseWriter) bool {
+	if s.keys == nil {
+		writeError(w, "authentication is not enabled", http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+// callerTenant returns the tenant of the admin managing keys. Admins only
+// manage their own tenant's keys.
+func callerTenant(r *http.Request) string {
+	if p, ok := PrincipalFromContext(r.Context()); ok {
+		return p.Tenant
+	}
+	return tenant.Default
+}
+
+func (s *Server) handleListKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := s.keys.ListKeys(r.Context(), callerTenant(r))
+	if err != nil {
+		writeError(w, "failed to list keys: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	out := listKeysResponse{Keys: make([]*apiKeyJSON, 0, len(keys))}
+	for _, k := range keys {
+		out.Keys = append(out.Keys, keyToJSON(k))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleCreateKey(w http.ResponseWriter, r *http.Request) {
+	var req createKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, "invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Subject == "" {
+		writeError(w, "subject is required", http.StatusBadRequest)
+		return
+	}
+	if own := callerTenant(r); req.Tenant == "" {
+		req.Tenant = own
+	} else if req.Tenant != own {
+		writeError(w, "cannot create keys for tenant "+req.Tenant, http.StatusForbidden)
+		return
+	}
+	role, err := resolveRole(req.Role, req.Admin)
+	if err != nil {
+		writeError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	k := &store.APIKey{
+		ID:      uuid.NewString(),
+		Name:    req.Name,
+		Subject: req.Subject,
+		Tenant:  req.Tenant,
+		Role:    string(role),
+	}
+	if req.ExpiresIn != "" {
+		d, err := time.ParseDuration(req.ExpiresIn)
+		if err != nil || d <= 0 {
+			writeError(w, "expires_in must be a positive duration such as 720h", http.StatusBadRequest)
+			return
+		}
+		k.ExpiresAt = time.Now().Add(d).UTC()
+	}
+
+	secret, err := newKeySecret()
+	if err != nil {
+		writeError(w, "failed to create key: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	k.Hash = hashKey(secret)
+
+	created, err := s.keys.CreateKey(r.Context(), k)
+	if err != nil {
+		writeError(w, "failed to create key: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusCreated, createdKeyJSON{apiKeyJSON: *keyToJSON(created), Key: secret})
+}
+
+func writeKeyError(w http.ResponseWriter, msg string, err error) {
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeError(w, msg+": "+err.Error(), http.StatusInternalServerError)
+}
+
+func keyToJSON(k *store.APIKey) *apiKeyJSON {
+	out := &apiKeyJSON{
+		ID:        k.ID,
+		Name:      k.Name,
+		Subject:   k.Subject,
+		Tenant:    k.Tenant,
+		Role:      k.Role,
+		CreatedAt: k.CreatedAt,
+	}
+	if !k.ExpiresAt.IsZero() {
+		t := k.ExpiresAt
+		out.ExpiresAt = &t
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stevenmed26/AutoFarm/internal/store"
+)
+
+// keyServer returns the routes of a gateway whose keys are an admin key of
+// tenant a, "afk_admin-a", an admin key of tenant b, "afk_admin-b", and
+// key "b1" of tenant b.
+func keyServer(t *testing.T) http.Handler {
+	t.Helper()
+	ks := store.NewMemoryStore()
+	for _, k := range []*store.APIKey{
+		{ID: "admin-a", Subject: "ops-a", Tenant: "a", Role: "admin", Hash: hashKey("afk_admin-a")},
+		{ID: "admin-b", Subject: "ops-b", Tenant: "b", Role: "admin", Hash: hashKey("afk_admin-b")},
+		{ID: "b1", Subject: "ci", Tenant: "b", Role: "operator", Hash: hashKey("afk_b1")},
+	} {
+		if _, err := ks.CreateKey(context.Background(), k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := NewServer(nil, WithAuth(NewAuthenticator(AuthConfig{Keys: ks}), ks))
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux)
+	return mux
+}
+
+func TestKeysTenantIsolation(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantIDs  []string // of the keys listed
+	}{
+		{
+			name: "list own keys", key: "afk_admin-b",
+			method: http.MethodGet, path: "/auth/keys",
+			wantCode: http.StatusOK, wantIDs: []string{"admin-b", "b1"},
+		},
+		{
+			name: "list leaves other tenants out", key: "afk_admin-a",
+			method: http.MethodGet, path: "/auth/keys",
+			wantCode: http.StatusOK, wantIDs: []string{"admin-a"},
+		},
+		{
+			name: "get own key", key: "afk_admin-b",
+			method: http.MethodGet, path: "/auth/keys/b1",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "get other tenant's key", key: "afk_admin-a",
+			method: http.MethodGet, path: "/auth/keys/b1",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "revoke other tenant's key", key: "afk_admin-a",
+			method: http.MethodDelete, path: "/auth/keys/b1",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "mint for other tenant", key: "afk_admin-a",
+			method: http.MethodPost, path: "/auth/keys", body: `{"subject": "x", "tenant": "b"}`,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "mint for own tenant", key: "afk_admin-a",
+			method: http.MethodPost, path: "/auth/keys", body: `{"subject": "x", "tenant": "a"}`,
+			wantCode: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := keyServer(t)
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("X-API-Key", tt.key)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("%s %s = %d %s, want %d", tt.method, tt.path, rec.Code, rec.Body, tt.wantCode)
+			}
+			if tt.wantIDs == nil {
+				return
+			}
+			var out listKeysResponse
+			if err := json.NewDecoder(rec.Body).Decode(&out); err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, k := range out.Keys {
+				ids = append(ids, k.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("listed keys %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+// TestKeysRevokeOtherTenant checks a key another tenant's admin failed to
+// revoke keeps working.
+func TestKeysRevokeOtherTenant(t *testing.T) {
+	h := keyServer(t)
+	do := func(key, method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := do("afk_admin-a", http.MethodDelete, "/auth/keys/b1"); code != http.StatusNotFound {
+		t.Fatalf("tenant a revoking b1 = %d, want %d", code, http.StatusNotFound)
+	}
+	if code := do("afk_b1", http.MethodGet, "/auth/whoami"); code != http.StatusOK {
+		t.Fatalf("b1 after tenant a revoked it = %d, want %d", code, http.StatusOK)
+	}
+	if code := do("afk_admin-b", http.MethodDelete, "/auth/keys/b1"); code != http.StatusNoContent {
+		t.Fatalf("tenant b revoking b1 = %d, want %d", code, http.StatusNoContent)
+	}
+	if code := do("afk_b1", http.MethodGet, "/auth/whoami"); code != http.StatusUnauthorized {
+		t.Fatalf("b1 after tenant b revoked it = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
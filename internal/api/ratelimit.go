@@ -0,0 +1,299 @@
+// internal/api/ratelimit.go
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limits bounds what each client may do. A client is an API key, a token
+// subject or, without authentication, an IP address. Zero fields disable
+// their limit.
+type Limits struct {
+	// ReadRate is the sustained rate of GET requests per second; ReadBurst
+	// how many may come at once.
+	ReadRate  float64
+	ReadBurst int
+
+	// WriteRate and WriteBurst are the same for every other request.
+	WriteRate  float64
+	WriteBurst int
+
+	// AuthFailureRate and AuthFailureBurst are the same for the failed
+	// authentications of each IP address. Once an address has spent them,
+	// its requests are refused before their credentials are checked.
+	AuthFailureRate  float64
+	AuthFailureBurst int
+
+	// MaxWebSockets caps the client's open WebSocket connections.
+	MaxWebSockets int
+
+	// MaxBodyBytes caps the size of request bodies, for every client.
+	MaxBodyBytes int64
+}
+
+// DefaultLimits leave room for dashboards and scripts while stopping
+// runaway ones.
+var DefaultLimits = Limits{
+	ReadRate:         50,
+	ReadBurst:        100,
+	WriteRate:        5,
+	WriteBurst:       20,
+	AuthFailureRate:  0.2,
+	AuthFailureBurst: 10,
+	MaxWebSockets:    16,
+	MaxBodyBytes:     1 << 20,
+}
+
+// Budgets of the limits, as reported in the budget label of the metrics.
+const (
+	budgetRead      = "read"
+	budgetWrite     = "write"
+	budgetWebSocket = "websocket"
+	budgetAuth      = "auth_failure"
+)
+
+// limitRate is a Middleware answering clients that exceed their read or
+// write budget with 429. It runs after the auth middleware, so it can tell
+// clients apart by key.
+func (s *Server) limitRate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		budget, l := budgetWrite, s.writeLimiter
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			budget, l = budgetRead, s.readLimiter
+		}
+		if ok, wait := l.allow(clientKey(r), time.Now()); !ok {
+			s.rateLimited.Inc(budget)
+			writeTooManyRequests(w, wait, fmt.Sprintf("rate limit exceeded: %g %s requests per second", l.rate, budget))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limitAuthFailures is a Middleware answering IP addresses that have spent
+// their budget of failed authentications with 429. It runs before the auth
+// middleware, counting the 401s it answers, so credentials cannot be
+// guessed at the rate of the read and write budgets, which only apply once
+// a caller has authenticated.
+func (s *Server) limitAuthFailures(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := "ip:" + clientIP(r)
+		if wait := s.authLimiter.wait(client, time.Now()); wait > 0 {
+			s.rateLimited.Inc(budgetAuth)
+			writeTooManyRequests(w, wait, fmt.Sprintf("too many failed authentications: %g per second", s.authLimiter.rate))
+			return
+		}
+		rec := &responseRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		if rec.status == http.StatusUnauthorized {
+			s.authLimiter.allow(client, time.Now())
+		}
+	})
+}
+
+// limitWebSockets caps the WebSocket connections each client holds open.
+func (s *Server) limitWebSockets(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		client := clientKey(r)
+		if !s.wsLimiter.acquire(client) {
+			s.rateLimited.Inc(budgetWebSocket)
+			writeTooManyRequests(w, time.Second, fmt.Sprintf("too many WebSocket connections: at most %d per client", s.wsLimiter.max))
+			return
+		}
+		s.webSockets.Inc()
+		defer func() {
+			s.wsLimiter.release(client)
+			s.webSockets.Dec()
+		}()
+		h(w, r)
+	}
+}
+
+// limitBody is a Middleware rejecting bodies larger than MaxBodyBytes:
+// with 413 when their Content-Length says so up front, and otherwise by
+// failing reads past the limit.
+func (s *Server) limitBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := s.limits.MaxBodyBytes
+		if limit > 0 {
+			if r.ContentLength > limit {
+				s.bodyTooLarge.Inc()
+				writeError(w, fmt.Sprintf("request body too large: at most %d bytes", limit), http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bodyErrorStatus is the status for a failure to read or decode a request
+// body: 413 when limitBody cut it short, 400 otherwise.
+func bodyErrorStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// clientKey identifies the client of a request: its API key, its token's
+// subject, or its IP address without authentication.
+func clientKey(r *http.Request) string {
+	if p, ok := PrincipalFromContext(r.Context()); ok {
+		if p.Method == "api_key" {
+			return "key:" + p.KeyID
+		}
+		return "sub:" + p.Subject
+	}
+	return "ip:" + clientIP(r)
+}
+
+func writeTooManyRequests(w http.ResponseWriter, retryAfter time.Duration, msg string) {
+	secs := max(1, int(math.Ceil(retryAfter.Seconds())))
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	writeError(w, msg, http.StatusTooManyRequests)
+}
+
+// rateLimiter keeps a token bucket per client. A nil *rateLimiter allows
+// everything.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a limiter refilling rate tokens per second up to
+// burst, or nil if rate is not positive.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(max(1, burst)),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// allow takes a token from the client's bucket. Without one, it reports
+// how long until the next.
+func (l *rateLimiter) allow(client string, now time.Time) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.refill(client, now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, l.untilToken(b)
+}
+
+// wait reports how long until the client's bucket has a token, without
+// taking it: 0 if it has one now.
+func (l *rateLimiter) wait(client string, now time.Time) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.refill(client, now)
+	if b.tokens >= 1 {
+		return 0
+	}
+	return l.untilToken(b)
+}
+
+// refill returns the client's bucket with the tokens it has gained since
+// it was last used. l.mu must be held.
+func (l *rateLimiter) refill(client string, now time.Time) *tokenBucket {
+	l.sweep(now)
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	return b
+}
+
+// untilToken is how long b takes to refill to a token.
+func (l *rateLimiter) untilToken(b *tokenBucket) time.Duration {
+	return time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep forgets, about once a minute, the buckets that have refilled, which
+// a new bucket would equal. l.mu must be held.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for client, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// connLimiter counts each client's open connections. A nil *connLimiter
+// allows any number.
+type connLimiter struct {
+	max int
+
+	mu   sync.Mutex
+	open map[string]int
+}
+
+func newConnLimiter(limit int) *connLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &connLimiter{max: limit, open: make(map[string]int)}
+}
+
+// acquire counts a new connection of client, unless it has max open.
+func (l *connLimiter) acquire(client string) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.open[client] >= l.max {
+		return false
+	}
+	l.open[client]++
+	return true
+}
+
+// release uncounts a connection acquire counted.
+func (l *connLimiter) release(client string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.open[client]--; l.open[client] <= 0 {
+		delete(l.open, client)
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stevenmed26/AutoFarm/internal/store"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	start := time.Unix(1_800_000_000, 0)
+
+	// Each step is a request of client at start+at.
+	type step struct {
+		client    string
+		at        time.Duration
+		allowed   bool
+		retryWait time.Duration
+	}
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int
+		steps []step
+	}{
+		{
+			name: "burst then refusal",
+			rate: 1, burst: 2,
+			steps: []step{
+				{"a", 0, true, 0},
+				{"a", 0, true, 0},
+				{"a", 0, false, time.Second},
+				{"a", 250 * time.Millisecond, false, 750 * time.Millisecond},
+			},
+		},
+		{
+			name: "refill",
+			rate: 2, burst: 1,
+			steps: []step{
+				{"a", 0, true, 0},
+				{"a", 100 * time.Millisecond, false, 400 * time.Millisecond},
+				{"a", 500 * time.Millisecond, true, 0},
+			},
+		},
+		{
+			name: "refill stops at burst",
+			rate: 10, burst: 2,
+			steps: []step{
+				{"a", 0, true, 0},
+				{"a", 0, true, 0},
+				{"a", time.Hour, true, 0},
+				{"a", time.Hour, true, 0},
+				{"a", time.Hour, false, 100 * time.Millisecond},
+			},
+		},
+		{
+			name: "clients have their own buckets",
+			rate: 1, burst: 1,
+			steps: []step{
+				{"a", 0, true, 0},
+				{"a", 0, false, time.Second},
+				{"b", 0, true, 0},
+			},
+		},
+		{
+			name: "burst of at least one",
+			rate: 1, burst: 0,
+			steps: []step{
+				{"a", 0, true, 0},
+				{"a", 0, false, time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(tt.rate, tt.burst)
+			for i, s := range tt.steps {
+				allowed, wait := l.allow(s.client, start.Add(s.at))
+				if allowed != s.allowed || wait != s.retryWait {
+					t.Fatalf("step %d: allow(%q) = %v, %v; want %v, %v", i, s.client, allowed, wait, s.allowed, s.retryWait)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	l := newRateLimiter(0, 10)
+	for i := 0; i < 100; i++ {
+		if ok, _ := l.allow("a", time.Now()); !ok {
+			t.Fatalf("request %d refused by a disabled limiter", i)
+		}
+	}
+}
+
+// TestLimitAuthFailures checks an address that keeps failing to
+// authenticate is refused before its credentials are checked, while good
+// credentials cost nothing.
+func TestLimitAuthFailures(t *testing.T) {
+	ks := store.NewMemoryStore()
+	k := &store.APIKey{ID: "k", Subject: "ops", Tenant: "a", Role: "viewer", Hash: hashKey("afk_good")}
+	if _, err := ks.CreateKey(context.Background(), k); err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(nil,
+		WithAuth(NewAuthenticator(AuthConfig{Keys: ks}), ks),
+		WithLimits(Limits{AuthFailureRate: 0.01, AuthFailureBurst: 2}))
+	mux := http.NewServeMux()
+	s.RegisterRoutes(mux)
+
+	steps := []struct {
+		ip       string
+		key      string
+		wantCode int
+	}{
+		{"192.0.2.1", "afk_good", http.StatusOK},
+		{"192.0.2.1", "afk_good", http.StatusOK},
+		{"192.0.2.1", "afk_good", http.StatusOK},
+		{"192.0.2.1", "afk_bad", http.StatusUnauthorized},
+		{"192.0.2.1", "", http.StatusUnauthorized},
+		{"192.0.2.1", "afk_bad", http.StatusTooManyRequests},
+		{"192.0.2.1", "afk_good", http.StatusTooManyRequests},
+		{"192.0.2.2", "afk_good", http.StatusOK},
+		{"192.0.2.2", "afk_bad", http.StatusUnauthorized},
+	}
+	for i, st := range steps {
+		req := httptest.NewRequest(http.MethodGet, "/auth/whoami", nil)
+		req.RemoteAddr = st.ip + ":4321"
+		if st.key != "" {
+			req.Header.Set("X-API-Key", st.key)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if rec.Code != st.wantCode {
+			t.Fatalf("step %d: %s with key %q got %d, want %d: %s", i, st.ip, st.key, rec.Code, st.wantCode, rec.Body)
+		}
+		if rec.Code == http.StatusTooManyRequests && rec.Header().Get("Retry-After") != "100" {
+			t.Errorf("step %d: Retry-After %q, want 100", i, rec.Header().Get("Retry-After"))
+		}
+	}
+}
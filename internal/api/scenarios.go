@@ -0,0 +1,149 @@
+// internal/api/scenarios.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// terminationJSON ends a run once any of its non-zero conditions is met.
+type terminationJSON struct {
+	MaxTicks      uint64  `json:"max_ticks,omitempty"`
+	MaxSimTimeMs  uint64  `json:"max_sim_time_ms,omitempty"`
+	TasksComplete bool    `json:"tasks_complete,omitempty"`
+	TargetYield   float64 `json:"target_yield,omitempty"`
+}
+
+type scenarioJSON struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+type listScenariosResponse struct {
+	Scenarios []scenarioJSON `json:"scenarios"`
+}
+
+// isYAML reports whether a Content-Type header names YAML.
+func isYAML(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return true
+	}
+	return false
+}
+
+// sourceRequestFromJSON turns a JSON create body that takes its config from
+// elsewhere, {"scenario": {...}}, {"scenario_ref": "name"} or
+// {"template": "name"}, each optionally with "overrides", into a create
+// request. It reports false for any other body.
+func sourceRequestFromJSON(data []byte) (*simulationpb.CreateSimulationRequest, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		// Left for the regular decoder to report.
+		return nil, false, nil
+	}
+	doc, hasDoc := fields["scenario"]
+	ref, hasRef := fields["scenario_ref"]
+	tmpl, hasTemplate := fields["template"]
+	if !hasDoc && !hasRef && !hasTemplate {
+		return nil, false, nil
+	}
+
+	req := &simulationpb.CreateSimulationRequest{}
+	n := len(fields)
+	if raw, ok := fields["overrides"]; ok {
+		var oj overridesJSON
+		if err := json.Unmarshal(raw, &oj); err != nil {
+			return nil, false, fmt.Errorf("invalid overrides: %w", err)
+		}
+		o, err := overridesFromJSON(&oj)
+		if err != nil {
+			return nil, false, err
+		}
+		req.Overrides = o
+		n--
+	}
+	if n > 1 {
+		return nil, false, errors.New("scenario, scenario_ref and template cannot be combined with each other or with fields other than overrides")
+	}
+
+	switch {
+	case hasRef:
+		var name string
+		if err := json.Unmarshal(ref, &name); err != nil || name == "" {
+			return nil, false, errors.New("scenario_ref must be a scenario name")
+		}
+		req.ScenarioName = name
+	case hasTemplate:
+		var name string
+		if err := json.Unmarshal(tmpl, &name); err != nil || name == "" {
+			return nil, false, errors.New("template must be a template name")
+		}
+		req.TemplateName = name
+	default:
+		req.ScenarioDocument = doc
+	}
+	return req, true, nil
+}
+
+func (s *Server) handleScenarios(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.ListScenarios(ctx, &simulationpb.ListScenariosRequest{})
+	if err != nil {
+		writeRPCError(w, "failed to list scenarios", err)
+		return
+	}
+
+	out := listScenariosResponse{Scenarios: make([]scenarioJSON, 0, len(resp.GetScenarios()))}
+	for _, sc := range resp.GetScenarios() {
+		out.Scenarios = append(out.Scenarios, scenarioJSON{
+			Name:        sc.GetName(),
+			Description: sc.GetDescription(),
+			Error:       sc.GetError(),
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func terminationFromJSON(tj *terminationJSON) *simulationpb.Termination {
+	if tj == nil {
+		return nil
+	}
+	return &simulationpb.Termination{
+		MaxTicks:      tj.MaxTicks,
+		MaxSimTimeMs:  tj.MaxSimTimeMs,
+		TasksComplete: tj.TasksComplete,
+		TargetYield:   tj.TargetYield,
+	}
+}
+
+func terminationToJSON(t *simulationpb.Termination) *terminationJSON {
+	if t == nil {
+		return nil
+	}
+	return &terminationJSON{
+		MaxTicks:      t.GetMaxTicks(),
+		MaxSimTimeMs:  t.GetMaxSimTimeMs(),
+		TasksComplete: t.GetTasksComplete(),
+		TargetYield:   t.GetTargetYield(),
+	}
+}
@@ -3,27 +3,127 @@ package api
 
 import (
 	"net/http"
+	"net/url"
+	"strings"
 
+	"github.com/gorilla/websocket"
+
+	"github.com/stevenmed26/AutoFarm/internal/audit"
+	"github.com/stevenmed26/AutoFarm/internal/metrics"
 	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/rbac"
+	"github.com/stevenmed26/AutoFarm/internal/store"
 )
 
 type Server struct {
 	simClient simulationpb.SimulationServiceClient
+
+	// auth is nil when authentication is disabled.
+	auth *Authenticator
+	keys store.KeyStore
+
+	// audit records the requests RBAC denied; nil drops them.
+	audit *audit.Log
+
+	// allowedOrigins may open WebSockets besides the API's own origin.
+	allowedOrigins []string
+	upgrader       websocket.Upgrader
+
+	limits       Limits
+	readLimiter  *rateLimiter
+	writeLimiter *rateLimiter
+	authLimiter  *rateLimiter
+	wsLimiter    *connLimiter
+
+	// metrics are served at /metrics.
+	metrics      *metrics.Registry
+	rateLimited  *metrics.Vec
+	bodyTooLarge *metrics.Vec
+	webSockets   *metrics.Vec
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAuth requires every API and WebSocket request to authenticate with
+// a, and serves management of the API keys in keys under /auth/keys.
+func WithAuth(a *Authenticator, keys store.KeyStore) Option {
+	return func(s *Server) {
+		s.auth = a
+		s.keys = keys
+	}
+}
+
+// WithAuditLog records the requests RBAC denies in l.
+func WithAuditLog(l *audit.Log) Option {
+	return func(s *Server) {
+		s.audit = l
+	}
+}
+
+// WithLimits replaces DefaultLimits.
+func WithLimits(l Limits) Option {
+	return func(s *Server) {
+		s.limits = l
+	}
 }
 
-func NewServer(simClient simulationpb.SimulationServiceClient) *Server {
-	return &Server{
+// WithAllowedOrigins lets pages from origins, e.g.
+// "https://dashboard.example.com", open WebSockets. "*" allows any
+// origin.
+func WithAllowedOrigins(origins []string) Option {
+	return func(s *Server) {
+		s.allowedOrigins = origins
+	}
+}
+
+func NewServer(simClient simulationpb.SimulationServiceClient, opts ...Option) *Server {
+	s := &Server{
 		simClient: simClient,
+		limits:    DefaultLimits,
+		metrics:   metrics.NewRegistry(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	s.readLimiter = newRateLimiter(s.limits.ReadRate, s.limits.ReadBurst)
+	s.writeLimiter = newRateLimiter(s.limits.WriteRate, s.limits.WriteBurst)
+	s.authLimiter = newRateLimiter(s.limits.AuthFailureRate, s.limits.AuthFailureBurst)
+	s.wsLimiter = newConnLimiter(s.limits.MaxWebSockets)
+
+	s.rateLimited = s.metrics.Counter("autofarm_api_rate_limited_total",
+		"Requests rejected with 429 by the per-client limits, by budget.", "budget")
+	s.bodyTooLarge = s.metrics.Counter("autofarm_api_body_too_large_total",
+		"Requests rejected with 413 by their Content-Length.")
+	s.webSockets = s.metrics.Gauge("autofarm_api_websocket_connections",
+		"Open WebSocket connections.")
+	s.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     s.checkOrigin,
+	}
+	return s
 }
 
 func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 	// REST API
-	mux.HandleFunc("/simulations", s.handleSimulations)
-	mux.HandleFunc("/simulations/", s.handleSimulationByID)
+	mux.Handle("/simulations", s.protect(s.handleSimulations, readOr(rbac.Operator)))
+	mux.Handle("/simulations/", s.protect(s.handleSimulationByID, simulationPolicy))
+	mux.Handle("/scenarios", s.protect(s.handleScenarios, always(rbac.Viewer)))
+	mux.Handle("/templates", s.protect(s.handleTemplates, readOr(rbac.Admin)))
+	mux.Handle("/templates/", s.protect(s.handleTemplateByName, readOr(rbac.Admin)))
+	mux.Handle("/experiments", s.protect(s.handleExperiments, readOr(rbac.Operator)))
+	mux.Handle("/experiments/", s.protect(s.handleExperimentByID, readOr(rbac.Operator)))
+	mux.Handle("/audit/events", s.protect(s.handleAuditEvents, always(rbac.Admin)))
+	mux.Handle("/auth/whoami", s.protect(s.handleWhoAmI, always(rbac.Viewer)))
+	mux.Handle("/auth/keys", s.protect(s.handleKeys, always(rbac.Admin)))
+	mux.Handle("/auth/keys/", s.protect(s.handleKeyByID, always(rbac.Admin)))
 
 	// WebSocket stream for dashboard
-	mux.HandleFunc("/ws/simulations/", s.handleSimulationWebSocket)
+	mux.Handle("/ws/simulations/", s.protect(s.limitWebSockets(s.handleSimulationWebSocket), always(rbac.Viewer)))
+
+	// Prometheus metrics
+	mux.Handle("/metrics", s.metrics.Handler())
 
 	// Health check
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
@@ -37,3 +137,39 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/", fileServer)
 }
 
+// protect wraps an API handler in the middleware every API route shares:
+// it counts the request against the client's limits and lets through only
+// callers with the role need asks for. Failed authentications count
+// against the budget of the caller's IP address. Request IDs are given out before
+// routing, by RequestIDMiddleware around the whole mux.
+func (s *Server) protect(h http.HandlerFunc, need policy) http.Handler {
+	m := []Middleware{s.limitBody}
+	if s.auth != nil {
+		m = append(m, s.limitAuthFailures, s.auth.Middleware, s.limitRate, s.requireRole(need))
+	} else {
+		m = append(m, s.limitRate)
+	}
+	return Chain(h, m...)
+}
+
+// checkOrigin accepts WebSockets from clients that send no Origin, such as
+// scripts, from the API's own origin, and from the allowed origins.
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range s.allowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimRight(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
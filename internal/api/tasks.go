@@ -0,0 +1,212 @@
+// internal/api/tasks.go
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stevenmed26/AutoFarm/internal/fleet"
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+type pointJSON struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// taskJSON is a task as submitted and listed over REST. Fields below Priority
+// are filled in by the orchestrator.
+type taskJSON struct {
+	TaskID        uint64      `json:"task_id,omitempty"`
+	Type          string      `json:"type"`
+	State         string      `json:"state,omitempty"`
+	Waypoints     []pointJSON `json:"waypoints"`
+	WorkTicks     uint32      `json:"work_ticks,omitempty"`
+	Load          float64     `json:"load,omitempty"`
+	Priority      int32       `json:"priority,omitempty"`
+	EligibleTypes []string    `json:"eligible_types,omitempty"`
+
+	AssignedEntityID uint64 `json:"assigned_entity_id,omitempty"`
+	CreatedTick      uint64 `json:"created_tick,omitempty"`
+	AssignedTick     uint64 `json:"assigned_tick,omitempty"`
+	CompletedTick    uint64 `json:"completed_tick,omitempty"`
+	Attempts         uint32 `json:"attempts,omitempty"`
+}
+
+type tasksJSON struct {
+	Tasks []taskJSON `json:"tasks"`
+}
+
+// taskTypes maps the REST names of task types to the proto enum.
+var taskTypes = map[string]simulationpb.TaskType{
+	"harvest":   simulationpb.TaskType_TASK_TYPE_HARVEST,
+	"patrol":    simulationpb.TaskType_TASK_TYPE_PATROL,
+	"transport": simulationpb.TaskType_TASK_TYPE_TRANSPORT,
+}
+
+// taskStates maps the REST names of task states to the proto enum.
+var taskStates = map[string]simulationpb.TaskState{
+	"":          simulationpb.TaskState_TASK_STATE_UNSPECIFIED,
+	"pending":   simulationpb.TaskState_TASK_STATE_PENDING,
+	"assigned":  simulationpb.TaskState_TASK_STATE_ASSIGNED,
+	"completed": simulationpb.TaskState_TASK_STATE_COMPLETED,
+	"failed":    simulationpb.TaskState_TASK_STATE_FAILED,
+}
+
+// taskAllocations maps the REST names of allocation strategies to the proto enum.
+var taskAllocations = map[string]simulationpb.TaskAllocationStrategy{
+	"":               simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_UNSPECIFIED,
+	"greedy_nearest": simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST,
+	"auction":        simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_AUCTION,
+	"hungarian":      simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_HUNGARIAN,
+}
+
+func parseTaskAllocation(name string) (simulationpb.TaskAllocationStrategy, error) {
+	a, ok := taskAllocations[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown task_allocation %q (want greedy_nearest, auction or hungarian)", name)
+	}
+	return a, nil
+}
+
+func taskAllocationName(a simulationpb.TaskAllocationStrategy) string {
+	for name, v := range taskAllocations {
+		if v == a && name != "" {
+			return name
+		}
+	}
+	return "greedy_nearest"
+}
+
+func taskTypeName(t simulationpb.TaskType) string {
+	for name, v := range taskTypes {
+		if v == t {
+			return name
+		}
+	}
+	return t.String()
+}
+
+func taskStateName(st simulationpb.TaskState) string {
+	for name, v := range taskStates {
+		if v == st && name != "" {
+			return name
+		}
+	}
+	return st.String()
+}
+
+func (s *Server) handleSubmitTasks(w http.ResponseWriter, r *http.Request, id string) {
+	var reqBody tasksJSON
+	if err := decodeJSONBody(r, &reqBody); err != nil {
+		writeError(w, "invalid JSON: "+err.Error(), bodyErrorStatus(err))
+		return
+	}
+	if len(reqBody.Tasks) == 0 {
+		writeError(w, "tasks is required", http.StatusBadRequest)
+		return
+	}
+
+	tasks := make([]*simulationpb.Task, 0, len(reqBody.Tasks))
+	for i, t := range reqBody.Tasks {
+		task, err := taskFromJSON(t)
+		if err != nil {
+			writeError(w, fmt.Sprintf("tasks[%d]: %v", i, err), http.StatusBadRequest)
+			return
+		}
+		tasks = append(tasks, task)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.SubmitTasks(ctx, &simulationpb.SubmitTasksRequest{
+		Id:    &commonpb.SimulationId{Value: id},
+		Tasks: tasks,
+	})
+	if err != nil {
+		writeRPCError(w, "failed to submit tasks", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tasksToJSON(resp.GetTasks()))
+}
+
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request, id string) {
+	state, ok := taskStates[strings.ToLower(r.URL.Query().Get("state"))]
+	if !ok {
+		writeError(w, "state must be pending, assigned, completed or failed", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.ListTasks(ctx, &simulationpb.ListTasksRequest{
+		Id:    &commonpb.SimulationId{Value: id},
+		State: state,
+	})
+	if err != nil {
+		writeRPCError(w, "failed to list tasks", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tasksToJSON(resp.GetTasks()))
+}
+
+func taskFromJSON(t taskJSON) (*simulationpb.Task, error) {
+	taskType, ok := taskTypes[strings.ToLower(t.Type)]
+	if !ok {
+		return nil, fmt.Errorf("unknown task type %q (want harvest, patrol or transport)", t.Type)
+	}
+
+	out := &simulationpb.Task{
+		Type:      taskType,
+		WorkTicks: t.WorkTicks,
+		Load:      t.Load,
+		Priority:  t.Priority,
+	}
+	for _, p := range t.Waypoints {
+		out.Waypoints = append(out.Waypoints, &simulationpb.Point{X: p.X, Y: p.Y})
+	}
+	for _, name := range t.EligibleTypes {
+		et, err := fleet.ParseType(name)
+		if err != nil {
+			return nil, fmt.Errorf("eligible_types: %w", err)
+		}
+		out.EligibleTypes = append(out.EligibleTypes, et)
+	}
+	return out, nil
+}
+
+func tasksToJSON(tasks []*simulationpb.Task) *tasksJSON {
+	out := &tasksJSON{Tasks: make([]taskJSON, 0, len(tasks))}
+	for _, t := range tasks {
+		tj := taskJSON{
+			TaskID:           t.GetTaskId(),
+			Type:             taskTypeName(t.GetType()),
+			State:            taskStateName(t.GetState()),
+			Waypoints:        make([]pointJSON, 0, len(t.GetWaypoints())),
+			WorkTicks:        t.GetWorkTicks(),
+			Load:             t.GetLoad(),
+			Priority:         t.GetPriority(),
+			AssignedEntityID: t.GetAssignedEntityId(),
+			CreatedTick:      t.GetCreatedTick(),
+			AssignedTick:     t.GetAssignedTick(),
+			CompletedTick:    t.GetCompletedTick(),
+			Attempts:         t.GetAttempts(),
+		}
+		for _, p := range t.GetWaypoints() {
+			tj.Waypoints = append(tj.Waypoints, pointJSON{X: p.GetX(), Y: p.GetY()})
+		}
+		for _, et := range t.GetEligibleTypes() {
+			tj.EligibleTypes = append(tj.EligibleTypes, fleet.TypeName(et))
+		}
+		out.Tasks = append(out.Tasks, tj)
+	}
+	return out
+}
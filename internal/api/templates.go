@@ -0,0 +1,216 @@
+// internal/api/templates.go
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// templateJSON is a named simulation config. Config has the shape of a
+// create body.
+type templateJSON struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Config      *createSimulationRequest `json:"config"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+// templateRequest creates or replaces a template. The config comes as a
+// create body or as a scenario document.
+type templateRequest struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Config      *createSimulationRequest `json:"config"`
+	Scenario    json.RawMessage          `json:"scenario"`
+}
+
+type listTemplatesResponse struct {
+	Templates []*templateJSON `json:"templates"`
+}
+
+func (s *Server) handleTemplates(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.handleListTemplates(w, r)
+	case http.MethodPost:
+		s.handleCreateTemplate(w, r)
+	default:
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleTemplateByName(w http.ResponseWriter, r *http.Request) {
+	// Path format: /templates/{name}
+	name := strings.TrimPrefix(r.URL.Path, "/templates/")
+	if name == "" || strings.Contains(name, "/") {
+		writeError(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.handleGetTemplate(w, r, name)
+	case http.MethodPut:
+		s.handleUpdateTemplate(w, r, name)
+	case http.MethodDelete:
+		s.handleDeleteTemplate(w, r, name)
+	default:
+		writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleListTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.ListTemplates(ctx, &simulationpb.ListTemplatesRequest{})
+	if err != nil {
+		writeRPCError(w, "failed to list templates", err)
+		return
+	}
+
+	out := listTemplatesResponse{Templates: make([]*templateJSON, 0, len(resp.GetTemplates()))}
+	for _, t := range resp.GetTemplates() {
+		out.Templates = append(out.Templates, templateToJSON(t))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleCreateTemplate(w http.ResponseWriter, r *http.Request) {
+	t, doc, err := templateFromBody(r, "")
+	if err != nil {
+		writeError(w, err.Error(), bodyErrorStatus(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.CreateTemplate(ctx, &simulationpb.CreateTemplateRequest{
+		Template:         t,
+		ScenarioDocument: doc,
+	})
+	if err != nil {
+		writeRPCError(w, "failed to create template", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, templateToJSON(resp.GetTemplate()))
+}
+
+func (s *Server) handleGetTemplate(w http.ResponseWriter, r *http.Request, name string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.GetTemplate(ctx, &simulationpb.GetTemplateRequest{Name: name})
+	if err != nil {
+		writeRPCError(w, "failed to get template", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, templateToJSON(resp.GetTemplate()))
+}
+
+func (s *Server) handleUpdateTemplate(w http.ResponseWriter, r *http.Request, name string) {
+	t, doc, err := templateFromBody(r, name)
+	if err != nil {
+		writeError(w, err.Error(), bodyErrorStatus(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.simClient.UpdateTemplate(ctx, &simulationpb.UpdateTemplateRequest{
+		Template:         t,
+		ScenarioDocument: doc,
+	})
+	if err != nil {
+		writeRPCError(w, "failed to update template", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, templateToJSON(resp.GetTemplate()))
+}
+
+func (s *Server) handleDeleteTemplate(w http.ResponseWriter, r *http.Request, name string) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.simClient.DeleteTemplate(ctx, &simulationpb.DeleteTemplateRequest{Name: name}); err != nil {
+		writeRPCError(w, "failed to delete template", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// templateFromBody reads a template from a JSON templateRequest, or from a
+// YAML scenario document that supplies its own name and description. The
+// returned template has no config when it comes with a document. name is
+// the template named in the path, if any; the body may not rename it.
+func templateFromBody(r *http.Request, name string) (*simulationpb.SimulationTemplate, []byte, error) {
+	data, err := readBody(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid body: %w", err)
+	}
+	if isYAML(r.Header.Get("Content-Type")) {
+		return &simulationpb.SimulationTemplate{Name: name}, data, nil
+	}
+
+	var reqBody templateRequest
+	if err := json.Unmarshal(data, &reqBody); err != nil {
+		return nil, nil, errors.New("invalid JSON: " + err.Error())
+	}
+	if name != "" {
+		if reqBody.Name != "" && reqBody.Name != name {
+			return nil, nil, errors.New("template name cannot be changed")
+		}
+		reqBody.Name = name
+	}
+
+	t := &simulationpb.SimulationTemplate{
+		Name:        reqBody.Name,
+		Description: reqBody.Description,
+	}
+	switch {
+	case reqBody.Config != nil && len(reqBody.Scenario) > 0:
+		return nil, nil, errors.New("set config or scenario, not both")
+	case len(reqBody.Scenario) > 0:
+		return t, reqBody.Scenario, nil
+	case reqBody.Config == nil:
+		return nil, nil, errors.New("config or scenario is required")
+	}
+
+	// The config's name defaults to the template's.
+	if reqBody.Config.Name == "" {
+		reqBody.Config.Name = reqBody.Name
+	}
+	cfg, err := configFromJSON(reqBody.Config)
+	if err != nil {
+		return nil, nil, errors.New("config: " + err.Error())
+	}
+	t.Config = cfg
+	return t, nil, nil
+}
+
+func templateToJSON(t *simulationpb.SimulationTemplate) *templateJSON {
+	if t == nil {
+		return nil
+	}
+	return &templateJSON{
+		Name:        t.GetName(),
+		Description: t.GetDescription(),
+		Config:      configToJSON(t.GetConfig()),
+		CreatedAt:   t.GetCreatedAt().AsTime(),
+		UpdatedAt:   t.GetUpdatedAt().AsTime(),
+	}
+}
@@ -4,22 +4,21 @@ package api
 import (
     "context"
     "encoding/json"
-    "log"
+    "log/slog"
     "net/http"
     "strings"
     "time"
 
     "github.com/gorilla/websocket"
 
+    "github.com/stevenmed26/AutoFarm/internal/fleet"
     commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
     simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
 )
 
-var upgrader = websocket.Upgrader{
-    ReadBufferSize:  1024,
-    WriteBufferSize: 1024,
-    CheckOrigin: func(r *http.Request) bool { return true }, // relax for dev
-}
+// fastModeMinInterval is the minimum gap between updates written to one client
+// while a simulation runs faster than real time; ticks in between are dropped.
+const fastModeMinInterval = 50 * time.Millisecond
 
 // DashboardUpdate is the JSON payload shape sent to clients.
 type DashboardUpdate struct {
@@ -27,8 +26,37 @@ type DashboardUpdate struct {
     Tick         uint64            `json:"tick"`
     Entities     []DashboardEntity `json:"entities"`
     AvgComputeMs float64           `json:"avg_compute_ms"`
+    ComputeMs    map[string]float64 `json:"compute_breakdown_ms,omitempty"`
     WorkerCount  uint32            `json:"worker_count"`
     CompletedAt  time.Time         `json:"completed_at"`
+    SimTimeMs    uint64            `json:"sim_time_ms"`
+    Speed        float64           `json:"speed_multiplier"`
+    FastForward  bool              `json:"fast_forward"`
+    ScheduledAt  time.Time         `json:"scheduled_at"`
+    Deadline     time.Time         `json:"deadline"`
+    Late         bool              `json:"late"`
+    OverrunMs    float64           `json:"overrun_ms"`
+    SkippedTicks uint64            `json:"skipped_ticks"`
+    CommandAcks  []DashboardAck    `json:"command_acks,omitempty"`
+    TaskEvents   []DashboardTask   `json:"task_events,omitempty"`
+    Environment  *environmentJSON  `json:"environment,omitempty"`
+    Crops        *cropSummaryJSON  `json:"crops,omitempty"`
+}
+
+// DashboardAck reports an entity command applied at this tick.
+type DashboardAck struct {
+    CommandID uint64 `json:"command_id"`
+    EntityID  uint64 `json:"entity_id"`
+    Applied   bool   `json:"applied"`
+    Error     string `json:"error,omitempty"`
+}
+
+// DashboardTask reports a task assigned, completed or failed at this tick.
+type DashboardTask struct {
+    TaskID   uint64 `json:"task_id"`
+    EntityID uint64 `json:"entity_id"`
+    State    string `json:"state"`
+    Detail   string `json:"detail,omitempty"`
 }
 
 type DashboardEntity struct {
@@ -39,6 +67,8 @@ type DashboardEntity struct {
     Vy      float64 `json:"vy"`
     Battery float64 `json:"battery"`
     Status  string  `json:"status"`
+    Type    string  `json:"type"`
+    TaskID  uint64  `json:"task_id,omitempty"`
 }
 
 func dashboardUpdateFromProto(tick *simulationpb.AggregatedTick) *DashboardUpdate {
@@ -52,21 +82,62 @@ func dashboardUpdateFromProto(tick *simulationpb.AggregatedTick) *DashboardUpdat
             Vy:      e.GetVy(),
             Battery: e.GetBattery(),
             Status:  e.GetStatus(),
+            Type:    fleet.TypeName(e.GetType()),
+            TaskID:  e.GetTaskId(),
         })
     }
 
-    var completedAt time.Time
+    var acks []DashboardAck
+    for _, a := range tick.GetCommandAcks() {
+        acks = append(acks, DashboardAck{
+            CommandID: a.GetCommandId(),
+            EntityID:  a.GetEntityId(),
+            Applied:   a.GetApplied(),
+            Error:     a.GetError(),
+        })
+    }
+
+    var taskEvents []DashboardTask
+    for _, ev := range tick.GetTaskEvents() {
+        taskEvents = append(taskEvents, DashboardTask{
+            TaskID:   ev.GetTaskId(),
+            EntityID: ev.GetEntityId(),
+            State:    taskStateName(ev.GetState()),
+            Detail:   ev.GetDetail(),
+        })
+    }
+
+    var completedAt, scheduledAt, deadline time.Time
     if ts := tick.GetCompletedAt(); ts != nil {
         completedAt = ts.AsTime()
     }
+    if ts := tick.GetScheduledAt(); ts != nil {
+        scheduledAt = ts.AsTime()
+    }
+    if ts := tick.GetDeadline(); ts != nil {
+        deadline = ts.AsTime()
+    }
 
     return &DashboardUpdate{
         SimulationID: tick.GetSimulationId().GetValue(),
         Tick:         tick.GetTick(),
         Entities:     entities,
         AvgComputeMs: tick.GetAvgComputeMs(),
+        ComputeMs:    tick.GetComputeBreakdownMs(),
         WorkerCount:  tick.GetWorkerCount(),
         CompletedAt:  completedAt,
+        SimTimeMs:    tick.GetSimTimeMs(),
+        Speed:        tick.GetSpeedMultiplier(),
+        FastForward:  tick.GetFastForward(),
+        ScheduledAt:  scheduledAt,
+        Deadline:     deadline,
+        Late:         tick.GetLate(),
+        OverrunMs:    tick.GetOverrunMs(),
+        SkippedTicks: tick.GetSkippedTicks(),
+        CommandAcks:  acks,
+        TaskEvents:   taskEvents,
+        Environment:  environmentToJSON(tick.GetEnvironment()),
+        Crops:        cropSummaryToJSON(tick.GetCrops()),
     }
 }
 
@@ -74,20 +145,24 @@ func dashboardUpdateFromProto(tick *simulationpb.AggregatedTick) *DashboardUpdat
 func (s *Server) handleSimulationWebSocket(w http.ResponseWriter, r *http.Request) {
     path := strings.TrimPrefix(r.URL.Path, "/ws/simulations/")
     if path == "" {
-        http.Error(w, "missing simulation id", http.StatusBadRequest)
+        writeError(w, "missing simulation id", http.StatusBadRequest)
         return
     }
     simID := strings.SplitN(path, "/", 2)[0]
 
-    conn, err := upgrader.Upgrade(w, r, nil)
+    conn, err := s.upgrader.Upgrade(w, r, nil)
     if err != nil {
-        log.Printf("ws upgrade error: %v", err)
+        slog.WarnContext(r.Context(), "WebSocket upgrade failed", "simulation_id", simID, "error", err)
         return
     }
     defer conn.Close()
 
+    ctx, cancel := context.WithCancel(r.Context())
+    defer cancel()
+
     // Fire a goroutine to watch client disconnects (optional, mostly to read pings).
     go func() {
+        defer cancel()
         conn.SetReadLimit(1024)
         _ = conn.SetReadDeadline(time.Now().Add(60 * time.Second))
         conn.SetPongHandler(func(string) error {
@@ -102,23 +177,63 @@ func (s *Server) handleSimulationWebSocket(w http.ResponseWriter, r *http.Reques
         }
     }()
 
-    ctx, cancel := context.WithCancel(r.Context())
-    defer cancel()
-
     // Open a streaming RPC to the orchestrator.
     stream, err := s.simClient.StreamAggregatedTicks(ctx, &simulationpb.StreamAggregatedTicksRequest{
         Id: &commonpb.SimulationId{Value: simID},
     })
     if err != nil {
-        log.Printf("StreamAggregatedTicks error for %s: %v", simID, err)
+        slog.WarnContext(ctx, "failed to stream ticks", "simulation_id", simID, "error", err)
         return
     }
 
+    // Pull ticks from gRPC on their own goroutine so pings keep flowing.
+    ticks := make(chan *simulationpb.AggregatedTick, 16)
+    go func() {
+        defer close(ticks)
+        var lastQueued time.Time
+        // carried is what ticks left out of the updates reported besides
+        // their entities, for the next update sent.
+        var carried *simulationpb.AggregatedTick
+        for {
+            tick, err := stream.Recv()
+            if err != nil {
+                if ctx.Err() == nil {
+                    slog.WarnContext(ctx, "tick stream failed", "simulation_id", simID, "error", err)
+                }
+                return
+            }
+            tick = withCarried(tick, carried)
+            carried = nil
+
+            if !isFastMode(tick) {
+                select {
+                case ticks <- tick:
+                case <-ctx.Done():
+                    return
+                }
+                continue
+            }
+
+            // Faster than real time: decimate to a rate the client can
+            // render, and drop rather than queue if it is still behind.
+            // Only the entities of a dropped tick are lost.
+            if time.Since(lastQueued) < fastModeMinInterval {
+                carried = carryOver(tick)
+                continue
+            }
+            select {
+            case ticks <- tick:
+                lastQueued = time.Now()
+            default:
+                carried = carryOver(tick)
+            }
+        }
+    }()
+
     pingTicker := time.NewTicker(30 * time.Second)
     defer pingTicker.Stop()
 
     for {
-        // Pull a tick from gRPC or send pings.
         select {
         case <-ctx.Done():
             return
@@ -127,26 +242,54 @@ func (s *Server) handleSimulationWebSocket(w http.ResponseWriter, r *http.Reques
             if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
                 return
             }
-        default:
-            // Non-blocking check for next tick
-        }
+        case tick, ok := <-ticks:
+            if !ok {
+                return
+            }
 
-        tick, err := stream.Recv()
-        if err != nil {
-            log.Printf("StreamAggregatedTicks recv error for %s: %v", simID, err)
-            return
-        }
+            update := dashboardUpdateFromProto(tick)
+            data, err := json.Marshal(update)
+            if err != nil {
+                slog.ErrorContext(ctx, "failed to marshal dashboard update", "simulation_id", simID, "error", err)
+                continue
+            }
 
-        update := dashboardUpdateFromProto(tick)
-        data, err := json.Marshal(update)
-        if err != nil {
-            log.Printf("marshal dashboard update error: %v", err)
-            continue
+            _ = conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+            if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+                return
+            }
         }
+    }
+}
 
-        _ = conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-        if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
-            return
-        }
+// carryOver returns what tick reports besides its entities and its place
+// in the simulation: the command acks, task events, skipped tick slots and
+// crop totals an update must not lose when tick is left out.
+func carryOver(tick *simulationpb.AggregatedTick) *simulationpb.AggregatedTick {
+    return &simulationpb.AggregatedTick{
+        CommandAcks:  tick.GetCommandAcks(),
+        TaskEvents:   tick.GetTaskEvents(),
+        SkippedTicks: tick.GetSkippedTicks(),
+        Crops:        tick.GetCrops(),
     }
 }
+
+// withCarried returns tick with what carried, from the ticks left out
+// before it, reports put first.
+func withCarried(tick, carried *simulationpb.AggregatedTick) *simulationpb.AggregatedTick {
+    if carried == nil {
+        return tick
+    }
+    tick.CommandAcks = append(carried.GetCommandAcks(), tick.GetCommandAcks()...)
+    tick.TaskEvents = append(carried.GetTaskEvents(), tick.GetTaskEvents()...)
+    tick.SkippedTicks += carried.GetSkippedTicks()
+    if tick.Crops == nil {
+        tick.Crops = carried.GetCrops()
+    }
+    return tick
+}
+
+// isFastMode reports whether tick was produced faster than real time.
+func isFastMode(tick *simulationpb.AggregatedTick) bool {
+    return tick.GetFastForward() || tick.GetSpeedMultiplier() > 1
+}
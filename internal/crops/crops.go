@@ -0,0 +1,384 @@
+// Package crops models the crop cells of a simulation: how they grow from
+// seedling to ripe and spoil day by day, and what harvesting them yields.
+// Growth is a function of simulated time, so a cell's stage only needs
+// storing once it has been harvested.
+package crops
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/stevenmed26/AutoFarm/internal/environment"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+// Defaults for zero CropConfig fields.
+const (
+	DefaultCellSize     = 5.0
+	DefaultDaysToRipe   = 2.0
+	DefaultRipeDays     = 1.0
+	DefaultSpoilDays    = 1.0
+	DefaultYieldPerCell = 100.0
+
+	// MinCellSize bounds the field to 100x100 cells.
+	MinCellSize = 1.0
+
+	// MaxPatches bounds how many planted areas a config may list.
+	MaxPatches = 1000
+
+	// immatureYield is the share of the full yield a cell gives when
+	// harvested just before it ripens.
+	immatureYield = 0.25
+
+	// maxPlantingDelay spreads planting over this many days, so cells do
+	// not all ripen on the same tick.
+	maxPlantingDelay = 0.5
+)
+
+// Enabled reports whether cfg grows crops: it lists planted areas, or its
+// scenario is "harvest".
+func Enabled(cfg *simulationpb.SimulationConfig) bool {
+	return len(cfg.GetCrops().GetFields()) > 0 || cfg.GetScenarioType() == "harvest"
+}
+
+// Validate checks the crop settings of cfg.
+func Validate(cfg *simulationpb.CropConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if cs := cfg.GetCellSize(); cs != 0 && !(cs >= MinCellSize && cs <= world.Size) {
+		return fmt.Errorf("crops.cell_size must be between %g and %d", MinCellSize, world.Size)
+	}
+	amounts := []struct {
+		name string
+		v    float64
+	}{
+		{"days_to_ripe", cfg.GetDaysToRipe()},
+		{"ripe_days", cfg.GetRipeDays()},
+		{"spoil_days", cfg.GetSpoilDays()},
+		{"yield_per_cell", cfg.GetYieldPerCell()},
+	}
+	for _, a := range amounts {
+		if !(a.v >= 0) || math.IsInf(a.v, 0) {
+			return fmt.Errorf("crops.%s must be a finite number >= 0", a.name)
+		}
+	}
+	if len(cfg.GetFields()) > MaxPatches {
+		return fmt.Errorf("crops: at most %d fields", MaxPatches)
+	}
+	for i, f := range cfg.GetFields() {
+		if !inWorld(f.GetMinX()) || !inWorld(f.GetMinY()) || !inWorld(f.GetMaxX()) || !inWorld(f.GetMaxY()) {
+			return fmt.Errorf("crops.fields[%d] must lie within [0, %d]", i, world.Size)
+		}
+		if f.GetMinX() >= f.GetMaxX() || f.GetMinY() >= f.GetMaxY() {
+			return fmt.Errorf("crops.fields[%d] must have min < max", i)
+		}
+	}
+	return nil
+}
+
+// cell is one planted crop cell.
+type cell struct {
+	col, row int
+	area     float64
+
+	// days after sim time 0 the cell was planted
+	plantedDay float64
+
+	harvested bool
+	yield     float64
+
+	// a harvest task has been queued for it
+	queued bool
+}
+
+// Field is the crop field of one simulation. It is safe for concurrent use.
+type Field struct {
+	cellSize  float64
+	n         int // cells per side
+	dayLength time.Duration
+
+	daysToRipe, ripeDays, spoilDays float64
+	yieldPerCell                    float64
+	autoHarvest                     bool
+
+	mu         sync.Mutex
+	cells      []*cell
+	index      map[world.Cell]*cell
+	totalYield float64
+	harvested  int
+}
+
+// NewField plants the field described by cfg, which must have passed
+// Validate. Cells whose centre lies inside an obstacle of grid are left bare.
+// Planting times are spread from cfg's seed.
+func NewField(cfg *simulationpb.SimulationConfig, grid *world.Grid) *Field {
+	cc := cfg.GetCrops()
+	f := &Field{
+		cellSize:     orDefault(cc.GetCellSize(), DefaultCellSize),
+		dayLength:    environment.DayLength(cfg),
+		daysToRipe:   orDefault(cc.GetDaysToRipe(), DefaultDaysToRipe),
+		ripeDays:     orDefault(cc.GetRipeDays(), DefaultRipeDays),
+		spoilDays:    orDefault(cc.GetSpoilDays(), DefaultSpoilDays),
+		yieldPerCell: orDefault(cc.GetYieldPerCell(), DefaultYieldPerCell),
+		autoHarvest:  !cc.GetManualHarvest(),
+		index:        make(map[world.Cell]*cell),
+	}
+
+	rng := rand.New(rand.NewSource(int64(cfg.GetSeed())))
+	f.n = int(math.Ceil(world.Size / f.cellSize))
+	for row := 0; row < f.n; row++ {
+		for col := 0; col < f.n; col++ {
+			x, y := f.center(col, row)
+			if grid.BlockedAt(x, y) || !planted(cc.GetFields(), x, y) {
+				continue
+			}
+			c := &cell{
+				col:        col,
+				row:        row,
+				area:       f.span(col) * f.span(row),
+				plantedDay: rng.Float64() * maxPlantingDelay,
+			}
+			f.cells = append(f.cells, c)
+			f.index[world.Cell{Col: col, Row: row}] = c
+		}
+	}
+	return f
+}
+
+// CellSize returns the side of one crop cell in world units.
+func (f *Field) CellSize() float64 {
+	return f.cellSize
+}
+
+// Harvest harvests the cell containing (x, y) at simulated time t and
+// returns its yield. Bare and already harvested cells yield nothing.
+func (f *Field) Harvest(x, y float64, t time.Duration) float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c, ok := f.index[f.cellAt(x, y)]
+	if !ok || c.harvested {
+		return 0
+	}
+	c.harvested = true
+	c.yield = f.yieldAt(c, f.day(t))
+	f.totalYield += c.yield
+	f.harvested++
+	return c.yield
+}
+
+// Ripening returns the centres of the cells that are ripe at simulated time
+// t and have no harvest task yet, and marks them as queued. It returns
+// nothing when harvest tasks are queued by hand.
+func (f *Field) Ripening(t time.Duration) []*simulationpb.Point {
+	if !f.autoHarvest {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	day := f.day(t)
+	var out []*simulationpb.Point
+	for _, c := range f.cells {
+		if c.queued || c.harvested || f.stageAt(c, day) != simulationpb.CropStage_CROP_STAGE_RIPE {
+			continue
+		}
+		c.queued = true
+		x, y := f.center(c.col, c.row)
+		out = append(out, &simulationpb.Point{X: x, Y: y})
+	}
+	return out
+}
+
+// Summary returns the field's totals at simulated time t.
+func (f *Field) Summary(t time.Duration) *simulationpb.CropSummary {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	day := f.day(t)
+	s := &simulationpb.CropSummary{
+		TotalYield:     f.totalYield,
+		PlantedCells:   uint32(len(f.cells)),
+		HarvestedCells: uint32(f.harvested),
+	}
+	for _, c := range f.cells {
+		switch f.stageAt(c, day) {
+		case simulationpb.CropStage_CROP_STAGE_HARVESTED:
+			continue
+		case simulationpb.CropStage_CROP_STAGE_SPOILED:
+			s.SpoiledCells++
+			continue
+		case simulationpb.CropStage_CROP_STAGE_RIPE:
+			s.RipeCells++
+		}
+		s.HarvestableCells++
+		s.HarvestableArea += c.area
+	}
+	return s
+}
+
+// Cells returns every planted cell at simulated time t, row by row.
+func (f *Field) Cells(t time.Duration) []*simulationpb.CropCell {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	day := f.day(t)
+	out := make([]*simulationpb.CropCell, 0, len(f.cells))
+	for _, c := range f.cells {
+		x, y := f.center(c.col, c.row)
+		yield := c.yield
+		if !c.harvested {
+			yield = f.yieldAt(c, day)
+		}
+		out = append(out, &simulationpb.CropCell{
+			Col:   uint32(c.col),
+			Row:   uint32(c.row),
+			X:     x,
+			Y:     y,
+			Stage: f.stageAt(c, day),
+			Yield: yield,
+		})
+	}
+	return out
+}
+
+// stageAt returns the growth stage of c on the given day. The growing
+// period is split evenly between seedling, vegetative and flowering.
+// CellState is the harvest state of one planted cell.
+type CellState struct {
+	Harvested bool
+	Yield     float64
+	Queued    bool
+}
+
+// State returns the harvest state of every planted cell, row by row, for
+// Restore.
+func (f *Field) State() []CellState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := make([]CellState, len(f.cells))
+	for i, c := range f.cells {
+		out[i] = CellState{Harvested: c.harvested, Yield: c.yield, Queued: c.queued}
+	}
+	return out
+}
+
+// Restore sets the harvest state of the field's cells from State of a field
+// planted over the same areas and obstacles.
+func (f *Field) Restore(state []CellState) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.totalYield, f.harvested = 0, 0
+	for i, c := range f.cells {
+		if i >= len(state) {
+			break
+		}
+		c.harvested, c.yield, c.queued = state[i].Harvested, state[i].Yield, state[i].Queued
+		if c.harvested {
+			f.totalYield += c.yield
+			f.harvested++
+		}
+	}
+}
+
+func (f *Field) stageAt(c *cell, day float64) simulationpb.CropStage {
+	if c.harvested {
+		return simulationpb.CropStage_CROP_STAGE_HARVESTED
+	}
+	age := day - c.plantedDay
+	switch {
+	case age < f.daysToRipe/3:
+		return simulationpb.CropStage_CROP_STAGE_SEEDLING
+	case age < f.daysToRipe*2/3:
+		return simulationpb.CropStage_CROP_STAGE_VEGETATIVE
+	case age < f.daysToRipe:
+		return simulationpb.CropStage_CROP_STAGE_FLOWERING
+	case age < f.daysToRipe+f.ripeDays:
+		return simulationpb.CropStage_CROP_STAGE_RIPE
+	case age < f.daysToRipe+f.ripeDays+f.spoilDays:
+		return simulationpb.CropStage_CROP_STAGE_OVERRIPE
+	default:
+		return simulationpb.CropStage_CROP_STAGE_SPOILED
+	}
+}
+
+// yieldAt is what harvesting c on the given day gives: a little before it
+// ripens, everything while ripe, then less and less as it spoils.
+func (f *Field) yieldAt(c *cell, day float64) float64 {
+	age := day - c.plantedDay
+	var share float64
+	switch {
+	case age < 0:
+		share = 0
+	case age < f.daysToRipe:
+		share = immatureYield * age / f.daysToRipe
+	case age < f.daysToRipe+f.ripeDays:
+		share = 1
+	case age < f.daysToRipe+f.ripeDays+f.spoilDays:
+		share = 1 - (age-f.daysToRipe-f.ripeDays)/f.spoilDays
+	}
+	return share * f.yieldPerCell * c.area / (f.cellSize * f.cellSize)
+}
+
+// day converts simulated time to simulated days.
+func (f *Field) day(t time.Duration) float64 {
+	return t.Seconds() / f.dayLength.Seconds()
+}
+
+// cellAt returns the cell containing (x, y); points on the far world edge
+// belong to the last cell.
+func (f *Field) cellAt(x, y float64) world.Cell {
+	return world.Cell{Col: f.slot(x), Row: f.slot(y)}
+}
+
+func (f *Field) slot(v float64) int {
+	i := int(math.Floor(v / f.cellSize))
+	if i >= f.n {
+		return f.n - 1
+	}
+	return i
+}
+
+// center returns the middle of a cell, clipped to the world for edge cells.
+func (f *Field) center(col, row int) (x, y float64) {
+	return float64(col)*f.cellSize + f.span(col)/2, float64(row)*f.cellSize + f.span(row)/2
+}
+
+// span is the width of column (or row) i, which is less than the cell size
+// at the world edge when the size does not divide it.
+func (f *Field) span(i int) float64 {
+	return math.Min(f.cellSize, world.Size-float64(i)*f.cellSize)
+}
+
+// planted reports whether (x, y) lies in one of the planted areas. No areas
+// means the whole world is planted.
+func planted(fields []*simulationpb.CropPatch, x, y float64) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	for _, p := range fields {
+		if x >= p.GetMinX() && x < p.GetMaxX() && y >= p.GetMinY() && y < p.GetMaxY() {
+			return true
+		}
+	}
+	return false
+}
+
+func orDefault(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+func inWorld(v float64) bool {
+	return v >= 0 && v <= world.Size
+}
@@ -0,0 +1,106 @@
+package crops
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+// testField plants one 5x5 cell at the origin, on days of dayLength, and
+// plants it at sim time 0.
+func testField(dayLength time.Duration) *Field {
+	cfg := &simulationpb.SimulationConfig{
+		Seed:        1,
+		Environment: &simulationpb.EnvironmentConfig{DayLengthMs: uint64(dayLength.Milliseconds())},
+		Crops: &simulationpb.CropConfig{
+			Fields: []*simulationpb.CropPatch{{MinX: 0, MinY: 0, MaxX: 5, MaxY: 5}},
+		},
+	}
+	f := NewField(cfg, world.NewGrid(nil))
+	f.cells[0].plantedDay = 0
+	return f
+}
+
+func TestFieldGrowth(t *testing.T) {
+	const day = time.Minute
+
+	// Defaults: ripe after 2 days, for 1 day, then spoiling over 1 day.
+	tests := []struct {
+		days      float64
+		stage     simulationpb.CropStage
+		yield     float64
+		ripening  bool // Ripening queues it
+		spoiled   uint32
+		ripeCells uint32
+	}{
+		{0, simulationpb.CropStage_CROP_STAGE_SEEDLING, 0, false, 0, 0},
+		{0.5, simulationpb.CropStage_CROP_STAGE_SEEDLING, 6.25, false, 0, 0},
+		{1, simulationpb.CropStage_CROP_STAGE_VEGETATIVE, 12.5, false, 0, 0},
+		{1.5, simulationpb.CropStage_CROP_STAGE_FLOWERING, 18.75, false, 0, 0},
+		{2, simulationpb.CropStage_CROP_STAGE_RIPE, 100, true, 0, 1},
+		{2.9, simulationpb.CropStage_CROP_STAGE_RIPE, 100, false, 0, 1},
+		{3.25, simulationpb.CropStage_CROP_STAGE_OVERRIPE, 75, false, 0, 0},
+		{4, simulationpb.CropStage_CROP_STAGE_SPOILED, 0, false, 1, 0},
+	}
+
+	f := testField(day)
+	for _, tt := range tests {
+		at := time.Duration(tt.days * float64(day))
+		cells := f.Cells(at)
+		if len(cells) != 1 {
+			t.Fatalf("day %v: %d cells, want 1", tt.days, len(cells))
+		}
+		if c := cells[0]; c.GetStage() != tt.stage || math.Abs(c.GetYield()-tt.yield) > 1e-9 {
+			t.Errorf("day %v: %s yielding %v, want %s yielding %v", tt.days, c.GetStage(), c.GetYield(), tt.stage, tt.yield)
+		}
+		if ripening := len(f.Ripening(at)) > 0; ripening != tt.ripening {
+			t.Errorf("day %v: Ripening queued the cell: %v, want %v", tt.days, ripening, tt.ripening)
+		}
+		s := f.Summary(at)
+		if s.GetSpoiledCells() != tt.spoiled || s.GetRipeCells() != tt.ripeCells {
+			t.Errorf("day %v: %d spoiled, %d ripe; want %d, %d", tt.days, s.GetSpoiledCells(), s.GetRipeCells(), tt.spoiled, tt.ripeCells)
+		}
+	}
+}
+
+// TestFieldDayLength checks crops grow by simulated days, however long the
+// config makes them.
+func TestFieldDayLength(t *testing.T) {
+	short, long := testField(time.Minute), testField(10*time.Minute)
+	for _, days := range []float64{0.5, 1.2, 2.5, 3.5, 5} {
+		a := short.Cells(time.Duration(days * float64(time.Minute)))[0]
+		b := long.Cells(time.Duration(days * float64(10*time.Minute)))[0]
+		if a.GetStage() != b.GetStage() || math.Abs(a.GetYield()-b.GetYield()) > 1e-9 {
+			t.Errorf("day %v: %s yielding %v with short days, %s yielding %v with long ones",
+				days, a.GetStage(), a.GetYield(), b.GetStage(), b.GetYield())
+		}
+	}
+}
+
+func TestFieldHarvest(t *testing.T) {
+	const day = time.Minute
+	f := testField(day)
+
+	if got := f.Harvest(50, 50, 2*day); got != 0 {
+		t.Errorf("harvesting a bare cell yielded %v", got)
+	}
+	if got := f.Harvest(2.5, 2.5, 3*day+day/2); got != 50 {
+		t.Errorf("harvesting halfway through spoiling yielded %v, want 50", got)
+	}
+	if got := f.Harvest(2.5, 2.5, 3*day+day/2); got != 0 {
+		t.Errorf("harvesting twice yielded %v the second time", got)
+	}
+
+	// Harvested cells stay harvested, at their harvest yield.
+	c := f.Cells(10 * day)[0]
+	if c.GetStage() != simulationpb.CropStage_CROP_STAGE_HARVESTED || c.GetYield() != 50 {
+		t.Errorf("cell %s yielding %v after its harvest", c.GetStage(), c.GetYield())
+	}
+	s := f.Summary(10 * day)
+	if s.GetHarvestedCells() != 1 || s.GetTotalYield() != 50 || s.GetHarvestableCells() != 0 || s.GetSpoiledCells() != 0 {
+		t.Errorf("summary %v after the harvest", s)
+	}
+}
@@ -0,0 +1,60 @@
+package environment
+
+import (
+	"math"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// Effects of the conditions on one entity. airborne entities (drones) fly
+// over ground obstacles and terrain but are exposed to the wind. A nil
+// environment has no effect.
+
+const (
+	// rain slows ground entities more than airborne ones
+	rainSlowGround   = 0.4
+	rainSlowAirborne = 0.2
+
+	// battery drain grows by half at full rain, and doubles for airborne
+	// entities at full wind
+	rainDrain = 0.5
+
+	// driftPerWind is how far one m/s of wind pushes an airborne entity per
+	// tick, in world units.
+	driftPerWind = 0.02
+)
+
+// SpeedFactor scales an entity's top speed under env.
+func SpeedFactor(env *simulationpb.Environment, airborne bool) float64 {
+	slow := rainSlowGround
+	if airborne {
+		slow = rainSlowAirborne
+	}
+	return 1 - slow*env.GetWeather().GetRainIntensity()
+}
+
+// DrainFactor scales an entity's battery drain under env.
+func DrainFactor(env *simulationpb.Environment, airborne bool) float64 {
+	w := env.GetWeather()
+	f := 1 + rainDrain*w.GetRainIntensity()
+	if airborne {
+		f *= 1 + w.GetWindSpeed()/MaxWindSpeed
+	}
+	return f
+}
+
+// Drift returns how far the wind pushes an entity this tick.
+func Drift(env *simulationpb.Environment, airborne bool) (dx, dy float64) {
+	w := env.GetWeather()
+	if !airborne || w.GetWindSpeed() == 0 {
+		return 0, 0
+	}
+	d := w.GetWindSpeed() * driftPerWind
+	rad := w.GetWindDirectionDeg() * math.Pi / 180
+	return d * math.Cos(rad), d * math.Sin(rad)
+}
+
+// Grounded reports whether the wind is too strong for an entity to fly.
+func Grounded(env *simulationpb.Environment, airborne bool) bool {
+	return airborne && env.GetWeather().GetWindSpeed() >= GroundingWindSpeed
+}
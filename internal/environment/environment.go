@@ -0,0 +1,164 @@
+// Package environment models time of day and weather for a simulation and
+// how they slow, drain and push entities. Conditions are a pure function of
+// the simulation seed and simulated time, so any tick's weather can be
+// recomputed anywhere.
+package environment
+
+import (
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+const (
+	// DefaultStartHour is the hour of day at sim time 0.
+	DefaultStartHour = 6.0
+
+	// DefaultDayLength is the simulated length of one day.
+	DefaultDayLength = 10 * time.Minute
+
+	// MaxWindSpeed is the strongest wind the model produces, in m/s.
+	MaxWindSpeed = 20.0
+
+	// GroundingWindSpeed keeps flying entities on the ground.
+	GroundingWindSpeed = 15.0
+
+	// darkBelow is the light level under which sensor-based tasks stop.
+	darkBelow = 0.1
+)
+
+// noise channels, so each weather variable gets independent values.
+const (
+	channelCloud uint64 = iota + 1
+	channelRain
+	channelWind
+	channelWindDirection
+)
+
+// DayLength returns the simulated length of one day under cfg.
+func DayLength(cfg *simulationpb.SimulationConfig) time.Duration {
+	if ms := cfg.GetEnvironment().GetDayLengthMs(); ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return DefaultDayLength
+}
+
+// At returns the seeded conditions at simulated time t.
+func At(cfg *simulationpb.SimulationConfig, t time.Duration) *simulationpb.Environment {
+	day := DayLength(cfg)
+
+	start := cfg.GetEnvironment().GetStartHour()
+	if start == 0 {
+		start = DefaultStartHour
+	}
+	elapsed := start/24 + t.Seconds()/day.Seconds()
+	dayNum, frac := math.Modf(elapsed)
+
+	env := &simulationpb.Environment{
+		TimeOfDayHours: frac * 24,
+		Day:            uint64(dayNum),
+		Weather:        weatherAt(cfg.GetSeed(), t, day),
+	}
+	setLight(env)
+	return env
+}
+
+// Override replaces parts of the seeded conditions for what-if testing.
+type Override struct {
+	// Weather, when set, replaces the seeded weather.
+	Weather *simulationpb.Weather
+
+	// FixTime freezes the clock at TimeOfDayHours.
+	FixTime        bool
+	TimeOfDayHours float64
+}
+
+// Apply applies o to env in place. A nil override does nothing.
+func (o *Override) Apply(env *simulationpb.Environment) {
+	if o == nil {
+		return
+	}
+	if o.Weather != nil {
+		w := proto.Clone(o.Weather).(*simulationpb.Weather)
+		if w.Condition == simulationpb.WeatherCondition_WEATHER_CONDITION_UNSPECIFIED {
+			w.Condition = classify(w)
+		}
+		env.Weather = w
+	}
+	if o.FixTime {
+		env.TimeOfDayHours = o.TimeOfDayHours
+	}
+	env.Overridden = true
+	setLight(env)
+}
+
+// weatherAt samples smooth noise on time scales of a few hours for rain and
+// clouds, and faster for wind. Rain follows the clouds.
+func weatherAt(seed uint64, t, day time.Duration) *simulationpb.Weather {
+	x := t.Seconds() / day.Seconds()
+
+	cloud := noise(seed, channelCloud, x*8)
+	rainDrive := 0.6*noise(seed, channelRain, x*8) + 0.4*cloud
+	rain := clamp((rainDrive-0.6)/0.3, 0, 1)
+
+	wind := MaxWindSpeed * math.Pow(noise(seed, channelWind, x*12), 2)
+	wind = math.Min(MaxWindSpeed, wind+5*rain)
+
+	w := &simulationpb.Weather{
+		CloudCover:       cloud,
+		RainIntensity:    rain,
+		WindSpeed:        wind,
+		WindDirectionDeg: 360 * noise(seed, channelWindDirection, x*2),
+	}
+	w.Condition = classify(w)
+	return w
+}
+
+func classify(w *simulationpb.Weather) simulationpb.WeatherCondition {
+	switch {
+	case w.GetRainIntensity() >= 0.6 && w.GetWindSpeed() >= 10:
+		return simulationpb.WeatherCondition_WEATHER_CONDITION_STORM
+	case w.GetRainIntensity() > 0.05:
+		return simulationpb.WeatherCondition_WEATHER_CONDITION_RAIN
+	case w.GetCloudCover() > 0.6:
+		return simulationpb.WeatherCondition_WEATHER_CONDITION_CLOUDY
+	default:
+		return simulationpb.WeatherCondition_WEATHER_CONDITION_CLEAR
+	}
+}
+
+// setLight derives the light level from the sun's height, dimmed by clouds.
+// The sun rises at 6:00 and sets at 18:00.
+func setLight(env *simulationpb.Environment) {
+	sun := math.Sin(2 * math.Pi * (env.GetTimeOfDayHours() - 6) / 24)
+	env.LightLevel = clamp(sun*2, 0, 1) * (1 - 0.6*env.GetWeather().GetCloudCover())
+	env.Dark = env.LightLevel < darkBelow
+}
+
+// noise is 1-D value noise in [0, 1): random values at integer x, smoothly
+// interpolated in between.
+func noise(seed, channel uint64, x float64) float64 {
+	i := math.Floor(x)
+	f := x - i
+	a := hash(seed, channel, int64(i))
+	b := hash(seed, channel, int64(i)+1)
+	u := f * f * (3 - 2*f)
+	return a + (b-a)*u
+}
+
+// hash maps its inputs to [0, 1) with splitmix64.
+func hash(seed, channel uint64, i int64) float64 {
+	z := seed ^ channel*0x9e3779b97f4a7c15 ^ uint64(i)*0xbf58476d1ce4e5b9
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return float64(z>>11) / (1 << 53)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,272 @@
+// Package experiment expands the parameter space of an experiment into the
+// points its runs take, by grid or random sampling, and applies a point to
+// a simulation config.
+package experiment
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+const (
+	// MaxRuns bounds how many runs one experiment may have.
+	MaxRuns = 1000
+
+	// DefaultConcurrency and MaxConcurrency bound how many runs execute at
+	// once.
+	DefaultConcurrency = 2
+	MaxConcurrency     = 16
+)
+
+// Point is one combination of parameter values.
+type Point struct {
+	Values  map[string]float64
+	Choices map[string]string
+}
+
+// domain is the values one parameter takes.
+type domain struct {
+	name    string
+	param   parameter
+	values  []float64 // explicit values, or the grid steps
+	choices []string
+
+	// random sampling without explicit values draws from [min, max]
+	min, max float64
+}
+
+// Concurrency returns how many of spec's runs may execute at once.
+func Concurrency(spec *simulationpb.ExperimentSpec) (int, error) {
+	n := spec.GetConcurrency()
+	switch {
+	case n == 0:
+		return DefaultConcurrency, nil
+	case n > MaxConcurrency:
+		return 0, fmt.Errorf("concurrency must be <= %d", MaxConcurrency)
+	}
+	return int(n), nil
+}
+
+// Points returns the points spec's sampling picks, in run order. A grid
+// varies the last parameter fastest.
+func Points(spec *simulationpb.ExperimentSpec) ([]Point, error) {
+	if len(spec.GetParameters()) == 0 {
+		return nil, errors.New("at least one parameter is required")
+	}
+
+	grid := true
+	switch spec.GetSampling() {
+	case simulationpb.ExperimentSampling_EXPERIMENT_SAMPLING_UNSPECIFIED,
+		simulationpb.ExperimentSampling_EXPERIMENT_SAMPLING_GRID:
+		if spec.GetSamples() != 0 {
+			return nil, errors.New("samples is only used with random sampling")
+		}
+	case simulationpb.ExperimentSampling_EXPERIMENT_SAMPLING_RANDOM:
+		grid = false
+		if n := spec.GetSamples(); n == 0 || n > MaxRuns {
+			return nil, fmt.Errorf("random sampling needs samples between 1 and %d", MaxRuns)
+		}
+	default:
+		return nil, fmt.Errorf("unknown sampling %d", spec.GetSampling())
+	}
+
+	domains := make([]domain, 0, len(spec.GetParameters()))
+	seen := make(map[string]bool, len(spec.GetParameters()))
+	for i, p := range spec.GetParameters() {
+		if seen[p.GetName()] {
+			return nil, fmt.Errorf("parameters[%d]: %q listed twice", i, p.GetName())
+		}
+		seen[p.GetName()] = true
+
+		d, err := newDomain(p, grid)
+		if err != nil {
+			return nil, fmt.Errorf("parameters[%d]: %w", i, err)
+		}
+		domains = append(domains, d)
+	}
+
+	if grid {
+		return gridPoints(domains)
+	}
+	return randomPoints(domains, int(spec.GetSamples()), spec.GetSamplingSeed()), nil
+}
+
+func newDomain(p *simulationpb.ExperimentParameter, grid bool) (domain, error) {
+	d := domain{name: p.GetName()}
+	numeric := len(p.GetValues()) > 0 || p.GetMin() != 0 || p.GetMax() != 0 || p.GetStep() != 0
+
+	if d.name == choiceParameter {
+		if numeric || len(p.GetChoices()) == 0 {
+			return d, fmt.Errorf("%s takes choices", d.name)
+		}
+		for _, c := range p.GetChoices() {
+			if _, ok := taskAllocations[c]; !ok {
+				return d, fmt.Errorf("unknown %s %q (want greedy_nearest, auction or hungarian)", d.name, c)
+			}
+		}
+		d.choices = p.GetChoices()
+		return d, nil
+	}
+
+	param, err := lookup(d.name)
+	if err != nil {
+		return d, err
+	}
+	d.param = param
+	if len(p.GetChoices()) > 0 {
+		return d, fmt.Errorf("%s takes values or min and max, not choices", d.name)
+	}
+
+	if len(p.GetValues()) > 0 {
+		if p.GetMin() != 0 || p.GetMax() != 0 || p.GetStep() != 0 {
+			return d, fmt.Errorf("%s: set values or min and max, not both", d.name)
+		}
+		for _, v := range p.GetValues() {
+			if err := d.check(v); err != nil {
+				return d, err
+			}
+		}
+		d.values = p.GetValues()
+		return d, nil
+	}
+
+	d.min, d.max = p.GetMin(), p.GetMax()
+	if err := d.check(d.min); err != nil {
+		return d, err
+	}
+	if err := d.check(d.max); err != nil {
+		return d, err
+	}
+	if d.min > d.max {
+		return d, fmt.Errorf("%s: min must be <= max", d.name)
+	}
+
+	if !grid {
+		if p.GetStep() != 0 {
+			return d, fmt.Errorf("%s: step is only used with grid sampling", d.name)
+		}
+		return d, nil
+	}
+
+	step := p.GetStep()
+	if !(step > 0) || math.IsInf(step, 0) {
+		return d, fmt.Errorf("%s: a grid over min and max needs a step > 0", d.name)
+	}
+	// Allow for rounding so that max itself is included.
+	n := math.Floor((d.max-d.min)/step+1e-9) + 1
+	if n > MaxRuns {
+		return d, fmt.Errorf("%s: more than %d values", d.name, MaxRuns)
+	}
+	for i := 0; i < int(n); i++ {
+		d.values = append(d.values, d.min+float64(i)*step)
+	}
+	if err := d.check(d.values[len(d.values)-1]); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+// check reports whether v is a valid value for d.
+func (d domain) check(v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Errorf("%s: values must be finite", d.name)
+	}
+	if d.param.integer && (v < 0 || v != math.Trunc(v)) {
+		return fmt.Errorf("%s: values must be whole numbers >= 0, got %g", d.name, v)
+	}
+	return nil
+}
+
+func (d domain) size() int {
+	if len(d.choices) > 0 {
+		return len(d.choices)
+	}
+	return len(d.values)
+}
+
+func gridPoints(domains []domain) ([]Point, error) {
+	total := 1
+	for _, d := range domains {
+		total *= d.size()
+		if total > MaxRuns {
+			return nil, fmt.Errorf("the grid has more than %d points", MaxRuns)
+		}
+	}
+
+	points := make([]Point, total)
+	for i := range points {
+		p := newPoint()
+		rest := i
+		for j := len(domains) - 1; j >= 0; j-- {
+			d := domains[j]
+			k := rest % d.size()
+			rest /= d.size()
+			if len(d.choices) > 0 {
+				p.Choices[d.name] = d.choices[k]
+			} else {
+				p.Values[d.name] = d.values[k]
+			}
+		}
+		points[i] = p
+	}
+	return points, nil
+}
+
+// randomPoints draws n points, each parameter independently and uniformly.
+// The same seed gives the same points.
+func randomPoints(domains []domain, n int, seed uint64) []Point {
+	rng := rand.New(rand.NewSource(int64(seed)))
+
+	points := make([]Point, n)
+	for i := range points {
+		p := newPoint()
+		for _, d := range domains {
+			switch {
+			case len(d.choices) > 0:
+				p.Choices[d.name] = d.choices[rng.Intn(len(d.choices))]
+			case len(d.values) > 0:
+				p.Values[d.name] = d.values[rng.Intn(len(d.values))]
+			case d.param.integer:
+				lo, hi := int64(d.min), int64(d.max)
+				p.Values[d.name] = float64(lo + rng.Int63n(hi-lo+1))
+			default:
+				p.Values[d.name] = d.min + rng.Float64()*(d.max-d.min)
+			}
+		}
+		points[i] = p
+	}
+	return points
+}
+
+func newPoint() Point {
+	return Point{Values: map[string]float64{}, Choices: map[string]string{}}
+}
+
+// Apply sets p's parameter values on cfg. Parameters are applied in name
+// order, so the result does not depend on map iteration.
+func Apply(cfg *simulationpb.SimulationConfig, p Point) error {
+	names := make([]string, 0, len(p.Values))
+	for name := range p.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		param, err := lookup(name)
+		if err != nil {
+			return err
+		}
+		if err := param.set(cfg, p.Values[name]); err != nil {
+			return err
+		}
+	}
+	if c, ok := p.Choices[choiceParameter]; ok {
+		cfg.TaskAllocation = taskAllocations[c]
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package experiment
+
+import (
+	"strings"
+	"testing"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+func TestPointsGrid(t *testing.T) {
+	spec := &simulationpb.ExperimentSpec{
+		Parameters: []*simulationpb.ExperimentParameter{
+			{Name: "entities", Values: []float64{2, 4}},
+			{Name: "task_allocation", Choices: []string{"greedy_nearest", "hungarian"}},
+			{Name: "crops.days_to_ripe", Min: 1, Max: 2, Step: 0.5},
+		},
+	}
+	points, err := Points(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The last parameter varies fastest.
+	type run struct {
+		entities   uint32
+		allocation simulationpb.TaskAllocationStrategy
+		daysToRipe float64
+	}
+	greedy := simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST
+	hungarian := simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_HUNGARIAN
+	want := []run{
+		{2, greedy, 1}, {2, greedy, 1.5}, {2, greedy, 2},
+		{2, hungarian, 1}, {2, hungarian, 1.5}, {2, hungarian, 2},
+		{4, greedy, 1}, {4, greedy, 1.5}, {4, greedy, 2},
+		{4, hungarian, 1}, {4, hungarian, 1.5}, {4, hungarian, 2},
+	}
+	if len(points) != len(want) {
+		t.Fatalf("%d points, want %d", len(points), len(want))
+	}
+	for i, p := range points {
+		cfg := &simulationpb.SimulationConfig{EntityCount: 1}
+		if err := Apply(cfg, p); err != nil {
+			t.Fatalf("Apply(points[%d]) error = %v", i, err)
+		}
+		got := run{cfg.GetEntityCount(), cfg.GetTaskAllocation(), cfg.GetCrops().GetDaysToRipe()}
+		if got != want[i] {
+			t.Errorf("points[%d] applied %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestPointsMaxRuns(t *testing.T) {
+	values := func(n int) []float64 {
+		out := make([]float64, n)
+		for i := range out {
+			out[i] = float64(i)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		spec    *simulationpb.ExperimentSpec
+		want    int
+		wantErr string
+	}{
+		{
+			name: "grid of MaxRuns",
+			spec: &simulationpb.ExperimentSpec{Parameters: []*simulationpb.ExperimentParameter{
+				{Name: "seed", Values: values(MaxRuns / 2)},
+				{Name: "entities", Values: []float64{1, 2}},
+			}},
+			want: MaxRuns,
+		},
+		{
+			name: "grid over MaxRuns",
+			spec: &simulationpb.ExperimentSpec{Parameters: []*simulationpb.ExperimentParameter{
+				{Name: "seed", Values: values(MaxRuns/2 + 1)},
+				{Name: "entities", Values: []float64{1, 2}},
+			}},
+			wantErr: "the grid has more than 1000 points",
+		},
+		{
+			name: "steps over MaxRuns",
+			spec: &simulationpb.ExperimentSpec{Parameters: []*simulationpb.ExperimentParameter{
+				{Name: "seed", Min: 0, Max: MaxRuns, Step: 1},
+			}},
+			wantErr: "seed: more than 1000 values",
+		},
+		{
+			name: "samples over MaxRuns",
+			spec: &simulationpb.ExperimentSpec{
+				Sampling:   simulationpb.ExperimentSampling_EXPERIMENT_SAMPLING_RANDOM,
+				Samples:    MaxRuns + 1,
+				Parameters: []*simulationpb.ExperimentParameter{{Name: "seed", Min: 1, Max: 9}},
+			},
+			wantErr: "samples between 1 and 1000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := Points(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Points() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Points() error = %v", err)
+			}
+			if len(points) != tt.want {
+				t.Errorf("%d points, want %d", len(points), tt.want)
+			}
+		})
+	}
+}
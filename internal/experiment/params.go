@@ -0,0 +1,150 @@
+package experiment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stevenmed26/AutoFarm/internal/fleet"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// taskAllocations are the choices of the task_allocation parameter, named as
+// in the REST API.
+var taskAllocations = map[string]simulationpb.TaskAllocationStrategy{
+	"greedy_nearest": simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST,
+	"auction":        simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_AUCTION,
+	"hungarian":      simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_HUNGARIAN,
+}
+
+// choiceParameter is the one parameter that takes names rather than numbers.
+const choiceParameter = "task_allocation"
+
+// setter sets a numeric parameter on a config.
+type setter func(cfg *simulationpb.SimulationConfig, v float64) error
+
+// parameter describes a numeric parameter: how to set it and whether it only
+// takes whole numbers.
+type parameter struct {
+	set     setter
+	integer bool
+}
+
+// lookup resolves a numeric parameter name.
+func lookup(name string) (parameter, error) {
+	switch name {
+	case "entities":
+		return parameter{set: setEntities, integer: true}, nil
+	case "seed":
+		return parameter{set: func(cfg *simulationpb.SimulationConfig, v float64) error {
+			cfg.Seed = uint64(v)
+			return nil
+		}, integer: true}, nil
+	case "crops.days_to_ripe":
+		return parameter{set: func(cfg *simulationpb.SimulationConfig, v float64) error {
+			crops(cfg).DaysToRipe = v
+			return nil
+		}}, nil
+	case "crops.yield_per_cell":
+		return parameter{set: func(cfg *simulationpb.SimulationConfig, v float64) error {
+			crops(cfg).YieldPerCell = v
+			return nil
+		}}, nil
+	case "environment.start_hour":
+		return parameter{set: func(cfg *simulationpb.SimulationConfig, v float64) error {
+			if cfg.Environment == nil {
+				cfg.Environment = &simulationpb.EnvironmentConfig{}
+			}
+			cfg.Environment.StartHour = v
+			return nil
+		}}, nil
+	}
+
+	parts := strings.Split(name, ".")
+	switch {
+	case len(parts) == 3 && parts[0] == "fleet" && parts[2] == "count":
+		t, err := fleet.ParseType(parts[1])
+		if err != nil {
+			return parameter{}, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		return parameter{set: func(cfg *simulationpb.SimulationConfig, v float64) error {
+			return setFleetCount(cfg, t, uint32(v))
+		}, integer: true}, nil
+
+	case len(parts) == 3 && parts[0] == "entity_types":
+		t, err := fleet.ParseType(parts[1])
+		if err != nil {
+			return parameter{}, fmt.Errorf("parameter %q: %w", name, err)
+		}
+		var set func(p *simulationpb.EntityTypeParams, v float64)
+		switch parts[2] {
+		case "max_speed":
+			set = func(p *simulationpb.EntityTypeParams, v float64) { p.MaxSpeed = v }
+		case "battery_capacity":
+			set = func(p *simulationpb.EntityTypeParams, v float64) { p.BatteryCapacity = v }
+		case "drain_rate":
+			set = func(p *simulationpb.EntityTypeParams, v float64) { p.DrainRate = v }
+		case "payload_capacity":
+			set = func(p *simulationpb.EntityTypeParams, v float64) { p.PayloadCapacity = v }
+		default:
+			return parameter{}, fmt.Errorf("parameter %q: unknown entity type field %q", name, parts[2])
+		}
+		return parameter{set: func(cfg *simulationpb.SimulationConfig, v float64) error {
+			set(entityType(cfg, t), v)
+			return nil
+		}}, nil
+	}
+
+	return parameter{}, fmt.Errorf("unknown parameter %q", name)
+}
+
+// setEntities resizes a config with no fleet mix or a single fleet group.
+func setEntities(cfg *simulationpb.SimulationConfig, v float64) error {
+	n := uint32(v)
+	switch len(cfg.GetFleet()) {
+	case 0:
+		cfg.EntityCount = n
+	case 1:
+		return setFleetCount(cfg, cfg.Fleet[0].GetType(), n)
+	default:
+		return fmt.Errorf("parameter \"entities\": the config has %d fleet groups; vary fleet.<type>.count instead", len(cfg.GetFleet()))
+	}
+	return nil
+}
+
+// setFleetCount sets the size of the fleet group of type t, dropping
+// placements beyond the new count.
+func setFleetCount(cfg *simulationpb.SimulationConfig, t simulationpb.EntityType, n uint32) error {
+	for _, g := range cfg.GetFleet() {
+		if g.GetType() != t {
+			continue
+		}
+		g.Count = n
+		if len(g.Placements) > int(n) {
+			g.Placements = g.Placements[:n]
+		}
+		// Normalize derives the entity count from the fleet.
+		cfg.EntityCount = 0
+		return nil
+	}
+	return fmt.Errorf("parameter \"fleet.%s.count\": the config has no %s fleet group", fleet.TypeName(t), fleet.TypeName(t))
+}
+
+func crops(cfg *simulationpb.SimulationConfig) *simulationpb.CropConfig {
+	if cfg.Crops == nil {
+		cfg.Crops = &simulationpb.CropConfig{}
+	}
+	return cfg.Crops
+}
+
+// entityType returns the parameters of type t in cfg, adding them when the
+// config has none; zero fields take the defaults.
+func entityType(cfg *simulationpb.SimulationConfig, t simulationpb.EntityType) *simulationpb.EntityTypeParams {
+	for _, p := range cfg.GetEntityTypes() {
+		if p.GetType() == t {
+			return p
+		}
+	}
+	p := &simulationpb.EntityTypeParams{Type: t}
+	cfg.EntityTypes = append(cfg.EntityTypes, p)
+	return p
+}
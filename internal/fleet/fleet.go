@@ -0,0 +1,227 @@
+// Package fleet describes the kinds of entity a simulation can contain and
+// their default physical parameters.
+package fleet
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+// defaults holds the built-in parameters for each entity type. The generic
+// robot matches the original single-type behaviour: 1 unit/tick and 0.1%
+// battery per tick.
+var defaults = map[simulationpb.EntityType]*simulationpb.EntityTypeParams{
+	simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED: {
+		Type:            simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED,
+		MaxSpeed:        1.0,
+		BatteryCapacity: 100,
+		DrainRate:       0.1,
+	},
+	simulationpb.EntityType_ENTITY_TYPE_TRACTOR: {
+		Type:            simulationpb.EntityType_ENTITY_TYPE_TRACTOR,
+		MaxSpeed:        0.8,
+		BatteryCapacity: 400,
+		DrainRate:       0.2,
+		PayloadCapacity: 500,
+	},
+	simulationpb.EntityType_ENTITY_TYPE_DRONE: {
+		Type:                   simulationpb.EntityType_ENTITY_TYPE_DRONE,
+		MaxSpeed:               2.5,
+		BatteryCapacity:        50,
+		DrainRate:              0.15,
+		PayloadCapacity:        5,
+		IgnoresGroundObstacles: true,
+	},
+	simulationpb.EntityType_ENTITY_TYPE_HARVESTER: {
+		Type:            simulationpb.EntityType_ENTITY_TYPE_HARVESTER,
+		MaxSpeed:        0.5,
+		BatteryCapacity: 600,
+		DrainRate:       0.3,
+		PayloadCapacity: 2000,
+	},
+}
+
+// names are the short type names used by the REST API and dashboard.
+var names = map[simulationpb.EntityType]string{
+	simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED: "robot",
+	simulationpb.EntityType_ENTITY_TYPE_TRACTOR:     "tractor",
+	simulationpb.EntityType_ENTITY_TYPE_DRONE:       "drone",
+	simulationpb.EntityType_ENTITY_TYPE_HARVESTER:   "harvester",
+}
+
+// TypeName returns the short name of t, e.g. "drone".
+func TypeName(t simulationpb.EntityType) string {
+	if name, ok := names[t]; ok {
+		return name
+	}
+	return t.String()
+}
+
+// ParseType parses a short type name. The empty string is the generic robot.
+func ParseType(name string) (simulationpb.EntityType, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED, nil
+	}
+	for t, n := range names {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown entity type %q", name)
+}
+
+// DefaultParams returns a copy of the built-in parameters for t.
+func DefaultParams(t simulationpb.EntityType) *simulationpb.EntityTypeParams {
+	p, ok := defaults[t]
+	if !ok {
+		p = defaults[simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED]
+	}
+	out := proto.Clone(p).(*simulationpb.EntityTypeParams)
+	out.Type = t
+	return out
+}
+
+// Params returns the parameters for t under cfg, falling back to the
+// built-in defaults when cfg does not list the type.
+func Params(cfg *simulationpb.SimulationConfig, t simulationpb.EntityType) *simulationpb.EntityTypeParams {
+	for _, p := range cfg.GetEntityTypes() {
+		if p.GetType() == t {
+			return p
+		}
+	}
+	return DefaultParams(t)
+}
+
+// Normalize validates the fleet mix in cfg, sets entity_count from it and
+// fills in default parameters for every type in use. Zero-valued fields in an
+// explicit entity_types entry are taken from the defaults.
+func Normalize(cfg *simulationpb.SimulationConfig) error {
+	used := map[simulationpb.EntityType]bool{}
+
+	if len(cfg.GetFleet()) > 0 {
+		var total uint32
+		for i, g := range cfg.GetFleet() {
+			if _, ok := names[g.GetType()]; !ok {
+				return fmt.Errorf("fleet: unknown entity type %s", g.GetType())
+			}
+			if g.GetCount() == 0 {
+				return fmt.Errorf("fleet: %s count must be > 0", TypeName(g.GetType()))
+			}
+			if err := validatePlacements(i, g); err != nil {
+				return err
+			}
+			total += g.GetCount()
+			used[g.GetType()] = true
+		}
+		if cfg.EntityCount != 0 && cfg.EntityCount != total {
+			return fmt.Errorf("entity_count %d does not match fleet total %d", cfg.EntityCount, total)
+		}
+		cfg.EntityCount = total
+	} else {
+		used[simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED] = true
+	}
+
+	resolved := make([]*simulationpb.EntityTypeParams, 0, len(used))
+	seen := map[simulationpb.EntityType]bool{}
+	for _, p := range cfg.GetEntityTypes() {
+		if _, ok := names[p.GetType()]; !ok {
+			return fmt.Errorf("entity_types: unknown entity type %s", p.GetType())
+		}
+		if seen[p.GetType()] {
+			return fmt.Errorf("entity_types: %s listed twice", TypeName(p.GetType()))
+		}
+		if p.GetMaxSpeed() < 0 || p.GetBatteryCapacity() < 0 || p.GetDrainRate() < 0 || p.GetPayloadCapacity() < 0 {
+			return fmt.Errorf("entity_types: %s parameters must not be negative", TypeName(p.GetType()))
+		}
+		seen[p.GetType()] = true
+		resolved = append(resolved, mergeDefaults(p))
+	}
+	for _, t := range sortedTypes(used) {
+		if !seen[t] {
+			resolved = append(resolved, DefaultParams(t))
+		}
+	}
+
+	cfg.EntityTypes = resolved
+	return nil
+}
+
+// TypeFor returns the type of entity id under the fleet mix in cfg. Ids are
+// handed out to fleet groups in order, starting at 1.
+func TypeFor(cfg *simulationpb.SimulationConfig, id uint64) simulationpb.EntityType {
+	var upper uint64
+	for _, g := range cfg.GetFleet() {
+		upper += uint64(g.GetCount())
+		if id <= upper {
+			return g.GetType()
+		}
+	}
+	return simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+// PlacementFor returns the starting position of entity id under the fleet
+// mix in cfg, or nil when the entity starts at a random position.
+func PlacementFor(cfg *simulationpb.SimulationConfig, id uint64) *simulationpb.EntityPlacement {
+	var first uint64 = 1
+	for _, g := range cfg.GetFleet() {
+		if id < first+uint64(g.GetCount()) {
+			if i := id - first; i < uint64(len(g.GetPlacements())) {
+				return g.GetPlacements()[i]
+			}
+			return nil
+		}
+		first += uint64(g.GetCount())
+	}
+	return nil
+}
+
+func validatePlacements(i int, g *simulationpb.FleetGroup) error {
+	if len(g.GetPlacements()) > int(g.GetCount()) {
+		return fmt.Errorf("fleet[%d]: %d placements for %d entities", i, len(g.GetPlacements()), g.GetCount())
+	}
+	for j, p := range g.GetPlacements() {
+		if !(p.GetX() >= 0 && p.GetX() <= world.Size && p.GetY() >= 0 && p.GetY() <= world.Size) {
+			return fmt.Errorf("fleet[%d].placements[%d] must lie within [0, %d]", i, j, world.Size)
+		}
+		if !(p.GetBattery() >= 0 && p.GetBattery() <= 100) {
+			return fmt.Errorf("fleet[%d].placements[%d].battery must be between 0 and 100", i, j)
+		}
+	}
+	return nil
+}
+
+func mergeDefaults(p *simulationpb.EntityTypeParams) *simulationpb.EntityTypeParams {
+	out := DefaultParams(p.GetType())
+	if p.GetMaxSpeed() > 0 {
+		out.MaxSpeed = p.GetMaxSpeed()
+	}
+	if p.GetBatteryCapacity() > 0 {
+		out.BatteryCapacity = p.GetBatteryCapacity()
+	}
+	if p.GetDrainRate() > 0 {
+		out.DrainRate = p.GetDrainRate()
+	}
+	if p.GetPayloadCapacity() > 0 {
+		out.PayloadCapacity = p.GetPayloadCapacity()
+	}
+	if p.GetIgnoresGroundObstacles() {
+		out.IgnoresGroundObstacles = true
+	}
+	return out
+}
+
+func sortedTypes(set map[simulationpb.EntityType]bool) []simulationpb.EntityType {
+	out := make([]simulationpb.EntityType, 0, len(set))
+	for t := simulationpb.EntityType(0); int(t) < len(simulationpb.EntityType_name); t++ {
+		if set[t] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
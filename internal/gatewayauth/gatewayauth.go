@@ -0,0 +1,73 @@
+// Package gatewayauth lets the orchestrator tell the API gateway, and the
+// tools trusted like it, from any other gRPC client. Trusted clients send
+// a token shared with the orchestrator with every call; only their calls
+// may name the caller's role, subject, tenant and source address.
+package gatewayauth
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// TokenEnv names the environment variable holding the shared token, read
+// by the orchestrator and its trusted clients alike.
+const TokenEnv = "ORCHESTRATOR_TOKEN"
+
+// MetadataKey is the gRPC metadata key carrying the token.
+const MetadataKey = "x-autofarm-token"
+
+// Credentials sends the token with every call, as per-RPC credentials
+// (grpc.WithPerRPCCredentials).
+type Credentials string
+
+func (c Credentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{MetadataKey: string(c)}, nil
+}
+
+// RequireTransportSecurity is false: the orchestrator's listener is
+// plaintext, inside the deployment's network.
+func (Credentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// Verifier checks the token of incoming calls.
+type Verifier struct {
+	token []byte
+}
+
+// NewVerifier returns a Verifier of token. With an empty token no call is
+// trusted.
+func NewVerifier(token string) *Verifier {
+	return &Verifier{token: []byte(token)}
+}
+
+// Trusted reports whether the call of ctx carries the token.
+func (v *Verifier) Trusted(ctx context.Context) bool {
+	if len(v.token) == 0 {
+		return false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens := md.Get(MetadataKey)
+	return len(tokens) == 1 && subtle.ConstantTimeCompare([]byte(tokens[0]), v.token) == 1
+}
+
+// Strip returns ctx without the token and, unless the call is trusted,
+// without the metadata under keys, so handlers only ever see what a
+// trusted client vouched for.
+func (v *Verifier) Strip(ctx context.Context, keys ...string) (context.Context, bool) {
+	trusted := v.Trusted(ctx)
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, trusted
+	}
+	md = md.Copy()
+	md.Delete(MetadataKey)
+	if !trusted {
+		for _, k := range keys {
+			md.Delete(k)
+		}
+	}
+	return metadata.NewIncomingContext(ctx, md), trusted
+}
@@ -0,0 +1,151 @@
+// Package logging sets up the structured logs of the services: one JSON
+// object per line, tagged with the service and, for work done on behalf of
+// a request, with the request's ID and the trace and span it is part of.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stevenmed26/AutoFarm/internal/requestid"
+)
+
+// LevelEnv names the environment variable Setup reads the level from.
+const LevelEnv = "LOG_LEVEL"
+
+// New returns a logger writing JSON lines at level and above to w. Every
+// line names service; lines logged with a context carrying a request ID
+// (see requestid.NewContext) also have a request_id, and those logged
+// within a recorded span its trace_id and span_id.
+func New(service string, w io.Writer, level slog.Leveler) *slog.Logger {
+	h := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})
+	return slog.New(contextHandler{h}).With("service", service)
+}
+
+// Setup makes a logger for service, at the level in LOG_LEVEL, the default
+// of slog and returns it. What is still written with the log package goes
+// to it too, at info level.
+func Setup(service string) (*slog.Logger, error) {
+	level, err := ParseLevel(os.Getenv(LevelEnv))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", LevelEnv, err)
+	}
+	l := New(service, os.Stderr, level)
+	slog.SetDefault(l)
+	return l, nil
+}
+
+// ParseLevel parses debug, info, warn or error, in any case. Empty is info.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("unknown log level %q: want debug, info, warn or error", s)
+	}
+	return level, nil
+}
+
+// Fatal logs msg at error level and exits, like log.Fatal.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// contextHandler adds the request ID and span of a record's context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// UnaryServerInterceptor gives each call the request ID it came with, or a
+// new one, for logging and for the calls it makes in turn, and logs the
+// call once it returns.
+func UnaryServerInterceptor(l *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+		ctx = withRequestID(ctx)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, l, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streams, which are
+// logged when they end.
+func StreamServerInterceptor(l *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx := withRequestID(ss.Context())
+		start := time.Now()
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, l, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// withRequestID returns ctx carrying the call's request ID, both for
+// FromContext and as the request ID of outgoing calls.
+func withRequestID(ctx context.Context) context.Context {
+	id := requestid.FromIncomingContext(ctx)
+	if id == "" {
+		id = requestid.New()
+	}
+	ctx = requestid.NewContext(ctx, id)
+	return requestid.NewOutgoingContext(ctx, id)
+}
+
+// logCall logs a finished call, at warn level if it failed other than by
+// being canceled.
+func logCall(ctx context.Context, l *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if code != codes.OK && code != codes.Canceled {
+		level = slog.LevelWarn
+	}
+	l.Log(ctx, level, "grpc call",
+		"method", method,
+		"code", code.String(),
+		"latency_ms", float64(time.Since(start).Microseconds())/1000)
+}
+
+// serverStream replaces the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
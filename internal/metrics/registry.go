@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry holds counters and gauges and serves them in the Prometheus
+// text exposition format.
+type Registry struct {
+	mu      sync.Mutex
+	metrics []*Vec
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Vec is a counter or gauge with one series per combination of label
+// values.
+type Vec struct {
+	name, help, kind string
+	labels           []string
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	values []string
+	value  float64
+}
+
+// Counter registers a counter; its series only go up.
+func (r *Registry) Counter(name, help string, labels ...string) *Vec {
+	return r.register(name, help, "counter", labels)
+}
+
+// Gauge registers a gauge; its series go up and down.
+func (r *Registry) Gauge(name, help string, labels ...string) *Vec {
+	return r.register(name, help, "gauge", labels)
+}
+
+func (r *Registry) register(name, help, kind string, labels []string) *Vec {
+	v := &Vec{name: name, help: help, kind: kind, labels: labels, series: make(map[string]*series)}
+	r.mu.Lock()
+	r.metrics = append(r.metrics, v)
+	r.mu.Unlock()
+	return v
+}
+
+// Add adds delta to the series of the given label values, which must match
+// the Vec's labels in number. Counters ignore negative deltas.
+func (v *Vec) Add(delta float64, values ...string) {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", v.name, len(v.labels), len(values)))
+	}
+	if v.kind == "counter" && delta < 0 {
+		return
+	}
+	key := strings.Join(values, "\xff")
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	s, ok := v.series[key]
+	if !ok {
+		s = &series{values: values}
+		v.series[key] = s
+	}
+	s.value += delta
+}
+
+// Inc adds 1 to the series of the given label values.
+func (v *Vec) Inc(values ...string) {
+	v.Add(1, values...)
+}
+
+// Dec subtracts 1 from the series of a gauge.
+func (v *Vec) Dec(values ...string) {
+	v.Add(-1, values...)
+}
+
+// WriteText writes every metric in the Prometheus text exposition format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	metrics := append([]*Vec(nil), r.metrics...)
+	r.mu.Unlock()
+
+	for _, v := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", v.name, v.help, v.name, v.kind); err != nil {
+			return err
+		}
+		for _, line := range v.lines() {
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lines returns the Vec's series as exposition lines, sorted by labels.
+func (v *Vec) lines() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	out := make([]string, 0, len(v.series))
+	for _, s := range v.series {
+		var b strings.Builder
+		b.WriteString(v.name)
+		if len(v.labels) > 0 {
+			b.WriteByte('{')
+			for i, l := range v.labels {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				fmt.Fprintf(&b, "%s=%s", l, strconv.Quote(s.values[i]))
+			}
+			b.WriteByte('}')
+		}
+		fmt.Fprintf(&b, " %s\n", strconv.FormatFloat(s.value, 'g', -1, 64))
+		out = append(out, b.String())
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Handler serves the registry's metrics, e.g. at /metrics.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.WriteText(w)
+	})
+}
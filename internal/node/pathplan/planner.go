@@ -0,0 +1,183 @@
+package pathplan
+
+import (
+	"sync"
+	"time"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+// maxCachedPaths bounds the path cache; it is cleared when full.
+const maxCachedPaths = 4096
+
+// Point is a position in world units.
+type Point struct {
+	X, Y float64
+}
+
+// Planner plans waypoint paths on one world's grid and caches them by start
+// and goal cell. It is safe for concurrent use.
+type Planner struct {
+	grid   *world.Grid
+	search func(g *world.Grid, start, goal world.Cell) []world.Cell
+
+	mu    sync.Mutex
+	cache map[[2]world.Cell][]world.Cell
+	spent time.Duration
+}
+
+// NewPlanner creates a planner for the world in def using the algorithm it
+// names; JPS is the default. Worlds with terrain costs always use A*, since
+// JPS assumes every cell costs the same.
+func NewPlanner(def *simulationpb.WorldDefinition) *Planner {
+	grid := world.NewGrid(def)
+	search := JPS
+	if def.GetPathAlgorithm() == simulationpb.PathAlgorithm_PATH_ALGORITHM_ASTAR || !grid.Uniform() {
+		search = AStar
+	}
+	return &Planner{
+		grid:   grid,
+		search: search,
+		cache:  make(map[[2]world.Cell][]world.Cell),
+	}
+}
+
+// Grid returns the occupancy grid the planner works on.
+func (p *Planner) Grid() *world.Grid {
+	return p.grid
+}
+
+// Plan returns the waypoints from (fromX, fromY) to (toX, toY), ending
+// exactly at the destination. A world without obstacles or terrain plans a
+// straight line. ok is
+// false when the destination is blocked or cannot be reached.
+func (p *Planner) Plan(fromX, fromY, toX, toY float64) (waypoints []Point, ok bool) {
+	start := time.Now()
+	defer func() {
+		p.mu.Lock()
+		p.spent += time.Since(start)
+		p.mu.Unlock()
+	}()
+
+	if p.grid.Empty() && p.grid.Uniform() {
+		return []Point{{X: toX, Y: toY}}, true
+	}
+
+	from := p.grid.CellAt(fromX, fromY)
+	to := p.grid.CellAt(toX, toY)
+	if !p.grid.Walkable(to) {
+		return nil, false
+	}
+
+	// An entity that ended up inside an obstacle first steps out to the
+	// nearest free cell.
+	var detour []Point
+	if !p.grid.Walkable(from) {
+		free, found := nearestWalkable(p.grid, from)
+		if !found {
+			return nil, false
+		}
+		x, y := p.grid.Center(free)
+		detour = append(detour, Point{X: x, Y: y})
+		from = free
+	}
+
+	cells, ok := p.cells(from, to)
+	if !ok {
+		return nil, false
+	}
+
+	// Skip the start cell and end on the exact destination rather than the
+	// centre of its cell.
+	waypoints = detour
+	for _, c := range cells[1:] {
+		x, y := p.grid.Center(c)
+		waypoints = append(waypoints, Point{X: x, Y: y})
+	}
+	if n := len(waypoints); n > len(detour) {
+		waypoints[n-1] = Point{X: toX, Y: toY}
+	} else {
+		waypoints = append(waypoints, Point{X: toX, Y: toY})
+	}
+	return waypoints, true
+}
+
+// TakeSpent returns the time spent planning since the last call and resets
+// it.
+func (p *Planner) TakeSpent() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	spent := p.spent
+	p.spent = 0
+	return spent
+}
+
+// cells returns the turning points of the path between two cells, from the
+// cache when possible.
+func (p *Planner) cells(from, to world.Cell) ([]world.Cell, bool) {
+	key := [2]world.Cell{from, to}
+
+	p.mu.Lock()
+	cached, hit := p.cache[key]
+	p.mu.Unlock()
+	if hit {
+		return cached, cached != nil
+	}
+
+	path := turningPoints(p.search(p.grid, from, to))
+
+	p.mu.Lock()
+	if len(p.cache) >= maxCachedPaths {
+		p.cache = make(map[[2]world.Cell][]world.Cell)
+	}
+	p.cache[key] = path // nil caches "unreachable" too
+	p.mu.Unlock()
+
+	return path, path != nil
+}
+
+// turningPoints drops cells in the middle of straight or diagonal runs.
+func turningPoints(cells []world.Cell) []world.Cell {
+	if len(cells) <= 2 {
+		return cells
+	}
+	out := []world.Cell{cells[0]}
+	for i := 1; i < len(cells)-1; i++ {
+		prev, cur, next := cells[i-1], cells[i], cells[i+1]
+		if sign(cur.Col-prev.Col) != sign(next.Col-cur.Col) || sign(cur.Row-prev.Row) != sign(next.Row-cur.Row) {
+			out = append(out, cur)
+		}
+	}
+	return append(out, cells[len(cells)-1])
+}
+
+// nearestWalkable searches outward from c, ring by ring, for a free cell.
+func nearestWalkable(g *world.Grid, c world.Cell) (world.Cell, bool) {
+	cols, rows := g.Dims()
+	maxRing := cols
+	if rows > maxRing {
+		maxRing = rows
+	}
+	for ring := 1; ring < maxRing; ring++ {
+		for dr := -ring; dr <= ring; dr++ {
+			for dc := -ring; dc <= ring; dc++ {
+				if abs(dr) != ring && abs(dc) != ring {
+					continue
+				}
+				n := world.Cell{Col: c.Col + dc, Row: c.Row + dr}
+				if g.Walkable(n) {
+					return n, true
+				}
+			}
+		}
+	}
+	return world.Cell{}, false
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
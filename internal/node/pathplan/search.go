@@ -0,0 +1,311 @@
+// Package pathplan finds paths for ground entities across a world's
+// occupancy grid. Movement is 8-connected; a diagonal step is only allowed
+// when both cells it passes between are free, so paths never cut the corner
+// of an obstacle.
+package pathplan
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+// AStar returns the cells of a cheapest path from start to goal, both
+// included, or nil if goal cannot be reached. Each step costs its length
+// times the mean terrain cost of the two cells it joins.
+func AStar(g *world.Grid, start, goal world.Cell) []world.Cell {
+	s := newSearch(g, start, goal)
+	return s.run(func(n world.Cell) []world.Cell {
+		var out []world.Cell
+		for _, d := range directions {
+			next := world.Cell{Col: n.Col + d.Col, Row: n.Row + d.Row}
+			if s.canStep(n, d) {
+				out = append(out, next)
+			}
+		}
+		return out
+	})
+}
+
+// JPS returns the jump points of a shortest path from start to goal, both
+// included, or nil if goal cannot be reached. Consecutive jump points are
+// joined by straight or diagonal runs of free cells. It ignores terrain
+// cost, so it is only used on uniform-cost grids, where it finds paths as
+// short as AStar's while expanding far fewer cells.
+func JPS(g *world.Grid, start, goal world.Cell) []world.Cell {
+	s := newSearch(g, start, goal)
+	return s.run(func(n world.Cell) []world.Cell {
+		var out []world.Cell
+		for _, next := range s.prunedNeighbors(n) {
+			if jp, ok := s.jump(next, n); ok {
+				out = append(out, jp)
+			}
+		}
+		return out
+	})
+}
+
+// directions are the 8 neighbour offsets.
+var directions = []world.Cell{
+	{Col: 1, Row: 0}, {Col: -1, Row: 0}, {Col: 0, Row: 1}, {Col: 0, Row: -1},
+	{Col: 1, Row: 1}, {Col: 1, Row: -1}, {Col: -1, Row: 1}, {Col: -1, Row: -1},
+}
+
+// search is the best-first search shared by AStar and JPS. Costs are in
+// cells: 1 for a straight step, sqrt(2) for a diagonal one, scaled by
+// terrain cost. Since no cell costs less than 1 the octile heuristic stays
+// admissible.
+type search struct {
+	g           *world.Grid
+	start, goal world.Cell
+	cols        int
+
+	cost   []float64
+	parent []int
+	closed []bool
+}
+
+func newSearch(g *world.Grid, start, goal world.Cell) *search {
+	cols, rows := g.Dims()
+	s := &search{
+		g:      g,
+		start:  start,
+		goal:   goal,
+		cols:   cols,
+		cost:   make([]float64, cols*rows),
+		parent: make([]int, cols*rows),
+		closed: make([]bool, cols*rows),
+	}
+	for i := range s.cost {
+		s.cost[i] = math.Inf(1)
+		s.parent[i] = -1
+	}
+	return s
+}
+
+// run expands cells in order of cost plus octile distance to the goal,
+// asking successors for the cells reachable from each.
+func (s *search) run(successors func(world.Cell) []world.Cell) []world.Cell {
+	if !s.g.Walkable(s.start) || !s.g.Walkable(s.goal) {
+		return nil
+	}
+
+	open := &openList{}
+	s.cost[s.id(s.start)] = 0
+	heap.Push(open, openItem{cell: s.start, f: octile(s.start, s.goal)})
+
+	for open.Len() > 0 {
+		n := heap.Pop(open).(openItem).cell
+		id := s.id(n)
+		if s.closed[id] {
+			continue
+		}
+		s.closed[id] = true
+
+		if n == s.goal {
+			return s.path()
+		}
+
+		for _, next := range successors(n) {
+			nid := s.id(next)
+			if s.closed[nid] {
+				continue
+			}
+			c := s.cost[id] + s.stepCost(n, next)
+			if c < s.cost[nid] {
+				s.cost[nid] = c
+				s.parent[nid] = id
+				heap.Push(open, openItem{cell: next, f: c + octile(next, s.goal)})
+			}
+		}
+	}
+	return nil
+}
+
+// stepCost is the cost of moving from a to b, which are joined by a straight
+// or diagonal run.
+func (s *search) stepCost(a, b world.Cell) float64 {
+	d := octile(a, b)
+	if s.g.Uniform() {
+		return d
+	}
+	return d * (s.g.Cost(a) + s.g.Cost(b)) / 2
+}
+
+func (s *search) path() []world.Cell {
+	var out []world.Cell
+	for id := s.id(s.goal); id >= 0; id = s.parent[id] {
+		out = append(out, world.Cell{Col: id % s.cols, Row: id / s.cols})
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+func (s *search) id(c world.Cell) int {
+	return c.Row*s.cols + c.Col
+}
+
+func (s *search) free(col, row int) bool {
+	return s.g.Walkable(world.Cell{Col: col, Row: row})
+}
+
+// canStep reports whether one step in direction d from n is allowed.
+func (s *search) canStep(n, d world.Cell) bool {
+	if !s.free(n.Col+d.Col, n.Row+d.Row) {
+		return false
+	}
+	if d.Col != 0 && d.Row != 0 {
+		return s.free(n.Col+d.Col, n.Row) && s.free(n.Col, n.Row+d.Row)
+	}
+	return true
+}
+
+// prunedNeighbors returns the neighbours of n worth exploring given the
+// direction the search arrived from. The start cell explores every
+// direction.
+func (s *search) prunedNeighbors(n world.Cell) []world.Cell {
+	pid := s.parent[s.id(n)]
+	if pid < 0 {
+		var out []world.Cell
+		for _, d := range directions {
+			if s.canStep(n, d) {
+				out = append(out, world.Cell{Col: n.Col + d.Col, Row: n.Row + d.Row})
+			}
+		}
+		return out
+	}
+
+	x, y := n.Col, n.Row
+	dx, dy := sign(x-pid%s.cols), sign(y-pid/s.cols)
+
+	var out []world.Cell
+	add := func(col, row int) { out = append(out, world.Cell{Col: col, Row: row}) }
+
+	switch {
+	case dx != 0 && dy != 0:
+		if s.free(x, y+dy) {
+			add(x, y+dy)
+		}
+		if s.free(x+dx, y) {
+			add(x+dx, y)
+		}
+		if s.free(x, y+dy) && s.free(x+dx, y) {
+			add(x+dx, y+dy)
+		}
+	case dx != 0:
+		next, up, down := s.free(x+dx, y), s.free(x, y+1), s.free(x, y-1)
+		if next {
+			add(x+dx, y)
+			if up {
+				add(x+dx, y+1)
+			}
+			if down {
+				add(x+dx, y-1)
+			}
+		}
+		if up {
+			add(x, y+1)
+		}
+		if down {
+			add(x, y-1)
+		}
+	default:
+		next, right, left := s.free(x, y+dy), s.free(x+1, y), s.free(x-1, y)
+		if next {
+			add(x, y+dy)
+			if right {
+				add(x+1, y+dy)
+			}
+			if left {
+				add(x-1, y+dy)
+			}
+		}
+		if right {
+			add(x+1, y)
+		}
+		if left {
+			add(x-1, y)
+		}
+	}
+	return out
+}
+
+// jump moves from "from" through n in the same direction until it reaches
+// the goal, a cell with a forced neighbour, or an obstacle. It returns the
+// jump point it stopped at.
+func (s *search) jump(n, from world.Cell) (world.Cell, bool) {
+	dx, dy := n.Col-from.Col, n.Row-from.Row
+	for {
+		x, y := n.Col, n.Row
+		if !s.free(x, y) {
+			return world.Cell{}, false
+		}
+		if n == s.goal {
+			return n, true
+		}
+
+		switch {
+		case dx != 0 && dy != 0:
+			// A diagonal run stops where a straight run from it would
+			// find a jump point.
+			if _, ok := s.jump(world.Cell{Col: x + dx, Row: y}, n); ok {
+				return n, true
+			}
+			if _, ok := s.jump(world.Cell{Col: x, Row: y + dy}, n); ok {
+				return n, true
+			}
+		case dx != 0:
+			if (s.free(x, y-1) && !s.free(x-dx, y-1)) || (s.free(x, y+1) && !s.free(x-dx, y+1)) {
+				return n, true
+			}
+		default:
+			if (s.free(x-1, y) && !s.free(x-1, y-dy)) || (s.free(x+1, y) && !s.free(x+1, y-dy)) {
+				return n, true
+			}
+		}
+
+		if !s.free(x+dx, y) || !s.free(x, y+dy) {
+			return world.Cell{}, false
+		}
+		n = world.Cell{Col: x + dx, Row: y + dy}
+	}
+}
+
+// octile is the cost of the shortest obstacle-free path between a and b.
+func octile(a, b world.Cell) float64 {
+	dx := math.Abs(float64(a.Col - b.Col))
+	dy := math.Abs(float64(a.Row - b.Row))
+	return math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy)
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+type openItem struct {
+	cell world.Cell
+	f    float64
+}
+
+// openList is a min-heap of cells by f.
+type openList []openItem
+
+func (o openList) Len() int            { return len(o) }
+func (o openList) Less(i, j int) bool  { return o[i].f < o[j].f }
+func (o openList) Swap(i, j int)       { o[i], o[j] = o[j], o[i] }
+func (o *openList) Push(x interface{}) { *o = append(*o, x.(openItem)) }
+func (o *openList) Pop() interface{} {
+	old := *o
+	item := old[len(old)-1]
+	*o = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,163 @@
+package pathplan
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+// pathLength returns the length of the path through cells, in cells, after
+// checking every run between two of them is a straight or diagonal line
+// of steps AStar could take.
+func pathLength(t *testing.T, g *world.Grid, cells []world.Cell) float64 {
+	t.Helper()
+	s := newSearch(g, cells[0], cells[len(cells)-1])
+	total := 0.0
+	for i := 1; i < len(cells); i++ {
+		a, b := cells[i-1], cells[i]
+		dc, dr := b.Col-a.Col, b.Row-a.Row
+		if dc != 0 && dr != 0 && abs(dc) != abs(dr) || dc == 0 && dr == 0 {
+			t.Fatalf("run %v -> %v is neither straight nor diagonal", a, b)
+		}
+		d := world.Cell{Col: sign(dc), Row: sign(dr)}
+		for c := a; c != b; c = (world.Cell{Col: c.Col + d.Col, Row: c.Row + d.Row}) {
+			if !s.canStep(c, d) {
+				t.Fatalf("step %v -> %+v is not allowed", c, d)
+			}
+		}
+		total += octile(a, b)
+	}
+	return total
+}
+
+// testGrid returns a 10x10 grid with the cells of blocked, each 10 world
+// units square, blocked.
+func testGrid(blocked ...world.Cell) *world.Grid {
+	def := &simulationpb.WorldDefinition{CellSize: 10}
+	for _, c := range blocked {
+		x, y := float64(c.Col*10), float64(c.Row*10)
+		def.Obstacles = append(def.Obstacles, &simulationpb.Obstacle{MinX: x, MinY: y, MaxX: x + 10, MaxY: y + 10})
+	}
+	return world.NewGrid(def)
+}
+
+// wall returns the cells of column col, except those in rows gaps.
+func wall(col int, gaps ...int) []world.Cell {
+	var out []world.Cell
+rows:
+	for r := 0; r < 10; r++ {
+		for _, g := range gaps {
+			if r == g {
+				continue rows
+			}
+		}
+		out = append(out, world.Cell{Col: col, Row: r})
+	}
+	return out
+}
+
+func TestJPSMatchesAStar(t *testing.T) {
+	tests := []struct {
+		name        string
+		grid        *world.Grid
+		start, goal world.Cell
+		want        float64 // -1 when goal is unreachable
+	}{
+		{
+			name:  "open diagonal",
+			grid:  testGrid(),
+			start: world.Cell{Col: 0, Row: 0}, goal: world.Cell{Col: 9, Row: 9},
+			want: 9 * math.Sqrt2,
+		},
+		{
+			name:  "open straight",
+			grid:  testGrid(),
+			start: world.Cell{Col: 2, Row: 5}, goal: world.Cell{Col: 8, Row: 5},
+			want: 6,
+		},
+		{
+			name:  "start is goal",
+			grid:  testGrid(),
+			start: world.Cell{Col: 4, Row: 4}, goal: world.Cell{Col: 4, Row: 4},
+			want: 0,
+		},
+		{
+			name:  "through a gap in a wall",
+			grid:  testGrid(wall(5, 0)...),
+			start: world.Cell{Col: 0, Row: 9}, goal: world.Cell{Col: 9, Row: 9},
+			want: 13 + 7*math.Sqrt2,
+		},
+		{
+			name:  "walled off",
+			grid:  testGrid(wall(5)...),
+			start: world.Cell{Col: 0, Row: 0}, goal: world.Cell{Col: 9, Row: 0},
+			want: -1,
+		},
+		{
+			name:  "no corner cutting",
+			grid:  testGrid(world.Cell{Col: 1, Row: 0}, world.Cell{Col: 0, Row: 1}),
+			start: world.Cell{Col: 0, Row: 0}, goal: world.Cell{Col: 1, Row: 1},
+			want: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			astar := AStar(tt.grid, tt.start, tt.goal)
+			jps := JPS(tt.grid, tt.start, tt.goal)
+			if tt.want < 0 {
+				if astar != nil || jps != nil {
+					t.Fatalf("AStar = %v, JPS = %v; want no path", astar, jps)
+				}
+				return
+			}
+			if astar == nil || jps == nil {
+				t.Fatalf("AStar = %v, JPS = %v; want paths of length %v", astar, jps, tt.want)
+			}
+			for name, cells := range map[string][]world.Cell{"AStar": astar, "JPS": jps} {
+				if cells[0] != tt.start || cells[len(cells)-1] != tt.goal {
+					t.Errorf("%s path %v does not join %v and %v", name, cells, tt.start, tt.goal)
+				}
+				if got := pathLength(t, tt.grid, cells); math.Abs(got-tt.want) > 1e-9 {
+					t.Errorf("%s path %v has length %v, want %v", name, cells, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestJPSMatchesAStarRandom checks JPS finds paths exactly as short as
+// AStar's on random uniform-cost grids.
+func TestJPSMatchesAStarRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		var blocked []world.Cell
+		for c := 0; c < 10; c++ {
+			for r := 0; r < 10; r++ {
+				if rng.Float64() < 0.25 {
+					blocked = append(blocked, world.Cell{Col: c, Row: r})
+				}
+			}
+		}
+		g := testGrid(blocked...)
+		start := world.Cell{Col: rng.Intn(10), Row: rng.Intn(10)}
+		goal := world.Cell{Col: rng.Intn(10), Row: rng.Intn(10)}
+		if !g.Walkable(start) || !g.Walkable(goal) {
+			continue
+		}
+
+		astar, jps := AStar(g, start, goal), JPS(g, start, goal)
+		if (astar == nil) != (jps == nil) {
+			t.Fatalf("%v -> %v around %v: AStar = %v, JPS = %v", start, goal, blocked, astar, jps)
+		}
+		if astar == nil {
+			continue
+		}
+		if a, j := pathLength(t, g, astar), pathLength(t, g, jps); math.Abs(a-j) > 1e-9 {
+			t.Fatalf("%v -> %v around %v: AStar length %v, JPS length %v", start, goal, blocked, a, j)
+		}
+	}
+}
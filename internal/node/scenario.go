@@ -0,0 +1,173 @@
+package node
+
+import (
+	"math"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// Scenario hooks let a scenario type decide how entities carry out their
+// tasks on the worker. Hooks run under the worker lock, in entity id order.
+type Scenario interface {
+	// AssignTask hands t to e before the tick's step. It returns a FAILED
+	// event if e cannot take the task.
+	AssignTask(e *entity, t *simulationpb.Task, ctx *tickContext) *simulationpb.TaskEvent
+
+	// StepTask runs before e moves on every tick e holds a task. It steers
+	// e and returns an event once the task completes or fails.
+	StepTask(e *entity, ctx *tickContext) *simulationpb.TaskEvent
+}
+
+// scenarios maps scenario_type to its hooks. Types not listed use
+// taskScenario.
+var scenarios = map[string]Scenario{
+	"harvest": taskScenario{},
+	"patrol":  taskScenario{},
+}
+
+// scenarioFor returns the hooks for a scenario type.
+func scenarioFor(scenarioType string) Scenario {
+	if sc, ok := scenarios[scenarioType]; ok {
+		return sc
+	}
+	return taskScenario{}
+}
+
+// taskProgress is the worker-side progress of an entity through its task.
+type taskProgress struct {
+	task *simulationpb.Task
+
+	// next waypoint to reach
+	waypoint int
+
+	// ticks left working the harvest cell; 0 until the cell is reached
+	workLeft uint32
+	working  bool
+
+	// set when a command takes manual control of the entity
+	aborted string
+}
+
+// arrivalRadius is how close an entity must get to a waypoint to count as
+// having reached it.
+const arrivalRadius = 0.01
+
+// taskScenario is the default way of running tasks:
+//   - HARVEST: drive to the cell, then work it for work_ticks;
+//   - PATROL: visit each waypoint in order; patrols scout with cameras, so
+//     they wait in place while it is dark;
+//   - TRANSPORT: drive to the pickup, load for a tick, then drive to the depot.
+type taskScenario struct{}
+
+func (taskScenario) AssignTask(e *entity, t *simulationpb.Task, ctx *tickContext) *simulationpb.TaskEvent {
+	tick := ctx.tick
+	if e.disabled || e.state.GetBattery() <= 0 {
+		return taskEvent(t.GetTaskId(), e, simulationpb.TaskState_TASK_STATE_FAILED, tick, "entity unavailable")
+	}
+	if e.task != nil {
+		return taskEvent(t.GetTaskId(), e, simulationpb.TaskState_TASK_STATE_FAILED, tick, "entity already has a task")
+	}
+
+	e.task = &taskProgress{task: t}
+	e.state.TaskId = t.GetTaskId()
+	e.setTarget(t.GetWaypoints()[0].GetX(), t.GetWaypoints()[0].GetY())
+	return nil
+}
+
+func (taskScenario) StepTask(e *entity, ctx *tickContext) *simulationpb.TaskEvent {
+	p := e.task
+	t := p.task
+	tick := ctx.tick
+
+	switch {
+	case p.aborted != "":
+		return e.finishTask(simulationpb.TaskState_TASK_STATE_FAILED, tick, p.aborted)
+	case e.unreachable:
+		return e.finishTask(simulationpb.TaskState_TASK_STATE_FAILED, tick, "no path to waypoint")
+	case e.disabled:
+		return e.finishTask(simulationpb.TaskState_TASK_STATE_FAILED, tick, "entity disabled")
+	case e.state.GetBattery() <= 0:
+		return e.finishTask(simulationpb.TaskState_TASK_STATE_FAILED, tick, "battery depleted")
+	}
+
+	if p.working {
+		p.workLeft--
+		if p.workLeft == 0 {
+			return e.finishTask(simulationpb.TaskState_TASK_STATE_COMPLETED, tick, "")
+		}
+		e.hold("working")
+		return nil
+	}
+
+	if t.GetType() == simulationpb.TaskType_TASK_TYPE_PATROL && ctx.env.GetDark() {
+		e.hold("waiting_for_light")
+		return nil
+	}
+
+	wp := t.GetWaypoints()[p.waypoint]
+	if math.Hypot(wp.GetX()-e.state.X, wp.GetY()-e.state.Y) > arrivalRadius {
+		// Keep an existing route rather than replanning every tick.
+		if !e.hasTarget || e.targetX != wp.GetX() || e.targetY != wp.GetY() {
+			e.setTarget(wp.GetX(), wp.GetY())
+		}
+		return nil
+	}
+
+	// Reached the current waypoint.
+	switch t.GetType() {
+	case simulationpb.TaskType_TASK_TYPE_HARVEST:
+		p.working = true
+		p.workLeft = t.GetWorkTicks()
+		if p.workLeft == 0 {
+			return e.finishTask(simulationpb.TaskState_TASK_STATE_COMPLETED, tick, "")
+		}
+		e.hold("working")
+		return nil
+	case simulationpb.TaskType_TASK_TYPE_TRANSPORT:
+		if p.waypoint == 0 {
+			p.waypoint = 1
+			e.hold("loading")
+			return nil
+		}
+	default:
+		if p.waypoint+1 < len(t.GetWaypoints()) {
+			p.waypoint++
+			next := t.GetWaypoints()[p.waypoint]
+			e.setTarget(next.GetX(), next.GetY())
+			return nil
+		}
+	}
+
+	return e.finishTask(simulationpb.TaskState_TASK_STATE_COMPLETED, tick, "")
+}
+
+// finishTask clears e's task and reports the outcome. A completed task
+// leaves e stopped where it finished; a failed one leaves its movement alone,
+// so a command that took over keeps control.
+func (e *entity) finishTask(state simulationpb.TaskState, tick uint64, detail string) *simulationpb.TaskEvent {
+	ev := taskEvent(e.task.task.GetTaskId(), e, state, tick, detail)
+	e.task = nil
+	e.state.TaskId = 0
+	if state == simulationpb.TaskState_TASK_STATE_COMPLETED {
+		e.hasTarget = false
+		e.state.Vx, e.state.Vy = 0, 0
+	}
+	return ev
+}
+
+// hold keeps e in place for this tick under the given status.
+func (e *entity) hold(status string) {
+	e.hasTarget = false
+	e.state.Vx, e.state.Vy = 0, 0
+	e.activity = status
+}
+
+func taskEvent(taskID uint64, e *entity, state simulationpb.TaskState, tick uint64, detail string) *simulationpb.TaskEvent {
+	return &simulationpb.TaskEvent{
+		TaskId:   taskID,
+		EntityId: e.state.GetEntityId(),
+		State:    state,
+		Tick:     tick,
+		Detail:   detail,
+	}
+}
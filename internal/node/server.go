@@ -2,27 +2,48 @@
 package node
 
 import (
+    "context"
     "io"
-    "log"
+    "log/slog"
     "math/rand"
+    "sort"
     "sync"
     "time"
 
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    otelcodes "go.opentelemetry.io/otel/codes"
+    "go.opentelemetry.io/otel/trace"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+
+    "github.com/stevenmed26/AutoFarm/internal/fleet"
+    "github.com/stevenmed26/AutoFarm/internal/node/pathplan"
     nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
     simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+    "github.com/stevenmed26/AutoFarm/internal/tracing"
 )
 
+// tracer makes a span for each tick a worker runs, in the trace of the
+// orchestrator's tick.
+var tracer = otel.Tracer("github.com/stevenmed26/AutoFarm/internal/node")
+
 type WorkerServer struct {
     nodepb.UnimplementedNodeWorkerServiceServer
 
-    mu     sync.RWMutex
-    states map[string]map[uint64]*simulationpb.EntityState
+    logic *SimulationLogic
+
+    mu       sync.RWMutex
+    states   map[string]map[uint64]*entity
+    planners map[string]*pathplan.Planner
 }
 
 func NewWorkerServer() *WorkerServer {
     rand.Seed(time.Now().UnixNano())
     return &WorkerServer{
-        states: make(map[string]map[uint64]*simulationpb.EntityState),
+        logic:    NewSimulationLogic(),
+        states:   make(map[string]map[uint64]*entity),
+        planners: make(map[string]*pathplan.Planner),
     }
 }
 
@@ -31,13 +52,17 @@ func NewWorkerServer() *WorkerServer {
 // - Updates entity state in memory
 // - Streams back WorkerTickResponse messages
 func (s *WorkerServer) RunWorkerTicks(stream nodepb.NodeWorkerService_RunWorkerTicksServer) error {
+    // Carries the request ID of the orchestrator's stream.
+    streamCtx := stream.Context()
     for {
         req, err := stream.Recv()
         if err == io.EOF {
             return nil
         }
         if err != nil {
-            log.Printf("RunWorkerTicks recv error: %v", err)
+            if status.Code(err) != codes.Canceled {
+                slog.WarnContext(streamCtx, "failed to receive tick", "error", err)
+            }
             return err
         }
 
@@ -46,82 +71,201 @@ func (s *WorkerServer) RunWorkerTicks(stream nodepb.NodeWorkerService_RunWorkerT
         simID := req.GetSimulationId().GetValue()
         entityIDs := req.GetEntityIds()
 
+        // The request carries the context of the orchestrator's span for
+        // this partition; the stream's own span belongs to whatever
+        // started the tick loop.
+        _, span := tracer.Start(tracing.Extract(streamCtx, req.GetTraceContext()), "worker tick",
+            trace.WithSpanKind(trace.SpanKindServer),
+            trace.WithAttributes(
+                attribute.String("simulation.id", simID),
+                attribute.Int64("simulation.tick", int64(req.GetTick())),
+                attribute.Int64("partition.index", int64(req.GetPartitionIndex())),
+                attribute.Int("entities", len(entityIDs))))
+
         // Initialize state map for this simulation if needed.
         s.mu.Lock()
         simStates, ok := s.states[simID]
         if !ok {
-            simStates = make(map[uint64]*simulationpb.EntityState)
+            simStates = make(map[uint64]*entity)
             s.states[simID] = simStates
+            slog.InfoContext(streamCtx, "simulation state created", "simulation_id", simID, "tick", req.GetTick())
         }
 
-        // Update entities.
-        updated := make([]*simulationpb.EntityState, 0, len(entityIDs))
+        // The world is fixed for the life of a simulation, so its planner
+        // and path cache are built once.
+        nav, ok := s.planners[simID]
+        if !ok {
+            nav = pathplan.NewPlanner(req.GetConfig().GetWorld())
+            s.planners[simID] = nav
+        }
+        nav.TakeSpent()
+
+        // Roster changes: spawn before retiring, so an entity spawned and
+        // retired between two ticks never lingers.
+        for _, sp := range req.GetSpawns() {
+            if _, ok := simStates[sp.GetEntityId()]; !ok {
+                simStates[sp.GetEntityId()] = s.logic.SpawnEntity(sp, req.GetConfig(), nav)
+            }
+        }
+        for _, eid := range req.GetRetiredEntityIds() {
+            delete(simStates, eid)
+        }
+
+        for _, eid := range entityIDs {
+            if _, ok := simStates[eid]; !ok {
+                simStates[eid] = s.logic.NewEntity(eid, fleet.TypeFor(req.GetConfig(), eid), req.GetConfig(), nav)
+            }
+        }
+
+        // Apply commands before the tick runs, in command id order so
+        // every replay of the same commands gives the same result.
+        acks := s.applyCommands(simStates, req.GetCommands(), req.GetTick())
+        commandsDone := time.Now()
 
+        ctx := &tickContext{
+            tick: req.GetTick(),
+            env:  req.GetEnvironment(),
+            nav:  nav,
+        }
+
+        // Let the scenario drive entities with a task, then move everyone.
+        scenario := scenarioFor(req.GetConfig().GetScenarioType())
+        events := s.assignTasks(scenario, simStates, req.GetTaskAssignments(), ctx)
         for _, eid := range entityIDs {
-            st, ok := simStates[eid]
-            if !ok {
-                st = s.newEntityState(eid)
-                simStates[eid] = st
+            e := simStates[eid]
+            if e.task != nil {
+                if ev := scenario.StepTask(e, ctx); ev != nil {
+                    events = append(events, ev)
+                }
             }
+        }
+        tasksDone := time.Now()
 
-            // Simple "simulation": move diagonally and drain battery.
-            s.updateEntity(st)
+        updated := make([]*simulationpb.EntityState, 0, len(entityIDs))
 
-            updated = append(updated, cloneEntityState(st))
+        for _, eid := range entityIDs {
+            e := simStates[eid]
+            s.logic.Step(e, ctx)
+            updated = append(updated, cloneEntityState(e.state))
         }
         s.mu.Unlock()
 
-        computeMs := time.Since(start).Seconds() * 1000.0
+        end := time.Now()
+        computeMs := end.Sub(start).Seconds() * 1000.0
+
+        // Paths are planned during the step, so planning time is carved
+        // out of it.
+        planning := nav.TakeSpent()
+        breakdown := map[string]float64{
+            "commands": commandsDone.Sub(start).Seconds() * 1000.0,
+            "tasks":    tasksDone.Sub(commandsDone).Seconds() * 1000.0,
+            "planning": planning.Seconds() * 1000.0,
+            "step":     (end.Sub(tasksDone) - planning).Seconds() * 1000.0,
+        }
 
         resp := &nodepb.WorkerTickResponse{
             SimulationId: req.GetSimulationId(),
             Tick:         req.GetTick(),
             Entities:     updated,
             ComputeMs:    computeMs,
+            CommandAcks:  acks,
+            TaskEvents:   events,
+
+            ComputeBreakdownMs: breakdown,
+        }
+
+        for phase, ms := range breakdown {
+            span.SetAttributes(attribute.Float64("compute_ms."+phase, ms))
         }
 
         if err := stream.Send(resp); err != nil {
-            log.Printf("RunWorkerTicks send error: %v", err)
+            span.RecordError(err)
+            span.SetStatus(otelcodes.Error, err.Error())
+            span.End()
+            slog.WarnContext(streamCtx, "failed to send tick", "simulation_id", simID, "tick", req.GetTick(), "error", err)
             return err
         }
+        span.End()
     }
 }
 
-func (s *WorkerServer) newEntityState(id uint64) *simulationpb.EntityState {
-    return &simulationpb.EntityState{
-        EntityId: id,
-        X:        rand.Float64() * 100,
-        Y:        rand.Float64() * 100,
-        Vx:       (rand.Float64() - 0.5) * 2, // -1 to +1
-        Vy:       (rand.Float64() - 0.5) * 2,
-        Battery:  100.0,
-        Status:   "idle",
+// ReleaseSimulation frees the entity states and planner of a simulation
+// that has ended.
+func (s *WorkerServer) ReleaseSimulation(
+    ctx context.Context,
+    req *nodepb.ReleaseSimulationRequest,
+) (*nodepb.ReleaseSimulationResponse, error) {
+    simID := req.GetSimulationId().GetValue()
+
+    s.mu.Lock()
+    _, ok := s.states[simID]
+    delete(s.states, simID)
+    delete(s.planners, simID)
+    s.mu.Unlock()
+
+    if ok {
+        slog.InfoContext(ctx, "simulation state released", "simulation_id", simID)
     }
+    return &nodepb.ReleaseSimulationResponse{}, nil
 }
 
-func (s *WorkerServer) updateEntity(st *simulationpb.EntityState) {
-    // Advance position.
-    st.X += st.Vx
-    st.Y += st.Vy
-
-    // Simple boundary bounce.
-    if st.X < 0 || st.X > 100 {
-        st.Vx = -st.Vx
+func (s *WorkerServer) applyCommands(
+    simStates map[uint64]*entity,
+    commands []*simulationpb.EntityCommand,
+    tick uint64,
+) []*simulationpb.EntityCommandAck {
+    if len(commands) == 0 {
+        return nil
     }
-    if st.Y < 0 || st.Y > 100 {
-        st.Vy = -st.Vy
+
+    ordered := append([]*simulationpb.EntityCommand(nil), commands...)
+    sort.Slice(ordered, func(i, j int) bool {
+        return ordered[i].GetCommandId() < ordered[j].GetCommandId()
+    })
+
+    acks := make([]*simulationpb.EntityCommandAck, 0, len(ordered))
+    for _, cmd := range ordered {
+        e, ok := simStates[cmd.GetEntityId()]
+        if !ok {
+            acks = append(acks, &simulationpb.EntityCommandAck{
+                CommandId: cmd.GetCommandId(),
+                EntityId:  cmd.GetEntityId(),
+                Tick:      tick,
+                Error:     "entity not owned by this worker",
+            })
+            continue
+        }
+        acks = append(acks, s.logic.ApplyCommand(e, cmd, tick))
     }
+    return acks
+}
 
-    // Drain battery a bit.
-    st.Battery -= 0.1
-    if st.Battery < 0 {
-        st.Battery = 0
-        st.Status = "offline"
-    } else if st.Battery < 20 {
-        st.Status = "low_battery"
-    } else {
-        st.Status = "active"
+// assignTasks hands newly assigned tasks to their entities through the
+// scenario hooks and returns events for tasks that could not be taken.
+func (s *WorkerServer) assignTasks(
+    scenario Scenario,
+    simStates map[uint64]*entity,
+    assignments []*simulationpb.Task,
+    ctx *tickContext,
+) []*simulationpb.TaskEvent {
+    var events []*simulationpb.TaskEvent
+    for _, t := range assignments {
+        e, ok := simStates[t.GetAssignedEntityId()]
+        if !ok {
+            events = append(events, &simulationpb.TaskEvent{
+                TaskId:   t.GetTaskId(),
+                EntityId: t.GetAssignedEntityId(),
+                State:    simulationpb.TaskState_TASK_STATE_FAILED,
+                Tick:     ctx.tick,
+                Detail:   "entity not owned by this worker",
+            })
+            continue
+        }
+        if ev := scenario.AssignTask(e, t, ctx); ev != nil {
+            events = append(events, ev)
+        }
     }
+    return events
 }
 
 func cloneEntityState(st *simulationpb.EntityState) *simulationpb.EntityState {
@@ -136,5 +280,7 @@ func cloneEntityState(st *simulationpb.EntityState) *simulationpb.EntityState {
         Vy:       st.Vy,
         Battery:  st.Battery,
         Status:   st.Status,
+        Type:     st.Type,
+        TaskId:   st.TaskId,
     }
 }
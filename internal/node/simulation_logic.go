@@ -1,10 +1,366 @@
 package node
 
-// SimulationLogic is a placeholder for node-side simulation behavior.
-// TODO: implement tick advancement, state updates, and communication with the orchestrator.
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/stevenmed26/AutoFarm/internal/environment"
+	"github.com/stevenmed26/AutoFarm/internal/fleet"
+	"github.com/stevenmed26/AutoFarm/internal/node/pathplan"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+// entity is the worker-side state of one simulated entity. state is what gets
+// reported to the orchestrator; the remaining fields stay on the worker.
+type entity struct {
+	state  *simulationpb.EntityState
+	params *simulationpb.EntityTypeParams
+
+	// spawn position, used by RETURN_TO_BASE
+	homeX, homeY float64
+
+	hasTarget        bool
+	targetX, targetY float64
+
+	// waypoints still to visit on the way to the target; planned on the
+	// first step after the target is set
+	route       []pathplan.Point
+	routed      bool
+	unreachable bool
+
+	disabled bool
+
+	// grounded by wind; the heading to resume with once the wind drops
+	grounded           bool
+	resumeVx, resumeVy float64
+
+	// current task, nil when idle
+	task *taskProgress
+
+	// status override for this tick, set by task hooks
+	activity string
+}
+
+// tickContext is what the worker knows about the tick being computed.
+type tickContext struct {
+	tick uint64
+
+	// time of day and weather; nil from orchestrators that do not send it,
+	// which behaves like a clear day
+	env *simulationpb.Environment
+
+	// path planner for the simulation's world
+	nav *pathplan.Planner
+}
+
+// SimulationLogic advances entity state one tick at a time.
 type SimulationLogic struct{}
 
 // NewSimulationLogic creates a new SimulationLogic instance.
 func NewSimulationLogic() *SimulationLogic {
 	return &SimulationLogic{}
 }
+
+// maxSpawnAttempts bounds how often NewEntity redraws a position that landed
+// inside an obstacle.
+const maxSpawnAttempts = 100
+
+// NewEntity spawns an entity of type t at its placement in cfg's fleet mix,
+// at rest, or else at a random free position with a random heading no
+// faster than the type's max speed.
+func (l *SimulationLogic) NewEntity(id uint64, t simulationpb.EntityType, cfg *simulationpb.SimulationConfig, nav *pathplan.Planner) *entity {
+	params := fleet.Params(cfg, t)
+
+	if p := fleet.PlacementFor(cfg, id); p != nil {
+		battery := p.GetBattery()
+		if battery <= 0 {
+			battery = 100.0
+		}
+		return &entity{
+			state: &simulationpb.EntityState{
+				EntityId: id,
+				X:        p.GetX(),
+				Y:        p.GetY(),
+				Battery:  battery,
+				Status:   "idle",
+				Type:     t,
+			},
+			params: params,
+			homeX:  p.GetX(),
+			homeY:  p.GetY(),
+		}
+	}
+
+	x, y := rand.Float64()*world.Size, rand.Float64()*world.Size
+	for i := 0; i < maxSpawnAttempts && nav.Grid().BlockedAt(x, y); i++ {
+		x, y = rand.Float64()*world.Size, rand.Float64()*world.Size
+	}
+
+	st := &simulationpb.EntityState{
+		EntityId: id,
+		X:        x,
+		Y:        y,
+		Vx:       (rand.Float64() - 0.5) * 2 * params.GetMaxSpeed(), // -max to +max
+		Vy:       (rand.Float64() - 0.5) * 2 * params.GetMaxSpeed(),
+		Battery:  100.0,
+		Status:   "idle",
+		Type:     t,
+	}
+	return &entity{
+		state:  st,
+		params: params,
+		homeX:  st.X,
+		homeY:  st.Y,
+	}
+}
+
+// SpawnEntity creates an entity from a spawn. Without an initial state it
+// falls back to NewEntity; a non-positive battery starts full unless the
+// state is restored from a snapshot.
+func (l *SimulationLogic) SpawnEntity(sp *simulationpb.EntitySpawn, cfg *simulationpb.SimulationConfig, nav *pathplan.Planner) *entity {
+	initial := sp.GetInitialState()
+	if initial == nil {
+		return l.NewEntity(sp.GetEntityId(), sp.GetType(), cfg, nav)
+	}
+
+	st := cloneEntityState(initial)
+	st.EntityId = sp.GetEntityId()
+	st.Type = sp.GetType()
+	if st.Battery <= 0 && !sp.GetRestored() {
+		st.Battery = 100.0
+	}
+	if st.Status == "" {
+		st.Status = "idle"
+	}
+	return &entity{
+		state:    st,
+		params:   fleet.Params(cfg, st.Type),
+		homeX:    st.X,
+		homeY:    st.Y,
+		disabled: sp.GetRestored() && st.Status == "disabled",
+	}
+}
+
+// ApplyCommand applies cmd to e before tick runs and reports the outcome.
+func (l *SimulationLogic) ApplyCommand(e *entity, cmd *simulationpb.EntityCommand, tick uint64) *simulationpb.EntityCommandAck {
+	ack := &simulationpb.EntityCommandAck{
+		CommandId: cmd.GetCommandId(),
+		EntityId:  cmd.GetEntityId(),
+		Tick:      tick,
+		Applied:   true,
+	}
+
+	st := e.state
+
+	// Manual control ends the entity's task; the scenario reports it as
+	// failed so the orchestrator can reassign it.
+	if e.task != nil && cmd.GetType() != simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_ENABLE {
+		e.task.aborted = "overridden by command"
+	}
+
+	switch cmd.GetType() {
+	case simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_MOVE_TO:
+		e.setTarget(cmd.GetX(), cmd.GetY())
+	case simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_RETURN_TO_BASE:
+		e.setTarget(e.homeX, e.homeY)
+	case simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_SET_VELOCITY:
+		e.hasTarget = false
+		st.Vx, st.Vy = clampSpeed(cmd.GetVx(), cmd.GetVy(), e.params.GetMaxSpeed())
+	case simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_DISABLE:
+		e.disabled = true
+		e.hasTarget = false
+		st.Vx, st.Vy = 0, 0
+	case simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_ENABLE:
+		e.disabled = false
+	default:
+		ack.Applied = false
+		ack.Error = fmt.Sprintf("unsupported command type %s", cmd.GetType())
+	}
+
+	return ack
+}
+
+// Step advances e by one tick, planning a path on the world grid when e has
+// a new target. Rain and rough terrain slow e down, rain and wind drain its
+// battery faster, and wind pushes airborne entities off course or keeps them
+// on the ground.
+func (l *SimulationLogic) Step(e *entity, ctx *tickContext) {
+	st := e.state
+	activity := e.activity
+	e.activity = ""
+
+	if e.disabled {
+		st.Status = "disabled"
+		return
+	}
+
+	airborne := e.params.GetIgnoresGroundObstacles()
+	if environment.Grounded(ctx.env, airborne) {
+		if !e.grounded {
+			e.grounded = true
+			e.resumeVx, e.resumeVy = st.Vx, st.Vy
+		}
+		st.Vx, st.Vy = 0, 0
+		activity = "grounded"
+	} else {
+		if e.grounded {
+			e.grounded = false
+			if !e.hasTarget {
+				st.Vx, st.Vy = e.resumeVx, e.resumeVy
+			}
+		}
+		l.move(e, ctx, airborne)
+	}
+
+	// Drain battery as a percentage of the type's capacity.
+	st.Battery -= e.drainPerTick() * environment.DrainFactor(ctx.env, airborne)
+	if st.Battery < 0 {
+		st.Battery = 0
+		st.Status = "offline"
+	} else if st.Battery < 20 {
+		st.Status = "low_battery"
+	} else if activity != "" {
+		st.Status = activity
+	} else if e.hasTarget {
+		st.Status = "en_route"
+	} else {
+		st.Status = "active"
+	}
+}
+
+// move advances e's position by one tick.
+func (l *SimulationLogic) move(e *entity, ctx *tickContext, airborne bool) {
+	st := e.state
+
+	factor := environment.SpeedFactor(ctx.env, airborne)
+	if !airborne {
+		factor /= ctx.nav.Grid().CostAt(st.X, st.Y)
+	}
+
+	if e.hasTarget && !e.routed {
+		e.planRoute(ctx.nav)
+	}
+
+	steering := e.hasTarget
+	arrived := false
+	if steering {
+		arrived = e.steer(factor)
+	} else if !airborne {
+		bounceOffObstacles(st, ctx.nav.Grid())
+	}
+
+	// Advance position. A steering entity's velocity already accounts for
+	// the conditions; a wandering one keeps its heading and covers less
+	// ground.
+	if steering {
+		st.X += st.Vx
+		st.Y += st.Vy
+	} else {
+		st.X += st.Vx * factor
+		st.Y += st.Vy * factor
+	}
+
+	if arrived {
+		st.Vx, st.Vy = 0, 0
+	} else if dx, dy := environment.Drift(ctx.env, airborne); dx != 0 || dy != 0 {
+		st.X = math.Max(0, math.Min(world.Size, st.X+dx))
+		st.Y = math.Max(0, math.Min(world.Size, st.Y+dy))
+	}
+
+	// Simple boundary bounce.
+	if st.X < 0 || st.X > world.Size {
+		st.Vx = -st.Vx
+	}
+	if st.Y < 0 || st.Y > world.Size {
+		st.Vy = -st.Vy
+	}
+}
+
+func (e *entity) setTarget(x, y float64) {
+	e.hasTarget = true
+	e.targetX, e.targetY = x, y
+	e.route, e.routed, e.unreachable = nil, false, false
+}
+
+// planRoute plans the waypoints to the target. Drones fly straight there;
+// ground entities follow a grid path around obstacles. An unreachable target
+// stops the entity.
+func (e *entity) planRoute(nav *pathplan.Planner) {
+	e.routed = true
+	if e.params.GetIgnoresGroundObstacles() {
+		e.route = []pathplan.Point{{X: e.targetX, Y: e.targetY}}
+		return
+	}
+
+	route, ok := nav.Plan(e.state.X, e.state.Y, e.targetX, e.targetY)
+	if !ok {
+		e.hasTarget = false
+		e.unreachable = true
+		e.state.Vx, e.state.Vy = 0, 0
+		return
+	}
+	e.route = route
+}
+
+// steer points the velocity at the next waypoint at max speed scaled by
+// factor. Within one tick's travel of a waypoint this tick lands on it; it
+// reports true when that waypoint is the target.
+func (e *entity) steer(factor float64) bool {
+	st := e.state
+	speed := e.params.GetMaxSpeed() * factor
+	wp := e.route[0]
+	dx, dy := wp.X-st.X, wp.Y-st.Y
+	dist := math.Hypot(dx, dy)
+	if dist <= speed {
+		st.Vx, st.Vy = dx, dy
+		e.route = e.route[1:]
+		if len(e.route) > 0 {
+			return false
+		}
+		e.hasTarget = false
+		return true
+	}
+	st.Vx = dx / dist * speed
+	st.Vy = dy / dist * speed
+	return false
+}
+
+// bounceOffObstacles reverses the velocity components that would carry st
+// into a blocked cell. An entity already inside an obstacle is left to drive
+// out of it.
+func bounceOffObstacles(st *simulationpb.EntityState, g *world.Grid) {
+	if g.Empty() || g.BlockedAt(st.X, st.Y) || !g.BlockedAt(st.X+st.Vx, st.Y+st.Vy) {
+		return
+	}
+	vx, vy := st.Vx, st.Vy
+	if g.BlockedAt(st.X+vx, st.Y) {
+		st.Vx = -vx
+	}
+	if g.BlockedAt(st.X, st.Y+vy) {
+		st.Vy = -vy
+	}
+	if g.BlockedAt(st.X+st.Vx, st.Y+st.Vy) {
+		// Heading straight into a corner.
+		st.Vx, st.Vy = -vx, -vy
+	}
+}
+
+// drainPerTick converts the type's drain rate into battery percentage.
+func (e *entity) drainPerTick() float64 {
+	capacity := e.params.GetBatteryCapacity()
+	if capacity <= 0 {
+		return 0
+	}
+	return e.params.GetDrainRate() / capacity * 100
+}
+
+// clampSpeed scales (vx, vy) down to at most max.
+func clampSpeed(vx, vy, max float64) (float64, float64) {
+	speed := math.Hypot(vx, vy)
+	if speed <= max || speed == 0 {
+		return vx, vy
+	}
+	return vx / speed * max, vy / speed * max
+}
@@ -0,0 +1,100 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stevenmed26/AutoFarm/internal/fleet"
+	"github.com/stevenmed26/AutoFarm/internal/node/pathplan"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+// testWorld has a non-default cell size, and an obstacle along its east
+// edge.
+var testWorld = &simulationpb.WorldDefinition{
+	CellSize:  2.5,
+	Obstacles: []*simulationpb.Obstacle{{MinX: world.Size - 10, MinY: 0, MaxX: world.Size, MaxY: world.Size / 2}},
+}
+
+func TestNewEntitySpawnsInWorld(t *testing.T) {
+	l := NewSimulationLogic()
+	nav := pathplan.NewPlanner(testWorld)
+	cfg := &simulationpb.SimulationConfig{EntityCount: 500, World: testWorld}
+
+	var maxX, maxY float64
+	for id := uint64(1); id <= 500; id++ {
+		st := l.NewEntity(id, simulationpb.EntityType_ENTITY_TYPE_HARVESTER, cfg, nav).state
+		if st.X < 0 || st.X > world.Size || st.Y < 0 || st.Y > world.Size {
+			t.Fatalf("entity %d spawned at (%v, %v), outside [0, %d]", id, st.X, st.Y, world.Size)
+		}
+		if nav.Grid().BlockedAt(st.X, st.Y) {
+			t.Fatalf("entity %d spawned at (%v, %v), inside an obstacle", id, st.X, st.Y)
+		}
+		maxX, maxY = max(maxX, st.X), max(maxY, st.Y)
+	}
+	// Spawns cover the whole world, not a corner of it.
+	if maxX < world.Size*0.9 || maxY < world.Size*0.9 {
+		t.Errorf("spawns reach only (%v, %v) of (%d, %d)", maxX, maxY, world.Size, world.Size)
+	}
+}
+
+func TestStepBouncesAtWorldEdge(t *testing.T) {
+	const edge = world.Size
+
+	tests := []struct {
+		name           string
+		x, y, vx, vy   float64
+		wantVx, wantVy float64
+	}{
+		{"east edge", edge - 0.5, 75, 1, 0.2, -1, 0.2},
+		{"west edge", 0.5, 75, -1, 0.2, 1, 0.2},
+		{"south edge", 20, edge - 0.5, 0.2, 1, 0.2, -1},
+		{"north edge", 20, 0.5, 0.2, -1, 0.2, 1},
+		{"corner", edge - 0.5, edge - 0.5, 1, 1, -1, -1},
+		{"inside", 20, 75, 1, 1, 1, 1},
+	}
+
+	l := NewSimulationLogic()
+	ctx := &tickContext{tick: 1, nav: pathplan.NewPlanner(testWorld)}
+	cfg := &simulationpb.SimulationConfig{World: testWorld}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &entity{
+				state:  &simulationpb.EntityState{EntityId: 1, X: tt.x, Y: tt.y, Vx: tt.vx, Vy: tt.vy, Battery: 100},
+				params: fleet.Params(cfg, simulationpb.EntityType_ENTITY_TYPE_HARVESTER),
+			}
+			l.Step(e, ctx)
+			if e.state.Vx != tt.wantVx || e.state.Vy != tt.wantVy {
+				t.Errorf("velocity (%v, %v) after a step from (%v, %v), want (%v, %v)",
+					e.state.Vx, e.state.Vy, tt.x, tt.y, tt.wantVx, tt.wantVy)
+			}
+		})
+	}
+}
+
+// TestStepDriftStaysInWorld checks the wind never pushes a drone past the
+// world's edge.
+func TestStepDriftStaysInWorld(t *testing.T) {
+	l := NewSimulationLogic()
+	ctx := &tickContext{
+		tick: 1,
+		nav:  pathplan.NewPlanner(testWorld),
+		env:  &simulationpb.Environment{Weather: &simulationpb.Weather{WindSpeed: 14, WindDirectionDeg: 45}},
+	}
+	cfg := &simulationpb.SimulationConfig{World: testWorld}
+	e := &entity{
+		state:  &simulationpb.EntityState{EntityId: 1, X: world.Size - 1, Y: world.Size - 1, Battery: 100},
+		params: fleet.Params(cfg, simulationpb.EntityType_ENTITY_TYPE_DRONE),
+	}
+
+	for i := 0; i < 50; i++ {
+		l.Step(e, ctx)
+		if e.state.X > world.Size || e.state.Y > world.Size {
+			t.Fatalf("step %d: drone blown to (%v, %v), past %d", i, e.state.X, e.state.Y, world.Size)
+		}
+	}
+	if e.state.X != world.Size || e.state.Y != world.Size {
+		t.Errorf("drone at (%v, %v), want pinned to the corner", e.state.X, e.state.Y)
+	}
+}
@@ -0,0 +1,162 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// applyOverrides changes cfg as o asks. Overriding the fleet or entity
+// count drops the config's placements beyond the new counts.
+func applyOverrides(cfg *simulationpb.SimulationConfig, o *simulationpb.ConfigOverrides) error {
+	if o == nil {
+		return nil
+	}
+
+	if o.GetName() != "" {
+		cfg.Name = o.GetName()
+	}
+
+	switch {
+	case o.GetRandomizeSeed() && o.GetSeed() != 0:
+		return errors.New("overrides: set seed or randomize_seed, not both")
+	case o.GetRandomizeSeed():
+		// Picked by validateConfig.
+		cfg.Seed = 0
+	case o.GetSeed() != 0:
+		cfg.Seed = o.GetSeed()
+	}
+
+	if len(o.GetFleet()) > 0 {
+		if o.GetEntityCount() != 0 {
+			return errors.New("overrides: set entity_count or fleet, not both")
+		}
+		cfg.Fleet = o.GetFleet()
+		cfg.EntityCount = 0
+	}
+
+	if n := o.GetEntityCount(); n != 0 {
+		switch len(cfg.GetFleet()) {
+		case 0:
+			cfg.EntityCount = n
+		case 1:
+			g := cfg.Fleet[0]
+			g.Count = n
+			if len(g.Placements) > int(n) {
+				g.Placements = g.Placements[:n]
+			}
+			cfg.EntityCount = 0
+		default:
+			return fmt.Errorf("overrides.entity_count: the config has %d fleet groups; override fleet instead", len(cfg.GetFleet()))
+		}
+	}
+
+	if o.GetTickRateMs() != 0 {
+		cfg.TickRateMs = o.GetTickRateMs()
+	}
+	if o.GetScenarioType() != "" {
+		cfg.ScenarioType = o.GetScenarioType()
+	}
+	if a := o.GetTaskAllocation(); a != simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_UNSPECIFIED {
+		if _, ok := simulationpb.TaskAllocationStrategy_name[int32(a)]; !ok {
+			return fmt.Errorf("overrides: unknown task_allocation %d", a)
+		}
+		cfg.TaskAllocation = a
+	}
+	return nil
+}
+
+// validateForkOverrides checks that o changes nothing but the name and seed:
+// a fork continues the source's world, fleet and schedule.
+func validateForkOverrides(o *simulationpb.ConfigOverrides) error {
+	if o.GetEntityCount() != 0 || len(o.GetFleet()) > 0 || o.GetTickRateMs() != 0 ||
+		o.GetScenarioType() != "" || o.GetTaskAllocation() != simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_UNSPECIFIED {
+		return errors.New("a fork can only override name and seed")
+	}
+	return nil
+}
+
+// sourceConfig returns a copy of sim's config to start a clone from. A
+// fleet's counts stand for the entity count, which ScaleEntities may have
+// changed since.
+func (s *SimulationServer) sourceConfig(sim *simulationpb.Simulation) *simulationpb.SimulationConfig {
+	s.mu.RLock()
+	cfg := proto.Clone(sim.Config).(*simulationpb.SimulationConfig)
+	s.mu.RUnlock()
+
+	if len(cfg.GetFleet()) > 0 {
+		cfg.EntityCount = 0
+	}
+	return cfg
+}
+
+// CloneSimulation creates a simulation with the config of another one,
+// with overrides applied. A fork instead starts from the source's latest
+// snapshot at or before fork_tick (the latest of all when 0) and continues
+// its tick numbering, entities, tasks, crops and weather from there.
+func (s *SimulationServer) CloneSimulation(
+	ctx context.Context,
+	req *simulationpb.CloneSimulationRequest,
+) (*simulationpb.CloneSimulationResponse, error) {
+
+	src, srcRt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	cfg := s.sourceConfig(src)
+
+	var snap *snapshot
+	if req.GetFork() {
+		if err := validateForkOverrides(req.GetOverrides()); err != nil {
+			return nil, invalidArgument(err)
+		}
+		if snap = srcRt.snapshots.at(req.GetForkTick()); snap == nil {
+			if req.GetForkTick() == 0 {
+				return nil, failedPrecondition(resourceSimulation+"/"+src.Id.GetValue(),
+					"simulation %s has no snapshots yet", src.Id.GetValue())
+			}
+			return nil, failedPrecondition(resourceSimulation+"/"+src.Id.GetValue(),
+				"simulation %s has no snapshot at or before tick %d", src.Id.GetValue(), req.GetForkTick())
+		}
+	}
+
+	if err := applyOverrides(cfg, req.GetOverrides()); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	rt, err := s.newRuntime(cfg, snap == nil)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	rt.sim.SourceSimulationId = src.Id.GetValue()
+	rt.sim.Tenant = src.Tenant
+	if snap != nil {
+		rt.restore(snap)
+		rt.sim.ForkedFromTick = snap.tick
+
+		// The fork starts with the source's entities at the snapshot,
+		// however many the config asks for.
+		rt.sim.Config.EntityCount = uint32(len(snap.entities))
+	}
+	if err := s.register(rt); err != nil {
+		return nil, err
+	}
+
+	detail := "clone of " + src.Id.GetValue()
+	if snap != nil {
+		detail = fmt.Sprintf("fork of %s at tick %d", src.Id.GetValue(), snap.tick)
+	}
+	s.recordEvent(ctx, rt.sim, actionClone,
+		commonpb.SimulationStatus_SIMULATION_STATUS_UNSPECIFIED, commonpb.SimulationStatus_SIMULATION_STATUS_CREATED, detail)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &simulationpb.CloneSimulationResponse{
+		Simulation: cloneSimulation(rt.sim),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package orchestrator
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// commandQueue holds entity commands until the next tick picks them up.
+// Command ids increase monotonically per simulation, which gives workers a
+// deterministic order to apply them in.
+type commandQueue struct {
+	mu      sync.Mutex
+	lastID  uint64
+	pending []*simulationpb.EntityCommand
+}
+
+// push assigns cmd the next command id and queues a copy of it.
+func (q *commandQueue) push(cmd *simulationpb.EntityCommand) *simulationpb.EntityCommand {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queued := proto.Clone(cmd).(*simulationpb.EntityCommand)
+	q.lastID++
+	queued.CommandId = q.lastID
+	q.pending = append(q.pending, queued)
+
+	return queued
+}
+
+// drain removes and returns every queued command.
+func (q *commandQueue) drain() []*simulationpb.EntityCommand {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	out := q.pending
+	q.pending = nil
+	return out
+}
+
+// commandsForPartition returns the commands addressed to entityIDs. The
+// commands of entities in no partition never reach a worker; retiredAcks
+// answers them instead.
+func commandsForPartition(commands []*simulationpb.EntityCommand, entityIDs []uint64) []*simulationpb.EntityCommand {
+	if len(commands) == 0 {
+		return nil
+	}
+
+	owned := make(map[uint64]struct{}, len(entityIDs))
+	for _, id := range entityIDs {
+		owned[id] = struct{}{}
+	}
+
+	var out []*simulationpb.EntityCommand
+	for _, cmd := range commands {
+		if _, ok := owned[cmd.GetEntityId()]; ok {
+			out = append(out, cmd)
+		}
+	}
+	return out
+}
+
+// retiredAcks returns error acks for the commands of plan addressed to
+// entities it does not have. Commands are only queued for live entities, so
+// these were retired after their commands were sent.
+func retiredAcks(plan *simulationpb.SimulationTickRequest) []*simulationpb.EntityCommandAck {
+	if len(plan.GetCommands()) == 0 {
+		return nil
+	}
+
+	live := make(map[uint64]struct{}, len(plan.GetEntityIds()))
+	for _, id := range plan.GetEntityIds() {
+		live[id] = struct{}{}
+	}
+
+	var acks []*simulationpb.EntityCommandAck
+	for _, cmd := range plan.GetCommands() {
+		if _, ok := live[cmd.GetEntityId()]; !ok {
+			acks = append(acks, &simulationpb.EntityCommandAck{
+				CommandId: cmd.GetCommandId(),
+				EntityId:  cmd.GetEntityId(),
+				Tick:      plan.GetTick(),
+				Error:     "entity retired",
+			})
+		}
+	}
+	return acks
+}
+
+// assignmentsForPartition returns the task assignments for entities in
+// entityIDs.
+func assignmentsForPartition(assignments []*simulationpb.Task, entityIDs []uint64) []*simulationpb.Task {
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	owned := make(map[uint64]struct{}, len(entityIDs))
+	for _, id := range entityIDs {
+		owned[id] = struct{}{}
+	}
+
+	var out []*simulationpb.Task
+	for _, t := range assignments {
+		if _, ok := owned[t.GetAssignedEntityId()]; ok {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// TestCommandsToRetiredEntities checks a command whose entity is retired
+// before the tick that would apply it is acked with an error, not dropped.
+func TestCommandsToRetiredEntities(t *testing.T) {
+	ctx := context.Background()
+	s := NewSimulationServer()
+	startTestWorker(t, s)
+	sim := createTestSimulation(t, s, &simulationpb.SimulationConfig{EntityCount: 2, TickRateMs: 100})
+	id := sim.GetId()
+	rt := s.runtimes[id.GetValue()]
+
+	// Only paused simulations step.
+	if _, err := s.StartSimulation(ctx, &simulationpb.StartSimulationRequest{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.PauseSimulation(ctx, &simulationpb.PauseSimulationRequest{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+
+	var sent []uint64
+	for _, entity := range []uint64{1, 2} {
+		resp, err := s.SendEntityCommand(ctx, &simulationpb.SendEntityCommandRequest{Id: id, Command: &simulationpb.EntityCommand{
+			EntityId: entity,
+			Type:     simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_MOVE_TO,
+			X:        50,
+			Y:        50,
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sent = append(sent, resp.GetCommand().GetCommandId())
+	}
+	if _, err := s.ScaleEntities(ctx, &simulationpb.ScaleEntitiesRequest{Id: id, Retire: []uint64{2}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ticks := make(chan *simulationpb.AggregatedTick, 4)
+	rt.subMu.Lock()
+	rt.subscribers[ticks] = struct{}{}
+	rt.subMu.Unlock()
+	if _, err := s.StepSimulation(ctx, &simulationpb.StepSimulationRequest{Id: id, Ticks: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	acks := (<-ticks).GetCommandAcks()
+	if len(acks) != 2 {
+		t.Fatalf("%d acks, want 2: %v", len(acks), acks)
+	}
+	if a := acks[0]; a.GetCommandId() != sent[0] || !a.GetApplied() || a.GetError() != "" {
+		t.Errorf("ack %v for the command to a live entity", a)
+	}
+	if a := acks[1]; a.GetCommandId() != sent[1] || a.GetEntityId() != 2 || a.GetApplied() || a.GetError() != "entity retired" {
+		t.Errorf("ack %v for the command to a retired entity, want error %q", a, "entity retired")
+	}
+}
@@ -0,0 +1,55 @@
+package orchestrator
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/tasks"
+)
+
+// queueRipeHarvests queues a harvest task for every crop cell that has
+// ripened by tick, so harvesters pick them up in the same allocation.
+func (rt *simulationRuntime) queueRipeHarvests(ctx context.Context, cfg *simulationpb.SimulationConfig, tick uint64) {
+	if rt.crops == nil {
+		return
+	}
+
+	ripe := rt.crops.Ripening(simTime(cfg, tick))
+	for len(ripe) > 0 {
+		n := len(ripe)
+		if n > tasks.MaxSubmit {
+			n = tasks.MaxSubmit
+		}
+		batch := make([]*simulationpb.Task, n)
+		for i, p := range ripe[:n] {
+			batch[i] = &simulationpb.Task{
+				Type:      simulationpb.TaskType_TASK_TYPE_HARVEST,
+				Waypoints: []*simulationpb.Point{p},
+			}
+		}
+		if _, err := rt.tasks.Submit(batch, tick); err != nil {
+			slog.WarnContext(ctx, "failed to queue harvest tasks", "simulation_id", rt.sim.GetId().GetValue(), "tick", tick, "error", err)
+		}
+		ripe = ripe[n:]
+	}
+}
+
+// recordHarvests harvests the cells of the harvest tasks completed in agg
+// and attaches the crop totals after it.
+func (rt *simulationRuntime) recordHarvests(agg *simulationpb.AggregatedTick, completed []*simulationpb.Task) {
+	if rt.crops == nil {
+		return
+	}
+
+	t := time.Duration(agg.GetSimTimeMs()) * time.Millisecond
+	for _, task := range completed {
+		if task.GetType() != simulationpb.TaskType_TASK_TYPE_HARVEST {
+			continue
+		}
+		cell := task.GetWaypoints()[0]
+		rt.crops.Harvest(cell.GetX(), cell.GetY(), t)
+	}
+	agg.Crops = rt.crops.Summary(t)
+}
@@ -0,0 +1,111 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// harvestConfig grows a 10x10 patch of four crop cells on 10-tick days, with
+// a harvester at the centre of the cell at the origin. Cells ripen in under
+// a day and stay ripe for ten.
+func harvestConfig() *simulationpb.SimulationConfig {
+	return &simulationpb.SimulationConfig{
+		TickRateMs:   100,
+		Seed:         3,
+		ScenarioType: "harvest",
+		Environment:  &simulationpb.EnvironmentConfig{DayLengthMs: 1000},
+		Fleet: []*simulationpb.FleetGroup{{
+			Type:       simulationpb.EntityType_ENTITY_TYPE_HARVESTER,
+			Count:      1,
+			Placements: []*simulationpb.EntityPlacement{{X: 2.5, Y: 2.5}},
+		}},
+		Crops: &simulationpb.CropConfig{
+			Fields:        []*simulationpb.CropPatch{{MinX: 0, MinY: 0, MaxX: 10, MaxY: 10}},
+			DaysToRipe:    0.1,
+			RipeDays:      10,
+			ManualHarvest: true,
+		},
+	}
+}
+
+// harvestCell runs a simulation of harvestConfig on s, with weather, if
+// set, in force, that harvests the cell at the origin, and returns its
+// crops afterwards.
+func harvestCell(t *testing.T, s *SimulationServer, weather *simulationpb.Weather) *simulationpb.GetCropsResponse {
+	t.Helper()
+	ctx := context.Background()
+	id := createTestSimulation(t, s, harvestConfig()).GetId()
+
+	if weather != nil {
+		if _, err := s.SetEnvironment(ctx, &simulationpb.SetEnvironmentRequest{Id: id, Weather: weather}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := s.SubmitTasks(ctx, &simulationpb.SubmitTasksRequest{Id: id, Tasks: []*simulationpb.Task{{
+		Type:      simulationpb.TaskType_TASK_TYPE_HARVEST,
+		Waypoints: []*simulationpb.Point{{X: 2.5, Y: 2.5}},
+		WorkTicks: 10,
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only paused simulations step.
+	if _, err := s.StartSimulation(ctx, &simulationpb.StartSimulationRequest{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.PauseSimulation(ctx, &simulationpb.PauseSimulationRequest{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.StepSimulation(ctx, &simulationpb.StepSimulationRequest{Id: id, Ticks: 20}); err != nil {
+		t.Fatal(err)
+	}
+
+	crops, err := s.GetCrops(ctx, &simulationpb.GetCropsRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crops
+}
+
+func TestGetCropsAfterHarvest(t *testing.T) {
+	s := NewSimulationServer()
+	startTestWorker(t, s)
+	crops := harvestCell(t, s, nil)
+
+	if len(crops.GetCells()) != 4 {
+		t.Fatalf("%d cells, want 4", len(crops.GetCells()))
+	}
+	for _, c := range crops.GetCells() {
+		harvested := c.GetCol() == 0 && c.GetRow() == 0
+		switch {
+		case harvested && (c.GetStage() != simulationpb.CropStage_CROP_STAGE_HARVESTED || c.GetYield() != 100):
+			t.Errorf("harvested cell %s yielding %v, want HARVESTED yielding 100", c.GetStage(), c.GetYield())
+		case !harvested && c.GetStage() != simulationpb.CropStage_CROP_STAGE_RIPE:
+			t.Errorf("cell (%d, %d) %s, want RIPE", c.GetCol(), c.GetRow(), c.GetStage())
+		}
+	}
+
+	sum := crops.GetSummary()
+	if sum.GetPlantedCells() != 4 || sum.GetHarvestedCells() != 1 || sum.GetTotalYield() != 100 ||
+		sum.GetRipeCells() != 3 || sum.GetHarvestableCells() != 3 || sum.GetHarvestableArea() != 75 {
+		t.Errorf("summary %v after one harvest", sum)
+	}
+}
+
+// TestCropsUnderWeather checks the weather does not change how crops grow:
+// a storm leaves every cell at the stage and yield of a clear day.
+func TestCropsUnderWeather(t *testing.T) {
+	s := NewSimulationServer()
+	startTestWorker(t, s)
+
+	clear := harvestCell(t, s, &simulationpb.Weather{})
+	storm := harvestCell(t, s, &simulationpb.Weather{RainIntensity: 1, WindSpeed: 14, CloudCover: 1})
+	if !proto.Equal(clear, storm) {
+		t.Errorf("crops on a clear day %v, in a storm %v", clear, storm)
+	}
+}
@@ -1,10 +1,154 @@
 package orchestrator
 
-// Dispatcher is a placeholder for orchestrator dispatch logic.
-// TODO: implement routing of simulation work to nodes, fan-out, etc.
-type Dispatcher struct{}
+import (
+	"context"
+	"fmt"
+	"time"
 
-// NewDispatcher creates a new Dispatcher.
-func NewDispatcher() *Dispatcher {
-	return &Dispatcher{}
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// Dispatcher sends worker tick requests over a RunWorkerTicks stream and
+// collects the responses. Responses are received on a separate goroutine so
+// callers can enforce tick deadlines instead of blocking in stream.Recv.
+type Dispatcher struct {
+	conn   *grpc.ClientConn
+	stream nodepb.NodeWorkerService_RunWorkerTicksClient
+	cancel context.CancelFunc
+
+	results chan *nodepb.WorkerTickResponse
+	recvErr chan error
+
+	// lateAcks and lateEvents are from responses to ticks abandoned
+	// earlier, for the next response returned.
+	lateAcks   []*simulationpb.EntityCommandAck
+	lateEvents []*simulationpb.TaskEvent
+}
+
+// NewDispatcher connects to the worker at addr and opens a tick stream that
+// lives until ctx is canceled or Close is called.
+func NewDispatcher(ctx context.Context, addr string) (*Dispatcher, error) {
+	conn, err := grpc.Dial(
+		addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("connect to worker at %s: %w", addr, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := nodepb.NewNodeWorkerServiceClient(conn).RunWorkerTicks(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return nil, fmt.Errorf("open RunWorkerTicks stream: %w", err)
+	}
+
+	d := &Dispatcher{
+		conn:    conn,
+		stream:  stream,
+		cancel:  cancel,
+		results: make(chan *nodepb.WorkerTickResponse, 1),
+		recvErr: make(chan error, 1),
+	}
+
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				d.recvErr <- err
+				return
+			}
+			select {
+			case d.results <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return d, nil
+}
+
+// Dispatch sends req and waits for the response to the same tick. If deadline
+// is non-zero and passes first, Dispatch returns a nil response when abandon
+// is set and otherwise keeps waiting. The entities of responses to ticks
+// abandoned earlier are discarded, but the worker did apply those ticks'
+// commands and assignments, so their command acks and task events come
+// first in the next response returned.
+func (d *Dispatcher) Dispatch(
+	ctx context.Context,
+	req *nodepb.WorkerTickRequest,
+	deadline time.Time,
+	abandon bool,
+) (*nodepb.WorkerTickResponse, error) {
+	if err := d.stream.Send(req); err != nil {
+		return nil, fmt.Errorf("send WorkerTickRequest: %w", err)
+	}
+
+	var deadlineC <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		deadlineC = timer.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-d.recvErr:
+			return nil, fmt.Errorf("recv WorkerTickResponse: %w", err)
+		case resp := <-d.results:
+			if resp.GetTick() != req.GetTick() {
+				// Late result of a tick we already gave up on.
+				d.lateAcks = append(d.lateAcks, resp.GetCommandAcks()...)
+				d.lateEvents = append(d.lateEvents, resp.GetTaskEvents()...)
+				continue
+			}
+			resp.CommandAcks = append(d.lateAcks, resp.GetCommandAcks()...)
+			resp.TaskEvents = append(d.lateEvents, resp.GetTaskEvents()...)
+			d.lateAcks, d.lateEvents = nil, nil
+			return resp, nil
+		case <-deadlineC:
+			deadlineC = nil
+			if abandon {
+				return nil, nil
+			}
+		}
+	}
+}
+
+// releaseWorker tells the worker at addr to free its state of simulation
+// id, which has ended.
+func releaseWorker(ctx context.Context, addr, id string) error {
+	conn, err := grpc.Dial(
+		addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return fmt.Errorf("connect to worker at %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	_, err = nodepb.NewNodeWorkerServiceClient(conn).ReleaseSimulation(ctx, &nodepb.ReleaseSimulationRequest{
+		SimulationId: &commonpb.SimulationId{Value: id},
+	})
+	return err
+}
+
+// Close tears down the stream and the worker connection.
+func (d *Dispatcher) Close() {
+	d.cancel()
+	d.conn.Close()
 }
@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// fakeTickStream is a RunWorkerTicks stream that only takes requests.
+type fakeTickStream struct {
+	nodepb.NodeWorkerService_RunWorkerTicksClient
+	sent []uint64
+}
+
+func (f *fakeTickStream) Send(req *nodepb.WorkerTickRequest) error {
+	f.sent = append(f.sent, req.GetTick())
+	return nil
+}
+
+// newTestDispatcher returns a Dispatcher whose worker responses are sent
+// on the returned channel.
+func newTestDispatcher() (*Dispatcher, chan<- *nodepb.WorkerTickResponse) {
+	results := make(chan *nodepb.WorkerTickResponse, 8)
+	return &Dispatcher{
+		stream:  &fakeTickStream{},
+		results: results,
+		recvErr: make(chan error, 1),
+	}, results
+}
+
+// workerResponse is the response to tick with an ack of command ack and an
+// event of task event.
+func workerResponse(tick, ack, event uint64) *nodepb.WorkerTickResponse {
+	return &nodepb.WorkerTickResponse{
+		Tick:        tick,
+		CommandAcks: []*simulationpb.EntityCommandAck{{CommandId: ack, Tick: tick, Applied: true}},
+		TaskEvents:  []*simulationpb.TaskEvent{{TaskId: event, Tick: tick, State: simulationpb.TaskState_TASK_STATE_COMPLETED}},
+	}
+}
+
+func TestDispatchCarriesAbandonedTicks(t *testing.T) {
+	ctx := context.Background()
+	d, results := newTestDispatcher()
+	past := time.Now().Add(-time.Second)
+
+	// Ticks 1 and 2 are abandoned at their deadlines; their responses come
+	// in while tick 3 is waited for.
+	for tick := uint64(1); tick <= 2; tick++ {
+		resp, err := d.Dispatch(ctx, &nodepb.WorkerTickRequest{Tick: tick}, past, true)
+		if resp != nil || err != nil {
+			t.Fatalf("Dispatch(tick %d) = %v, %v; want it abandoned", tick, resp, err)
+		}
+	}
+	results <- workerResponse(1, 10, 100)
+	results <- workerResponse(2, 20, 200)
+	results <- workerResponse(3, 30, 300)
+
+	resp, err := d.Dispatch(ctx, &nodepb.WorkerTickRequest{Tick: 3}, time.Time{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetTick() != 3 {
+		t.Fatalf("Dispatch(tick 3) returned tick %d", resp.GetTick())
+	}
+	checkAcks(t, resp, 10, 20, 30)
+	checkEvents(t, resp, 100, 200, 300)
+
+	// What was carried is not carried again.
+	results <- workerResponse(4, 40, 400)
+	resp, err = d.Dispatch(ctx, &nodepb.WorkerTickRequest{Tick: 4}, time.Time{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAcks(t, resp, 40)
+	checkEvents(t, resp, 400)
+}
+
+func TestDispatchWaitsPastDeadline(t *testing.T) {
+	d, results := newTestDispatcher()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		results <- workerResponse(1, 10, 100)
+	}()
+
+	// Without abandon, a missed deadline only makes the tick late.
+	resp, err := d.Dispatch(context.Background(), &nodepb.WorkerTickRequest{Tick: 1}, time.Now(), false)
+	if err != nil || resp.GetTick() != 1 {
+		t.Fatalf("Dispatch(tick 1) = %v, %v; want its response", resp, err)
+	}
+}
+
+func checkAcks(t *testing.T, resp *nodepb.WorkerTickResponse, want ...uint64) {
+	t.Helper()
+	var got []uint64
+	for _, a := range resp.GetCommandAcks() {
+		got = append(got, a.GetCommandId())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("tick %d acks %v, want %v", resp.GetTick(), got, want)
+	}
+}
+
+func checkEvents(t *testing.T, resp *nodepb.WorkerTickResponse, want ...uint64) {
+	t.Helper()
+	var got []uint64
+	for _, e := range resp.GetTaskEvents() {
+		got = append(got, e.GetTaskId())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("tick %d task events %v, want %v", resp.GetTick(), got, want)
+	}
+}
@@ -0,0 +1,193 @@
+package orchestrator
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stevenmed26/AutoFarm/internal/fleet"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// maxScaleEntities bounds how many entities one ScaleEntities call may spawn.
+const maxScaleEntities = 10000
+
+// entityRoster tracks the live entity ids of a simulation, allocates ids for
+// new entities and remembers roster changes workers have not seen yet. Ids are
+// never reused, so a retired entity's state can't leak into a new one.
+type entityRoster struct {
+	mu     sync.Mutex
+	ids    []uint64 // sorted; replaced, never mutated, so snapshots can share it
+	live   map[uint64]simulationpb.EntityType
+	lastID uint64
+
+	pendingSpawns  []*simulationpb.EntitySpawn
+	pendingRetires []uint64
+}
+
+// newEntityRoster creates a roster with entities 1..entity_count, typed by
+// the fleet mix in cfg.
+func newEntityRoster(cfg *simulationpb.SimulationConfig) *entityRoster {
+	count := cfg.GetEntityCount()
+	r := &entityRoster{
+		ids:  make([]uint64, 0, count),
+		live: make(map[uint64]simulationpb.EntityType, count),
+	}
+	for i := uint64(1); i <= uint64(count); i++ {
+		r.ids = append(r.ids, i)
+		r.live[i] = fleet.TypeFor(cfg, i)
+	}
+	r.lastID = uint64(count)
+	return r
+}
+
+// restoredEntityRoster creates a roster holding the entities in states,
+// which are spawned from those states on the first tick.
+func restoredEntityRoster(states []*simulationpb.EntityState) *entityRoster {
+	r := &entityRoster{
+		ids:  make([]uint64, 0, len(states)),
+		live: make(map[uint64]simulationpb.EntityType, len(states)),
+	}
+	for _, st := range states {
+		id := st.GetEntityId()
+		r.ids = append(r.ids, id)
+		r.live[id] = st.GetType()
+		r.pendingSpawns = append(r.pendingSpawns, &simulationpb.EntitySpawn{
+			EntityId:     id,
+			Type:         st.GetType(),
+			InitialState: st,
+			Restored:     true,
+		})
+		if id > r.lastID {
+			r.lastID = id
+		}
+	}
+	sort.Slice(r.ids, func(i, j int) bool { return r.ids[i] < r.ids[j] })
+	return r
+}
+
+// has reports whether id is a live entity.
+func (r *entityRoster) has(id uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.live[id]
+	return ok
+}
+
+// count returns the number of live entities.
+func (r *entityRoster) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.ids)
+}
+
+// snapshot returns the live ids for the next tick together with the roster
+// changes workers still need to apply, clearing the pending changes.
+func (r *entityRoster) snapshot() (ids []uint64, spawns []*simulationpb.EntitySpawn, retired []uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spawns, retired = r.pendingSpawns, r.pendingRetires
+	r.pendingSpawns, r.pendingRetires = nil, nil
+	return r.ids, spawns, retired
+}
+
+// spawn adds one entity per initial state plus extra entities of extraType
+// with no initial state, returning the new ids.
+func (r *entityRoster) spawn(
+	initial []*simulationpb.EntityState,
+	extra uint32,
+	extraType simulationpb.EntityType,
+) []uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := len(initial) + int(extra)
+	if n == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(r.ids)+n)
+	ids = append(ids, r.ids...)
+
+	added := make([]uint64, 0, n)
+	for i := 0; i < n; i++ {
+		r.lastID++
+		id := r.lastID
+
+		sp := &simulationpb.EntitySpawn{EntityId: id, Type: extraType}
+		if i < len(initial) {
+			st := proto.Clone(initial[i]).(*simulationpb.EntityState)
+			st.EntityId = id
+			sp.InitialState = st
+			sp.Type = st.GetType()
+		}
+
+		r.live[id] = sp.Type
+		r.pendingSpawns = append(r.pendingSpawns, sp)
+		ids = append(ids, id)
+		added = append(added, id)
+	}
+
+	// New ids are always the largest, so ids stays sorted.
+	r.ids = ids
+	return added
+}
+
+// retire removes the given entities. Unknown ids are an error and leave the
+// roster unchanged.
+func (r *entityRoster) retire(retire []uint64) ([]uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	drop := make(map[uint64]struct{}, len(retire))
+	for _, id := range retire {
+		if _, ok := r.live[id]; !ok {
+			return nil, notFound(resourceEntity, strconv.FormatUint(id, 10))
+		}
+		drop[id] = struct{}{}
+	}
+	if len(drop) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint64, 0, len(r.ids))
+	for _, id := range r.ids {
+		if _, ok := drop[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+
+	retired := make([]uint64, 0, len(drop))
+	for id := range drop {
+		delete(r.live, id)
+		retired = append(retired, id)
+	}
+	sort.Slice(retired, func(i, j int) bool { return retired[i] < retired[j] })
+
+	r.ids = ids
+	r.pendingRetires = append(r.pendingRetires, retired...)
+	return retired, nil
+}
+
+// spawnsForPartition returns the spawns for entities in entityIDs.
+func spawnsForPartition(spawns []*simulationpb.EntitySpawn, entityIDs []uint64) []*simulationpb.EntitySpawn {
+	if len(spawns) == 0 {
+		return nil
+	}
+
+	owned := make(map[uint64]struct{}, len(entityIDs))
+	for _, id := range entityIDs {
+		owned[id] = struct{}{}
+	}
+
+	var out []*simulationpb.EntitySpawn
+	for _, sp := range spawns {
+		if _, ok := owned[sp.GetEntityId()]; ok {
+			out = append(out, sp)
+		}
+	}
+	return out
+}
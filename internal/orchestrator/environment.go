@@ -0,0 +1,171 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/stevenmed26/AutoFarm/internal/environment"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// environmentOverride holds the weather and time of day set through
+// SetEnvironment or the config's weather script. While none is set, every
+// tick gets the seeded conditions.
+type environmentOverride struct {
+	mu       sync.Mutex
+	override *environment.Override
+
+	// script is the config's weather script; next is the first change not
+	// yet in force.
+	script []*simulationpb.EnvironmentChange
+	next   int
+}
+
+// set replaces the override; nil clears it. It holds until the next
+// scripted change.
+func (o *environmentOverride) set(override *environment.Override) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.override = override
+}
+
+// advance puts into force every scripted change due by tick.
+func (o *environmentOverride) advance(cfg *simulationpb.SimulationConfig, tick uint64) {
+	due := uint64(simTime(cfg, tick).Milliseconds())
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for o.next < len(o.script) && o.script[o.next].GetAtMs() <= due {
+		o.override = overrideFromChange(o.script[o.next])
+		o.next++
+	}
+}
+
+// state returns the override in force and the next scripted change, for
+// restore.
+func (o *environmentOverride) state() (*environment.Override, int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.override, o.next
+}
+
+// restore puts back a state returned by state.
+func (o *environmentOverride) restore(override *environment.Override, next int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.override, o.next = override, next
+}
+
+// at returns the conditions for tick with any override applied.
+func (o *environmentOverride) at(cfg *simulationpb.SimulationConfig, tick uint64) *simulationpb.Environment {
+	env := environment.At(cfg, simTime(cfg, tick))
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.override.Apply(env)
+	return env
+}
+
+// normalizeEnvironment checks the environment settings of cfg and picks a
+// seed when none is given, so the run can be reproduced from its config.
+// Picked seeds fit in 53 bits so they survive a round trip through JSON
+// numbers.
+func normalizeEnvironment(cfg *simulationpb.SimulationConfig) error {
+	if h := cfg.GetEnvironment().GetStartHour(); !(h >= 0 && h < 24) {
+		return errors.New("environment.start_hour must be in [0, 24)")
+	}
+	for cfg.Seed == 0 {
+		cfg.Seed = rand.Uint64() >> 11
+	}
+	return nil
+}
+
+// overrideFromRequest validates a SetEnvironment request and turns it into
+// an override.
+func overrideFromRequest(req *simulationpb.SetEnvironmentRequest) (*environment.Override, error) {
+	o := &environment.Override{}
+
+	if w := req.GetWeather(); w != nil {
+		if err := validateWeather(w); err != nil {
+			return nil, err
+		}
+		o.Weather = w
+	}
+
+	if req.GetSetTimeOfDay() {
+		if h := req.GetTimeOfDayHours(); !(h >= 0 && h < 24) {
+			return nil, errors.New("time_of_day_hours must be in [0, 24)")
+		}
+		o.FixTime = true
+		o.TimeOfDayHours = req.GetTimeOfDayHours()
+	}
+
+	if o.Weather == nil && !o.FixTime {
+		return nil, errors.New("nothing to override: set weather or time_of_day_hours, or clear")
+	}
+	return o, nil
+}
+
+// validateWeatherScript checks that every change in the script sets
+// something valid and that the changes are in time order.
+func validateWeatherScript(script []*simulationpb.EnvironmentChange) error {
+	for i, c := range script {
+		if i > 0 && c.GetAtMs() < script[i-1].GetAtMs() {
+			return fmt.Errorf("weather_script[%d].at_ms must not be before weather_script[%d]", i, i-1)
+		}
+		if c.GetClear() {
+			if c.GetWeather() != nil || c.GetSetTimeOfDay() {
+				return fmt.Errorf("weather_script[%d]: clear cannot be combined with weather or time_of_day_hours", i)
+			}
+			continue
+		}
+		if w := c.GetWeather(); w != nil {
+			if err := validateWeather(w); err != nil {
+				return fmt.Errorf("weather_script[%d].%w", i, err)
+			}
+		}
+		if c.GetSetTimeOfDay() {
+			if h := c.GetTimeOfDayHours(); !(h >= 0 && h < 24) {
+				return fmt.Errorf("weather_script[%d].time_of_day_hours must be in [0, 24)", i)
+			}
+		}
+		if c.GetWeather() == nil && !c.GetSetTimeOfDay() {
+			return fmt.Errorf("weather_script[%d]: set weather or time_of_day_hours, or clear", i)
+		}
+	}
+	return nil
+}
+
+// overrideFromChange turns a validated script change into an override; a
+// clearing change gives nil.
+func overrideFromChange(c *simulationpb.EnvironmentChange) *environment.Override {
+	if c.GetClear() {
+		return nil
+	}
+	return &environment.Override{
+		Weather:        c.GetWeather(),
+		FixTime:        c.GetSetTimeOfDay(),
+		TimeOfDayHours: c.GetTimeOfDayHours(),
+	}
+}
+
+func validateWeather(w *simulationpb.Weather) error {
+	if _, ok := simulationpb.WeatherCondition_name[int32(w.GetCondition())]; !ok {
+		return fmt.Errorf("weather.condition %d is not a known condition", w.GetCondition())
+	}
+	if !(w.GetCloudCover() >= 0 && w.GetCloudCover() <= 1) {
+		return errors.New("weather.cloud_cover must be between 0 and 1")
+	}
+	if !(w.GetRainIntensity() >= 0 && w.GetRainIntensity() <= 1) {
+		return errors.New("weather.rain_intensity must be between 0 and 1")
+	}
+	if !(w.GetWindSpeed() >= 0 && w.GetWindSpeed() <= environment.MaxWindSpeed) {
+		return fmt.Errorf("weather.wind_speed must be between 0 and %g", environment.MaxWindSpeed)
+	}
+	if !isFinite(w.GetWindDirectionDeg()) {
+		return errors.New("weather.wind_direction_deg must be finite")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+func TestEnvironmentAdvance(t *testing.T) {
+	storm := &simulationpb.Weather{RainIntensity: 0.9, WindSpeed: 12}
+	cfg := &simulationpb.SimulationConfig{
+		TickRateMs: 100,
+		Seed:       42,
+		WeatherScript: []*simulationpb.EnvironmentChange{
+			{AtMs: 300, Weather: storm},
+			{AtMs: 500, SetTimeOfDay: true, TimeOfDayHours: 23},
+			{AtMs: 1000, Clear: true},
+			{AtMs: 1550, Weather: &simulationpb.Weather{CloudCover: 0.9}},
+		},
+	}
+
+	// What is in force after each tick; ticks are 100ms of simulated time.
+	tests := []struct {
+		tick       uint64
+		overridden bool
+		condition  simulationpb.WeatherCondition
+		hour       float64 // checked when overridden
+	}{
+		{tick: 2},
+		{tick: 3, overridden: true, condition: simulationpb.WeatherCondition_WEATHER_CONDITION_STORM},
+		{tick: 4, overridden: true, condition: simulationpb.WeatherCondition_WEATHER_CONDITION_STORM},
+		// Each change replaces the one before: the storm ends.
+		{tick: 5, overridden: true, hour: 23},
+		{tick: 9, overridden: true, hour: 23},
+		{tick: 10},
+		{tick: 15},
+		{tick: 16, overridden: true, condition: simulationpb.WeatherCondition_WEATHER_CONDITION_CLOUDY},
+		{tick: 100, overridden: true, condition: simulationpb.WeatherCondition_WEATHER_CONDITION_CLOUDY},
+	}
+
+	var o environmentOverride
+	o.script = cfg.WeatherScript
+	for _, tt := range tests {
+		o.advance(cfg, tt.tick)
+		env := o.at(cfg, tt.tick)
+		if env.GetOverridden() != tt.overridden {
+			t.Fatalf("tick %d: overridden %v, want %v", tt.tick, env.GetOverridden(), tt.overridden)
+		}
+		if !tt.overridden {
+			continue
+		}
+		if tt.hour != 0 && env.GetTimeOfDayHours() != tt.hour {
+			t.Errorf("tick %d: hour %v, want %v", tt.tick, env.GetTimeOfDayHours(), tt.hour)
+		}
+		if tt.condition != 0 && env.GetWeather().GetCondition() != tt.condition {
+			t.Errorf("tick %d: weather %s, want %s", tt.tick, env.GetWeather().GetCondition(), tt.condition)
+		}
+	}
+	if override, next := o.state(); override.Weather.GetCloudCover() != 0.9 || next != len(cfg.WeatherScript) {
+		t.Errorf("state() = %+v, %d after the script", override, next)
+	}
+}
+
+// TestEnvironmentSeeded checks the seeded weather changes as the simulation
+// advances, the same way for the same seed.
+func TestEnvironmentSeeded(t *testing.T) {
+	cfg := &simulationpb.SimulationConfig{TickRateMs: 1000, Seed: 7}
+	var a, b environmentOverride
+
+	changes := 0
+	prev := a.at(cfg, 0)
+	// A default day is 600 ticks of a second.
+	for tick := uint64(1); tick <= 1200; tick++ {
+		a.advance(cfg, tick)
+		env := a.at(cfg, tick)
+		if !proto.Equal(env, b.at(cfg, tick)) {
+			t.Fatalf("tick %d: %v and %v from the same seed", tick, env, b.at(cfg, tick))
+		}
+		if env.GetOverridden() {
+			t.Fatalf("tick %d: overridden without a script", tick)
+		}
+		if !proto.Equal(env.GetWeather(), prev.GetWeather()) {
+			changes++
+		}
+		if env.GetDay() < prev.GetDay() || env.GetDay() == prev.GetDay() && env.GetTimeOfDayHours() <= prev.GetTimeOfDayHours() {
+			t.Fatalf("tick %d: time went from day %d %vh to day %d %vh",
+				tick, prev.GetDay(), prev.GetTimeOfDayHours(), env.GetDay(), env.GetTimeOfDayHours())
+		}
+		prev = env
+	}
+	if changes < 1000 {
+		t.Errorf("weather changed on %d of 1200 ticks", changes)
+	}
+	if prev.GetDay() != 2 {
+		t.Errorf("day %d after two simulated days, want 2", prev.GetDay())
+	}
+
+	other := proto.Clone(cfg).(*simulationpb.SimulationConfig)
+	other.Seed = 8
+	if proto.Equal(a.at(cfg, 300).GetWeather(), a.at(other, 300).GetWeather()) {
+		t.Errorf("seeds 7 and 8 give the same weather")
+	}
+}
@@ -0,0 +1,211 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	"github.com/stevenmed26/AutoFarm/internal/store"
+)
+
+// Errors of the SimulationService carry a status code callers can act on,
+// and details saying what went wrong:
+//
+//   - InvalidArgument for requests that can never succeed as sent, with a
+//     BadRequest naming the field when one is to blame;
+//   - NotFound, with a ResourceInfo naming what is missing;
+//   - AlreadyExists, likewise;
+//   - FailedPrecondition for what the resource's state does not allow now,
+//     such as an invalid lifecycle transition, with a PreconditionFailure;
+//   - PermissionDenied for what the caller's role or tenant quota never
+//     allows, and ResourceExhausted for what its quota does not allow now;
+//   - Unavailable when the workers cannot be reached, and DeadlineExceeded
+//     when they do not answer in time;
+//   - Aborted for a status watch that fell behind, and Internal.
+//
+// Every one also has an ErrorInfo whose reason is stable across releases.
+
+// errorDomain is the domain of the ErrorInfo of the service's errors.
+const errorDomain = "autofarm.simulation"
+
+// Reasons in the ErrorInfo of the service's errors.
+const (
+	reasonInvalidArgument    = "INVALID_ARGUMENT"
+	reasonNotFound           = "NOT_FOUND"
+	reasonAlreadyExists      = "ALREADY_EXISTS"
+	reasonInvalidTransition  = "INVALID_TRANSITION"
+	reasonFailedPrecondition = "FAILED_PRECONDITION"
+	reasonWorkerUnavailable  = "WORKER_UNAVAILABLE"
+	reasonWorkerTimeout      = "WORKER_TIMEOUT"
+	reasonPermissionDenied   = "PERMISSION_DENIED"
+	reasonQuotaExceeded      = "QUOTA_EXCEEDED"
+	reasonWatchFellBehind    = "WATCH_FELL_BEHIND"
+	reasonInternal           = "INTERNAL"
+)
+
+// Resource types named in ResourceInfo details.
+const (
+	resourceSimulation = "simulation"
+	resourceEntity     = "entity"
+	resourceTemplate   = "template"
+	resourceExperiment = "experiment"
+	resourceScenario   = "scenario"
+)
+
+// statusError returns an error with code and msg, and an ErrorInfo with
+// reason and metadata.
+func statusError(code codes.Code, reason string, metadata map[string]string, msg string) error {
+	st := status.New(code, msg)
+	return attach(st, &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain, Metadata: metadata}).Err()
+}
+
+// withPrefix returns err with prefix before its message, keeping its code
+// and details.
+func withPrefix(prefix string, err error) error {
+	p := status.Convert(err).Proto()
+	p.Message = prefix + ": " + p.Message
+	return status.FromProto(p).Err()
+}
+
+// attach returns st with details. Should they fail to attach, st goes
+// without them rather than losing the error.
+func attach(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// invalidArgument reports err, from validating a request, as
+// InvalidArgument. Errors that already have a status keep it.
+func invalidArgument(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return statusError(codes.InvalidArgument, reasonInvalidArgument, nil, err.Error())
+}
+
+// invalidField reports a request field that can never be right as sent.
+func invalidField(field, format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	st := status.New(codes.InvalidArgument, msg)
+	st = attach(st,
+		&errdetails.ErrorInfo{Reason: reasonInvalidArgument, Domain: errorDomain,
+			Metadata: map[string]string{"field": field}},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: msg},
+		}})
+	return st.Err()
+}
+
+// notFound reports that the resource of resourceType named name does not
+// exist, or belongs to another tenant.
+func notFound(resourceType, name string) error {
+	msg := fmt.Sprintf("%s %s not found", resourceType, name)
+	st := status.New(codes.NotFound, msg)
+	st = attach(st,
+		&errdetails.ErrorInfo{Reason: reasonNotFound, Domain: errorDomain,
+			Metadata: map[string]string{"resource_type": resourceType, "resource_name": name}},
+		&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name, Description: msg})
+	return st.Err()
+}
+
+// alreadyExists reports that the resource of resourceType named name
+// exists already.
+func alreadyExists(resourceType, name string) error {
+	msg := fmt.Sprintf("%s %s already exists", resourceType, name)
+	st := status.New(codes.AlreadyExists, msg)
+	st = attach(st,
+		&errdetails.ErrorInfo{Reason: reasonAlreadyExists, Domain: errorDomain,
+			Metadata: map[string]string{"resource_type": resourceType, "resource_name": name}},
+		&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name, Description: msg})
+	return st.Err()
+}
+
+// invalidTransition reports that the status of simulation id does not allow
+// action, e.g. "pause". msg, if set, replaces the default message.
+func invalidTransition(id string, current commonpb.SimulationStatus, action, msg string) error {
+	if msg == "" {
+		msg = fmt.Sprintf("cannot %s simulation in status %s", action, current)
+	}
+	st := status.New(codes.FailedPrecondition, msg)
+	st = attach(st,
+		&errdetails.ErrorInfo{Reason: reasonInvalidTransition, Domain: errorDomain,
+			Metadata: map[string]string{
+				"simulation_id": id,
+				"status":        current.String(),
+				"action":        action,
+			}},
+		&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: "STATUS", Subject: resourceSimulation + "/" + id, Description: msg},
+		}})
+	return st.Err()
+}
+
+// failedPrecondition reports that the state of subject, e.g.
+// "simulation/<id>", does not allow the request yet.
+func failedPrecondition(subject, format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	st := status.New(codes.FailedPrecondition, msg)
+	st = attach(st,
+		&errdetails.ErrorInfo{Reason: reasonFailedPrecondition, Domain: errorDomain,
+			Metadata: map[string]string{"subject": subject}},
+		&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: "STATE", Subject: subject, Description: msg},
+		}})
+	return st.Err()
+}
+
+// workerUnavailable reports that ticks could not be run on the workers, or
+// not before the call's deadline.
+func workerUnavailable(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded:
+		return statusError(codes.DeadlineExceeded, reasonWorkerTimeout, nil, err.Error())
+	}
+	return statusError(codes.Unavailable, reasonWorkerUnavailable, nil, err.Error())
+}
+
+// permissionDenied reports that the caller's role does not allow method.
+func permissionDenied(method, role, need, msg string) error {
+	return statusError(codes.PermissionDenied, reasonPermissionDenied,
+		map[string]string{"method": method, "role": role, "required_role": need}, msg)
+}
+
+// quotaExceeded reports a request beyond limit, e.g. "max_entities", of
+// the quota of tenant name: with PermissionDenied when no use of the
+// tenant's would allow it, ResourceExhausted when its current use does
+// not.
+func quotaExceeded(code codes.Code, name, limit, format string, args ...any) error {
+	return statusError(code, reasonQuotaExceeded,
+		map[string]string{"tenant": name, "limit": limit}, fmt.Sprintf(format, args...))
+}
+
+// internalError reports an error of the service itself.
+func internalError(format string, args ...any) error {
+	return statusError(codes.Internal, reasonInternal, nil, fmt.Sprintf(format, args...))
+}
+
+// storeError reports an error of the template store: NotFound and
+// AlreadyExists for the template named name, Internal otherwise.
+func storeError(err error, name string) error {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return notFound(resourceTemplate, name)
+	case errors.Is(err, store.ErrExists):
+		return alreadyExists(resourceTemplate, name)
+	default:
+		return internalError("%v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package orchestrator
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stevenmed26/AutoFarm/internal/audit"
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/rbac"
+	"github.com/stevenmed26/AutoFarm/internal/requestid"
+	"github.com/stevenmed26/AutoFarm/internal/store"
+)
+
+// Actions of audit events.
+const (
+	actionCreate        = "create"
+	actionClone         = "clone"
+	actionStart         = "start"
+	actionPause         = "pause"
+	actionStop          = "stop"
+	actionDelete        = "delete"
+	actionSetSpeed      = "set_speed"
+	actionEntityCommand = "entity_command"
+)
+
+// Bounds for the number of audit events listed at once.
+const (
+	defaultEventLimit = 100
+	maxEventLimit     = 1000
+)
+
+// recordEvent appends a change the caller of ctx made to sim to the audit
+// trail. before and after are sim's status around the change.
+func (s *SimulationServer) recordEvent(ctx context.Context, sim *simulationpb.Simulation, action string,
+	before, after commonpb.SimulationStatus, detail string) {
+	e := &simulationpb.AuditEvent{
+		Tenant:       sim.GetTenant(),
+		SimulationId: sim.GetId(),
+		Action:       action,
+		SourceIp:     audit.SourceIP(ctx),
+		RequestId:    requestid.FromContext(ctx),
+		StatusBefore: before,
+		StatusAfter:  after,
+		Detail:       detail,
+	}
+	if c, ok, _ := rbac.FromIncomingContext(ctx); ok {
+		e.Actor = c.Subject
+	}
+	// Record the change even if the caller has gone away meanwhile.
+	if _, err := s.events.AppendEvent(context.WithoutCancel(ctx), e); err != nil {
+		slog.ErrorContext(ctx, "failed to record audit event", "action", action, "simulation_id", sim.GetId().GetValue(), "error", err)
+	}
+}
+
+// ListSimulationEvents returns the audit trail of a simulation of the
+// caller's tenant. Deleted simulations keep theirs.
+func (s *SimulationServer) ListSimulationEvents(
+	ctx context.Context,
+	req *simulationpb.ListSimulationEventsRequest,
+) (*simulationpb.ListSimulationEventsResponse, error) {
+	if req.GetId().GetValue() == "" {
+		return nil, invalidField("id", "missing simulation id")
+	}
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.events.ListEvents(ctx, store.EventFilter{
+		Tenant:       owner,
+		SimulationID: req.GetId().GetValue(),
+		Since:        timeOrZero(req.GetSince()),
+		Until:        timeOrZero(req.GetUntil()),
+		Limit:        eventLimit(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &simulationpb.ListSimulationEventsResponse{Events: events}, nil
+}
+
+// ListAuditEvents returns the audit trail of the caller's tenant.
+func (s *SimulationServer) ListAuditEvents(
+	ctx context.Context,
+	req *simulationpb.ListAuditEventsRequest,
+) (*simulationpb.ListAuditEventsResponse, error) {
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.events.ListEvents(ctx, store.EventFilter{
+		Tenant: owner,
+		Action: req.GetAction(),
+		Actor:  req.GetActor(),
+		Since:  timeOrZero(req.GetSince()),
+		Until:  timeOrZero(req.GetUntil()),
+		Limit:  eventLimit(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &simulationpb.ListAuditEventsResponse{Events: events}, nil
+}
+
+// commandName returns the short name of an entity command type, e.g.
+// "move_to".
+func commandName(t simulationpb.EntityCommandType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "ENTITY_COMMAND_TYPE_"))
+}
+
+// timeOrZero returns the time of ts, or the zero time if ts is unset.
+func timeOrZero(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func eventLimit(n uint32) int {
+	if n == 0 {
+		return defaultEventLimit
+	}
+	return min(int(n), maxEventLimit)
+}
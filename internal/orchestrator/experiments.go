@@ -0,0 +1,534 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stevenmed26/AutoFarm/internal/experiment"
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// experimentRetention is how long a finished experiment, and the
+// simulations of its runs, are kept after it ends.
+const experimentRetention = time.Hour
+
+// experimentRuntime is an experiment and the configs of its runs. The
+// experiment is guarded by the server's expMu.
+type experimentRuntime struct {
+	exp     *simulationpb.Experiment
+	configs []*simulationpb.SimulationConfig
+
+	concurrency int
+	cancel      context.CancelFunc
+}
+
+// CreateExperiment expands the spec's parameter space into runs and starts
+// running them headless, a few at a time, each fast-forwarded until its
+// termination condition is met.
+func (s *SimulationServer) CreateExperiment(
+	ctx context.Context,
+	req *simulationpb.CreateExperimentRequest,
+) (*simulationpb.CreateExperimentResponse, error) {
+
+	spec := proto.Clone(req.GetSpec()).(*simulationpb.ExperimentSpec)
+	if spec == nil {
+		return nil, invalidField("spec", "missing spec")
+	}
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := s.experimentBase(ctx, owner, spec)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	// Runs share the base's world unless the seed is one of the
+	// parameters; the seeds picked are kept so the experiment can be
+	// repeated.
+	for base.Seed == 0 {
+		base.Seed = rand.Uint64() >> 11
+	}
+	spec.BaseConfig = base
+	if spec.GetSampling() == simulationpb.ExperimentSampling_EXPERIMENT_SAMPLING_RANDOM {
+		for spec.SamplingSeed == 0 {
+			spec.SamplingSeed = rand.Uint64() >> 11
+		}
+	}
+
+	concurrency, err := experiment.Concurrency(spec)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	concurrency = s.experimentConcurrency(owner, concurrency)
+	spec.Concurrency = uint32(concurrency)
+	points, err := experiment.Points(spec)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	exp := &simulationpb.Experiment{
+		Id:        uuid.NewString(),
+		Spec:      spec,
+		State:     simulationpb.ExperimentState_EXPERIMENT_STATE_RUNNING,
+		CreatedAt: timestamppb.Now(),
+		TotalRuns: uint32(len(points)),
+		Tenant:    owner,
+	}
+	er := &experimentRuntime{
+		exp:         exp,
+		configs:     make([]*simulationpb.SimulationConfig, len(points)),
+		concurrency: concurrency,
+	}
+
+	// Reject a spec any of whose runs would not start before running any.
+	s.mu.RLock()
+	quota := s.quotas.For(owner)
+	s.mu.RUnlock()
+	for i, p := range points {
+		cfg := proto.Clone(base).(*simulationpb.SimulationConfig)
+		if err := experiment.Apply(cfg, p); err != nil {
+			return nil, invalidArgument(fmt.Errorf("runs[%d]: %w", i, err))
+		}
+		normalized := proto.Clone(cfg).(*simulationpb.SimulationConfig)
+		if _, err := validateConfig(normalized); err != nil {
+			return nil, invalidArgument(fmt.Errorf("runs[%d]: %w", i, err))
+		}
+		if err := checkConfigQuota(owner, quota, normalized); err != nil {
+			return nil, withPrefix(fmt.Sprintf("runs[%d]", i), err)
+		}
+		// Runs are fast-forwarded, which the tenant may not be allowed.
+		if err := checkSpeedQuota(owner, quota, normalized, 1, true); err != nil {
+			return nil, withPrefix(fmt.Sprintf("runs[%d]", i), err)
+		}
+		er.configs[i] = cfg
+		exp.Runs = append(exp.Runs, &simulationpb.ExperimentRun{
+			Index:   uint32(i),
+			Values:  p.Values,
+			Choices: p.Choices,
+			State:   simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_QUEUED,
+		})
+	}
+	countRuns(exp)
+
+	// Runs outlive the call, and keep its request ID.
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	er.cancel = cancel
+
+	s.expMu.Lock()
+	s.sweepExperiments(time.Now())
+	s.experiments[exp.Id] = er
+	out := proto.Clone(exp).(*simulationpb.Experiment)
+	s.expMu.Unlock()
+
+	go s.runExperiment(runCtx, er)
+
+	return &simulationpb.CreateExperimentResponse{
+		Experiment: out,
+	}, nil
+}
+
+// experimentBase returns a copy of the config the spec's runs start from.
+// Base templates are looked up among the tenant's.
+func (s *SimulationServer) experimentBase(ctx context.Context, owner string, spec *simulationpb.ExperimentSpec) (*simulationpb.SimulationConfig, error) {
+	var base *simulationpb.SimulationConfig
+	switch {
+	case spec.GetBaseConfig() != nil && spec.GetBaseTemplate() != "":
+		return nil, errors.New("set base_config or base_template, not both")
+	case spec.GetBaseConfig() != nil:
+		base = proto.Clone(spec.GetBaseConfig()).(*simulationpb.SimulationConfig)
+	case spec.GetBaseTemplate() != "":
+		t, err := s.templates.GetTemplate(ctx, owner, spec.GetBaseTemplate())
+		if err != nil {
+			return nil, storeError(err, spec.GetBaseTemplate())
+		}
+		base = t.GetConfig()
+	default:
+		return nil, errors.New("base_config or base_template is required")
+	}
+
+	t := base.GetTermination()
+	if t.GetMaxTicks() == 0 && t.GetMaxSimTimeMs() == 0 {
+		return nil, errors.New("the base config needs termination.max_ticks or termination.max_sim_time_ms")
+	}
+	return base, nil
+}
+
+// GetExperiment returns an experiment with its runs.
+func (s *SimulationServer) GetExperiment(
+	ctx context.Context,
+	req *simulationpb.GetExperimentRequest,
+) (*simulationpb.GetExperimentResponse, error) {
+
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expMu.Lock()
+	defer s.expMu.Unlock()
+
+	s.sweepExperiments(time.Now())
+	er, ok := s.experiments[req.GetId()]
+	if !ok || er.exp.Tenant != owner {
+		return nil, notFound(resourceExperiment, req.GetId())
+	}
+
+	return &simulationpb.GetExperimentResponse{
+		Experiment: proto.Clone(er.exp).(*simulationpb.Experiment),
+	}, nil
+}
+
+// ListExperiments returns the caller's tenant's experiments, oldest
+// first, without runs.
+func (s *SimulationServer) ListExperiments(
+	ctx context.Context,
+	req *simulationpb.ListExperimentsRequest,
+) (*simulationpb.ListExperimentsResponse, error) {
+
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expMu.Lock()
+	s.sweepExperiments(time.Now())
+	out := make([]*simulationpb.Experiment, 0)
+	for _, er := range s.experiments {
+		if er.exp.Tenant != owner {
+			continue
+		}
+		exp := proto.Clone(er.exp).(*simulationpb.Experiment)
+		exp.Runs = nil
+		out = append(out, exp)
+	}
+	s.expMu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].GetCreatedAt().AsTime(), out[j].GetCreatedAt().AsTime()
+		if a.Equal(b) {
+			return out[i].GetId() < out[j].GetId()
+		}
+		return a.Before(b)
+	})
+
+	return &simulationpb.ListExperimentsResponse{
+		Experiments: out,
+	}, nil
+}
+
+// CancelExperiment stops an experiment's running runs and drops its queued
+// ones. Canceling a finished experiment changes nothing.
+func (s *SimulationServer) CancelExperiment(
+	ctx context.Context,
+	req *simulationpb.CancelExperimentRequest,
+) (*simulationpb.CancelExperimentResponse, error) {
+
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expMu.Lock()
+	defer s.expMu.Unlock()
+
+	er, ok := s.experiments[req.GetId()]
+	if !ok || er.exp.Tenant != owner {
+		return nil, notFound(resourceExperiment, req.GetId())
+	}
+
+	if er.exp.State == simulationpb.ExperimentState_EXPERIMENT_STATE_RUNNING {
+		er.exp.State = simulationpb.ExperimentState_EXPERIMENT_STATE_CANCELED
+		for _, run := range er.exp.Runs {
+			if run.State == simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_QUEUED {
+				run.State = simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_CANCELED
+			}
+		}
+		countRuns(er.exp)
+		er.cancel()
+	}
+
+	return &simulationpb.CancelExperimentResponse{
+		Experiment: proto.Clone(er.exp).(*simulationpb.Experiment),
+	}, nil
+}
+
+// runExperiment runs er's queued runs in order, at most its concurrency at
+// once, and completes the experiment when they are done.
+func (s *SimulationServer) runExperiment(ctx context.Context, er *experimentRuntime) {
+	sem := make(chan struct{}, er.concurrency)
+	var wg sync.WaitGroup
+
+	for i := range er.configs {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.runExperimentRun(ctx, er, i)
+		}(i)
+	}
+	wg.Wait()
+
+	s.expMu.Lock()
+	defer s.expMu.Unlock()
+
+	if er.exp.State == simulationpb.ExperimentState_EXPERIMENT_STATE_RUNNING {
+		er.exp.State = simulationpb.ExperimentState_EXPERIMENT_STATE_COMPLETED
+	}
+	er.exp.EndedAt = timestamppb.Now()
+	er.cancel()
+	slog.InfoContext(ctx, "experiment finished",
+		"experiment_id", er.exp.Id,
+		"state", er.exp.State.String(),
+		"completed", er.exp.CompletedRuns,
+		"failed", er.exp.FailedRuns,
+		"canceled", er.exp.CanceledRuns)
+}
+
+// sweepExperiments forgets, about once a minute, the experiments that ended
+// more than experimentRetention ago, and deletes the simulations of their
+// runs. s.expMu must be held.
+func (s *SimulationServer) sweepExperiments(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for id, er := range s.experiments {
+		ended := er.exp.GetEndedAt()
+		if ended == nil || now.Sub(ended.AsTime()) < experimentRetention {
+			continue
+		}
+		delete(s.experiments, id)
+		for _, run := range er.exp.Runs {
+			if run.SimulationId != "" {
+				s.deleteEndedSimulation(run.SimulationId)
+			}
+		}
+		slog.Info("experiment expired", "experiment_id", id)
+	}
+}
+
+// deleteEndedSimulation deletes simulation id if it has ended.
+func (s *SimulationServer) deleteEndedSimulation(id string) {
+	s.mu.Lock()
+	rt, ok := s.runtimes[id]
+	if !ok || !isFinal(rt.sim.Status) {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.sims, id)
+	delete(s.runtimes, id)
+	s.mu.Unlock()
+
+	close(rt.deleted)
+}
+
+// runExperimentRun creates run i's simulation, runs it to completion and
+// records its summary.
+func (s *SimulationServer) runExperimentRun(ctx context.Context, er *experimentRuntime, i int) {
+	s.expMu.Lock()
+	run := er.exp.Runs[i]
+	if run.State != simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_QUEUED {
+		s.expMu.Unlock()
+		return
+	}
+	s.expMu.Unlock()
+
+	// The run stays queued while it waits for its tenant's quota.
+	onStart := func() {
+		s.expMu.Lock()
+		defer s.expMu.Unlock()
+		if run.State == simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_QUEUED {
+			run.State = simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_RUNNING
+			countRuns(er.exp)
+		}
+	}
+
+	started := time.Now()
+	rt, err := s.newRuntime(proto.Clone(er.configs[i]).(*simulationpb.SimulationConfig), true)
+	if err == nil {
+		rt.sim.ExperimentId = er.exp.Id
+		rt.sim.Tenant = er.exp.Tenant
+		err = s.register(rt)
+	}
+	if err == nil {
+		s.expMu.Lock()
+		run.SimulationId = rt.sim.Id.GetValue()
+		s.expMu.Unlock()
+
+		err = s.runHeadless(ctx, rt, onStart)
+	}
+
+	s.expMu.Lock()
+	defer s.expMu.Unlock()
+
+	switch {
+	case err == nil:
+		run.State = simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_COMPLETED
+		run.Summary = s.runSummary(rt, time.Since(started))
+	case ctx.Err() != nil:
+		run.State = simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_CANCELED
+	default:
+		run.State = simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_FAILED
+		run.Error = status.Convert(err).Message()
+	}
+	countRuns(er.exp)
+}
+
+// runHeadless starts rt fast-forwarded, calls onStart, and runs its tick
+// loop until the simulation completes. While its tenant already runs as
+// many simulations as its quota allows, it waits for one of them to stop.
+// It returns an error, and stops the simulation, if its tenant's quota
+// does not allow fast-forwarding. It also returns an error if the
+// simulation does not complete: because ctx was canceled, because it was
+// paused or stopped, or because a tick failed. The simulation is then
+// stopped, unless it failed.
+func (s *SimulationServer) runHeadless(ctx context.Context, rt *simulationRuntime, onStart func()) error {
+	id := rt.sim.Id.GetValue()
+	loopCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.mu.Lock()
+	err := checkSpeedQuota(rt.sim.Tenant, s.quotas.For(rt.sim.Tenant), rt.config, 1, true)
+	for err == nil && s.checkRunningUsage(rt.sim.Tenant) != nil {
+		freed := s.slotFreed
+		s.mu.Unlock()
+		select {
+		case <-freed:
+		case <-rt.deleted:
+		case <-ctx.Done():
+		}
+		s.mu.Lock()
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		if rt.sim.Status != commonpb.SimulationStatus_SIMULATION_STATUS_CREATED {
+			// Stopped or deleted while it waited.
+			s.mu.Unlock()
+			return fmt.Errorf("simulation %s stopped before it started", id)
+		}
+	}
+	if err != nil {
+		_ = s.transition(rt, commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED, actionStop, status.Convert(err).Message())
+		s.mu.Unlock()
+		return err
+	}
+	rt.sim.FastForward = true
+	if err := s.transition(rt, commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING, actionStart, ""); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	rt.cancel = cancel
+	s.mu.Unlock()
+	rt.setSpeed(1, true)
+	onStart()
+
+	s.runSimulationLoop(loopCtx, id, rt)
+	if rt.completed.Load() {
+		return nil
+	}
+
+	s.mu.Lock()
+	if !isFinal(rt.sim.Status) {
+		_ = s.transition(rt, commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED, actionStop, "")
+	}
+	final, reason := rt.sim.Status, rt.sim.EndReason
+	rt.cancel = nil
+	s.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if final == commonpb.SimulationStatus_SIMULATION_STATUS_FAILED {
+		return fmt.Errorf("simulation %s failed: %s", id, reason)
+	}
+	return fmt.Errorf("simulation %s stopped before it completed", id)
+}
+
+// runSummary sums up a completed simulation from the snapshot taken on
+// completion.
+func (s *SimulationServer) runSummary(rt *simulationRuntime, wall time.Duration) *simulationpb.ExperimentRunSummary {
+	s.mu.RLock()
+	cfg := rt.sim.GetConfig()
+	reason := rt.sim.GetEndReason()
+	s.mu.RUnlock()
+
+	out := &simulationpb.ExperimentRunSummary{
+		Ticks:      rt.lastTick.Load(),
+		EndReason:  reason,
+		WallTimeMs: float64(wall) / float64(time.Millisecond),
+	}
+	snap := rt.snapshots.at(0)
+	if snap != nil {
+		out.Ticks = snap.tick
+	}
+	out.SimTimeMs = uint64(simTime(cfg, out.Ticks).Milliseconds())
+
+	if rt.crops != nil {
+		c := rt.crops.Summary(simTime(cfg, out.Ticks))
+		out.TotalYield = c.GetTotalYield()
+		out.HarvestedCells = c.GetHarvestedCells()
+		out.SpoiledCells = c.GetSpoiledCells()
+	}
+
+	for _, t := range rt.tasks.List(simulationpb.TaskState_TASK_STATE_UNSPECIFIED) {
+		out.TasksTotal++
+		if t.GetState() == simulationpb.TaskState_TASK_STATE_COMPLETED {
+			out.TasksCompleted++
+		}
+	}
+
+	if snap != nil && len(snap.entities) > 0 {
+		var battery float64
+		for _, e := range snap.entities {
+			battery += e.GetBattery()
+			if e.GetStatus() == "offline" {
+				out.OfflineEntities++
+			}
+		}
+		out.Entities = uint32(len(snap.entities))
+		out.AvgBattery = battery / float64(len(snap.entities))
+	}
+	return out
+}
+
+// countRuns updates exp's progress from the states of its runs.
+func countRuns(exp *simulationpb.Experiment) {
+	exp.QueuedRuns, exp.RunningRuns, exp.CompletedRuns, exp.FailedRuns, exp.CanceledRuns = 0, 0, 0, 0, 0
+	for _, run := range exp.Runs {
+		switch run.State {
+		case simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_QUEUED:
+			exp.QueuedRuns++
+		case simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_RUNNING:
+			exp.RunningRuns++
+		case simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_COMPLETED:
+			exp.CompletedRuns++
+		case simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_FAILED:
+			exp.FailedRuns++
+		case simulationpb.ExperimentRunState_EXPERIMENT_RUN_STATE_CANCELED:
+			exp.CanceledRuns++
+		}
+	}
+}
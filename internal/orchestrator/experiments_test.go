@@ -0,0 +1,153 @@
+package orchestrator
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/stevenmed26/AutoFarm/internal/node"
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/tenant"
+)
+
+// startTestWorker serves a node worker on a local port until the test ends,
+// and points s at it.
+func startTestWorker(t *testing.T, s *SimulationServer) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	nodepb.RegisterNodeWorkerServiceServer(srv, node.NewWorkerServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	s.workerAddr = lis.Addr().String()
+}
+
+// waitFor polls cond until it holds, failing t after a few seconds.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for start := time.Now(); !cond(); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+	}
+}
+
+func TestExperimentWaitsForRunningQuota(t *testing.T) {
+	ctx := context.Background()
+	s := NewSimulationServer()
+	startTestWorker(t, s)
+	s.SetQuotas(&tenant.Quotas{Default: tenant.Quota{MaxRunningSimulations: 1}})
+
+	// The one running simulation the quota allows, which never ends.
+	blocker := createTestSimulation(t, s, &simulationpb.SimulationConfig{EntityCount: 2, TickRateMs: 100})
+	if _, err := s.StartSimulation(ctx, &simulationpb.StartSimulationRequest{Id: blocker.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := s.CreateExperiment(ctx, &simulationpb.CreateExperimentRequest{Spec: &simulationpb.ExperimentSpec{
+		BaseConfig: &simulationpb.SimulationConfig{
+			EntityCount: 2,
+			TickRateMs:  100,
+			Termination: &simulationpb.Termination{MaxTicks: 5},
+		},
+		Parameters:  []*simulationpb.ExperimentParameter{{Name: "entities", Values: []float64{2, 3}}},
+		Concurrency: 1,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := func() *simulationpb.Experiment {
+		resp, err := s.GetExperiment(ctx, &simulationpb.GetExperimentRequest{Id: created.GetExperiment().GetId()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.GetExperiment()
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if exp := get(); exp.GetQueuedRuns() != 2 || exp.GetRunningRuns() != 0 {
+		t.Fatalf("%d queued, %d running behind a full quota; want 2, 0", exp.GetQueuedRuns(), exp.GetRunningRuns())
+	}
+
+	if _, err := s.StopSimulation(ctx, &simulationpb.StopSimulationRequest{Id: blocker.GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the experiment to complete", func() bool {
+		return get().GetState() == simulationpb.ExperimentState_EXPERIMENT_STATE_COMPLETED
+	})
+	if exp := get(); exp.GetCompletedRuns() != 2 {
+		t.Errorf("%d runs completed, want 2: %v", exp.GetCompletedRuns(), exp.GetRuns())
+	}
+}
+
+func TestSweepExperiments(t *testing.T) {
+	now := time.Now()
+	s := NewSimulationServer()
+
+	// ended returns an experiment that ended ago before now, whose run's
+	// simulation has stopped; a negative ago means it is still running.
+	ended := func(ago time.Duration) (*experimentRuntime, string) {
+		sim := createTestSimulation(t, s, &simulationpb.SimulationConfig{EntityCount: 2, TickRateMs: 100})
+		id := sim.GetId().GetValue()
+		s.mu.Lock()
+		if err := s.transition(s.runtimes[id], commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED, actionStop, ""); err != nil {
+			t.Fatal(err)
+		}
+		s.mu.Unlock()
+
+		exp := &simulationpb.Experiment{Runs: []*simulationpb.ExperimentRun{{SimulationId: id}}}
+		if ago >= 0 {
+			exp.EndedAt = timestamppb.New(now.Add(-ago))
+		}
+		return &experimentRuntime{exp: exp}, id
+	}
+
+	// Experiments added after the first sweep wait for the next, a
+	// minute later, even when expired.
+	tests := []struct {
+		name       string
+		ago        time.Duration
+		afterSweep bool
+		kept       bool
+	}{
+		{"running", -1, false, true},
+		{"ended recently", experimentRetention - time.Minute, false, true},
+		{"ended long ago", experimentRetention + time.Minute, false, false},
+		{"ended long ago, added after the sweep", 2 * experimentRetention, true, true},
+	}
+
+	sims := map[string]string{}
+	s.expMu.Lock()
+	for _, afterSweep := range []bool{false, true} {
+		for _, tt := range tests {
+			if tt.afterSweep == afterSweep {
+				s.experiments[tt.name], sims[tt.name] = ended(tt.ago)
+			}
+		}
+		at := now
+		if afterSweep {
+			at = now.Add(30 * time.Second)
+		}
+		s.sweepExperiments(at)
+	}
+	s.expMu.Unlock()
+
+	for _, tt := range tests {
+		_, kept := s.experiments[tt.name]
+		s.mu.RLock()
+		_, simKept := s.runtimes[sims[tt.name]]
+		s.mu.RUnlock()
+		if kept != tt.kept || simKept != tt.kept {
+			t.Errorf("%s: experiment kept %v, simulation kept %v; want %v", tt.name, kept, simKept, tt.kept)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// Actions of the status changes no caller asks for.
+const (
+	actionComplete = "complete"
+	actionFail     = "fail"
+)
+
+// transitions is the lifecycle of a simulation: the statuses each status
+// may change to.
+//
+//	CREATED ──start──▶ RUNNING ──pause──▶ PAUSED
+//	                      ▲                  │
+//	                      └──────start───────┘
+//
+// Until it has ended, a simulation can be stopped (STOPPED). A running or
+// paused simulation completes (COMPLETED) when a termination condition is
+// met, and a running one fails (FAILED) when its ticks cannot be run. The
+// three are final.
+var transitions = map[commonpb.SimulationStatus][]commonpb.SimulationStatus{
+	commonpb.SimulationStatus_SIMULATION_STATUS_CREATED: {
+		commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING,
+		commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED,
+	},
+	commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING: {
+		commonpb.SimulationStatus_SIMULATION_STATUS_PAUSED,
+		commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED,
+		commonpb.SimulationStatus_SIMULATION_STATUS_COMPLETED,
+		commonpb.SimulationStatus_SIMULATION_STATUS_FAILED,
+	},
+	commonpb.SimulationStatus_SIMULATION_STATUS_PAUSED: {
+		commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING,
+		commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED,
+		commonpb.SimulationStatus_SIMULATION_STATUS_COMPLETED,
+	},
+}
+
+// workerReleaseTimeout bounds telling the worker to free the state of a
+// simulation that has ended.
+const workerReleaseTimeout = 10 * time.Second
+
+// statusWatchBuffer is how many status changes a watcher may fall behind
+// by before its watch ends.
+const statusWatchBuffer = 16
+
+// canTransition reports whether a simulation in status from may change to
+// status to.
+func canTransition(from, to commonpb.SimulationStatus) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// isFinal reports whether a simulation in status st has ended.
+func isFinal(st commonpb.SimulationStatus) bool {
+	return st == commonpb.SimulationStatus_SIMULATION_STATUS_COMPLETED ||
+		st == commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED ||
+		st == commonpb.SimulationStatus_SIMULATION_STATUS_FAILED
+}
+
+// transition changes the status of rt's simulation to to by action, e.g.
+// "pause", if its lifecycle allows, and sends the change to the watchers
+// of its status. Leaving RUNNING stops the tick loop. A final status sets
+// EndedAt, and reason, why the simulation completed or failed, as its
+// EndReason, and frees the worker's state of the simulation. s.mu must be
+// held for writing.
+func (s *SimulationServer) transition(rt *simulationRuntime, to commonpb.SimulationStatus, action, reason string) error {
+	sim := rt.sim
+	from := sim.Status
+	if !canTransition(from, to) {
+		return invalidTransition(sim.Id.GetValue(), from, action, "")
+	}
+
+	now := timestamppb.Now()
+	sim.Status = to
+	if to == commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING && sim.StartedAt == nil {
+		sim.StartedAt = now
+	}
+	if from == commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING {
+		if rt.cancel != nil {
+			rt.cancel()
+			rt.cancel = nil
+		}
+		close(s.slotFreed)
+		s.slotFreed = make(chan struct{})
+	}
+	if isFinal(to) {
+		sim.EndedAt = now
+		sim.EndReason = reason
+		go s.releaseWorkerState(rt)
+	}
+
+	e := &simulationpb.SimulationStatusEvent{
+		Id:             sim.Id,
+		Time:           now,
+		PreviousStatus: from,
+		Status:         to,
+		Action:         action,
+		Reason:         reason,
+		Simulation:     cloneSimulation(sim),
+	}
+	for ch := range rt.watchers {
+		select {
+		case ch <- e:
+		default:
+			// Fell behind: end its watch rather than skip a change.
+			delete(rt.watchers, ch)
+			close(ch)
+		}
+	}
+	return nil
+}
+
+// releaseWorkerState tells the worker to free its state of rt's simulation,
+// which has ended, once the ticks being executed have finished.
+func (s *SimulationServer) releaseWorkerState(rt *simulationRuntime) {
+	rt.execMu.Lock()
+	defer rt.execMu.Unlock()
+
+	if rt.lastTick.Load() == 0 {
+		// No tick ever ran, so the worker holds nothing.
+		return
+	}
+	id := rt.sim.Id.GetValue()
+	ctx, cancel := context.WithTimeout(context.Background(), workerReleaseTimeout)
+	defer cancel()
+	if err := releaseWorker(ctx, s.workerAddr, id); err != nil {
+		slog.WarnContext(ctx, "failed to release worker state", "simulation_id", id, "error", err)
+	}
+}
+
+// completeSimulation marks a simulation COMPLETED after a termination
+// condition was met, and stops its tick loop.
+func (s *SimulationServer) completeSimulation(ctx context.Context, rt *simulationRuntime, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.transition(rt, commonpb.SimulationStatus_SIMULATION_STATUS_COMPLETED, actionComplete, reason); err != nil {
+		return
+	}
+	slog.InfoContext(ctx, "simulation completed", "simulation_id", rt.sim.Id.GetValue(), "reason", reason)
+}
+
+// failSimulation marks a running simulation FAILED after its ticks could
+// not be run.
+func (s *SimulationServer) failSimulation(ctx context.Context, rt *simulationRuntime, cause error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.transition(rt, commonpb.SimulationStatus_SIMULATION_STATUS_FAILED, actionFail, cause.Error()); err != nil {
+		return
+	}
+	slog.ErrorContext(ctx, "simulation failed", "simulation_id", rt.sim.Id.GetValue(), "error", cause)
+}
+
+// cloneSimulation returns a copy of sim for callers, which may read it
+// while the simulation changes. s.mu must be held.
+func cloneSimulation(sim *simulationpb.Simulation) *simulationpb.Simulation {
+	return proto.Clone(sim).(*simulationpb.Simulation)
+}
+
+// WatchSimulationStatus streams a simulation's status changes, starting
+// with its status now, until it reaches a final status.
+func (s *SimulationServer) WatchSimulationStatus(
+	req *simulationpb.WatchSimulationStatusRequest,
+	stream simulationpb.SimulationService_WatchSimulationStatusServer,
+) error {
+	_, rt, err := s.getSimulationAndRuntime(stream.Context(), req.GetId())
+	if err != nil {
+		return err
+	}
+
+	// Take the status and start watching at once, so no change is missed.
+	ch := make(chan *simulationpb.SimulationStatusEvent, statusWatchBuffer)
+	s.mu.Lock()
+	current := &simulationpb.SimulationStatusEvent{
+		Id:         rt.sim.Id,
+		Time:       timestamppb.Now(),
+		Status:     rt.sim.Status,
+		Simulation: cloneSimulation(rt.sim),
+	}
+	if !isFinal(current.Status) {
+		rt.watchers[ch] = struct{}{}
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(rt.watchers, ch)
+		s.mu.Unlock()
+	}()
+
+	if err := stream.Send(current); err != nil {
+		return err
+	}
+	if isFinal(current.Status) {
+		return nil
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-ch:
+			if !ok {
+				id := current.GetId().GetValue()
+				return statusError(codes.Aborted, reasonWatchFellBehind, map[string]string{"simulation_id": id},
+					fmt.Sprintf("status watch of simulation %s fell behind", id))
+			}
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+			if isFinal(e.Status) {
+				return nil
+			}
+		}
+	}
+}
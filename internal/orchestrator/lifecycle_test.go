@@ -0,0 +1,179 @@
+package orchestrator
+
+import (
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+const (
+	created   = commonpb.SimulationStatus_SIMULATION_STATUS_CREATED
+	running   = commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING
+	paused    = commonpb.SimulationStatus_SIMULATION_STATUS_PAUSED
+	stopped   = commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED
+	completed = commonpb.SimulationStatus_SIMULATION_STATUS_COMPLETED
+	failed    = commonpb.SimulationStatus_SIMULATION_STATUS_FAILED
+)
+
+func TestCanTransition(t *testing.T) {
+	statuses := []commonpb.SimulationStatus{created, running, paused, stopped, completed, failed}
+	allowed := map[[2]commonpb.SimulationStatus]bool{
+		{created, running}:   true,
+		{created, stopped}:   true,
+		{running, paused}:    true,
+		{running, stopped}:   true,
+		{running, completed}: true,
+		{running, failed}:    true,
+		{paused, running}:    true,
+		{paused, stopped}:    true,
+		{paused, completed}:  true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := allowed[[2]commonpb.SimulationStatus{from, to}]
+			if got := canTransition(from, to); got != want {
+				t.Errorf("canTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+		// Final statuses, and only those, allow no change at all.
+		if isFinal(from) != (len(transitions[from]) == 0) {
+			t.Errorf("isFinal(%s) = %v with transitions %v", from, isFinal(from), transitions[from])
+		}
+	}
+}
+
+func TestTransition(t *testing.T) {
+	type step struct {
+		to     commonpb.SimulationStatus
+		action string
+		ok     bool
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "start, pause, resume, complete",
+			steps: []step{
+				{running, actionStart, true},
+				{paused, actionPause, true},
+				{running, actionStart, true},
+				{completed, actionComplete, true},
+			},
+		},
+		{
+			name: "stop before starting",
+			steps: []step{
+				{stopped, actionStop, true},
+				{running, actionStart, false},
+			},
+		},
+		{
+			name: "pause before starting",
+			steps: []step{
+				{paused, actionPause, false},
+				{running, actionStart, true},
+			},
+		},
+		{
+			name: "fail while paused",
+			steps: []step{
+				{running, actionStart, true},
+				{paused, actionPause, true},
+				{failed, actionFail, false},
+				{stopped, actionStop, true},
+			},
+		},
+		{
+			name: "nothing after failing",
+			steps: []step{
+				{running, actionStart, true},
+				{failed, actionFail, true},
+				{running, actionStart, false},
+				{stopped, actionStop, false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSimulationServer()
+			rt := &simulationRuntime{
+				sim:      &simulationpb.Simulation{Id: &commonpb.SimulationId{Value: "sim-1"}, Status: created},
+				watchers: make(map[chan *simulationpb.SimulationStatusEvent]struct{}),
+			}
+			watch := make(chan *simulationpb.SimulationStatusEvent, len(tt.steps))
+			rt.watchers[watch] = struct{}{}
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			for i, st := range tt.steps {
+				from := rt.sim.Status
+				canceled := false
+				if from == running {
+					rt.cancel = func() { canceled = true }
+				}
+
+				err := s.transition(rt, st.to, st.action, "why")
+				if !st.ok {
+					checkInvalidTransition(t, err, st.action)
+					if rt.sim.Status != from {
+						t.Fatalf("step %d: status %s after a rejected %s", i, rt.sim.Status, st.action)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("step %d: %s from %s: %v", i, st.action, from, err)
+				}
+
+				if rt.sim.Status != st.to {
+					t.Fatalf("step %d: status %s, want %s", i, rt.sim.Status, st.to)
+				}
+				if from == running && !canceled {
+					t.Errorf("step %d: leaving RUNNING did not stop the tick loop", i)
+				}
+				if st.to == running && rt.sim.StartedAt == nil {
+					t.Errorf("step %d: no StartedAt after starting", i)
+				}
+				if isFinal(st.to) && (rt.sim.EndedAt == nil || rt.sim.EndReason != "why") {
+					t.Errorf("step %d: EndedAt %v, EndReason %q after ending", i, rt.sim.EndedAt, rt.sim.EndReason)
+				}
+
+				select {
+				case e := <-watch:
+					if e.PreviousStatus != from || e.Status != st.to || e.Action != st.action {
+						t.Errorf("step %d: event %s -%s-> %s, want %s -%s-> %s",
+							i, e.PreviousStatus, e.Action, e.Status, from, st.action, st.to)
+					}
+				default:
+					t.Errorf("step %d: watcher got no event", i)
+				}
+			}
+		})
+	}
+}
+
+// checkInvalidTransition fails t unless err is an invalid transition of
+// action.
+func checkInvalidTransition(t *testing.T, err error, action string) {
+	t.Helper()
+	st, _ := status.FromError(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("%s: error %v, want FailedPrecondition", action, err)
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.GetReason() != reasonInvalidTransition || info.GetMetadata()["action"] != action {
+				t.Errorf("%s: ErrorInfo %v", action, info)
+			}
+			return
+		}
+	}
+	t.Errorf("%s: error without ErrorInfo", action)
+}
@@ -3,86 +3,266 @@ package orchestrator
 
 import (
     "context"
-    "log"
+    "fmt"
+    "log/slog"
     "time"
 
-    "google.golang.org/grpc"
-    "google.golang.org/grpc/credentials/insecure"
+    "go.opentelemetry.io/otel/attribute"
     "google.golang.org/protobuf/types/known/timestamppb"
 
     nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
     simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
 )
 
-func (s *SimulationServer) runSimulationLoop(simID string, rt *simulationRuntime) {
-    // Create a dedicated context for this simulation.
-    ctx, cancel := context.WithCancel(context.Background())
-    rt.cancel = cancel
-
-    tickInterval := time.Duration(rt.sim.GetConfig().GetTickRateMs()) * time.Millisecond
-    ticker := time.NewTicker(tickInterval)
-    defer ticker.Stop()
-
-    // Connect to worker.
-    conn, err := grpc.Dial(
-        s.workerAddr,
-        grpc.WithTransportCredentials(insecure.NewCredentials()),
-        grpc.WithBlock(),
-    )
+// maxStepTicks bounds how many ticks a single StepSimulation call may run.
+const maxStepTicks = 1000
+
+func (s *SimulationServer) runSimulationLoop(ctx context.Context, simID string, rt *simulationRuntime) {
+    // Never overlap with StepSimulation or with a previous loop that is still
+    // finishing its last tick after a pause.
+    rt.execMu.Lock()
+    defer rt.execMu.Unlock()
+
+    dispatcher, err := NewDispatcher(ctx, s.workerAddr)
     if err != nil {
-        log.Printf("simulation %s: failed to connect to worker at %s: %v", simID, s.workerAddr, err)
+        if ctx.Err() != nil {
+            slog.InfoContext(ctx, "tick loop canceled", "simulation_id", simID)
+        } else {
+            slog.ErrorContext(ctx, "tick loop failed to start", "simulation_id", simID, "error", err)
+            s.failSimulation(ctx, rt, err)
+        }
         return
     }
-    defer conn.Close()
+    defer dispatcher.Close()
 
-    workerClient := nodepb.NewNodeWorkerServiceClient(conn)
+    // Resume numbering where the last loop or step left off.
+    tickNum := rt.lastTick.Load() + 1
 
-    stream, err := workerClient.RunWorkerTicks(ctx)
-    if err != nil {
-        log.Printf("simulation %s: failed to open RunWorkerTicks stream: %v", simID, err)
-        return
+    s.mu.RLock()
+    cfg := rt.sim.GetConfig()
+    s.mu.RUnlock()
+
+    clock := newTickClock(cfg, tickNum, time.Now())
+    if multiplier, fastForward := rt.currentSpeed(); multiplier != 1 || fastForward {
+        clock.setSpeed(tickNum, multiplier, fastForward, time.Now())
     }
 
-    log.Printf("simulation %s: tick loop started (entities=%d, tickRateMs=%d)", simID, len(rt.entityIDs), rt.sim.Config.GetTickRateMs())
+    slog.InfoContext(ctx, "tick loop started",
+        "simulation_id", simID,
+        "entities", rt.entities.count(),
+        "tick_rate_ms", cfg.GetTickRateMs(),
+        "deadline", clock.deadline.String(),
+        "overrun", clock.policy.String(),
+        "pipeline_depth", cfg.GetPipelineDepth())
 
-    var tickNum uint64 = 0
+    // publish aggregates and broadcasts a received tick. In pipelined mode
+    // that work moves to a separate stage so the next tick can be dispatched
+    // as soon as this one's results are in.
+    publish := func(res tickResult) bool {
+        rt.publishTick(res)
+        return true
+    }
+    if depth := cfg.GetPipelineDepth(); depth > 0 {
+        pipeline := newTickPipeline(rt, depth)
+        defer pipeline.close()
+        publish = func(res tickResult) bool {
+            return pipeline.submit(ctx, res)
+        }
+    }
+
+    final := finalTick(rt.config)
+    var skipped uint64
+    var assigned []*simulationpb.TaskEvent
 
     for {
-        select {
-        case <-ctx.Done():
-            log.Printf("simulation %s: tick loop canceled", simID)
-            return
-        case <-ticker.C:
-            tickNum++
-
-            if err := stream.Send(&nodepb.WorkerTickRequest{
-                SimulationId: rt.sim.GetId(),
-                Tick:         tickNum,
-                PartitionIndex: 0,
-                PartitionTotal: 1,
-                EntityIds:    rt.entityIDs,
-                Config:       rt.sim.GetConfig(),
-            }); err != nil {
-                log.Printf("simulation %s: send WorkerTickRequest error: %v", simID, err)
+        // Wait for the tick to fall due, picking up speed changes as they
+        // arrive. Fast-forwarded ticks run back-to-back without a timer.
+        if clock.fastForward {
+            select {
+            case <-ctx.Done():
+                slog.InfoContext(ctx, "tick loop canceled", "simulation_id", simID)
                 return
+            case <-rt.speedChanged:
+                multiplier, fastForward := rt.currentSpeed()
+                clock.setSpeed(tickNum, multiplier, fastForward, time.Now())
+                continue
+            default:
             }
+        } else {
+            timer := time.NewTimer(time.Until(clock.scheduledAt(tickNum)))
+            select {
+            case <-ctx.Done():
+                timer.Stop()
+                slog.InfoContext(ctx, "tick loop canceled", "simulation_id", simID)
+                return
+            case <-rt.speedChanged:
+                timer.Stop()
+                multiplier, fastForward := rt.currentSpeed()
+                clock.setSpeed(tickNum, multiplier, fastForward, time.Now())
+                continue
+            case <-timer.C:
+            }
+        }
+
+        scheduledAt, deadline := clock.window(tickNum, time.Now())
+        tickCtx, tickSpan := startTick(ctx, simID, tickNum)
 
-            resp, err := stream.Recv()
-            if err != nil {
-                log.Printf("simulation %s: recv WorkerTickResponse error: %v", simID, err)
+        res := tickResult{
+            ctx:         tickCtx,
+            plan:        rt.tickPlan(tickCtx, tickNum, scheduledAt, cfg),
+            deadline:    deadline,
+            skipped:     skipped,
+            assigned:    assigned,
+            simTime:     simTime(cfg, tickNum),
+            multiplier:  clock.multiplier,
+            fastForward: clock.fastForward,
+        }
+
+        resp, err := dispatchPartition(tickCtx, dispatcher, workerRequestFromPlan(res.plan, deadline, 0, 1), deadline, clock.isSkip())
+        if err != nil {
+            markError(tickSpan, err)
+            tickSpan.End()
+            if ctx.Err() != nil {
+                slog.InfoContext(ctx, "tick loop canceled", "simulation_id", simID)
+            } else {
+                slog.ErrorContext(ctx, "tick loop failed", "simulation_id", simID, "tick", tickNum, "error", err)
+                s.failSimulation(ctx, rt, fmt.Errorf("tick %d: %w", tickNum, err))
+            }
+            return
+        }
+        rt.lastTick.Store(tickNum)
+
+        if resp == nil {
+            // Abandoned at its deadline under the SKIP policy. The worker
+            // still runs it, so its assignments are reported with the next
+            // tick published, as are its command acks and task events (see
+            // Dispatch).
+            skipped++
+            assigned = append(assigned, assignmentEvents(res.plan)...)
+            tickSpan.SetAttributes(attribute.Bool("skipped", true))
+            tickSpan.End()
+        } else {
+            res.responses = []*nodepb.WorkerTickResponse{resp} // single worker instance in this v1
+            if !publish(res) {
+                tickSpan.End()
+                slog.InfoContext(ctx, "tick loop canceled", "simulation_id", simID)
                 return
             }
+            skipped = 0
+            assigned = nil
 
-            agg := &simulationpb.AggregatedTick{
-                SimulationId: rt.sim.GetId(),
-                Tick:         resp.GetTick(),
-                Entities:     resp.GetEntities(),
-                AvgComputeMs: resp.GetComputeMs(),
-                WorkerCount:  1, // single worker instance in this v1
-                CompletedAt:  timestamppb.New(time.Now()),
+            // Publishing it completes the simulation, which ends the
+            // loop; ticks dispatched after it would only be dropped.
+            if final > 0 && tickNum >= final {
+                slog.InfoContext(ctx, "tick loop reached its final tick", "simulation_id", simID, "tick", tickNum)
+                return
             }
+        }
 
-            rt.broadcastTick(agg)
+        next, dropped := clock.next(tickNum, time.Now())
+        tickNum = next
+        skipped += dropped
+    }
+}
+
+// stepTicks runs n ticks back-to-back outside the tick loop and returns their
+// aggregates. Stepped ticks go through the same dispatch and broadcast path
+// as the loop, without a schedule or deadline. Stepping ends early when the
+// simulation completes.
+func (s *SimulationServer) stepTicks(ctx context.Context, rt *simulationRuntime, n uint32) ([]*simulationpb.AggregatedTick, error) {
+    rt.execMu.Lock()
+    defer rt.execMu.Unlock()
+
+    dispatcher, err := NewDispatcher(ctx, s.workerAddr)
+    if err != nil {
+        return nil, err
+    }
+    defer dispatcher.Close()
+
+    s.mu.RLock()
+    cfg := rt.sim.GetConfig()
+    s.mu.RUnlock()
+
+    out := make([]*simulationpb.AggregatedTick, 0, n)
+
+    for i := uint32(0); i < n && !rt.completed.Load(); i++ {
+        tickNum := rt.lastTick.Load() + 1
+        tickCtx, tickSpan := startTick(ctx, rt.sim.GetId().GetValue(), tickNum)
+
+        res := tickResult{
+            ctx:        tickCtx,
+            plan:       rt.tickPlan(tickCtx, tickNum, time.Now(), cfg),
+            simTime:    simTime(cfg, tickNum),
+            multiplier: 1,
+        }
+
+        resp, err := dispatchPartition(tickCtx, dispatcher, workerRequestFromPlan(res.plan, time.Time{}, 0, 1), time.Time{}, false)
+        if err != nil {
+            markError(tickSpan, err)
+            tickSpan.End()
+            return out, err
         }
+        rt.lastTick.Store(tickNum)
+
+        res.responses = []*nodepb.WorkerTickResponse{resp}
+        out = append(out, rt.publishTick(res))
+    }
+
+    return out, nil
+}
+
+// tickPlan describes tick for the whole simulation; it is cut into
+// per-partition worker requests by workerRequestFromPlan.
+func (rt *simulationRuntime) tickPlan(
+    ctx context.Context,
+    tick uint64,
+    scheduledAt time.Time,
+    cfg *simulationpb.SimulationConfig,
+) *simulationpb.SimulationTickRequest {
+    ids, spawns, retired := rt.entities.snapshot()
+    rt.queueRipeHarvests(ctx, cfg, tick)
+    rt.environment.advance(cfg, tick)
+    return &simulationpb.SimulationTickRequest{
+        SimulationId:     rt.sim.GetId(),
+        Tick:             tick,
+        EntityIds:        ids,
+        Config:           cfg,
+        ScheduledAt:      timestamppb.New(scheduledAt),
+        Commands:         rt.commands.drain(),
+        Spawns:           spawns,
+        RetiredEntityIds: retired,
+        TaskAssignments:  rt.tasks.Allocate(cfg, ids, tick),
+        Environment:      rt.environment.at(cfg, tick),
+    }
+}
+
+// workerRequestFromPlan cuts the worker request for one partition out of the
+// tick plan.
+func workerRequestFromPlan(
+    plan *simulationpb.SimulationTickRequest,
+    deadline time.Time,
+    partitionIndex, partitionTotal uint32,
+) *nodepb.WorkerTickRequest {
+    req := &nodepb.WorkerTickRequest{
+        SimulationId:   plan.GetSimulationId(),
+        Tick:           plan.GetTick(),
+        PartitionIndex: partitionIndex,
+        PartitionTotal: partitionTotal,
+        EntityIds:      plan.GetEntityIds(),
+        Config:         plan.GetConfig(),
+        ScheduledAt:    plan.GetScheduledAt(),
+        Commands:       commandsForPartition(plan.GetCommands(), plan.GetEntityIds()),
+        Spawns:         spawnsForPartition(plan.GetSpawns(), plan.GetEntityIds()),
+
+        TaskAssignments: assignmentsForPartition(plan.GetTaskAssignments(), plan.GetEntityIds()),
+        Environment:     plan.GetEnvironment(),
+
+        // Retired entities are no longer in any partition, so every
+        // worker gets the full list and drops whatever it holds.
+        RetiredEntityIds: plan.GetRetiredEntityIds(),
+    }
+    if !deadline.IsZero() {
+        req.Deadline = timestamppb.New(deadline)
     }
+    return req
 }
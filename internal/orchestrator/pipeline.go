@@ -0,0 +1,179 @@
+package orchestrator
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// maxPipelineDepth caps how many received ticks may queue for aggregation.
+const maxPipelineDepth = 16
+
+// tickResult is everything the aggregate/broadcast stage needs for one tick.
+type tickResult struct {
+	// ctx carries the tick's span, which ends once the tick is published.
+	ctx context.Context
+
+	plan     *simulationpb.SimulationTickRequest
+	deadline time.Time // zero when fast-forwarding
+	skipped  uint64
+
+	// assigned are the ASSIGNED events of ticks abandoned since the last
+	// one published, whose workers still received their assignments.
+	assigned []*simulationpb.TaskEvent
+
+	simTime     time.Duration
+	multiplier  float64
+	fastForward bool
+
+	responses []*nodepb.WorkerTickResponse
+}
+
+// aggregateTick merges worker responses into a single AggregatedTick. The
+// completion time is taken here so late flags include aggregation time.
+func aggregateTick(res tickResult) *simulationpb.AggregatedTick {
+	var entities []*simulationpb.EntityState
+	var computeMs float64
+	breakdown := map[string]float64{}
+
+	// Assignments delivered with this tick, or with the abandoned ticks
+	// before it, come first, then whatever the workers report.
+	events := append(res.assigned, assignmentEvents(res.plan)...)
+	assigned := len(events)
+
+	// Commands no worker received are answered here.
+	acks := retiredAcks(res.plan)
+
+	for _, resp := range res.responses {
+		entities = append(entities, resp.GetEntities()...)
+		acks = append(acks, resp.GetCommandAcks()...)
+		events = append(events, resp.GetTaskEvents()...)
+		computeMs += resp.GetComputeMs()
+		for phase, ms := range resp.GetComputeBreakdownMs() {
+			breakdown[phase] += ms
+		}
+	}
+	if n := len(res.responses); n > 0 {
+		computeMs /= float64(n)
+		for phase := range breakdown {
+			breakdown[phase] /= float64(n)
+		}
+	}
+	sort.Slice(acks, func(i, j int) bool {
+		return acks[i].GetCommandId() < acks[j].GetCommandId()
+	})
+	reported := events[assigned:]
+	sort.Slice(reported, func(i, j int) bool {
+		return reported[i].GetTaskId() < reported[j].GetTaskId()
+	})
+
+	completedAt := time.Now()
+
+	agg := &simulationpb.AggregatedTick{
+		SimulationId: res.plan.GetSimulationId(),
+		Tick:         res.plan.GetTick(),
+		Entities:     entities,
+		AvgComputeMs: computeMs,
+		WorkerCount:  uint32(len(res.responses)),
+		CompletedAt:  timestamppb.New(completedAt),
+		ScheduledAt:  res.plan.GetScheduledAt(),
+		SkippedTicks: res.skipped,
+		CommandAcks:  acks,
+		TaskEvents:   events,
+
+		ComputeBreakdownMs: breakdown,
+		Environment:        res.plan.GetEnvironment(),
+
+		SimTimeMs:       uint64(res.simTime / time.Millisecond),
+		SpeedMultiplier: res.multiplier,
+		FastForward:     res.fastForward,
+	}
+	if res.deadline.IsZero() {
+		return agg
+	}
+
+	agg.Deadline = timestamppb.New(res.deadline)
+	if completedAt.After(res.deadline) {
+		agg.Late = true
+		agg.OverrunMs = completedAt.Sub(res.deadline).Seconds() * 1000.0
+	}
+
+	return agg
+}
+
+// assignmentEvents returns the ASSIGNED events of the task assignments
+// delivered with plan.
+func assignmentEvents(plan *simulationpb.SimulationTickRequest) []*simulationpb.TaskEvent {
+	var events []*simulationpb.TaskEvent
+	for _, t := range plan.GetTaskAssignments() {
+		events = append(events, &simulationpb.TaskEvent{
+			TaskId:   t.GetTaskId(),
+			EntityId: t.GetAssignedEntityId(),
+			State:    simulationpb.TaskState_TASK_STATE_ASSIGNED,
+			Tick:     plan.GetTick(),
+		})
+	}
+	return events
+}
+
+// tickPipeline hands received ticks to a single aggregate/broadcast goroutine
+// so the loop can dispatch the next tick straight away. A single consumer
+// reading a FIFO channel keeps AggregatedTicks in tick order, and the channel
+// capacity bounds how many ticks can be in flight.
+type tickPipeline struct {
+	rt      *simulationRuntime
+	pending chan tickResult
+	wg      sync.WaitGroup
+}
+
+// newTickPipeline starts the aggregate/broadcast stage. depth is clamped to
+// [1, maxPipelineDepth].
+func newTickPipeline(rt *simulationRuntime, depth uint32) *tickPipeline {
+	if depth > maxPipelineDepth {
+		depth = maxPipelineDepth
+	}
+	if depth == 0 {
+		depth = 1
+	}
+
+	p := &tickPipeline{
+		rt:      rt,
+		pending: make(chan tickResult, depth),
+	}
+
+	// Every queued tick was executed by the workers, so it is published
+	// even after the loop is canceled; close waits for the last of them.
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		for res := range p.pending {
+			p.rt.publishTick(res)
+		}
+	}()
+
+	return p
+}
+
+// submit queues res for aggregation, blocking while the pipeline is full.
+// It returns false if ctx is canceled first.
+func (p *tickPipeline) submit(ctx context.Context, res tickResult) bool {
+	select {
+	case p.pending <- res:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// close stops accepting ticks and waits for the queued ones to be
+// broadcast.
+func (p *tickPipeline) close() {
+	close(p.pending)
+	p.wg.Wait()
+}
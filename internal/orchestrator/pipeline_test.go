@@ -0,0 +1,160 @@
+package orchestrator
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// newTestRuntime returns the runtime of a new simulation, and a channel
+// subscribed to its ticks.
+func newTestRuntime(t *testing.T) (*simulationRuntime, chan *simulationpb.AggregatedTick) {
+	t.Helper()
+	s := NewSimulationServer()
+	sim := createTestSimulation(t, s, &simulationpb.SimulationConfig{EntityCount: 2, TickRateMs: 100})
+	rt := s.runtimes[sim.GetId().GetValue()]
+
+	ticks := make(chan *simulationpb.AggregatedTick, 64)
+	rt.subscribers[ticks] = struct{}{}
+	return rt, ticks
+}
+
+// testTick returns a received tick to publish.
+func testTick(tick uint64) tickResult {
+	return tickResult{
+		ctx:       context.Background(),
+		plan:      &simulationpb.SimulationTickRequest{Tick: tick},
+		responses: []*nodepb.WorkerTickResponse{{Tick: tick}},
+	}
+}
+
+// receivedTicks returns the numbers of the ticks published on ticks so far.
+func receivedTicks(ticks chan *simulationpb.AggregatedTick) []uint64 {
+	var out []uint64
+	for {
+		select {
+		case agg := <-ticks:
+			out = append(out, agg.GetTick())
+		default:
+			return out
+		}
+	}
+}
+
+func TestTickPipelineOrder(t *testing.T) {
+	tests := []struct {
+		depth    uint32
+		wantCap  int
+		numTicks uint64
+	}{
+		{depth: 0, wantCap: 1, numTicks: 20},
+		{depth: 1, wantCap: 1, numTicks: 20},
+		{depth: 4, wantCap: 4, numTicks: 20},
+		{depth: maxPipelineDepth + 10, wantCap: maxPipelineDepth, numTicks: 40},
+	}
+
+	for _, tt := range tests {
+		rt, ticks := newTestRuntime(t)
+		p := newTickPipeline(rt, tt.depth)
+		if got := cap(p.pending); got != tt.wantCap {
+			t.Errorf("depth %d: pipeline holds %d ticks, want %d", tt.depth, got, tt.wantCap)
+		}
+
+		var want []uint64
+		for tick := uint64(1); tick <= tt.numTicks; tick++ {
+			if !p.submit(context.Background(), testTick(tick)) {
+				t.Fatalf("depth %d: submit(tick %d) refused", tt.depth, tick)
+			}
+			want = append(want, tick)
+		}
+		p.close()
+
+		if got := receivedTicks(ticks); !slices.Equal(got, want) {
+			t.Errorf("depth %d: published ticks %v, want %v", tt.depth, got, want)
+		}
+	}
+}
+
+func TestTickPipelineBackPressure(t *testing.T) {
+	const depth = 3
+	rt, ticks := newTestRuntime(t)
+	p := newTickPipeline(rt, depth)
+
+	// Holding the subscribers' lock stalls the broadcast of the first tick,
+	// so the pipeline fills up behind it.
+	rt.subMu.Lock()
+	accepted := uint64(0)
+	for tick := uint64(1); tick <= depth+3; tick++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		ok := p.submit(ctx, testTick(tick))
+		cancel()
+		if !ok {
+			break
+		}
+		accepted++
+	}
+	if accepted != depth+1 {
+		t.Errorf("%d ticks accepted by a stalled pipeline of depth %d, want %d", accepted, depth, depth+1)
+	}
+
+	rt.subMu.Unlock()
+	p.close()
+
+	var want []uint64
+	for tick := uint64(1); tick <= accepted; tick++ {
+		want = append(want, tick)
+	}
+	if got := receivedTicks(ticks); !slices.Equal(got, want) {
+		t.Errorf("published ticks %v, want %v", got, want)
+	}
+}
+
+// TestAggregateTickCarriesAbandoned checks the assignments of abandoned
+// ticks come first in the next tick published, before its own.
+func TestAggregateTickCarriesAbandoned(t *testing.T) {
+	abandoned := &simulationpb.SimulationTickRequest{Tick: 1, TaskAssignments: []*simulationpb.Task{{TaskId: 7, AssignedEntityId: 2}}}
+	plan := &simulationpb.SimulationTickRequest{Tick: 2, TaskAssignments: []*simulationpb.Task{{TaskId: 3, AssignedEntityId: 1}}}
+
+	// The worker reports tick 1's acks and events with tick 2; see
+	// Dispatch.
+	resp := workerResponse(2, 20, 9)
+	resp.CommandAcks = append(workerResponse(1, 10, 5).GetCommandAcks(), resp.GetCommandAcks()...)
+	resp.TaskEvents = append(workerResponse(1, 10, 5).GetTaskEvents(), resp.GetTaskEvents()...)
+
+	agg := aggregateTick(tickResult{
+		plan:      plan,
+		assigned:  assignmentEvents(abandoned),
+		skipped:   1,
+		responses: []*nodepb.WorkerTickResponse{resp},
+	})
+
+	type event struct {
+		task, tick uint64
+		state      simulationpb.TaskState
+	}
+	var got []event
+	for _, e := range agg.GetTaskEvents() {
+		got = append(got, event{e.GetTaskId(), e.GetTick(), e.GetState()})
+	}
+	want := []event{
+		{7, 1, simulationpb.TaskState_TASK_STATE_ASSIGNED},
+		{3, 2, simulationpb.TaskState_TASK_STATE_ASSIGNED},
+		{5, 1, simulationpb.TaskState_TASK_STATE_COMPLETED},
+		{9, 2, simulationpb.TaskState_TASK_STATE_COMPLETED},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("task events %v, want %v", got, want)
+	}
+
+	var acks []uint64
+	for _, a := range agg.GetCommandAcks() {
+		acks = append(acks, a.GetCommandId())
+	}
+	if !slices.Equal(acks, []uint64{10, 20}) || agg.GetSkippedTicks() != 1 {
+		t.Errorf("acks %v, %d skipped; want [10 20], 1 skipped", acks, agg.GetSkippedTicks())
+	}
+}
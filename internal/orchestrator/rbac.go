@@ -0,0 +1,151 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/stevenmed26/AutoFarm/internal/audit"
+	"github.com/stevenmed26/AutoFarm/internal/gatewayauth"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/rbac"
+	"github.com/stevenmed26/AutoFarm/internal/tenant"
+)
+
+// methodRoles is the least role each SimulationService method needs.
+// Methods missing here are denied to everyone.
+var methodRoles = map[string]rbac.Role{
+	simulationpb.SimulationService_GetSimulation_FullMethodName:         rbac.Viewer,
+	simulationpb.SimulationService_ListSimulations_FullMethodName:       rbac.Viewer,
+	simulationpb.SimulationService_StreamAggregatedTicks_FullMethodName: rbac.Viewer,
+	simulationpb.SimulationService_WatchSimulationStatus_FullMethodName: rbac.Viewer,
+	simulationpb.SimulationService_ListTasks_FullMethodName:             rbac.Viewer,
+	simulationpb.SimulationService_GetCrops_FullMethodName:              rbac.Viewer,
+	simulationpb.SimulationService_ListScenarios_FullMethodName:         rbac.Viewer,
+	simulationpb.SimulationService_GetTemplate_FullMethodName:           rbac.Viewer,
+	simulationpb.SimulationService_ListTemplates_FullMethodName:         rbac.Viewer,
+	simulationpb.SimulationService_GetExperiment_FullMethodName:         rbac.Viewer,
+	simulationpb.SimulationService_ListExperiments_FullMethodName:       rbac.Viewer,
+	simulationpb.SimulationService_ListSimulationEvents_FullMethodName:  rbac.Viewer,
+
+	simulationpb.SimulationService_CreateSimulation_FullMethodName:   rbac.Operator,
+	simulationpb.SimulationService_StartSimulation_FullMethodName:    rbac.Operator,
+	simulationpb.SimulationService_PauseSimulation_FullMethodName:    rbac.Operator,
+	simulationpb.SimulationService_StopSimulation_FullMethodName:     rbac.Operator,
+	simulationpb.SimulationService_SetSimulationSpeed_FullMethodName: rbac.Operator,
+	simulationpb.SimulationService_StepSimulation_FullMethodName:     rbac.Operator,
+	simulationpb.SimulationService_SendEntityCommand_FullMethodName:  rbac.Operator,
+	simulationpb.SimulationService_ScaleEntities_FullMethodName:      rbac.Operator,
+	simulationpb.SimulationService_SubmitTasks_FullMethodName:        rbac.Operator,
+	simulationpb.SimulationService_SetEnvironment_FullMethodName:     rbac.Operator,
+	simulationpb.SimulationService_CloneSimulation_FullMethodName:    rbac.Operator,
+	simulationpb.SimulationService_CreateExperiment_FullMethodName:   rbac.Operator,
+	simulationpb.SimulationService_CancelExperiment_FullMethodName:   rbac.Operator,
+
+	simulationpb.SimulationService_DeleteSimulation_FullMethodName: rbac.Admin,
+	simulationpb.SimulationService_CreateTemplate_FullMethodName:   rbac.Admin,
+	simulationpb.SimulationService_UpdateTemplate_FullMethodName:   rbac.Admin,
+	simulationpb.SimulationService_DeleteTemplate_FullMethodName:   rbac.Admin,
+	simulationpb.SimulationService_ListAuditEvents_FullMethodName:  rbac.Admin,
+}
+
+// callerMetadataKeys are the metadata keys naming who a call is made for.
+// Only trusted clients may set them.
+var callerMetadataKeys = []string{
+	rbac.RoleMetadataKey,
+	rbac.SubjectMetadataKey,
+	tenant.MetadataKey,
+	audit.SourceIPMetadataKey,
+}
+
+// Authorizer checks the role of every incoming call against methodRoles.
+//
+// Only clients presenting the shared token, like the API gateway, may
+// name the caller's role, subject, tenant and address; their calls that
+// name no role, such as those of autofarmctl, are admin. Other clients'
+// calls have that metadata removed and get defaultRole in the default
+// tenant.
+type Authorizer struct {
+	verifier    *gatewayauth.Verifier
+	defaultRole rbac.Role
+	audit       *audit.Log
+}
+
+// NewAuthorizer returns an Authorizer trusting clients with token that
+// records denied calls in log.
+func NewAuthorizer(token string, defaultRole rbac.Role, log *audit.Log) *Authorizer {
+	return &Authorizer{verifier: gatewayauth.NewVerifier(token), defaultRole: defaultRole, audit: log}
+}
+
+// UnaryInterceptor rejects unary calls the caller's role does not allow
+// with PermissionDenied.
+func (a *Authorizer) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor is UnaryInterceptor for streams.
+func (a *Authorizer) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize returns ctx with only the caller metadata the client may set,
+// or an error if the caller may not call method.
+func (a *Authorizer) authorize(ctx context.Context, method string) (context.Context, error) {
+	ctx, trusted := a.verifier.Strip(ctx, callerMetadataKeys...)
+	caller, ok, err := rbac.FromIncomingContext(ctx)
+	if err != nil {
+		return nil, statusError(codes.InvalidArgument, reasonInvalidArgument,
+			map[string]string{"metadata": rbac.RoleMetadataKey}, err.Error())
+	}
+	switch {
+	case ok:
+	case trusted:
+		caller.Role = rbac.Admin
+	default:
+		caller.Role = a.defaultRole
+	}
+
+	need, known := methodRoles[method]
+	if known && caller.Role.Allows(need) {
+		return ctx, nil
+	}
+	reason := fmt.Sprintf("requires the %s role", need)
+	if !known {
+		reason = "not open to any role"
+	}
+
+	e := audit.Event{
+		Outcome: audit.Denied,
+		Actor:   caller.Subject,
+		Role:    string(caller.Role),
+		Action:  method,
+		Reason:  reason,
+	}
+	e.Tenant, _ = tenant.FromIncomingContext(ctx)
+	e.SourceIP = audit.SourceIP(ctx)
+	a.audit.Record(e)
+	return nil, permissionDenied(method, string(caller.Role), string(need),
+		fmt.Sprintf("%s %s; the caller is %s", method, reason, caller.Role))
+}
+
+// authorizedStream replaces the context of a stream.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
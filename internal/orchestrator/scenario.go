@@ -0,0 +1,151 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stevenmed26/AutoFarm/internal/crops"
+	"github.com/stevenmed26/AutoFarm/internal/fleet"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/scenario"
+	"github.com/stevenmed26/AutoFarm/internal/tasks"
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+// configFromRequest returns the config a CreateSimulation request asks for:
+// given directly, as a scenario document, by the name of a file in the
+// scenario library, or by the name of one of the tenant's templates.
+func (s *SimulationServer) configFromRequest(ctx context.Context, owner string, req *simulationpb.CreateSimulationRequest) (*simulationpb.SimulationConfig, error) {
+	set := 0
+	for _, ok := range []bool{req.GetConfig() != nil, len(req.GetScenarioDocument()) > 0, req.GetScenarioName() != "", req.GetTemplateName() != ""} {
+		if ok {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		return nil, errors.New("missing simulation config")
+	case set > 1:
+		return nil, errors.New("set only one of config, scenario_document, scenario_name and template_name")
+	}
+
+	switch {
+	case req.GetConfig() != nil:
+		return req.GetConfig(), nil
+	case req.GetTemplateName() != "":
+		t, err := s.templates.GetTemplate(ctx, owner, req.GetTemplateName())
+		if err != nil {
+			return nil, storeError(err, req.GetTemplateName())
+		}
+		return t.GetConfig(), nil
+	case req.GetScenarioName() != "":
+		data, err := s.scenarios.Read(req.GetScenarioName())
+		if errors.Is(err, scenario.ErrNotFound) {
+			return nil, notFound(resourceScenario, req.GetScenarioName())
+		}
+		if err != nil {
+			return nil, err
+		}
+		return scenario.Load(data)
+	default:
+		return scenario.Load(req.GetScenarioDocument())
+	}
+}
+
+// validateConfig checks cfg and fills in its defaults: fleet parameters,
+// entity_count and, when missing, the seed. It returns the world's
+// occupancy grid.
+func validateConfig(cfg *simulationpb.SimulationConfig) (*world.Grid, error) {
+	if err := fleet.Normalize(cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.EntityCount == 0 || cfg.TickRateMs == 0 {
+		return nil, errors.New("entity_count and tick_rate_ms must be > 0")
+	}
+
+	if _, ok := simulationpb.TickOverrunPolicy_name[int32(cfg.OverrunPolicy)]; !ok {
+		return nil, fmt.Errorf("unknown overrun_policy %d", cfg.OverrunPolicy)
+	}
+
+	if cfg.PipelineDepth > maxPipelineDepth {
+		return nil, fmt.Errorf("pipeline_depth must be <= %d", maxPipelineDepth)
+	}
+
+	if err := world.Validate(cfg.World); err != nil {
+		return nil, err
+	}
+	grid := world.NewGrid(cfg.World)
+	for i, g := range cfg.GetFleet() {
+		for j, p := range g.GetPlacements() {
+			if err := grid.ValidatePoint(p.GetX(), p.GetY()); err != nil {
+				return nil, fmt.Errorf("fleet[%d].placements[%d]: %w", i, j, err)
+			}
+		}
+	}
+
+	if err := normalizeEnvironment(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := crops.Validate(cfg.Crops); err != nil {
+		return nil, err
+	}
+
+	if len(cfg.GetTasks()) > tasks.MaxSubmit {
+		return nil, fmt.Errorf("tasks: at most %d", tasks.MaxSubmit)
+	}
+	for i, t := range cfg.GetTasks() {
+		if err := tasks.Validate(t); err != nil {
+			return nil, fmt.Errorf("tasks[%d]: %w", i, err)
+		}
+	}
+
+	if err := validateWeatherScript(cfg.GetWeatherScript()); err != nil {
+		return nil, err
+	}
+
+	if err := validateTermination(cfg); err != nil {
+		return nil, err
+	}
+
+	return grid, nil
+}
+
+// listScenarios describes every file in the scenario library, with the
+// reason it would be rejected if it does not validate.
+func (s *SimulationServer) listScenarios() ([]*simulationpb.ScenarioInfo, error) {
+	names, err := s.scenarios.Names()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*simulationpb.ScenarioInfo, 0, len(names))
+	for _, name := range names {
+		info := &simulationpb.ScenarioInfo{Name: name}
+		out = append(out, info)
+
+		data, err := s.scenarios.Read(name)
+		if err != nil {
+			info.Error = err.Error()
+			continue
+		}
+		doc, err := scenario.Parse(data)
+		if err != nil {
+			info.Error = err.Error()
+			continue
+		}
+		info.Description = doc.Description
+		cfg, err := doc.Config()
+		if err == nil {
+			_, err = validateConfig(proto.Clone(cfg).(*simulationpb.SimulationConfig))
+		}
+		if err != nil {
+			info.Error = err.Error()
+		}
+	}
+	return out, nil
+}
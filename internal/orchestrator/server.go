@@ -5,16 +5,28 @@ import (
     "context"
     "errors"
     "fmt"
-    "log"
+    "log/slog"
+    "math"
+    "sort"
+    "strconv"
     "sync"
-    //"time"
+    "sync/atomic"
+    "time"
 	"os"
 
     "github.com/google/uuid"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/protobuf/proto"
     //"google.golang.org/grpc"
     //"google.golang.org/grpc/credentials/insecure"
     "google.golang.org/protobuf/types/known/timestamppb"
 
+    "github.com/stevenmed26/AutoFarm/internal/crops"
+    "github.com/stevenmed26/AutoFarm/internal/scenario"
+    "github.com/stevenmed26/AutoFarm/internal/store"
+    "github.com/stevenmed26/AutoFarm/internal/tenant"
+    "github.com/stevenmed26/AutoFarm/internal/tasks"
+    "github.com/stevenmed26/AutoFarm/internal/world"
     commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
     //nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
     simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
@@ -22,16 +34,73 @@ import (
 
 // simulationRuntime holds the in-memory runtime state for a simulation.
 type simulationRuntime struct {
-    sim       *simulationpb.Simulation
-    entityIDs []uint64
+    sim      *simulationpb.Simulation
+    entities *entityRoster
+
+    // config is the config the simulation was created with. Unlike sim's,
+    // it is never swapped, so the tick loop can read what does not change,
+    // like the termination conditions, without the server's mu.
+    config *simulationpb.SimulationConfig
+
+    // grid is the world's occupancy grid, used to reject destinations
+    // inside obstacles.
+    grid *world.Grid
 
     // subscribers receive AggregatedTicks over this channel.
     subscribers map[chan *simulationpb.AggregatedTick]struct{}
     subMu       sync.RWMutex
 
+    // watchers receive the simulation's status changes. Guarded by the
+    // server's mu, under which the status changes.
+    watchers map[chan *simulationpb.SimulationStatusEvent]struct{}
+
+    // speed is read by the tick loop; speedChanged wakes it after a change.
+    speedMu      sync.Mutex
+    multiplier   float64
+    fastForward  bool
+    speedChanged chan struct{}
+
+    // commands waits for the next tick to deliver it to workers.
+    commands commandQueue
+
+    // tasks holds submitted tasks; pending ones go to idle entities as each
+    // tick is planned.
+    tasks *tasks.Queue
+
+    // crops is the crop field, nil when the simulation grows none.
+    crops *crops.Field
+
+    // environment holds what-if overrides of the seeded weather and time
+    // of day.
+    environment environmentOverride
+
+    // snapshots are taken every few ticks for forks to start from.
+    snapshots snapshotHistory
+
+    // deleted is closed when the simulation is deleted, which ends the
+    // streams of its ticks.
+    deleted chan struct{}
+
+    // completed is set once a termination condition is met; ticks
+    // received after that are dropped. onComplete marks the simulation
+    // COMPLETED and stops its tick loop.
+    completed  atomic.Bool
+    onComplete func(ctx context.Context, reason string)
+
+    // execMu is held by whatever is executing ticks (the tick loop or a
+    // step), so the two never overlap. lastTick is the last tick executed.
+    execMu   sync.Mutex
+    lastTick atomic.Uint64
+
     cancel context.CancelFunc
 }
 
+// Bounds for SetSimulationSpeed multipliers.
+const (
+    minSpeedMultiplier = 0.01
+    maxSpeedMultiplier = 1000.0
+)
+
 // SimulationServer implements the SimulationService gRPC server.
 type SimulationServer struct {
     simulationpb.UnimplementedSimulationServiceServer
@@ -40,13 +109,44 @@ type SimulationServer struct {
     sims      map[string]*simulationpb.Simulation
     runtimes  map[string]*simulationRuntime
     workerAddr string
+
+    // scenarios is the library CreateSimulation loads named scenarios from.
+    scenarios *scenario.Library
+
+    // templates holds named simulation templates.
+    templates store.TemplateStore
+
+    // events is the audit trail of changes callers made to simulations.
+    events store.EventStore
+
+    // quotas limit what each tenant may use; nil is unlimited. Guarded by
+    // mu.
+    quotas *tenant.Quotas
+
+    // slotFreed is closed, and replaced, whenever a simulation stops
+    // running, waking experiment runs that wait for their tenant's quota
+    // of running simulations. Guarded by mu.
+    slotFreed chan struct{}
+
+    // experiments are guarded by their own lock, so progress updates never
+    // wait on the simulations'. lastSweep is when finished experiments
+    // were last looked over for removal.
+    expMu       sync.Mutex
+    experiments map[string]*experimentRuntime
+    lastSweep   time.Time
 }
 
 func NewSimulationServer() *SimulationServer {
+    mem := store.NewMemoryStore()
     return &SimulationServer{
         sims:       make(map[string]*simulationpb.Simulation),
         runtimes:   make(map[string]*simulationRuntime),
         workerAddr: getEnv("WORKER_GRPC_ADDR", "localhost:50052"),
+        scenarios:  scenario.NewLibrary(getEnv("SCENARIO_DIR", "scenarios")),
+        templates:  mem,
+        events:     mem,
+        slotFreed:  make(chan struct{}),
+        experiments: make(map[string]*experimentRuntime),
     }
 }
 
@@ -55,39 +155,113 @@ func (s *SimulationServer) CreateSimulation(
     req *simulationpb.CreateSimulationRequest,
 ) (*simulationpb.CreateSimulationResponse, error) {
 
-    if req == nil || req.Config == nil {
-        return nil, errors.New("missing simulation config")
+    owner, err := callerTenant(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    cfg, err := s.configFromRequest(ctx, owner, req)
+    if err != nil {
+        return nil, invalidArgument(err)
+    }
+
+    if err := applyOverrides(cfg, req.GetOverrides()); err != nil {
+        return nil, invalidArgument(err)
+    }
+
+    rt, err := s.newRuntime(cfg, true)
+    if err != nil {
+        return nil, invalidArgument(err)
+    }
+    rt.sim.TemplateName = req.GetTemplateName()
+    rt.sim.Tenant = owner
+    if err := s.register(rt); err != nil {
+        return nil, err
     }
 
-    if req.Config.EntityCount == 0 || req.Config.TickRateMs == 0 {
-        return nil, errors.New("entity_count and tick_rate_ms must be > 0")
+    detail := ""
+    if rt.sim.TemplateName != "" {
+        detail = "template " + rt.sim.TemplateName
     }
+    s.recordEvent(ctx, rt.sim, actionCreate,
+        commonpb.SimulationStatus_SIMULATION_STATUS_UNSPECIFIED, commonpb.SimulationStatus_SIMULATION_STATUS_CREATED, detail)
 
-    id := uuid.NewString()
-    now := timestamppb.Now()
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return &simulationpb.CreateSimulationResponse{
+        Simulation: cloneSimulation(rt.sim),
+    }, nil
+}
+
+// newRuntime validates cfg and sets up a new CREATED simulation running it.
+// queueTasks queues the config's tasks; forks restore theirs instead.
+func (s *SimulationServer) newRuntime(cfg *simulationpb.SimulationConfig, queueTasks bool) (*simulationRuntime, error) {
+    grid, err := validateConfig(cfg)
+    if err != nil {
+        return nil, err
+    }
+
+    taskQueue, err := tasks.NewQueue(cfg.TaskAllocation)
+    if err != nil {
+        return nil, err
+    }
+    if queueTasks && len(cfg.Tasks) > 0 {
+        if _, err := taskQueue.Submit(cfg.Tasks, 0); err != nil {
+            return nil, err
+        }
+    }
 
     sim := &simulationpb.Simulation{
         Id: &commonpb.SimulationId{
-            Value: id,
+            Value: uuid.NewString(),
         },
-        Config: req.Config,
+        Config: cfg,
         Status: commonpb.SimulationStatus_SIMULATION_STATUS_CREATED,
-        CreatedAt: now,
+        CreatedAt: timestamppb.Now(),
+        SpeedMultiplier: 1,
     }
 
     rt := &simulationRuntime{
-        sim:         sim,
-        subscribers: make(map[chan *simulationpb.AggregatedTick]struct{}),
+        sim:          sim,
+        entities:     newEntityRoster(cfg),
+        config:       cfg,
+        grid:         grid,
+        tasks:        taskQueue,
+        subscribers:  make(map[chan *simulationpb.AggregatedTick]struct{}),
+        watchers:     make(map[chan *simulationpb.SimulationStatusEvent]struct{}),
+        multiplier:   1,
+        speedChanged: make(chan struct{}, 1),
+        deleted:      make(chan struct{}),
     }
+    if crops.Enabled(cfg) {
+        rt.crops = crops.NewField(cfg, grid)
+    }
+    rt.environment.script = cfg.WeatherScript
+    rt.onComplete = func(ctx context.Context, reason string) {
+        s.completeSimulation(ctx, rt, reason)
+    }
+    return rt, nil
+}
+
+// register makes a runtime built by newRuntime visible to the other RPCs,
+// if its tenant's quota leaves room for it.
+func (s *SimulationServer) register(rt *simulationRuntime) error {
+    id := rt.sim.Id.GetValue()
+    owner := rt.sim.Tenant
 
     s.mu.Lock()
-    s.sims[id] = sim
-    s.runtimes[id] = rt
-    s.mu.Unlock()
+    defer s.mu.Unlock()
 
-    return &simulationpb.CreateSimulationResponse{
-        Simulation: sim,
-    }, nil
+    if err := checkConfigQuota(owner, s.quotas.For(owner), rt.sim.Config); err != nil {
+        return err
+    }
+    if err := s.checkEntityUsage(owner, int(rt.sim.Config.GetEntityCount())); err != nil {
+        return err
+    }
+
+    s.sims[id] = rt.sim
+    s.runtimes[id] = rt
+    return nil
 }
 
 func (s *SimulationServer) StartSimulation(
@@ -95,7 +269,7 @@ func (s *SimulationServer) StartSimulation(
     req *simulationpb.StartSimulationRequest,
 ) (*simulationpb.StartSimulationResponse, error) {
 
-    sim, rt, err := s.getSimulationAndRuntime(req.GetId())
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
     if err != nil {
         return nil, err
     }
@@ -104,35 +278,33 @@ func (s *SimulationServer) StartSimulation(
     defer s.mu.Unlock()
 
     if sim.Status == commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING {
-        return &simulationpb.StartSimulationResponse{Simulation: sim}, nil
+        return &simulationpb.StartSimulationResponse{Simulation: cloneSimulation(sim)}, nil
     }
 
-    if sim.Status == commonpb.SimulationStatus_SIMULATION_STATUS_COMPLETED ||
-        sim.Status == commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED {
-        return nil, fmt.Errorf("cannot start simulation in status %s", sim.Status.String())
+    if !canTransition(sim.Status, commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING) {
+        return nil, invalidTransition(sim.Id.GetValue(), sim.Status, actionStart, "")
     }
 
-    sim.Status = commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING
-    if sim.StartedAt == nil {
-        sim.StartedAt = timestamppb.Now()
+    if err := s.checkRunningUsage(sim.Tenant); err != nil {
+        return nil, err
     }
 
-    // Initialize entity IDs once.
-    if len(rt.entityIDs) == 0 {
-        count := sim.Config.GetEntityCount()
-        rt.entityIDs = make([]uint64, 0, count)
-        for i := uint64(1); i <= uint64(count); i++ {
-            rt.entityIDs = append(rt.entityIDs, i)
-        }
+    before := sim.Status
+    if err := s.transition(rt, commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING, actionStart, ""); err != nil {
+        return nil, err
     }
 
-    // If there is no active loop, start one.
+    // If there is no active loop, start one. It outlives the call, and
+    // keeps its request ID for its logs and worker streams.
     if rt.cancel == nil {
-        go s.runSimulationLoop(sim.Id.GetValue(), rt)
+        loopCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+        rt.cancel = cancel
+        go s.runSimulationLoop(loopCtx, sim.Id.GetValue(), rt)
     }
+    s.recordEvent(ctx, sim, actionStart, before, sim.Status, "")
 
     return &simulationpb.StartSimulationResponse{
-        Simulation: sim,
+        Simulation: cloneSimulation(sim),
     }, nil
 }
 
@@ -141,7 +313,7 @@ func (s *SimulationServer) PauseSimulation(
     req *simulationpb.PauseSimulationRequest,
 ) (*simulationpb.PauseSimulationResponse, error) {
 
-    sim, rt, err := s.getSimulationAndRuntime(req.GetId())
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
     if err != nil {
         return nil, err
     }
@@ -149,20 +321,14 @@ func (s *SimulationServer) PauseSimulation(
     s.mu.Lock()
     defer s.mu.Unlock()
 
-    if sim.Status != commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING {
-        return nil, fmt.Errorf("can only pause running simulations (current: %s)", sim.Status.String())
-    }
-
-    sim.Status = commonpb.SimulationStatus_SIMULATION_STATUS_PAUSED
-
-    // Stop the tick loop if running.
-    if rt.cancel != nil {
-        rt.cancel()
-        rt.cancel = nil
+    before := sim.Status
+    if err := s.transition(rt, commonpb.SimulationStatus_SIMULATION_STATUS_PAUSED, actionPause, ""); err != nil {
+        return nil, err
     }
+    s.recordEvent(ctx, sim, actionPause, before, sim.Status, "")
 
     return &simulationpb.PauseSimulationResponse{
-        Simulation: sim,
+        Simulation: cloneSimulation(sim),
     }, nil
 }
 
@@ -171,7 +337,7 @@ func (s *SimulationServer) StopSimulation(
     req *simulationpb.StopSimulationRequest,
 ) (*simulationpb.StopSimulationResponse, error) {
 
-    sim, rt, err := s.getSimulationAndRuntime(req.GetId())
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
     if err != nil {
         return nil, err
     }
@@ -179,21 +345,421 @@ func (s *SimulationServer) StopSimulation(
     s.mu.Lock()
     defer s.mu.Unlock()
 
-    if sim.Status == commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED ||
-        sim.Status == commonpb.SimulationStatus_SIMULATION_STATUS_COMPLETED {
-        return &simulationpb.StopSimulationResponse{Simulation: sim}, nil
+    if isFinal(sim.Status) {
+        return &simulationpb.StopSimulationResponse{Simulation: cloneSimulation(sim)}, nil
     }
 
-    sim.Status = commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED
-    sim.EndedAt = timestamppb.Now()
-
-    if rt.cancel != nil {
-        rt.cancel()
-        rt.cancel = nil
+    before := sim.Status
+    if err := s.transition(rt, commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED, actionStop, ""); err != nil {
+        return nil, err
     }
+    s.recordEvent(ctx, sim, actionStop, before, sim.Status, "")
 
     return &simulationpb.StopSimulationResponse{
-        Simulation: sim,
+        Simulation: cloneSimulation(sim),
+    }, nil
+}
+
+// DeleteSimulation stops a simulation that is still active and forgets it.
+// Streams of its ticks end, and an experiment it is a run of records the
+// run as stopped.
+func (s *SimulationServer) DeleteSimulation(
+    ctx context.Context,
+    req *simulationpb.DeleteSimulationRequest,
+) (*simulationpb.DeleteSimulationResponse, error) {
+
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+    id := sim.Id.GetValue()
+
+    s.mu.Lock()
+    if _, ok := s.runtimes[id]; !ok {
+        // Deleted by a concurrent call.
+        s.mu.Unlock()
+        return nil, notFound(resourceSimulation, id)
+    }
+    before := sim.Status
+    if !isFinal(sim.Status) {
+        if err := s.transition(rt, commonpb.SimulationStatus_SIMULATION_STATUS_STOPPED, actionDelete, ""); err != nil {
+            s.mu.Unlock()
+            return nil, err
+        }
+    }
+    delete(s.sims, id)
+    delete(s.runtimes, id)
+    after := sim.Status
+    s.mu.Unlock()
+
+    s.recordEvent(ctx, sim, actionDelete, before, after, "")
+
+    close(rt.deleted)
+    slog.InfoContext(ctx, "simulation deleted", "simulation_id", id)
+
+    return &simulationpb.DeleteSimulationResponse{}, nil
+}
+
+// StepSimulation advances a paused simulation by a fixed number of ticks and
+// returns the resulting aggregates. Stepped ticks are broadcast to subscribers
+// like any other tick.
+func (s *SimulationServer) StepSimulation(
+    ctx context.Context,
+    req *simulationpb.StepSimulationRequest,
+) (*simulationpb.StepSimulationResponse, error) {
+
+    n := req.GetTicks()
+    if n == 0 {
+        n = 1
+    }
+    if n > maxStepTicks {
+        return nil, invalidField("ticks", "ticks must be <= %d", maxStepTicks)
+    }
+
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    s.mu.RLock()
+    status := sim.Status
+    s.mu.RUnlock()
+
+    if status != commonpb.SimulationStatus_SIMULATION_STATUS_PAUSED {
+        return nil, invalidTransition(sim.Id.GetValue(), status, "step",
+            fmt.Sprintf("can only step paused simulations (current: %s)", status.String()))
+    }
+
+    ticks, err := s.stepTicks(ctx, rt, n)
+    if err != nil {
+        return nil, workerUnavailable(fmt.Errorf("step simulation %s: %w", sim.Id.GetValue(), err))
+    }
+
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    return &simulationpb.StepSimulationResponse{
+        Simulation: cloneSimulation(sim),
+        Ticks:      ticks,
+    }, nil
+}
+
+// SendEntityCommand queues a command for one entity. It is applied by the
+// owning worker at the start of the next tick and acknowledged in that tick's
+// AggregatedTick.
+func (s *SimulationServer) SendEntityCommand(
+    ctx context.Context,
+    req *simulationpb.SendEntityCommandRequest,
+) (*simulationpb.SendEntityCommandResponse, error) {
+
+    cmd := req.GetCommand()
+    if cmd == nil {
+        return nil, invalidField("command", "missing command")
+    }
+    if err := validateEntityCommand(cmd); err != nil {
+        return nil, invalidArgument(err)
+    }
+
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    s.mu.RLock()
+    status := sim.Status
+    s.mu.RUnlock()
+
+    if isFinal(status) {
+        return nil, invalidTransition(sim.Id.GetValue(), status, "command entities of", "")
+    }
+
+    if !rt.entities.has(cmd.GetEntityId()) {
+        return nil, notFound(resourceEntity, strconv.FormatUint(cmd.GetEntityId(), 10))
+    }
+
+    if cmd.GetType() == simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_MOVE_TO {
+        if err := rt.grid.ValidatePoint(cmd.GetX(), cmd.GetY()); err != nil {
+            return nil, invalidArgument(fmt.Errorf("move_to: %w", err))
+        }
+    }
+
+    queued := rt.commands.push(cmd)
+    s.recordEvent(ctx, sim, actionEntityCommand, status, status,
+        fmt.Sprintf("entity %d: %s", cmd.GetEntityId(), commandName(cmd.GetType())))
+
+    return &simulationpb.SendEntityCommandResponse{
+        Command: queued,
+    }, nil
+}
+
+// ScaleEntities spawns and retires entities. The orchestrator allocates ids
+// for new entities; workers create and drop entity state on the next tick.
+func (s *SimulationServer) ScaleEntities(
+    ctx context.Context,
+    req *simulationpb.ScaleEntitiesRequest,
+) (*simulationpb.ScaleEntitiesResponse, error) {
+
+    if n := len(req.GetSpawn()) + int(req.GetSpawnCount()); n > maxScaleEntities {
+        return nil, invalidField("spawn", "cannot spawn more than %d entities at once", maxScaleEntities)
+    }
+    if _, ok := simulationpb.EntityType_name[int32(req.GetSpawnType())]; !ok {
+        return nil, invalidField("spawn_type", "unknown spawn_type %d", req.GetSpawnType())
+    }
+    for _, st := range req.GetSpawn() {
+        if _, ok := simulationpb.EntityType_name[int32(st.GetType())]; !ok {
+            return nil, invalidField("spawn", "unknown entity type %d", st.GetType())
+        }
+    }
+
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if isFinal(sim.Status) {
+        return nil, invalidTransition(sim.Id.GetValue(), sim.Status, "scale entities of", "")
+    }
+
+    // Count each retired entity once, as retire does, or repeating an id
+    // would make room for entities that are never retired.
+    retiring := make(map[uint64]struct{}, len(req.GetRetire()))
+    for _, id := range req.GetRetire() {
+        retiring[id] = struct{}{}
+    }
+    if err := s.checkEntityUsage(sim.Tenant, len(req.GetSpawn())+int(req.GetSpawnCount())-len(retiring)); err != nil {
+        return nil, err
+    }
+
+    // Retire first so an invalid id rejects the call before anything spawns.
+    retired, err := rt.entities.retire(req.GetRetire())
+    if err != nil {
+        return nil, err
+    }
+    spawned := rt.entities.spawn(req.GetSpawn(), req.GetSpawnCount(), req.GetSpawnType())
+    rt.tasks.Release(retired)
+
+    // Swap in a new config rather than mutating the one the tick loop holds.
+    cfg := proto.Clone(sim.Config).(*simulationpb.SimulationConfig)
+    cfg.EntityCount = uint32(rt.entities.count())
+    sim.Config = cfg
+
+    return &simulationpb.ScaleEntitiesResponse{
+        Simulation: cloneSimulation(sim),
+        SpawnedIds: spawned,
+        RetiredIds: retired,
+    }, nil
+}
+
+// SubmitTasks queues tasks for the simulation's entities. Tasks are assigned
+// as ticks are planned; assignments, completions and failures show up as
+// task events in the AggregatedTick stream.
+func (s *SimulationServer) SubmitTasks(
+    ctx context.Context,
+    req *simulationpb.SubmitTasksRequest,
+) (*simulationpb.SubmitTasksResponse, error) {
+
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    s.mu.RLock()
+    status := sim.Status
+    s.mu.RUnlock()
+
+    if isFinal(status) {
+        return nil, invalidTransition(sim.Id.GetValue(), status, "submit tasks to", "")
+    }
+
+    for i, t := range req.GetTasks() {
+        for j, wp := range t.GetWaypoints() {
+            if err := rt.grid.ValidatePoint(wp.GetX(), wp.GetY()); err != nil {
+                return nil, invalidField(fmt.Sprintf("tasks[%d].waypoints[%d]", i, j), "tasks[%d].waypoints[%d]: %v", i, j, err)
+            }
+        }
+    }
+
+    queued, err := rt.tasks.Submit(req.GetTasks(), rt.lastTick.Load())
+    var fe *tasks.FieldError
+    if errors.As(err, &fe) {
+        return nil, invalidField(fe.Field, "%v", fe)
+    }
+    if err != nil {
+        return nil, invalidArgument(err)
+    }
+
+    return &simulationpb.SubmitTasksResponse{
+        Tasks: queued,
+    }, nil
+}
+
+// ListTasks returns the simulation's tasks, optionally filtered by state.
+func (s *SimulationServer) ListTasks(
+    ctx context.Context,
+    req *simulationpb.ListTasksRequest,
+) (*simulationpb.ListTasksResponse, error) {
+
+    if _, ok := simulationpb.TaskState_name[int32(req.GetState())]; !ok {
+        return nil, invalidField("state", "unknown task state %d", req.GetState())
+    }
+
+    _, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    return &simulationpb.ListTasksResponse{
+        Tasks: rt.tasks.List(req.GetState()),
+    }, nil
+}
+
+// SetEnvironment overrides a simulation's weather and/or time of day from
+// the next tick on, or clears the override. It returns the conditions the
+// next tick will see.
+func (s *SimulationServer) SetEnvironment(
+    ctx context.Context,
+    req *simulationpb.SetEnvironmentRequest,
+) (*simulationpb.SetEnvironmentResponse, error) {
+
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    s.mu.RLock()
+    status := sim.Status
+    cfg := sim.Config
+    s.mu.RUnlock()
+
+    if isFinal(status) {
+        return nil, invalidTransition(sim.Id.GetValue(), status, "set environment of", "")
+    }
+
+    if req.GetClear() {
+        rt.environment.set(nil)
+    } else {
+        override, err := overrideFromRequest(req)
+        if err != nil {
+            return nil, invalidArgument(err)
+        }
+        rt.environment.set(override)
+    }
+
+    return &simulationpb.SetEnvironmentResponse{
+        Environment: rt.environment.at(cfg, rt.lastTick.Load()+1),
+    }, nil
+}
+
+// ListScenarios lists the scenario library, validating every file.
+func (s *SimulationServer) ListScenarios(
+    ctx context.Context,
+    req *simulationpb.ListScenariosRequest,
+) (*simulationpb.ListScenariosResponse, error) {
+
+    scenarios, err := s.listScenarios()
+    if err != nil {
+        return nil, internalError("list scenarios: %v", err)
+    }
+
+    return &simulationpb.ListScenariosResponse{
+        Scenarios: scenarios,
+    }, nil
+}
+
+// GetCrops returns the state of every crop cell as of the last tick.
+func (s *SimulationServer) GetCrops(
+    ctx context.Context,
+    req *simulationpb.GetCropsRequest,
+) (*simulationpb.GetCropsResponse, error) {
+
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+    if rt.crops == nil {
+        return nil, failedPrecondition(resourceSimulation+"/"+sim.Id.GetValue(), "simulation has no crops")
+    }
+
+    s.mu.RLock()
+    cfg := sim.Config
+    s.mu.RUnlock()
+
+    t := simTime(cfg, rt.lastTick.Load())
+    return &simulationpb.GetCropsResponse{
+        Summary:  rt.crops.Summary(t),
+        CellSize: rt.crops.CellSize(),
+        Cells:    rt.crops.Cells(t),
+    }, nil
+}
+
+func validateEntityCommand(cmd *simulationpb.EntityCommand) error {
+    switch cmd.GetType() {
+    case simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_MOVE_TO:
+        if !isFinite(cmd.GetX()) || !isFinite(cmd.GetY()) {
+            return errors.New("move_to requires finite x and y")
+        }
+    case simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_SET_VELOCITY:
+        if !isFinite(cmd.GetVx()) || !isFinite(cmd.GetVy()) {
+            return errors.New("set_velocity requires finite vx and vy")
+        }
+    case simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_RETURN_TO_BASE,
+        simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_DISABLE,
+        simulationpb.EntityCommandType_ENTITY_COMMAND_TYPE_ENABLE:
+    default:
+        return fmt.Errorf("unknown command type %s", cmd.GetType())
+    }
+    return nil
+}
+
+func isFinite(v float64) bool {
+    return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// SetSimulationSpeed changes how fast a simulation's ticks run relative to
+// tick_rate_ms. A running tick loop picks up the new speed before its next tick.
+func (s *SimulationServer) SetSimulationSpeed(
+    ctx context.Context,
+    req *simulationpb.SetSimulationSpeedRequest,
+) (*simulationpb.SetSimulationSpeedResponse, error) {
+
+    multiplier := req.GetMultiplier()
+    if req.GetFastForward() {
+        multiplier = 1
+    }
+    if multiplier < minSpeedMultiplier || multiplier > maxSpeedMultiplier {
+        return nil, invalidField("multiplier", "multiplier must be between %g and %g", minSpeedMultiplier, maxSpeedMultiplier)
+    }
+
+    sim, rt, err := s.getSimulationAndRuntime(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    if isFinal(sim.Status) {
+        return nil, invalidTransition(sim.Id.GetValue(), sim.Status, "change speed of", "")
+    }
+
+    if err := checkSpeedQuota(sim.Tenant, s.quotas.For(sim.Tenant), sim.Config, multiplier, req.GetFastForward()); err != nil {
+        return nil, err
+    }
+
+    sim.SpeedMultiplier = multiplier
+    sim.FastForward = req.GetFastForward()
+    rt.setSpeed(multiplier, req.GetFastForward())
+
+    detail := fmt.Sprintf("multiplier %g", multiplier)
+    if sim.FastForward {
+        detail = "fast_forward"
+    }
+    s.recordEvent(ctx, sim, actionSetSpeed, sim.Status, sim.Status, detail)
+
+    return &simulationpb.SetSimulationSpeedResponse{
+        Simulation: cloneSimulation(sim),
     }, nil
 }
 
@@ -202,13 +768,55 @@ func (s *SimulationServer) GetSimulation(
     req *simulationpb.GetSimulationRequest,
 ) (*simulationpb.GetSimulationResponse, error) {
 
-    sim, _, err := s.getSimulationAndRuntime(req.GetId())
+    sim, _, err := s.getSimulationAndRuntime(ctx, req.GetId())
     if err != nil {
         return nil, err
     }
 
+    s.mu.RLock()
+    defer s.mu.RUnlock()
     return &simulationpb.GetSimulationResponse{
-        Simulation: sim,
+        Simulation: cloneSimulation(sim),
+    }, nil
+}
+
+// ListSimulations returns the caller's tenant's simulations, oldest first,
+// or only those in the requested status.
+func (s *SimulationServer) ListSimulations(
+    ctx context.Context,
+    req *simulationpb.ListSimulationsRequest,
+) (*simulationpb.ListSimulationsResponse, error) {
+
+    if _, ok := commonpb.SimulationStatus_name[int32(req.GetStatus())]; !ok {
+        return nil, invalidField("status", "unknown status %d", req.GetStatus())
+    }
+    owner, err := callerTenant(ctx)
+    if err != nil {
+        return nil, err
+    }
+
+    s.mu.RLock()
+    out := make([]*simulationpb.Simulation, 0)
+    for _, sim := range s.sims {
+        if sim.Tenant != owner {
+            continue
+        }
+        if req.GetStatus() == commonpb.SimulationStatus_SIMULATION_STATUS_UNSPECIFIED || sim.Status == req.GetStatus() {
+            out = append(out, cloneSimulation(sim))
+        }
+    }
+    s.mu.RUnlock()
+
+    sort.Slice(out, func(i, j int) bool {
+        a, b := out[i].GetCreatedAt().AsTime(), out[j].GetCreatedAt().AsTime()
+        if a.Equal(b) {
+            return out[i].GetId().GetValue() < out[j].GetId().GetValue()
+        }
+        return a.Before(b)
+    })
+
+    return &simulationpb.ListSimulationsResponse{
+        Simulations: out,
     }, nil
 }
 
@@ -220,7 +828,7 @@ func (s *SimulationServer) StreamAggregatedTicks(
 ) error {
 
     simID := req.GetId().GetValue()
-    _, rt, err := s.getSimulationAndRuntime(req.GetId())
+    _, rt, err := s.getSimulationAndRuntime(stream.Context(), req.GetId())
     if err != nil {
         return err
     }
@@ -233,25 +841,39 @@ func (s *SimulationServer) StreamAggregatedTicks(
         close(ch)
     }()
 
+    // Send headers now, so callers waiting on them know every tick from
+    // here on reaches them.
+    if err := stream.SendHeader(metadata.MD{}); err != nil {
+        return err
+    }
+
     for {
         select {
         case <-stream.Context().Done():
             return nil
+        case <-rt.deleted:
+            return notFound(resourceSimulation, simID)
         case tick, ok := <-ch:
             if !ok {
                 return nil
             }
             if err := stream.Send(tick); err != nil {
-                log.Printf("StreamAggregatedTicks send error for %s: %v", simID, err)
+                slog.WarnContext(stream.Context(), "failed to send tick", "simulation_id", simID, "error", err)
                 return err
             }
         }
     }
 }
 
-func (s *SimulationServer) getSimulationAndRuntime(id *commonpb.SimulationId) (*simulationpb.Simulation, *simulationRuntime, error) {
+// getSimulationAndRuntime looks up a simulation of the caller's tenant.
+// Other tenants' simulations are reported as not found.
+func (s *SimulationServer) getSimulationAndRuntime(ctx context.Context, id *commonpb.SimulationId) (*simulationpb.Simulation, *simulationRuntime, error) {
     if id == nil || id.Value == "" {
-        return nil, nil, errors.New("missing simulation id")
+        return nil, nil, invalidField("id", "missing simulation id")
+    }
+    owner, err := callerTenant(ctx)
+    if err != nil {
+        return nil, nil, err
     }
 
     s.mu.RLock()
@@ -259,13 +881,32 @@ func (s *SimulationServer) getSimulationAndRuntime(id *commonpb.SimulationId) (*
     rt, okRt := s.runtimes[id.Value]
     s.mu.RUnlock()
 
-    if !okSim || !okRt {
-        return nil, nil, fmt.Errorf("simulation %s not found", id.Value)
+    if !okSim || !okRt || sim.Tenant != owner {
+        return nil, nil, notFound(resourceSimulation, id.Value)
     }
 
     return sim, rt, nil
 }
 
+func (rt *simulationRuntime) setSpeed(multiplier float64, fastForward bool) {
+    rt.speedMu.Lock()
+    rt.multiplier = multiplier
+    rt.fastForward = fastForward
+    rt.speedMu.Unlock()
+
+    // Wake the tick loop; one pending notification is enough.
+    select {
+    case rt.speedChanged <- struct{}{}:
+    default:
+    }
+}
+
+func (rt *simulationRuntime) currentSpeed() (multiplier float64, fastForward bool) {
+    rt.speedMu.Lock()
+    defer rt.speedMu.Unlock()
+    return rt.multiplier, rt.fastForward
+}
+
 func (rt *simulationRuntime) addSubscriber(ch chan *simulationpb.AggregatedTick) {
     rt.subMu.Lock()
     defer rt.subMu.Unlock()
@@ -278,6 +919,28 @@ func (rt *simulationRuntime) removeSubscriber(ch chan *simulationpb.AggregatedTi
     delete(rt.subscribers, ch)
 }
 
+// publish records a tick's task events and entity positions for the task
+// queue, harvests the crops of completed harvest tasks, then broadcasts it.
+// The first tick to meet a termination condition completes the simulation;
+// ticks still in flight after it are dropped. Every few ticks, and on
+// completion, the state after the tick is kept as a snapshot.
+func (rt *simulationRuntime) publish(ctx context.Context, tick *simulationpb.AggregatedTick) {
+    if rt.completed.Load() {
+        return
+    }
+    completed := rt.tasks.Apply(tick.GetTaskEvents())
+    rt.tasks.Observe(tick.GetTick(), tick.GetEntities())
+    rt.recordHarvests(tick, completed)
+    reason := rt.endReason(tick)
+    if reason != "" && rt.completed.CompareAndSwap(false, true) {
+        rt.onComplete(ctx, reason)
+    }
+    if reason != "" || tick.GetTick()%snapshotInterval(rt.config) == 0 {
+        rt.takeSnapshot(tick)
+    }
+    rt.broadcastTick(tick)
+}
+
 func (rt *simulationRuntime) broadcastTick(tick *simulationpb.AggregatedTick) {
     rt.subMu.RLock()
     defer rt.subMu.RUnlock()
@@ -0,0 +1,103 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// createTestSimulation creates a CREATED simulation of cfg on s.
+func createTestSimulation(t *testing.T, s *SimulationServer, cfg *simulationpb.SimulationConfig) *simulationpb.Simulation {
+	t.Helper()
+	resp, err := s.CreateSimulation(context.Background(), &simulationpb.CreateSimulationRequest{Config: cfg})
+	if err != nil {
+		t.Fatalf("CreateSimulation() error = %v", err)
+	}
+	return resp.GetSimulation()
+}
+
+// badRequestField returns the field of err's BadRequest detail.
+func badRequestField(err error) string {
+	st, _ := status.FromError(err)
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok && len(br.GetFieldViolations()) > 0 {
+			return br.GetFieldViolations()[0].GetField()
+		}
+	}
+	return ""
+}
+
+func TestSubmitTasksInvalid(t *testing.T) {
+	point := func(x, y float64) *simulationpb.Point { return &simulationpb.Point{X: x, Y: y} }
+	harvest := &simulationpb.Task{Type: simulationpb.TaskType_TASK_TYPE_HARVEST, Waypoints: []*simulationpb.Point{point(5, 5)}}
+
+	tests := []struct {
+		name      string
+		tasks     []*simulationpb.Task
+		wantField string
+	}{
+		{
+			name:      "no tasks",
+			wantField: "tasks",
+		},
+		{
+			name: "unknown type",
+			tasks: []*simulationpb.Task{
+				harvest,
+				{Waypoints: []*simulationpb.Point{point(5, 5)}},
+			},
+			wantField: "tasks[1].type",
+		},
+		{
+			name: "harvest with two cells",
+			tasks: []*simulationpb.Task{
+				{Type: simulationpb.TaskType_TASK_TYPE_HARVEST, Waypoints: []*simulationpb.Point{point(5, 5), point(6, 6)}},
+			},
+			wantField: "tasks[0].waypoints",
+		},
+		{
+			name: "negative load",
+			tasks: []*simulationpb.Task{
+				harvest,
+				harvest,
+				{Type: simulationpb.TaskType_TASK_TYPE_TRANSPORT, Waypoints: []*simulationpb.Point{point(5, 5), point(6, 6)}, Load: -1},
+			},
+			wantField: "tasks[2].load",
+		},
+		{
+			name: "unknown eligible type",
+			tasks: []*simulationpb.Task{
+				{Type: simulationpb.TaskType_TASK_TYPE_PATROL, Waypoints: []*simulationpb.Point{point(5, 5)}, EligibleTypes: []simulationpb.EntityType{99}},
+			},
+			wantField: "tasks[0].eligible_types[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSimulationServer()
+			sim := createTestSimulation(t, s, &simulationpb.SimulationConfig{EntityCount: 2, TickRateMs: 100})
+
+			_, err := s.SubmitTasks(context.Background(), &simulationpb.SubmitTasksRequest{Id: sim.GetId(), Tasks: tt.tasks})
+			if code := status.Code(err); code != codes.InvalidArgument {
+				t.Fatalf("SubmitTasks() error = %v, want InvalidArgument", err)
+			}
+			if got := badRequestField(err); got != tt.wantField {
+				t.Errorf("SubmitTasks() field = %q, want %q", got, tt.wantField)
+			}
+
+			listed, err := s.ListTasks(context.Background(), &simulationpb.ListTasksRequest{Id: sim.GetId()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(listed.GetTasks()) != 0 {
+				t.Errorf("%d tasks queued from a refused batch", len(listed.GetTasks()))
+			}
+		})
+	}
+}
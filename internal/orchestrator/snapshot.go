@@ -0,0 +1,112 @@
+package orchestrator
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stevenmed26/AutoFarm/internal/crops"
+	"github.com/stevenmed26/AutoFarm/internal/environment"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+const (
+	// defaultSnapshotInterval is how many ticks apart snapshots are taken
+	// when the config does not say.
+	defaultSnapshotInterval = 20
+
+	// maxSnapshots bounds how many snapshots a simulation keeps; older ones
+	// are dropped.
+	maxSnapshots = 50
+)
+
+// snapshot is the state of a simulation after one tick: enough to start a
+// fork from it. Movement targets and task progress stay on the workers and
+// are not part of it.
+type snapshot struct {
+	tick     uint64
+	entities []*simulationpb.EntityState
+	tasks    []*simulationpb.Task
+	crops    []crops.CellState
+
+	override   *environment.Override
+	nextChange int
+}
+
+// snapshotHistory holds a simulation's most recent snapshots, oldest first.
+type snapshotHistory struct {
+	mu    sync.Mutex
+	snaps []*snapshot
+}
+
+func (h *snapshotHistory) add(s *snapshot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.snaps) == maxSnapshots {
+		h.snaps = append(h.snaps[:0], h.snaps[1:]...)
+	}
+	h.snaps = append(h.snaps, s)
+}
+
+// at returns the latest snapshot taken at or before tick, or the latest of
+// all when tick is 0. It returns nil when there is none.
+func (h *snapshotHistory) at(tick uint64) *snapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i := len(h.snaps) - 1; i >= 0; i-- {
+		if tick == 0 || h.snaps[i].tick <= tick {
+			return h.snaps[i]
+		}
+	}
+	return nil
+}
+
+// snapshotInterval returns how many ticks apart cfg's snapshots are taken.
+func snapshotInterval(cfg *simulationpb.SimulationConfig) uint64 {
+	if n := cfg.GetSnapshotIntervalTicks(); n > 0 {
+		return uint64(n)
+	}
+	return defaultSnapshotInterval
+}
+
+// takeSnapshot records the state after agg. With pipelining the task queue
+// may already hold the next tick's assignments; restoring returns those to
+// pending, so the snapshot stays consistent.
+func (rt *simulationRuntime) takeSnapshot(agg *simulationpb.AggregatedTick) {
+	snap := &snapshot{
+		tick:     agg.GetTick(),
+		entities: agg.GetEntities(),
+		tasks:    rt.tasks.List(simulationpb.TaskState_TASK_STATE_UNSPECIFIED),
+	}
+	if rt.crops != nil {
+		snap.crops = rt.crops.State()
+	}
+	snap.override, snap.nextChange = rt.environment.state()
+	rt.snapshots.add(snap)
+}
+
+// restore starts rt from snap: its entities are spawned from their
+// snapshot states without a task or movement target, and tick numbering
+// continues after the snapshot's tick. Entities that were on their way
+// somewhere stop; the rest keep their heading.
+func (rt *simulationRuntime) restore(snap *snapshot) {
+	states := make([]*simulationpb.EntityState, len(snap.entities))
+	for i, st := range snap.entities {
+		st = proto.Clone(st).(*simulationpb.EntityState)
+		if st.TaskId != 0 || st.Status == "en_route" {
+			st.Vx, st.Vy = 0, 0
+		}
+		st.TaskId = 0
+		states[i] = st
+	}
+
+	rt.entities = restoredEntityRoster(states)
+	rt.tasks.Restore(snap.tasks, states, snap.tick)
+	if rt.crops != nil {
+		rt.crops.Restore(snap.crops)
+	}
+	rt.environment.restore(snap.override, snap.nextChange)
+	rt.lastTick.Store(snap.tick)
+}
@@ -0,0 +1,172 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"google.golang.org/protobuf/proto"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/scenario"
+)
+
+// templateNamePattern is what template names may look like; they appear in
+// REST paths.
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// templateFromRequest builds the template a create or update request asks
+// for, taking the config from the template or from a scenario document. The
+// config is validated on a copy and stored as given, so a template without
+// a seed still gets a new one for every run.
+func templateFromRequest(t *simulationpb.SimulationTemplate, document []byte) (*simulationpb.SimulationTemplate, error) {
+	if t == nil {
+		t = &simulationpb.SimulationTemplate{}
+	}
+	out := &simulationpb.SimulationTemplate{
+		Name:        t.GetName(),
+		Description: t.GetDescription(),
+		Config:      t.GetConfig(),
+	}
+
+	switch {
+	case out.Config != nil && len(document) > 0:
+		return nil, errors.New("set only one of config and scenario_document")
+	case len(document) > 0:
+		doc, err := scenario.Parse(document)
+		if err != nil {
+			return nil, err
+		}
+		if out.Config, err = doc.Config(); err != nil {
+			return nil, err
+		}
+		if out.Name == "" {
+			out.Name = doc.Name
+		}
+		if out.Description == "" {
+			out.Description = doc.Description
+		}
+	case out.Config == nil:
+		return nil, errors.New("missing template config")
+	}
+
+	if !templateNamePattern.MatchString(out.Name) {
+		return nil, fmt.Errorf("invalid template name %q: use up to 64 letters, digits, '.', '_' and '-'", out.Name)
+	}
+	if _, err := validateConfig(proto.Clone(out.Config).(*simulationpb.SimulationConfig)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateTemplate validates and stores a new template of the caller's
+// tenant.
+func (s *SimulationServer) CreateTemplate(
+	ctx context.Context,
+	req *simulationpb.CreateTemplateRequest,
+) (*simulationpb.CreateTemplateResponse, error) {
+
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := templateFromRequest(req.GetTemplate(), req.GetScenarioDocument())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	t.Tenant = owner
+
+	stored, err := s.templates.CreateTemplate(ctx, t)
+	if err != nil {
+		return nil, storeError(err, t.GetName())
+	}
+
+	return &simulationpb.CreateTemplateResponse{
+		Template: stored,
+	}, nil
+}
+
+func (s *SimulationServer) GetTemplate(
+	ctx context.Context,
+	req *simulationpb.GetTemplateRequest,
+) (*simulationpb.GetTemplateResponse, error) {
+
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.templates.GetTemplate(ctx, owner, req.GetName())
+	if err != nil {
+		return nil, storeError(err, req.GetName())
+	}
+
+	return &simulationpb.GetTemplateResponse{
+		Template: t,
+	}, nil
+}
+
+func (s *SimulationServer) ListTemplates(
+	ctx context.Context,
+	req *simulationpb.ListTemplatesRequest,
+) (*simulationpb.ListTemplatesResponse, error) {
+
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := s.templates.ListTemplates(ctx, owner)
+	if err != nil {
+		return nil, internalError("list templates: %v", err)
+	}
+
+	return &simulationpb.ListTemplatesResponse{
+		Templates: templates,
+	}, nil
+}
+
+// UpdateTemplate replaces the description and config of a template.
+// Simulations already created from it keep the config they started with.
+func (s *SimulationServer) UpdateTemplate(
+	ctx context.Context,
+	req *simulationpb.UpdateTemplateRequest,
+) (*simulationpb.UpdateTemplateResponse, error) {
+
+	if req.GetTemplate().GetName() == "" {
+		return nil, invalidField("name", "missing template name")
+	}
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t, err := templateFromRequest(req.GetTemplate(), req.GetScenarioDocument())
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	t.Tenant = owner
+
+	stored, err := s.templates.UpdateTemplate(ctx, t)
+	if err != nil {
+		return nil, storeError(err, t.GetName())
+	}
+
+	return &simulationpb.UpdateTemplateResponse{
+		Template: stored,
+	}, nil
+}
+
+func (s *SimulationServer) DeleteTemplate(
+	ctx context.Context,
+	req *simulationpb.DeleteTemplateRequest,
+) (*simulationpb.DeleteTemplateResponse, error) {
+
+	owner, err := callerTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templates.DeleteTemplate(ctx, owner, req.GetName()); err != nil {
+		return nil, storeError(err, req.GetName())
+	}
+
+	return &simulationpb.DeleteTemplateResponse{}, nil
+}
@@ -0,0 +1,122 @@
+package orchestrator
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/tenant"
+)
+
+// SetQuotas sets the quotas tenants are kept to; nil lifts them all.
+func (s *SimulationServer) SetQuotas(q *tenant.Quotas) {
+	s.mu.Lock()
+	s.quotas = q
+	s.mu.Unlock()
+}
+
+// callerTenant returns the tenant a call acts for.
+func callerTenant(ctx context.Context) (string, error) {
+	t, err := tenant.FromIncomingContext(ctx)
+	if err != nil {
+		return "", statusError(codes.InvalidArgument, reasonInvalidArgument,
+			map[string]string{"metadata": tenant.MetadataKey}, err.Error())
+	}
+	return t, nil
+}
+
+// checkConfigQuota rejects configs the tenant's quota never allows, however
+// little it uses.
+func checkConfigQuota(name string, q tenant.Quota, cfg *simulationpb.SimulationConfig) error {
+	if q.MinTickRateMs > 0 && cfg.GetTickRateMs() < q.MinTickRateMs {
+		return quotaExceeded(codes.PermissionDenied, name, "min_tick_rate_ms",
+			"tick_rate_ms %d is below the minimum of %d for tenant %q", cfg.GetTickRateMs(), q.MinTickRateMs, name)
+	}
+	if q.MaxEntities > 0 && int(cfg.GetEntityCount()) > q.MaxEntities {
+		return quotaExceeded(codes.PermissionDenied, name, "max_entities",
+			"%d entities exceed the quota of %d for tenant %q", cfg.GetEntityCount(), q.MaxEntities, name)
+	}
+	return nil
+}
+
+// checkSpeedQuota rejects speeds that would tick faster than the tenant's
+// minimum tick interval.
+func checkSpeedQuota(name string, q tenant.Quota, cfg *simulationpb.SimulationConfig, multiplier float64, fastForward bool) error {
+	if q.MinTickRateMs == 0 {
+		return nil
+	}
+	if fastForward {
+		return quotaExceeded(codes.PermissionDenied, name, "min_tick_rate_ms",
+			"fast_forward is not allowed for tenant %q, whose minimum tick_rate_ms is %d", name, q.MinTickRateMs)
+	}
+	if interval := float64(cfg.GetTickRateMs()) / multiplier; interval < float64(q.MinTickRateMs) {
+		return quotaExceeded(codes.PermissionDenied, name, "min_tick_rate_ms",
+			"multiplier %g would tick every %.1fms, below the minimum of %dms for tenant %q",
+			multiplier, interval, q.MinTickRateMs, name)
+	}
+	return nil
+}
+
+// checkEntityUsage rejects adding entities beyond the tenant's quota for
+// the entities of its created, running and paused simulations. s.mu must
+// be held.
+func (s *SimulationServer) checkEntityUsage(name string, add int) error {
+	q := s.quotas.For(name)
+	if q.MaxEntities == 0 || add <= 0 {
+		return nil
+	}
+	used := 0
+	for _, sim := range s.sims {
+		if sim.Tenant == name && isActive(sim.Status) {
+			used += int(sim.Config.GetEntityCount())
+		}
+	}
+	if used+add > q.MaxEntities {
+		return quotaExceeded(codes.ResourceExhausted, name, "max_entities",
+			"tenant %q has %d entities in active simulations; %d more would exceed its quota of %d",
+			name, used, add, q.MaxEntities)
+	}
+	return nil
+}
+
+// checkRunningUsage rejects starting another simulation beyond the tenant's
+// quota for running simulations. s.mu must be held.
+func (s *SimulationServer) checkRunningUsage(name string) error {
+	q := s.quotas.For(name)
+	if q.MaxRunningSimulations == 0 {
+		return nil
+	}
+	running := 0
+	for _, sim := range s.sims {
+		if sim.Tenant == name && sim.Status == commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING {
+			running++
+		}
+	}
+	if running >= q.MaxRunningSimulations {
+		return quotaExceeded(codes.ResourceExhausted, name, "max_running_simulations",
+			"tenant %q already runs %d simulations, its quota", name, running)
+	}
+	return nil
+}
+
+// experimentConcurrency caps an experiment's concurrency at the tenant's
+// quota for running simulations.
+func (s *SimulationServer) experimentConcurrency(name string, concurrency int) int {
+	s.mu.RLock()
+	q := s.quotas.For(name)
+	s.mu.RUnlock()
+
+	if q.MaxRunningSimulations > 0 {
+		return min(concurrency, q.MaxRunningSimulations)
+	}
+	return concurrency
+}
+
+// isActive reports whether a simulation in status st holds its entities.
+func isActive(st commonpb.SimulationStatus) bool {
+	return st == commonpb.SimulationStatus_SIMULATION_STATUS_CREATED ||
+		st == commonpb.SimulationStatus_SIMULATION_STATUS_RUNNING ||
+		st == commonpb.SimulationStatus_SIMULATION_STATUS_PAUSED
+}
@@ -0,0 +1,173 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	commonpb "github.com/stevenmed26/AutoFarm/internal/proto/commonpb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/tenant"
+)
+
+// checkQuotaError fails t unless err is nil when wantCode is OK, or else
+// has wantCode and a QUOTA_EXCEEDED ErrorInfo naming tenant and limit.
+func checkQuotaError(t *testing.T, err error, wantCode codes.Code, tenant, limit string) {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != wantCode {
+		t.Fatalf("code %s (%v), want %s", st.Code(), err, wantCode)
+	}
+	if wantCode == codes.OK {
+		return
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.GetReason() != reasonQuotaExceeded || info.GetMetadata()["tenant"] != tenant || info.GetMetadata()["limit"] != limit {
+				t.Errorf("ErrorInfo %v, want %s for tenant %q, limit %q", info, reasonQuotaExceeded, tenant, limit)
+			}
+			return
+		}
+	}
+	t.Errorf("no ErrorInfo in %v", err)
+}
+
+func TestCheckConfigQuota(t *testing.T) {
+	q := tenant.Quota{MaxEntities: 10, MinTickRateMs: 50}
+
+	tests := []struct {
+		name      string
+		q         tenant.Quota
+		cfg       *simulationpb.SimulationConfig
+		wantCode  codes.Code
+		wantLimit string
+	}{
+		{"within", q, &simulationpb.SimulationConfig{EntityCount: 10, TickRateMs: 50}, codes.OK, ""},
+		{"too many entities", q, &simulationpb.SimulationConfig{EntityCount: 11, TickRateMs: 50}, codes.PermissionDenied, "max_entities"},
+		{"ticks too fast", q, &simulationpb.SimulationConfig{EntityCount: 1, TickRateMs: 49}, codes.PermissionDenied, "min_tick_rate_ms"},
+		{"unlimited", tenant.Quota{}, &simulationpb.SimulationConfig{EntityCount: 1000, TickRateMs: 1}, codes.OK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkQuotaError(t, checkConfigQuota("acme", tt.q, tt.cfg), tt.wantCode, "acme", tt.wantLimit)
+		})
+	}
+}
+
+func TestCheckSpeedQuota(t *testing.T) {
+	q := tenant.Quota{MinTickRateMs: 50}
+	cfg := &simulationpb.SimulationConfig{TickRateMs: 100}
+
+	tests := []struct {
+		name        string
+		q           tenant.Quota
+		multiplier  float64
+		fastForward bool
+		wantCode    codes.Code
+	}{
+		{"at the minimum", q, 2, false, codes.OK},
+		{"slower", q, 0.5, false, codes.OK},
+		{"faster", q, 2.5, false, codes.PermissionDenied},
+		{"fast forward", q, 1, true, codes.PermissionDenied},
+		{"unlimited fast forward", tenant.Quota{}, 1, true, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSpeedQuota("acme", tt.q, cfg, tt.multiplier, tt.fastForward)
+			checkQuotaError(t, err, tt.wantCode, "acme", "min_tick_rate_ms")
+		})
+	}
+}
+
+// usageServer returns a server whose quotas allow acme 10 entities and 2
+// running simulations, with sims already created.
+func usageServer(sims ...*simulationpb.Simulation) *SimulationServer {
+	s := NewSimulationServer()
+	s.SetQuotas(&tenant.Quotas{Tenants: map[string]tenant.Quota{
+		"acme": {MaxEntities: 10, MaxRunningSimulations: 2},
+	}})
+	for i, sim := range sims {
+		s.sims[fmt.Sprintf("sim-%d", i)] = sim
+	}
+	return s
+}
+
+// usedBy returns a simulation of name's with entities, in status st.
+func usedBy(name string, st commonpb.SimulationStatus, entities uint32) *simulationpb.Simulation {
+	return &simulationpb.Simulation{
+		Tenant: name,
+		Status: st,
+		Config: &simulationpb.SimulationConfig{EntityCount: entities},
+	}
+}
+
+func TestCheckEntityUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		sims     []*simulationpb.Simulation
+		add      int
+		wantCode codes.Code
+	}{
+		{"none used", nil, 10, codes.OK},
+		{"fills the quota", []*simulationpb.Simulation{usedBy("acme", created, 4), usedBy("acme", paused, 4)}, 2, codes.OK},
+		{"beyond the quota", []*simulationpb.Simulation{usedBy("acme", running, 4), usedBy("acme", paused, 4)}, 3, codes.ResourceExhausted},
+		{"ended simulations", []*simulationpb.Simulation{usedBy("acme", stopped, 8), usedBy("acme", completed, 8)}, 10, codes.OK},
+		{"other tenants", []*simulationpb.Simulation{usedBy("other", running, 8)}, 10, codes.OK},
+		{"retiring", []*simulationpb.Simulation{usedBy("acme", running, 12)}, -1, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := usageServer(tt.sims...)
+			checkQuotaError(t, s.checkEntityUsage("acme", tt.add), tt.wantCode, "acme", "max_entities")
+		})
+	}
+}
+
+func TestCheckRunningUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		sims     []*simulationpb.Simulation
+		wantCode codes.Code
+	}{
+		{"none running", nil, codes.OK},
+		{"room for one more", []*simulationpb.Simulation{usedBy("acme", running, 1), usedBy("acme", paused, 1)}, codes.OK},
+		{"at the quota", []*simulationpb.Simulation{usedBy("acme", running, 1), usedBy("acme", running, 1)}, codes.ResourceExhausted},
+		{"other tenants", []*simulationpb.Simulation{usedBy("other", running, 1), usedBy("other", running, 1)}, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := usageServer(tt.sims...)
+			checkQuotaError(t, s.checkRunningUsage("acme"), tt.wantCode, "acme", "max_running_simulations")
+		})
+	}
+}
+
+// TestCreateSimulationQuota checks CreateSimulation holds the calling
+// tenant to its own quota.
+func TestCreateSimulationQuota(t *testing.T) {
+	s := usageServer()
+	acme := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tenant.MetadataKey, "acme"))
+	create := func(ctx context.Context, entities uint32) error {
+		_, err := s.CreateSimulation(ctx, &simulationpb.CreateSimulationRequest{
+			Config: &simulationpb.SimulationConfig{EntityCount: entities, TickRateMs: 100},
+		})
+		return err
+	}
+
+	if err := create(acme, 6); err != nil {
+		t.Fatal(err)
+	}
+	checkQuotaError(t, create(acme, 11), codes.PermissionDenied, "acme", "max_entities")
+	checkQuotaError(t, create(acme, 5), codes.ResourceExhausted, "acme", "max_entities")
+	// The default tenant has no quota.
+	checkQuotaError(t, create(context.Background(), 50), codes.OK, "", "")
+}
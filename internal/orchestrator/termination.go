@@ -0,0 +1,62 @@
+package orchestrator
+
+import (
+	"errors"
+	"math"
+
+	"github.com/stevenmed26/AutoFarm/internal/crops"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// End reasons recorded on simulations that complete on their own.
+const (
+	endReasonMaxTicks      = "max_ticks"
+	endReasonMaxSimTime    = "max_sim_time"
+	endReasonTasksComplete = "tasks_complete"
+	endReasonTargetYield   = "target_yield"
+)
+
+func validateTermination(cfg *simulationpb.SimulationConfig) error {
+	y := cfg.GetTermination().GetTargetYield()
+	if !(y >= 0) || math.IsInf(y, 0) {
+		return errors.New("termination.target_yield must be a finite number >= 0")
+	}
+	if y > 0 && !crops.Enabled(cfg) {
+		return errors.New("termination.target_yield needs crops")
+	}
+	return nil
+}
+
+// endReason returns why the simulation should complete after agg, or ""
+// while none of its termination conditions is met.
+func (rt *simulationRuntime) endReason(agg *simulationpb.AggregatedTick) string {
+	t := rt.config.GetTermination()
+	switch {
+	case t == nil:
+		return ""
+	case t.GetMaxTicks() > 0 && agg.GetTick() >= t.GetMaxTicks():
+		return endReasonMaxTicks
+	case t.GetMaxSimTimeMs() > 0 && agg.GetSimTimeMs() >= t.GetMaxSimTimeMs():
+		return endReasonMaxSimTime
+	case t.GetTargetYield() > 0 && agg.GetCrops().GetTotalYield() >= t.GetTargetYield():
+		return endReasonTargetYield
+	case t.GetTasksComplete() && rt.tasks.AllCompleted():
+		return endReasonTasksComplete
+	}
+	return ""
+}
+
+// finalTick returns the tick that meets the max_ticks or max_sim_time_ms
+// condition of cfg, whichever comes first, or 0 when neither is set. Unlike
+// the other conditions these are known before the tick runs, so the tick
+// loop dispatches nothing after it.
+func finalTick(cfg *simulationpb.SimulationConfig) uint64 {
+	t := cfg.GetTermination()
+	final := t.GetMaxTicks()
+	if ms, rate := t.GetMaxSimTimeMs(), uint64(cfg.GetTickRateMs()); ms > 0 && rate > 0 {
+		if tick := (ms + rate - 1) / rate; final == 0 || tick < final {
+			final = tick
+		}
+	}
+	return final
+}
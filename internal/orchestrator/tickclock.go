@@ -0,0 +1,123 @@
+package orchestrator
+
+import (
+	"time"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// tickClock decides when each tick is due and how the tick grid reacts to
+// overruns. Tick N is normally due at anchor + (N-anchorTick)*interval, so a
+// tick number always maps to a point in simulated time.
+type tickClock struct {
+	// base values from the config, at 1x speed
+	baseInterval time.Duration
+	baseDeadline time.Duration
+
+	// effective values at the current speed
+	interval time.Duration
+	deadline time.Duration
+
+	policy      simulationpb.TickOverrunPolicy
+	multiplier  float64
+	fastForward bool
+
+	anchor     time.Time
+	anchorTick uint64
+}
+
+// newTickClock builds a real-time clock for cfg whose first tick is firstTick,
+// due one interval after start.
+func newTickClock(cfg *simulationpb.SimulationConfig, firstTick uint64, start time.Time) *tickClock {
+	interval := time.Duration(cfg.GetTickRateMs()) * time.Millisecond
+	deadline := time.Duration(cfg.GetTickDeadlineMs()) * time.Millisecond
+	if deadline <= 0 {
+		deadline = interval
+	}
+
+	policy := cfg.GetOverrunPolicy()
+	if policy == simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_UNSPECIFIED {
+		policy = simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_MARK_LATE
+	}
+
+	return &tickClock{
+		baseInterval: interval,
+		baseDeadline: deadline,
+		interval:     interval,
+		deadline:     deadline,
+		policy:       policy,
+		multiplier:   1,
+		anchor:       start.Add(interval),
+		anchorTick:   firstTick,
+	}
+}
+
+// setSpeed changes the clock speed. The grid is re-anchored so that tick, the
+// next tick to run, is due one new interval after now.
+func (c *tickClock) setSpeed(tick uint64, multiplier float64, fastForward bool, now time.Time) {
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	c.multiplier = multiplier
+	c.fastForward = fastForward
+	c.interval = time.Duration(float64(c.baseInterval) / multiplier)
+	c.deadline = time.Duration(float64(c.baseDeadline) / multiplier)
+	c.anchor = now.Add(c.interval)
+	c.anchorTick = tick
+}
+
+// scheduledAt returns the time tick is due to be dispatched.
+func (c *tickClock) scheduledAt(tick uint64) time.Time {
+	return c.anchor.Add(time.Duration(tick-c.anchorTick) * c.interval)
+}
+
+// window returns when tick is due and when it must complete. Fast-forwarded
+// ticks are due immediately and have no deadline (zero time).
+func (c *tickClock) window(tick uint64, now time.Time) (scheduled, deadline time.Time) {
+	if c.fastForward {
+		return now, time.Time{}
+	}
+	scheduled = c.scheduledAt(tick)
+	return scheduled, scheduled.Add(c.deadline)
+}
+
+// next returns the tick to run after prev has finished at now, along with the
+// number of tick slots the SKIP policy dropped to get there.
+func (c *tickClock) next(prev uint64, now time.Time) (tick uint64, skipped uint64) {
+	tick = prev + 1
+	if c.fastForward {
+		return tick, 0
+	}
+
+	due := c.scheduledAt(tick)
+	if !now.After(due) {
+		return tick, 0
+	}
+
+	switch c.policy {
+	case simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_SKIP:
+		// Jump to the first slot that is still in the future.
+		behind := uint64(now.Sub(due)/c.interval) + 1
+		return tick + behind, behind
+	case simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_STRETCH:
+		// Restart the grid so the next tick gets a full interval.
+		c.anchor = now.Add(c.interval)
+		c.anchorTick = tick
+		return tick, 0
+	default:
+		// MARK_LATE: keep the grid and run the overdue tick right away.
+		return tick, 0
+	}
+}
+
+// isSkip reports whether late results should be dropped instead of broadcast.
+func (c *tickClock) isSkip() bool {
+	return c.policy == simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_SKIP
+}
+
+// simTime returns the simulated time elapsed at the end of tick. Every tick
+// covers tick_rate_ms of simulated time regardless of speed.
+func simTime(cfg *simulationpb.SimulationConfig, tick uint64) time.Duration {
+	return time.Duration(tick) * time.Duration(cfg.GetTickRateMs()) * time.Millisecond
+}
@@ -0,0 +1,171 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+const (
+	skip     = simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_SKIP
+	stretch  = simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_STRETCH
+	markLate = simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_MARK_LATE
+)
+
+func TestTickClockNext(t *testing.T) {
+	// Tick 1 is due at start+100ms with a 100ms deadline; it finishes at
+	// start+finished.
+	start := time.Unix(1_800_000_000, 0)
+	ms := func(n int) time.Duration { return time.Duration(n) * time.Millisecond }
+
+	tests := []struct {
+		name     string
+		policy   simulationpb.TickOverrunPolicy
+		finished time.Duration
+
+		wantTick      uint64
+		wantSkipped   uint64
+		wantScheduled time.Duration
+		wantDeadline  time.Duration
+	}{
+		{"skip on time", skip, ms(150), 2, 0, ms(200), ms(300)},
+		{"stretch on time", stretch, ms(150), 2, 0, ms(200), ms(300)},
+		{"mark late on time", markLate, ms(150), 2, 0, ms(200), ms(300)},
+		{"skip finishing as the next is due", skip, ms(200), 2, 0, ms(200), ms(300)},
+
+		{"skip overrun under a period", skip, ms(250), 3, 1, ms(300), ms(400)},
+		{"stretch overrun under a period", stretch, ms(250), 2, 0, ms(350), ms(450)},
+		{"mark late overrun under a period", markLate, ms(250), 2, 0, ms(200), ms(300)},
+
+		{"skip overrun of several periods", skip, ms(480), 5, 3, ms(500), ms(600)},
+		{"stretch overrun of several periods", stretch, ms(480), 2, 0, ms(580), ms(680)},
+		{"mark late overrun of several periods", markLate, ms(480), 2, 0, ms(200), ms(300)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTickClock(&simulationpb.SimulationConfig{TickRateMs: 100, OverrunPolicy: tt.policy}, 1, start)
+			now := start.Add(tt.finished)
+
+			tick, skipped := c.next(1, now)
+			if tick != tt.wantTick || skipped != tt.wantSkipped {
+				t.Fatalf("next(1) = %d, %d skipped; want %d, %d skipped", tick, skipped, tt.wantTick, tt.wantSkipped)
+			}
+			scheduled, deadline := c.window(tick, now)
+			if got := scheduled.Sub(start); got != tt.wantScheduled {
+				t.Errorf("tick %d due at +%v, want +%v", tick, got, tt.wantScheduled)
+			}
+			if got := deadline.Sub(start); got != tt.wantDeadline {
+				t.Errorf("tick %d deadline at +%v, want +%v", tick, got, tt.wantDeadline)
+			}
+		})
+	}
+}
+
+func TestTickClockFastForward(t *testing.T) {
+	start := time.Unix(1_800_000_000, 0)
+	c := newTickClock(&simulationpb.SimulationConfig{TickRateMs: 100, OverrunPolicy: skip}, 1, start)
+	c.setSpeed(1, 1, true, start)
+
+	now := start.Add(time.Hour)
+	if tick, skipped := c.next(1, now); tick != 2 || skipped != 0 {
+		t.Fatalf("next(1) = %d, %d skipped; want 2, 0 skipped", tick, skipped)
+	}
+	if scheduled, deadline := c.window(2, now); !scheduled.Equal(now) || !deadline.IsZero() {
+		t.Errorf("window(2) = %v, %v; want now and no deadline", scheduled, deadline)
+	}
+}
+
+// TestTickClockRun drives the clock the way the tick loop does, with a fake
+// clock: each tick waits until it is due, takes its duration, and is late
+// if it ends after its deadline. Under SKIP, ticks still running at their
+// deadline are abandoned there.
+func TestTickClockRun(t *testing.T) {
+	ms := func(ns ...int) []time.Duration {
+		out := make([]time.Duration, len(ns))
+		for i, n := range ns {
+			out[i] = time.Duration(n) * time.Millisecond
+		}
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		policy     simulationpb.TickOverrunPolicy
+		deadlineMs uint32
+		durations  []time.Duration
+
+		wantTicks   []uint64
+		wantSkipped uint64
+		wantLate    int
+	}{
+		{
+			name:   "mark late catches up",
+			policy: markLate, durations: ms(50, 250, 50, 50),
+			wantTicks: []uint64{1, 2, 3, 4}, wantLate: 3,
+		},
+		{
+			name:   "stretch restarts the grid",
+			policy: stretch, durations: ms(50, 250, 50, 50),
+			wantTicks: []uint64{1, 2, 3, 4}, wantLate: 1,
+		},
+		{
+			name:   "skip abandons at the deadline",
+			policy: skip, durations: ms(50, 250, 50, 50),
+			wantTicks: []uint64{1, 2, 3, 4}, wantSkipped: 1,
+		},
+		{
+			name:   "skip drops the slots of a long tick",
+			policy: skip, deadlineMs: 350, durations: ms(50, 250, 50, 50),
+			wantTicks: []uint64{1, 2, 5, 6}, wantSkipped: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Unix(1_800_000_000, 0)
+			c := newTickClock(&simulationpb.SimulationConfig{
+				TickRateMs: 100, TickDeadlineMs: tt.deadlineMs, OverrunPolicy: tt.policy,
+			}, 1, start)
+
+			now := start
+			tick := uint64(1)
+			var ticks []uint64
+			var skipped uint64
+			late := 0
+			for _, d := range tt.durations {
+				ticks = append(ticks, tick)
+				scheduled, deadline := c.window(tick, now)
+				if now.Before(scheduled) {
+					now = scheduled
+				}
+				now = now.Add(d)
+				if now.After(deadline) {
+					if c.isSkip() {
+						now = deadline
+						skipped++
+					} else {
+						late++
+					}
+				}
+
+				next, dropped := c.next(tick, now)
+				tick = next
+				skipped += dropped
+			}
+
+			if len(ticks) != len(tt.wantTicks) {
+				t.Fatalf("ran ticks %v, want %v", ticks, tt.wantTicks)
+			}
+			for i := range ticks {
+				if ticks[i] != tt.wantTicks[i] {
+					t.Fatalf("ran ticks %v, want %v", ticks, tt.wantTicks)
+				}
+			}
+			if skipped != tt.wantSkipped || late != tt.wantLate {
+				t.Errorf("%d skipped, %d late; want %d skipped, %d late", skipped, late, tt.wantSkipped, tt.wantLate)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	nodepb "github.com/stevenmed26/AutoFarm/internal/proto/nodepb"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/tracing"
+)
+
+// tracer makes one trace per tick: a root span for the tick, with a child
+// for each worker partition it is dispatched to and for its aggregation and
+// broadcast.
+var tracer = otel.Tracer("github.com/stevenmed26/AutoFarm/internal/orchestrator")
+
+// startTick starts the root span of tick's trace, linked to the span of the
+// call that started the tick loop or step. The returned context keeps the
+// request ID of ctx for logging.
+func startTick(ctx context.Context, simID string, tick uint64) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "tick",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+		trace.WithAttributes(
+			attribute.String("simulation.id", simID),
+			attribute.Int64("simulation.tick", int64(tick))))
+}
+
+// dispatchPartition runs d.Dispatch in a span for req's partition. The
+// worker continues the span's trace from the context req carries.
+func dispatchPartition(
+	ctx context.Context,
+	d *Dispatcher,
+	req *nodepb.WorkerTickRequest,
+	deadline time.Time,
+	abandon bool,
+) (*nodepb.WorkerTickResponse, error) {
+	ctx, span := tracer.Start(ctx, "dispatch partition",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.Int64("partition.index", int64(req.GetPartitionIndex())),
+			attribute.Int64("partition.total", int64(req.GetPartitionTotal())),
+			attribute.Int("entities", len(req.GetEntityIds()))))
+	defer span.End()
+
+	req.TraceContext = tracing.Inject(ctx)
+	resp, err := d.Dispatch(ctx, req, deadline, abandon)
+	switch {
+	case err != nil:
+		markError(span, err)
+	case resp == nil:
+		span.SetAttributes(attribute.Bool("abandoned", true))
+	default:
+		span.SetAttributes(attribute.Float64("compute_ms", resp.GetComputeMs()))
+	}
+	return resp, err
+}
+
+// publishTick aggregates res and publishes it, each in a span of res's
+// tick, then ends the tick's span.
+func (rt *simulationRuntime) publishTick(res tickResult) *simulationpb.AggregatedTick {
+	tickSpan := trace.SpanFromContext(res.ctx)
+	defer tickSpan.End()
+
+	_, span := tracer.Start(res.ctx, "aggregate")
+	agg := aggregateTick(res)
+	span.End()
+
+	ctx, span := tracer.Start(res.ctx, "broadcast")
+	rt.publish(ctx, agg)
+	span.End()
+
+	tickSpan.SetAttributes(
+		attribute.Int("entities", len(agg.GetEntities())),
+		attribute.Bool("late", agg.GetLate()),
+		attribute.Float64("overrun_ms", agg.GetOverrunMs()))
+	return agg
+}
+
+// markError marks span failed with err.
+func markError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -13,6 +13,7 @@ import (
 	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -35,8 +36,23 @@ type WorkerTickRequest struct {
 	PartitionIndex uint32 `protobuf:"varint,3,opt,name=partition_index,json=partitionIndex,proto3" json:"partition_index,omitempty"`
 	PartitionTotal uint32 `protobuf:"varint,4,opt,name=partition_total,json=partitionTotal,proto3" json:"partition_total,omitempty"`
 	// subset of entities owned by this worker
-	EntityIds     []uint64                       `protobuf:"varint,5,rep,packed,name=entity_ids,json=entityIds,proto3" json:"entity_ids,omitempty"`
-	Config        *simulationpb.SimulationConfig `protobuf:"bytes,6,opt,name=config,proto3" json:"config,omitempty"`
+	EntityIds []uint64                       `protobuf:"varint,5,rep,packed,name=entity_ids,json=entityIds,proto3" json:"entity_ids,omitempty"`
+	Config    *simulationpb.SimulationConfig `protobuf:"bytes,6,opt,name=config,proto3" json:"config,omitempty"`
+	// copied from the SimulationTickRequest this partition was cut from
+	ScheduledAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	Deadline    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// commands for entities in this partition, applied before the tick runs
+	Commands []*simulationpb.EntityCommand `protobuf:"bytes,9,rep,name=commands,proto3" json:"commands,omitempty"`
+	// entities to create before the tick runs, and entities to drop
+	Spawns           []*simulationpb.EntitySpawn `protobuf:"bytes,10,rep,name=spawns,proto3" json:"spawns,omitempty"`
+	RetiredEntityIds []uint64                    `protobuf:"varint,11,rep,packed,name=retired_entity_ids,json=retiredEntityIds,proto3" json:"retired_entity_ids,omitempty"`
+	// tasks newly assigned to entities in this partition
+	TaskAssignments []*simulationpb.Task `protobuf:"bytes,12,rep,name=task_assignments,json=taskAssignments,proto3" json:"task_assignments,omitempty"`
+	// time of day and weather for this tick
+	Environment *simulationpb.Environment `protobuf:"bytes,13,opt,name=environment,proto3" json:"environment,omitempty"`
+	// W3C trace context of the orchestrator's span for this partition, so
+	// the worker's span joins the tick's trace
+	TraceContext  map[string]string `protobuf:"bytes,14,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -113,6 +129,62 @@ func (x *WorkerTickRequest) GetConfig() *simulationpb.SimulationConfig {
 	return nil
 }
 
+func (x *WorkerTickRequest) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+func (x *WorkerTickRequest) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *WorkerTickRequest) GetCommands() []*simulationpb.EntityCommand {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *WorkerTickRequest) GetSpawns() []*simulationpb.EntitySpawn {
+	if x != nil {
+		return x.Spawns
+	}
+	return nil
+}
+
+func (x *WorkerTickRequest) GetRetiredEntityIds() []uint64 {
+	if x != nil {
+		return x.RetiredEntityIds
+	}
+	return nil
+}
+
+func (x *WorkerTickRequest) GetTaskAssignments() []*simulationpb.Task {
+	if x != nil {
+		return x.TaskAssignments
+	}
+	return nil
+}
+
+func (x *WorkerTickRequest) GetEnvironment() *simulationpb.Environment {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+func (x *WorkerTickRequest) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
 // Response from worker with updated states for its partition.
 type WorkerTickResponse struct {
 	state        protoimpl.MessageState      `protogen:"open.v1"`
@@ -120,9 +192,14 @@ type WorkerTickResponse struct {
 	Tick         uint64                      `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
 	Entities     []*simulationpb.EntityState `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
 	// worker-local metrics
-	ComputeMs     float64 `protobuf:"fixed64,4,opt,name=compute_ms,json=computeMs,proto3" json:"compute_ms,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	ComputeMs   float64                          `protobuf:"fixed64,4,opt,name=compute_ms,json=computeMs,proto3" json:"compute_ms,omitempty"`
+	CommandAcks []*simulationpb.EntityCommandAck `protobuf:"bytes,5,rep,name=command_acks,json=commandAcks,proto3" json:"command_acks,omitempty"`
+	// tasks completed or failed by entities in this partition
+	TaskEvents []*simulationpb.TaskEvent `protobuf:"bytes,6,rep,name=task_events,json=taskEvents,proto3" json:"task_events,omitempty"`
+	// compute_ms split by phase: "commands", "tasks", "planning", "step"
+	ComputeBreakdownMs map[string]float64 `protobuf:"bytes,7,rep,name=compute_breakdown_ms,json=computeBreakdownMs,proto3" json:"compute_breakdown_ms,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *WorkerTickResponse) Reset() {
@@ -183,12 +260,114 @@ func (x *WorkerTickResponse) GetComputeMs() float64 {
 	return 0
 }
 
+func (x *WorkerTickResponse) GetCommandAcks() []*simulationpb.EntityCommandAck {
+	if x != nil {
+		return x.CommandAcks
+	}
+	return nil
+}
+
+func (x *WorkerTickResponse) GetTaskEvents() []*simulationpb.TaskEvent {
+	if x != nil {
+		return x.TaskEvents
+	}
+	return nil
+}
+
+func (x *WorkerTickResponse) GetComputeBreakdownMs() map[string]float64 {
+	if x != nil {
+		return x.ComputeBreakdownMs
+	}
+	return nil
+}
+
+// Sent once a simulation has ended, so the worker can free its state.
+type ReleaseSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId  *commonpb.SimulationId `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseSimulationRequest) Reset() {
+	*x = ReleaseSimulationRequest{}
+	mi := &file_node_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseSimulationRequest) ProtoMessage() {}
+
+func (x *ReleaseSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseSimulationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_node_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReleaseSimulationRequest) GetSimulationId() *commonpb.SimulationId {
+	if x != nil {
+		return x.SimulationId
+	}
+	return nil
+}
+
+type ReleaseSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseSimulationResponse) Reset() {
+	*x = ReleaseSimulationResponse{}
+	mi := &file_node_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseSimulationResponse) ProtoMessage() {}
+
+func (x *ReleaseSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseSimulationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_node_proto_rawDescGZIP(), []int{3}
+}
+
 var File_node_proto protoreflect.FileDescriptor
 
 const file_node_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"node.proto\x12\rautofarm.node\x1a\x10simulation.proto\x1a\fcommon.proto\"\x9b\x02\n" +
+	"node.proto\x12\rautofarm.node\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x10simulation.proto\x1a\fcommon.proto\"\xde\x06\n" +
 	"\x11WorkerTickRequest\x12B\n" +
 	"\rsimulation_id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\fsimulationId\x12\x12\n" +
 	"\x04tick\x18\x02 \x01(\x04R\x04tick\x12'\n" +
@@ -196,15 +375,38 @@ const file_node_proto_rawDesc = "" +
 	"\x0fpartition_total\x18\x04 \x01(\rR\x0epartitionTotal\x12\x1d\n" +
 	"\n" +
 	"entity_ids\x18\x05 \x03(\x04R\tentityIds\x12=\n" +
-	"\x06config\x18\x06 \x01(\v2%.autofarm.simulation.SimulationConfigR\x06config\"\xc9\x01\n" +
+	"\x06config\x18\x06 \x01(\v2%.autofarm.simulation.SimulationConfigR\x06config\x12=\n" +
+	"\fscheduled_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\x126\n" +
+	"\bdeadline\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12>\n" +
+	"\bcommands\x18\t \x03(\v2\".autofarm.simulation.EntityCommandR\bcommands\x128\n" +
+	"\x06spawns\x18\n" +
+	" \x03(\v2 .autofarm.simulation.EntitySpawnR\x06spawns\x12,\n" +
+	"\x12retired_entity_ids\x18\v \x03(\x04R\x10retiredEntityIds\x12D\n" +
+	"\x10task_assignments\x18\f \x03(\v2\x19.autofarm.simulation.TaskR\x0ftaskAssignments\x12B\n" +
+	"\venvironment\x18\r \x01(\v2 .autofarm.simulation.EnvironmentR\venvironment\x12W\n" +
+	"\rtrace_context\x18\x0e \x03(\v22.autofarm.node.WorkerTickRequest.TraceContextEntryR\ftraceContext\x1a?\n" +
+	"\x11TraceContextEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x88\x04\n" +
 	"\x12WorkerTickResponse\x12B\n" +
 	"\rsimulation_id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\fsimulationId\x12\x12\n" +
 	"\x04tick\x18\x02 \x01(\x04R\x04tick\x12<\n" +
 	"\bentities\x18\x03 \x03(\v2 .autofarm.simulation.EntityStateR\bentities\x12\x1d\n" +
 	"\n" +
-	"compute_ms\x18\x04 \x01(\x01R\tcomputeMs2n\n" +
+	"compute_ms\x18\x04 \x01(\x01R\tcomputeMs\x12H\n" +
+	"\fcommand_acks\x18\x05 \x03(\v2%.autofarm.simulation.EntityCommandAckR\vcommandAcks\x12?\n" +
+	"\vtask_events\x18\x06 \x03(\v2\x1e.autofarm.simulation.TaskEventR\n" +
+	"taskEvents\x12k\n" +
+	"\x14compute_breakdown_ms\x18\a \x03(\v29.autofarm.node.WorkerTickResponse.ComputeBreakdownMsEntryR\x12computeBreakdownMs\x1aE\n" +
+	"\x17ComputeBreakdownMsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"^\n" +
+	"\x18ReleaseSimulationRequest\x12B\n" +
+	"\rsimulation_id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\fsimulationId\"\x1b\n" +
+	"\x19ReleaseSimulationResponse2\xd6\x01\n" +
 	"\x11NodeWorkerService\x12Y\n" +
-	"\x0eRunWorkerTicks\x12 .autofarm.node.WorkerTickRequest\x1a!.autofarm.node.WorkerTickResponse(\x010\x01B7Z5github.com/stevenmed26/AutoFarm/internal/proto/nodepbb\x06proto3"
+	"\x0eRunWorkerTicks\x12 .autofarm.node.WorkerTickRequest\x1a!.autofarm.node.WorkerTickResponse(\x010\x01\x12f\n" +
+	"\x11ReleaseSimulation\x12'.autofarm.node.ReleaseSimulationRequest\x1a(.autofarm.node.ReleaseSimulationResponseB7Z5github.com/stevenmed26/AutoFarm/internal/proto/nodepbb\x06proto3"
 
 var (
 	file_node_proto_rawDescOnce sync.Once
@@ -218,26 +420,50 @@ func file_node_proto_rawDescGZIP() []byte {
 	return file_node_proto_rawDescData
 }
 
-var file_node_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_node_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_node_proto_goTypes = []any{
 	(*WorkerTickRequest)(nil),             // 0: autofarm.node.WorkerTickRequest
 	(*WorkerTickResponse)(nil),            // 1: autofarm.node.WorkerTickResponse
-	(*commonpb.SimulationId)(nil),         // 2: autofarm.common.SimulationId
-	(*simulationpb.SimulationConfig)(nil), // 3: autofarm.simulation.SimulationConfig
-	(*simulationpb.EntityState)(nil),      // 4: autofarm.simulation.EntityState
+	(*ReleaseSimulationRequest)(nil),      // 2: autofarm.node.ReleaseSimulationRequest
+	(*ReleaseSimulationResponse)(nil),     // 3: autofarm.node.ReleaseSimulationResponse
+	nil,                                   // 4: autofarm.node.WorkerTickRequest.TraceContextEntry
+	nil,                                   // 5: autofarm.node.WorkerTickResponse.ComputeBreakdownMsEntry
+	(*commonpb.SimulationId)(nil),         // 6: autofarm.common.SimulationId
+	(*simulationpb.SimulationConfig)(nil), // 7: autofarm.simulation.SimulationConfig
+	(*timestamppb.Timestamp)(nil),         // 8: google.protobuf.Timestamp
+	(*simulationpb.EntityCommand)(nil),    // 9: autofarm.simulation.EntityCommand
+	(*simulationpb.EntitySpawn)(nil),      // 10: autofarm.simulation.EntitySpawn
+	(*simulationpb.Task)(nil),             // 11: autofarm.simulation.Task
+	(*simulationpb.Environment)(nil),      // 12: autofarm.simulation.Environment
+	(*simulationpb.EntityState)(nil),      // 13: autofarm.simulation.EntityState
+	(*simulationpb.EntityCommandAck)(nil), // 14: autofarm.simulation.EntityCommandAck
+	(*simulationpb.TaskEvent)(nil),        // 15: autofarm.simulation.TaskEvent
 }
 var file_node_proto_depIdxs = []int32{
-	2, // 0: autofarm.node.WorkerTickRequest.simulation_id:type_name -> autofarm.common.SimulationId
-	3, // 1: autofarm.node.WorkerTickRequest.config:type_name -> autofarm.simulation.SimulationConfig
-	2, // 2: autofarm.node.WorkerTickResponse.simulation_id:type_name -> autofarm.common.SimulationId
-	4, // 3: autofarm.node.WorkerTickResponse.entities:type_name -> autofarm.simulation.EntityState
-	0, // 4: autofarm.node.NodeWorkerService.RunWorkerTicks:input_type -> autofarm.node.WorkerTickRequest
-	1, // 5: autofarm.node.NodeWorkerService.RunWorkerTicks:output_type -> autofarm.node.WorkerTickResponse
-	5, // [5:6] is the sub-list for method output_type
-	4, // [4:5] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6,  // 0: autofarm.node.WorkerTickRequest.simulation_id:type_name -> autofarm.common.SimulationId
+	7,  // 1: autofarm.node.WorkerTickRequest.config:type_name -> autofarm.simulation.SimulationConfig
+	8,  // 2: autofarm.node.WorkerTickRequest.scheduled_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: autofarm.node.WorkerTickRequest.deadline:type_name -> google.protobuf.Timestamp
+	9,  // 4: autofarm.node.WorkerTickRequest.commands:type_name -> autofarm.simulation.EntityCommand
+	10, // 5: autofarm.node.WorkerTickRequest.spawns:type_name -> autofarm.simulation.EntitySpawn
+	11, // 6: autofarm.node.WorkerTickRequest.task_assignments:type_name -> autofarm.simulation.Task
+	12, // 7: autofarm.node.WorkerTickRequest.environment:type_name -> autofarm.simulation.Environment
+	4,  // 8: autofarm.node.WorkerTickRequest.trace_context:type_name -> autofarm.node.WorkerTickRequest.TraceContextEntry
+	6,  // 9: autofarm.node.WorkerTickResponse.simulation_id:type_name -> autofarm.common.SimulationId
+	13, // 10: autofarm.node.WorkerTickResponse.entities:type_name -> autofarm.simulation.EntityState
+	14, // 11: autofarm.node.WorkerTickResponse.command_acks:type_name -> autofarm.simulation.EntityCommandAck
+	15, // 12: autofarm.node.WorkerTickResponse.task_events:type_name -> autofarm.simulation.TaskEvent
+	5,  // 13: autofarm.node.WorkerTickResponse.compute_breakdown_ms:type_name -> autofarm.node.WorkerTickResponse.ComputeBreakdownMsEntry
+	6,  // 14: autofarm.node.ReleaseSimulationRequest.simulation_id:type_name -> autofarm.common.SimulationId
+	0,  // 15: autofarm.node.NodeWorkerService.RunWorkerTicks:input_type -> autofarm.node.WorkerTickRequest
+	2,  // 16: autofarm.node.NodeWorkerService.ReleaseSimulation:input_type -> autofarm.node.ReleaseSimulationRequest
+	1,  // 17: autofarm.node.NodeWorkerService.RunWorkerTicks:output_type -> autofarm.node.WorkerTickResponse
+	3,  // 18: autofarm.node.NodeWorkerService.ReleaseSimulation:output_type -> autofarm.node.ReleaseSimulationResponse
+	17, // [17:19] is the sub-list for method output_type
+	15, // [15:17] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_node_proto_init() }
@@ -251,7 +477,7 @@ func file_node_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_node_proto_rawDesc), len(file_node_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
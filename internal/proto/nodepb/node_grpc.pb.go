@@ -21,7 +21,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NodeWorkerService_RunWorkerTicks_FullMethodName = "/autofarm.node.NodeWorkerService/RunWorkerTicks"
+	NodeWorkerService_RunWorkerTicks_FullMethodName    = "/autofarm.node.NodeWorkerService/RunWorkerTicks"
+	NodeWorkerService_ReleaseSimulation_FullMethodName = "/autofarm.node.NodeWorkerService/ReleaseSimulation"
 )
 
 // NodeWorkerServiceClient is the client API for NodeWorkerService service.
@@ -33,6 +34,9 @@ type NodeWorkerServiceClient interface {
 	// Bi-directional streaming RPC:
 	// Orchestrator streams WorkerTickRequest messages, worker responds with WorkerTickResponse messages.
 	RunWorkerTicks(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WorkerTickRequest, WorkerTickResponse], error)
+	// Frees the worker's entity states and planner of a simulation that has
+	// ended; none of its ticks are sent after it.
+	ReleaseSimulation(ctx context.Context, in *ReleaseSimulationRequest, opts ...grpc.CallOption) (*ReleaseSimulationResponse, error)
 }
 
 type nodeWorkerServiceClient struct {
@@ -56,6 +60,16 @@ func (c *nodeWorkerServiceClient) RunWorkerTicks(ctx context.Context, opts ...gr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type NodeWorkerService_RunWorkerTicksClient = grpc.BidiStreamingClient[WorkerTickRequest, WorkerTickResponse]
 
+func (c *nodeWorkerServiceClient) ReleaseSimulation(ctx context.Context, in *ReleaseSimulationRequest, opts ...grpc.CallOption) (*ReleaseSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseSimulationResponse)
+	err := c.cc.Invoke(ctx, NodeWorkerService_ReleaseSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeWorkerServiceServer is the server API for NodeWorkerService service.
 // All implementations must embed UnimplementedNodeWorkerServiceServer
 // for forward compatibility.
@@ -65,6 +79,9 @@ type NodeWorkerServiceServer interface {
 	// Bi-directional streaming RPC:
 	// Orchestrator streams WorkerTickRequest messages, worker responds with WorkerTickResponse messages.
 	RunWorkerTicks(grpc.BidiStreamingServer[WorkerTickRequest, WorkerTickResponse]) error
+	// Frees the worker's entity states and planner of a simulation that has
+	// ended; none of its ticks are sent after it.
+	ReleaseSimulation(context.Context, *ReleaseSimulationRequest) (*ReleaseSimulationResponse, error)
 	mustEmbedUnimplementedNodeWorkerServiceServer()
 }
 
@@ -78,6 +95,9 @@ type UnimplementedNodeWorkerServiceServer struct{}
 func (UnimplementedNodeWorkerServiceServer) RunWorkerTicks(grpc.BidiStreamingServer[WorkerTickRequest, WorkerTickResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method RunWorkerTicks not implemented")
 }
+func (UnimplementedNodeWorkerServiceServer) ReleaseSimulation(context.Context, *ReleaseSimulationRequest) (*ReleaseSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseSimulation not implemented")
+}
 func (UnimplementedNodeWorkerServiceServer) mustEmbedUnimplementedNodeWorkerServiceServer() {}
 func (UnimplementedNodeWorkerServiceServer) testEmbeddedByValue()                           {}
 
@@ -106,13 +126,36 @@ func _NodeWorkerService_RunWorkerTicks_Handler(srv interface{}, stream grpc.Serv
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type NodeWorkerService_RunWorkerTicksServer = grpc.BidiStreamingServer[WorkerTickRequest, WorkerTickResponse]
 
+func _NodeWorkerService_ReleaseSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeWorkerServiceServer).ReleaseSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeWorkerService_ReleaseSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeWorkerServiceServer).ReleaseSimulation(ctx, req.(*ReleaseSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NodeWorkerService_ServiceDesc is the grpc.ServiceDesc for NodeWorkerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var NodeWorkerService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "autofarm.node.NodeWorkerService",
 	HandlerType: (*NodeWorkerServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReleaseSimulation",
+			Handler:    _NodeWorkerService_ReleaseSimulation_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "RunWorkerTicks",
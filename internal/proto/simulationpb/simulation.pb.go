@@ -25,31 +25,5536 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// What the orchestrator does when a tick misses its deadline.
+type TickOverrunPolicy int32
+
+const (
+	TickOverrunPolicy_TICK_OVERRUN_POLICY_UNSPECIFIED TickOverrunPolicy = 0 // treated as MARK_LATE
+	TickOverrunPolicy_TICK_OVERRUN_POLICY_MARK_LATE   TickOverrunPolicy = 1 // flag the tick as late, keep the tick grid
+	TickOverrunPolicy_TICK_OVERRUN_POLICY_SKIP        TickOverrunPolicy = 2 // drop the late tick and any missed slots
+	TickOverrunPolicy_TICK_OVERRUN_POLICY_STRETCH     TickOverrunPolicy = 3 // re-anchor the tick grid after the late tick
+)
+
+// Enum value maps for TickOverrunPolicy.
+var (
+	TickOverrunPolicy_name = map[int32]string{
+		0: "TICK_OVERRUN_POLICY_UNSPECIFIED",
+		1: "TICK_OVERRUN_POLICY_MARK_LATE",
+		2: "TICK_OVERRUN_POLICY_SKIP",
+		3: "TICK_OVERRUN_POLICY_STRETCH",
+	}
+	TickOverrunPolicy_value = map[string]int32{
+		"TICK_OVERRUN_POLICY_UNSPECIFIED": 0,
+		"TICK_OVERRUN_POLICY_MARK_LATE":   1,
+		"TICK_OVERRUN_POLICY_SKIP":        2,
+		"TICK_OVERRUN_POLICY_STRETCH":     3,
+	}
+)
+
+func (x TickOverrunPolicy) Enum() *TickOverrunPolicy {
+	p := new(TickOverrunPolicy)
+	*p = x
+	return p
+}
+
+func (x TickOverrunPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TickOverrunPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[0].Descriptor()
+}
+
+func (TickOverrunPolicy) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[0]
+}
+
+func (x TickOverrunPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TickOverrunPolicy.Descriptor instead.
+func (TickOverrunPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{0}
+}
+
+// Kinds of farm robot. UNSPECIFIED is the generic robot.
+type EntityType int32
+
+const (
+	EntityType_ENTITY_TYPE_UNSPECIFIED EntityType = 0
+	EntityType_ENTITY_TYPE_TRACTOR     EntityType = 1
+	EntityType_ENTITY_TYPE_DRONE       EntityType = 2
+	EntityType_ENTITY_TYPE_HARVESTER   EntityType = 3
+)
+
+// Enum value maps for EntityType.
+var (
+	EntityType_name = map[int32]string{
+		0: "ENTITY_TYPE_UNSPECIFIED",
+		1: "ENTITY_TYPE_TRACTOR",
+		2: "ENTITY_TYPE_DRONE",
+		3: "ENTITY_TYPE_HARVESTER",
+	}
+	EntityType_value = map[string]int32{
+		"ENTITY_TYPE_UNSPECIFIED": 0,
+		"ENTITY_TYPE_TRACTOR":     1,
+		"ENTITY_TYPE_DRONE":       2,
+		"ENTITY_TYPE_HARVESTER":   3,
+	}
+)
+
+func (x EntityType) Enum() *EntityType {
+	p := new(EntityType)
+	*p = x
+	return p
+}
+
+func (x EntityType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntityType) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[1].Descriptor()
+}
+
+func (EntityType) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[1]
+}
+
+func (x EntityType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntityType.Descriptor instead.
+func (EntityType) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{1}
+}
+
+// How pending tasks are matched to idle entities.
+type TaskAllocationStrategy int32
+
+const (
+	TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_UNSPECIFIED    TaskAllocationStrategy = 0 // treated as GREEDY_NEAREST
+	TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST TaskAllocationStrategy = 1 // each task, in priority order, takes the nearest entity
+	TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_AUCTION        TaskAllocationStrategy = 2 // contract-net: entities bid, lowest bid wins each round
+	TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_HUNGARIAN      TaskAllocationStrategy = 3 // minimum total cost assignment
+)
+
+// Enum value maps for TaskAllocationStrategy.
+var (
+	TaskAllocationStrategy_name = map[int32]string{
+		0: "TASK_ALLOCATION_STRATEGY_UNSPECIFIED",
+		1: "TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST",
+		2: "TASK_ALLOCATION_STRATEGY_AUCTION",
+		3: "TASK_ALLOCATION_STRATEGY_HUNGARIAN",
+	}
+	TaskAllocationStrategy_value = map[string]int32{
+		"TASK_ALLOCATION_STRATEGY_UNSPECIFIED":    0,
+		"TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST": 1,
+		"TASK_ALLOCATION_STRATEGY_AUCTION":        2,
+		"TASK_ALLOCATION_STRATEGY_HUNGARIAN":      3,
+	}
+)
+
+func (x TaskAllocationStrategy) Enum() *TaskAllocationStrategy {
+	p := new(TaskAllocationStrategy)
+	*p = x
+	return p
+}
+
+func (x TaskAllocationStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskAllocationStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskAllocationStrategy) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[2]
+}
+
+func (x TaskAllocationStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskAllocationStrategy.Descriptor instead.
+func (TaskAllocationStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{2}
+}
+
+// Grid search used to plan ground entity paths.
+type PathAlgorithm int32
+
+const (
+	PathAlgorithm_PATH_ALGORITHM_UNSPECIFIED PathAlgorithm = 0 // treated as JPS
+	PathAlgorithm_PATH_ALGORITHM_JPS         PathAlgorithm = 1 // jump point search
+	PathAlgorithm_PATH_ALGORITHM_ASTAR       PathAlgorithm = 2
+)
+
+// Enum value maps for PathAlgorithm.
+var (
+	PathAlgorithm_name = map[int32]string{
+		0: "PATH_ALGORITHM_UNSPECIFIED",
+		1: "PATH_ALGORITHM_JPS",
+		2: "PATH_ALGORITHM_ASTAR",
+	}
+	PathAlgorithm_value = map[string]int32{
+		"PATH_ALGORITHM_UNSPECIFIED": 0,
+		"PATH_ALGORITHM_JPS":         1,
+		"PATH_ALGORITHM_ASTAR":       2,
+	}
+)
+
+func (x PathAlgorithm) Enum() *PathAlgorithm {
+	p := new(PathAlgorithm)
+	*p = x
+	return p
+}
+
+func (x PathAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PathAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[3].Descriptor()
+}
+
+func (PathAlgorithm) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[3]
+}
+
+func (x PathAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PathAlgorithm.Descriptor instead.
+func (PathAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{3}
+}
+
+type WeatherCondition int32
+
+const (
+	WeatherCondition_WEATHER_CONDITION_UNSPECIFIED WeatherCondition = 0
+	WeatherCondition_WEATHER_CONDITION_CLEAR       WeatherCondition = 1
+	WeatherCondition_WEATHER_CONDITION_CLOUDY      WeatherCondition = 2
+	WeatherCondition_WEATHER_CONDITION_RAIN        WeatherCondition = 3
+	WeatherCondition_WEATHER_CONDITION_STORM       WeatherCondition = 4
+)
+
+// Enum value maps for WeatherCondition.
+var (
+	WeatherCondition_name = map[int32]string{
+		0: "WEATHER_CONDITION_UNSPECIFIED",
+		1: "WEATHER_CONDITION_CLEAR",
+		2: "WEATHER_CONDITION_CLOUDY",
+		3: "WEATHER_CONDITION_RAIN",
+		4: "WEATHER_CONDITION_STORM",
+	}
+	WeatherCondition_value = map[string]int32{
+		"WEATHER_CONDITION_UNSPECIFIED": 0,
+		"WEATHER_CONDITION_CLEAR":       1,
+		"WEATHER_CONDITION_CLOUDY":      2,
+		"WEATHER_CONDITION_RAIN":        3,
+		"WEATHER_CONDITION_STORM":       4,
+	}
+)
+
+func (x WeatherCondition) Enum() *WeatherCondition {
+	p := new(WeatherCondition)
+	*p = x
+	return p
+}
+
+func (x WeatherCondition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WeatherCondition) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[4].Descriptor()
+}
+
+func (WeatherCondition) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[4]
+}
+
+func (x WeatherCondition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WeatherCondition.Descriptor instead.
+func (WeatherCondition) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{4}
+}
+
+type CropStage int32
+
+const (
+	CropStage_CROP_STAGE_UNSPECIFIED CropStage = 0
+	CropStage_CROP_STAGE_SEEDLING    CropStage = 1
+	CropStage_CROP_STAGE_VEGETATIVE  CropStage = 2
+	CropStage_CROP_STAGE_FLOWERING   CropStage = 3
+	CropStage_CROP_STAGE_RIPE        CropStage = 4 // full yield
+	CropStage_CROP_STAGE_OVERRIPE    CropStage = 5 // yield falling
+	CropStage_CROP_STAGE_SPOILED     CropStage = 6 // nothing left to harvest
+	CropStage_CROP_STAGE_HARVESTED   CropStage = 7
+)
+
+// Enum value maps for CropStage.
+var (
+	CropStage_name = map[int32]string{
+		0: "CROP_STAGE_UNSPECIFIED",
+		1: "CROP_STAGE_SEEDLING",
+		2: "CROP_STAGE_VEGETATIVE",
+		3: "CROP_STAGE_FLOWERING",
+		4: "CROP_STAGE_RIPE",
+		5: "CROP_STAGE_OVERRIPE",
+		6: "CROP_STAGE_SPOILED",
+		7: "CROP_STAGE_HARVESTED",
+	}
+	CropStage_value = map[string]int32{
+		"CROP_STAGE_UNSPECIFIED": 0,
+		"CROP_STAGE_SEEDLING":    1,
+		"CROP_STAGE_VEGETATIVE":  2,
+		"CROP_STAGE_FLOWERING":   3,
+		"CROP_STAGE_RIPE":        4,
+		"CROP_STAGE_OVERRIPE":    5,
+		"CROP_STAGE_SPOILED":     6,
+		"CROP_STAGE_HARVESTED":   7,
+	}
+)
+
+func (x CropStage) Enum() *CropStage {
+	p := new(CropStage)
+	*p = x
+	return p
+}
+
+func (x CropStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CropStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[5].Descriptor()
+}
+
+func (CropStage) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[5]
+}
+
+func (x CropStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CropStage.Descriptor instead.
+func (CropStage) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{5}
+}
+
+// How an experiment picks the points of its parameter space.
+type ExperimentSampling int32
+
+const (
+	ExperimentSampling_EXPERIMENT_SAMPLING_UNSPECIFIED ExperimentSampling = 0 // grid
+	ExperimentSampling_EXPERIMENT_SAMPLING_GRID        ExperimentSampling = 1 // every combination of values
+	ExperimentSampling_EXPERIMENT_SAMPLING_RANDOM      ExperimentSampling = 2 // independent uniform samples
+)
+
+// Enum value maps for ExperimentSampling.
+var (
+	ExperimentSampling_name = map[int32]string{
+		0: "EXPERIMENT_SAMPLING_UNSPECIFIED",
+		1: "EXPERIMENT_SAMPLING_GRID",
+		2: "EXPERIMENT_SAMPLING_RANDOM",
+	}
+	ExperimentSampling_value = map[string]int32{
+		"EXPERIMENT_SAMPLING_UNSPECIFIED": 0,
+		"EXPERIMENT_SAMPLING_GRID":        1,
+		"EXPERIMENT_SAMPLING_RANDOM":      2,
+	}
+)
+
+func (x ExperimentSampling) Enum() *ExperimentSampling {
+	p := new(ExperimentSampling)
+	*p = x
+	return p
+}
+
+func (x ExperimentSampling) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExperimentSampling) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[6].Descriptor()
+}
+
+func (ExperimentSampling) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[6]
+}
+
+func (x ExperimentSampling) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExperimentSampling.Descriptor instead.
+func (ExperimentSampling) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{6}
+}
+
+type ExperimentState int32
+
+const (
+	ExperimentState_EXPERIMENT_STATE_UNSPECIFIED ExperimentState = 0
+	ExperimentState_EXPERIMENT_STATE_RUNNING     ExperimentState = 1
+	ExperimentState_EXPERIMENT_STATE_COMPLETED   ExperimentState = 2 // every run finished, some may have failed
+	ExperimentState_EXPERIMENT_STATE_CANCELED    ExperimentState = 3
+)
+
+// Enum value maps for ExperimentState.
+var (
+	ExperimentState_name = map[int32]string{
+		0: "EXPERIMENT_STATE_UNSPECIFIED",
+		1: "EXPERIMENT_STATE_RUNNING",
+		2: "EXPERIMENT_STATE_COMPLETED",
+		3: "EXPERIMENT_STATE_CANCELED",
+	}
+	ExperimentState_value = map[string]int32{
+		"EXPERIMENT_STATE_UNSPECIFIED": 0,
+		"EXPERIMENT_STATE_RUNNING":     1,
+		"EXPERIMENT_STATE_COMPLETED":   2,
+		"EXPERIMENT_STATE_CANCELED":    3,
+	}
+)
+
+func (x ExperimentState) Enum() *ExperimentState {
+	p := new(ExperimentState)
+	*p = x
+	return p
+}
+
+func (x ExperimentState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExperimentState) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[7].Descriptor()
+}
+
+func (ExperimentState) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[7]
+}
+
+func (x ExperimentState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExperimentState.Descriptor instead.
+func (ExperimentState) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{7}
+}
+
+type ExperimentRunState int32
+
+const (
+	ExperimentRunState_EXPERIMENT_RUN_STATE_UNSPECIFIED ExperimentRunState = 0
+	ExperimentRunState_EXPERIMENT_RUN_STATE_QUEUED      ExperimentRunState = 1
+	ExperimentRunState_EXPERIMENT_RUN_STATE_RUNNING     ExperimentRunState = 2
+	ExperimentRunState_EXPERIMENT_RUN_STATE_COMPLETED   ExperimentRunState = 3
+	ExperimentRunState_EXPERIMENT_RUN_STATE_FAILED      ExperimentRunState = 4
+	ExperimentRunState_EXPERIMENT_RUN_STATE_CANCELED    ExperimentRunState = 5
+)
+
+// Enum value maps for ExperimentRunState.
+var (
+	ExperimentRunState_name = map[int32]string{
+		0: "EXPERIMENT_RUN_STATE_UNSPECIFIED",
+		1: "EXPERIMENT_RUN_STATE_QUEUED",
+		2: "EXPERIMENT_RUN_STATE_RUNNING",
+		3: "EXPERIMENT_RUN_STATE_COMPLETED",
+		4: "EXPERIMENT_RUN_STATE_FAILED",
+		5: "EXPERIMENT_RUN_STATE_CANCELED",
+	}
+	ExperimentRunState_value = map[string]int32{
+		"EXPERIMENT_RUN_STATE_UNSPECIFIED": 0,
+		"EXPERIMENT_RUN_STATE_QUEUED":      1,
+		"EXPERIMENT_RUN_STATE_RUNNING":     2,
+		"EXPERIMENT_RUN_STATE_COMPLETED":   3,
+		"EXPERIMENT_RUN_STATE_FAILED":      4,
+		"EXPERIMENT_RUN_STATE_CANCELED":    5,
+	}
+)
+
+func (x ExperimentRunState) Enum() *ExperimentRunState {
+	p := new(ExperimentRunState)
+	*p = x
+	return p
+}
+
+func (x ExperimentRunState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExperimentRunState) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[8].Descriptor()
+}
+
+func (ExperimentRunState) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[8]
+}
+
+func (x ExperimentRunState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExperimentRunState.Descriptor instead.
+func (ExperimentRunState) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{8}
+}
+
+type TaskType int32
+
+const (
+	TaskType_TASK_TYPE_UNSPECIFIED TaskType = 0
+	TaskType_TASK_TYPE_HARVEST     TaskType = 1 // work the field cell at waypoints[0] for work_ticks
+	TaskType_TASK_TYPE_PATROL      TaskType = 2 // visit waypoints in order
+	TaskType_TASK_TYPE_TRANSPORT   TaskType = 3 // carry load from waypoints[0] (pickup) to waypoints[1] (depot)
+)
+
+// Enum value maps for TaskType.
+var (
+	TaskType_name = map[int32]string{
+		0: "TASK_TYPE_UNSPECIFIED",
+		1: "TASK_TYPE_HARVEST",
+		2: "TASK_TYPE_PATROL",
+		3: "TASK_TYPE_TRANSPORT",
+	}
+	TaskType_value = map[string]int32{
+		"TASK_TYPE_UNSPECIFIED": 0,
+		"TASK_TYPE_HARVEST":     1,
+		"TASK_TYPE_PATROL":      2,
+		"TASK_TYPE_TRANSPORT":   3,
+	}
+)
+
+func (x TaskType) Enum() *TaskType {
+	p := new(TaskType)
+	*p = x
+	return p
+}
+
+func (x TaskType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskType) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[9].Descriptor()
+}
+
+func (TaskType) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[9]
+}
+
+func (x TaskType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskType.Descriptor instead.
+func (TaskType) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{9}
+}
+
+type TaskState int32
+
+const (
+	TaskState_TASK_STATE_UNSPECIFIED TaskState = 0
+	TaskState_TASK_STATE_PENDING     TaskState = 1
+	TaskState_TASK_STATE_ASSIGNED    TaskState = 2
+	TaskState_TASK_STATE_COMPLETED   TaskState = 3
+	TaskState_TASK_STATE_FAILED      TaskState = 4 // the task returns to PENDING for reassignment
+)
+
+// Enum value maps for TaskState.
+var (
+	TaskState_name = map[int32]string{
+		0: "TASK_STATE_UNSPECIFIED",
+		1: "TASK_STATE_PENDING",
+		2: "TASK_STATE_ASSIGNED",
+		3: "TASK_STATE_COMPLETED",
+		4: "TASK_STATE_FAILED",
+	}
+	TaskState_value = map[string]int32{
+		"TASK_STATE_UNSPECIFIED": 0,
+		"TASK_STATE_PENDING":     1,
+		"TASK_STATE_ASSIGNED":    2,
+		"TASK_STATE_COMPLETED":   3,
+		"TASK_STATE_FAILED":      4,
+	}
+)
+
+func (x TaskState) Enum() *TaskState {
+	p := new(TaskState)
+	*p = x
+	return p
+}
+
+func (x TaskState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskState) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[10].Descriptor()
+}
+
+func (TaskState) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[10]
+}
+
+func (x TaskState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskState.Descriptor instead.
+func (TaskState) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{10}
+}
+
+// Commands that can be sent to a single entity while a simulation runs.
+type EntityCommandType int32
+
+const (
+	EntityCommandType_ENTITY_COMMAND_TYPE_UNSPECIFIED    EntityCommandType = 0
+	EntityCommandType_ENTITY_COMMAND_TYPE_MOVE_TO        EntityCommandType = 1 // head to (x, y) and stop there
+	EntityCommandType_ENTITY_COMMAND_TYPE_SET_VELOCITY   EntityCommandType = 2 // drive at (vx, vy) until told otherwise
+	EntityCommandType_ENTITY_COMMAND_TYPE_RETURN_TO_BASE EntityCommandType = 3 // head back to the spawn position
+	EntityCommandType_ENTITY_COMMAND_TYPE_DISABLE        EntityCommandType = 4 // stop and ignore movement until enabled
+	EntityCommandType_ENTITY_COMMAND_TYPE_ENABLE         EntityCommandType = 5
+)
+
+// Enum value maps for EntityCommandType.
+var (
+	EntityCommandType_name = map[int32]string{
+		0: "ENTITY_COMMAND_TYPE_UNSPECIFIED",
+		1: "ENTITY_COMMAND_TYPE_MOVE_TO",
+		2: "ENTITY_COMMAND_TYPE_SET_VELOCITY",
+		3: "ENTITY_COMMAND_TYPE_RETURN_TO_BASE",
+		4: "ENTITY_COMMAND_TYPE_DISABLE",
+		5: "ENTITY_COMMAND_TYPE_ENABLE",
+	}
+	EntityCommandType_value = map[string]int32{
+		"ENTITY_COMMAND_TYPE_UNSPECIFIED":    0,
+		"ENTITY_COMMAND_TYPE_MOVE_TO":        1,
+		"ENTITY_COMMAND_TYPE_SET_VELOCITY":   2,
+		"ENTITY_COMMAND_TYPE_RETURN_TO_BASE": 3,
+		"ENTITY_COMMAND_TYPE_DISABLE":        4,
+		"ENTITY_COMMAND_TYPE_ENABLE":         5,
+	}
+)
+
+func (x EntityCommandType) Enum() *EntityCommandType {
+	p := new(EntityCommandType)
+	*p = x
+	return p
+}
+
+func (x EntityCommandType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntityCommandType) Descriptor() protoreflect.EnumDescriptor {
+	return file_simulation_proto_enumTypes[11].Descriptor()
+}
+
+func (EntityCommandType) Type() protoreflect.EnumType {
+	return &file_simulation_proto_enumTypes[11]
+}
+
+func (x EntityCommandType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntityCommandType.Descriptor instead.
+func (EntityCommandType) EnumDescriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{11}
+}
+
+// Physical parameters shared by every entity of a type.
+type EntityTypeParams struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Type                   EntityType             `protobuf:"varint,1,opt,name=type,proto3,enum=autofarm.simulation.EntityType" json:"type,omitempty"`
+	MaxSpeed               float64                `protobuf:"fixed64,2,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`                                            // world units per tick
+	BatteryCapacity        float64                `protobuf:"fixed64,3,opt,name=battery_capacity,json=batteryCapacity,proto3" json:"battery_capacity,omitempty"`                       // energy units
+	DrainRate              float64                `protobuf:"fixed64,4,opt,name=drain_rate,json=drainRate,proto3" json:"drain_rate,omitempty"`                                         // energy units per tick while active
+	PayloadCapacity        float64                `protobuf:"fixed64,5,opt,name=payload_capacity,json=payloadCapacity,proto3" json:"payload_capacity,omitempty"`                       // load units
+	IgnoresGroundObstacles bool                   `protobuf:"varint,6,opt,name=ignores_ground_obstacles,json=ignoresGroundObstacles,proto3" json:"ignores_ground_obstacles,omitempty"` // drones fly over obstacles
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *EntityTypeParams) Reset() {
+	*x = EntityTypeParams{}
+	mi := &file_simulation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityTypeParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityTypeParams) ProtoMessage() {}
+
+func (x *EntityTypeParams) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityTypeParams.ProtoReflect.Descriptor instead.
+func (*EntityTypeParams) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EntityTypeParams) GetType() EntityType {
+	if x != nil {
+		return x.Type
+	}
+	return EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+func (x *EntityTypeParams) GetMaxSpeed() float64 {
+	if x != nil {
+		return x.MaxSpeed
+	}
+	return 0
+}
+
+func (x *EntityTypeParams) GetBatteryCapacity() float64 {
+	if x != nil {
+		return x.BatteryCapacity
+	}
+	return 0
+}
+
+func (x *EntityTypeParams) GetDrainRate() float64 {
+	if x != nil {
+		return x.DrainRate
+	}
+	return 0
+}
+
+func (x *EntityTypeParams) GetPayloadCapacity() float64 {
+	if x != nil {
+		return x.PayloadCapacity
+	}
+	return 0
+}
+
+func (x *EntityTypeParams) GetIgnoresGroundObstacles() bool {
+	if x != nil {
+		return x.IgnoresGroundObstacles
+	}
+	return false
+}
+
+// Starting position of one entity. Placed entities start at rest.
+type EntityPlacement struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	X     float64                `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y     float64                `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	// battery percentage (0-100); 0 means full
+	Battery       float64 `protobuf:"fixed64,3,opt,name=battery,proto3" json:"battery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityPlacement) Reset() {
+	*x = EntityPlacement{}
+	mi := &file_simulation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityPlacement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityPlacement) ProtoMessage() {}
+
+func (x *EntityPlacement) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityPlacement.ProtoReflect.Descriptor instead.
+func (*EntityPlacement) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EntityPlacement) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *EntityPlacement) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *EntityPlacement) GetBattery() float64 {
+	if x != nil {
+		return x.Battery
+	}
+	return 0
+}
+
+// Part of a fleet mix, e.g. 20 tractors.
+type FleetGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  EntityType             `protobuf:"varint,1,opt,name=type,proto3,enum=autofarm.simulation.EntityType" json:"type,omitempty"`
+	Count uint32                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// starting positions for the first entities of the group, in id order;
+	// the rest start at random positions
+	Placements    []*EntityPlacement `protobuf:"bytes,3,rep,name=placements,proto3" json:"placements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FleetGroup) Reset() {
+	*x = FleetGroup{}
+	mi := &file_simulation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FleetGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FleetGroup) ProtoMessage() {}
+
+func (x *FleetGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FleetGroup.ProtoReflect.Descriptor instead.
+func (*FleetGroup) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FleetGroup) GetType() EntityType {
+	if x != nil {
+		return x.Type
+	}
+	return EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+func (x *FleetGroup) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *FleetGroup) GetPlacements() []*EntityPlacement {
+	if x != nil {
+		return x.Placements
+	}
+	return nil
+}
+
+// Axis-aligned rectangle ground entities cannot pass through, e.g. a barn
+// or a pond. Coordinates are world units.
+type Obstacle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinX          float64                `protobuf:"fixed64,1,opt,name=min_x,json=minX,proto3" json:"min_x,omitempty"`
+	MinY          float64                `protobuf:"fixed64,2,opt,name=min_y,json=minY,proto3" json:"min_y,omitempty"`
+	MaxX          float64                `protobuf:"fixed64,3,opt,name=max_x,json=maxX,proto3" json:"max_x,omitempty"`
+	MaxY          float64                `protobuf:"fixed64,4,opt,name=max_y,json=maxY,proto3" json:"max_y,omitempty"`
+	Label         string                 `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Obstacle) Reset() {
+	*x = Obstacle{}
+	mi := &file_simulation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Obstacle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Obstacle) ProtoMessage() {}
+
+func (x *Obstacle) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Obstacle.ProtoReflect.Descriptor instead.
+func (*Obstacle) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Obstacle) GetMinX() float64 {
+	if x != nil {
+		return x.MinX
+	}
+	return 0
+}
+
+func (x *Obstacle) GetMinY() float64 {
+	if x != nil {
+		return x.MinY
+	}
+	return 0
+}
+
+func (x *Obstacle) GetMaxX() float64 {
+	if x != nil {
+		return x.MaxX
+	}
+	return 0
+}
+
+func (x *Obstacle) GetMaxY() float64 {
+	if x != nil {
+		return x.MaxY
+	}
+	return 0
+}
+
+func (x *Obstacle) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// Area of the world that is slower to cross, e.g. mud or a steep slope.
+type TerrainPatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	MinX  float64                `protobuf:"fixed64,1,opt,name=min_x,json=minX,proto3" json:"min_x,omitempty"`
+	MinY  float64                `protobuf:"fixed64,2,opt,name=min_y,json=minY,proto3" json:"min_y,omitempty"`
+	MaxX  float64                `protobuf:"fixed64,3,opt,name=max_x,json=maxX,proto3" json:"max_x,omitempty"`
+	MaxY  float64                `protobuf:"fixed64,4,opt,name=max_y,json=maxY,proto3" json:"max_y,omitempty"`
+	// movement cost multiplier, >= 1; ground entities move 1/cost as fast
+	Cost          float64 `protobuf:"fixed64,5,opt,name=cost,proto3" json:"cost,omitempty"`
+	Label         string  `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerrainPatch) Reset() {
+	*x = TerrainPatch{}
+	mi := &file_simulation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerrainPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerrainPatch) ProtoMessage() {}
+
+func (x *TerrainPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerrainPatch.ProtoReflect.Descriptor instead.
+func (*TerrainPatch) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TerrainPatch) GetMinX() float64 {
+	if x != nil {
+		return x.MinX
+	}
+	return 0
+}
+
+func (x *TerrainPatch) GetMinY() float64 {
+	if x != nil {
+		return x.MinY
+	}
+	return 0
+}
+
+func (x *TerrainPatch) GetMaxX() float64 {
+	if x != nil {
+		return x.MaxX
+	}
+	return 0
+}
+
+func (x *TerrainPatch) GetMaxY() float64 {
+	if x != nil {
+		return x.MaxY
+	}
+	return 0
+}
+
+func (x *TerrainPatch) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *TerrainPatch) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// Static layout of the 100x100 world. Ground entities plan paths on an
+// occupancy grid of cell_size cells; a cell is blocked if any obstacle
+// overlaps it.
+type WorldDefinition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// side of one grid cell in world units; 0 means 1
+	CellSize      float64       `protobuf:"fixed64,1,opt,name=cell_size,json=cellSize,proto3" json:"cell_size,omitempty"`
+	Obstacles     []*Obstacle   `protobuf:"bytes,2,rep,name=obstacles,proto3" json:"obstacles,omitempty"`
+	PathAlgorithm PathAlgorithm `protobuf:"varint,3,opt,name=path_algorithm,json=pathAlgorithm,proto3,enum=autofarm.simulation.PathAlgorithm" json:"path_algorithm,omitempty"`
+	// where patches overlap a cell, the highest cost wins
+	Terrain       []*TerrainPatch `protobuf:"bytes,4,rep,name=terrain,proto3" json:"terrain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorldDefinition) Reset() {
+	*x = WorldDefinition{}
+	mi := &file_simulation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorldDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorldDefinition) ProtoMessage() {}
+
+func (x *WorldDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorldDefinition.ProtoReflect.Descriptor instead.
+func (*WorldDefinition) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WorldDefinition) GetCellSize() float64 {
+	if x != nil {
+		return x.CellSize
+	}
+	return 0
+}
+
+func (x *WorldDefinition) GetObstacles() []*Obstacle {
+	if x != nil {
+		return x.Obstacles
+	}
+	return nil
+}
+
+func (x *WorldDefinition) GetPathAlgorithm() PathAlgorithm {
+	if x != nil {
+		return x.PathAlgorithm
+	}
+	return PathAlgorithm_PATH_ALGORITHM_UNSPECIFIED
+}
+
+func (x *WorldDefinition) GetTerrain() []*TerrainPatch {
+	if x != nil {
+		return x.Terrain
+	}
+	return nil
+}
+
+// Time-of-day settings. Weather needs no settings: it evolves from the
+// simulation seed.
+type EnvironmentConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hour of day at sim time 0; 0 means 6 (dawn)
+	StartHour float64 `protobuf:"fixed64,1,opt,name=start_hour,json=startHour,proto3" json:"start_hour,omitempty"`
+	// simulated milliseconds per simulated day; 0 means 600000 (10 minutes)
+	DayLengthMs   uint64 `protobuf:"varint,2,opt,name=day_length_ms,json=dayLengthMs,proto3" json:"day_length_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnvironmentConfig) Reset() {
+	*x = EnvironmentConfig{}
+	mi := &file_simulation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvironmentConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentConfig) ProtoMessage() {}
+
+func (x *EnvironmentConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentConfig.ProtoReflect.Descriptor instead.
+func (*EnvironmentConfig) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EnvironmentConfig) GetStartHour() float64 {
+	if x != nil {
+		return x.StartHour
+	}
+	return 0
+}
+
+func (x *EnvironmentConfig) GetDayLengthMs() uint64 {
+	if x != nil {
+		return x.DayLengthMs
+	}
+	return 0
+}
+
+type Weather struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// derived from the values below when UNSPECIFIED
+	Condition     WeatherCondition `protobuf:"varint,1,opt,name=condition,proto3,enum=autofarm.simulation.WeatherCondition" json:"condition,omitempty"`
+	CloudCover    float64          `protobuf:"fixed64,2,opt,name=cloud_cover,json=cloudCover,proto3" json:"cloud_cover,omitempty"`          // 0-1
+	RainIntensity float64          `protobuf:"fixed64,3,opt,name=rain_intensity,json=rainIntensity,proto3" json:"rain_intensity,omitempty"` // 0-1
+	WindSpeed     float64          `protobuf:"fixed64,4,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`             // m/s
+	// direction the wind blows towards, degrees counter-clockwise from +x
+	WindDirectionDeg float64 `protobuf:"fixed64,5,opt,name=wind_direction_deg,json=windDirectionDeg,proto3" json:"wind_direction_deg,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Weather) Reset() {
+	*x = Weather{}
+	mi := &file_simulation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Weather) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Weather) ProtoMessage() {}
+
+func (x *Weather) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Weather.ProtoReflect.Descriptor instead.
+func (*Weather) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Weather) GetCondition() WeatherCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return WeatherCondition_WEATHER_CONDITION_UNSPECIFIED
+}
+
+func (x *Weather) GetCloudCover() float64 {
+	if x != nil {
+		return x.CloudCover
+	}
+	return 0
+}
+
+func (x *Weather) GetRainIntensity() float64 {
+	if x != nil {
+		return x.RainIntensity
+	}
+	return 0
+}
+
+func (x *Weather) GetWindSpeed() float64 {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return 0
+}
+
+func (x *Weather) GetWindDirectionDeg() float64 {
+	if x != nil {
+		return x.WindDirectionDeg
+	}
+	return 0
+}
+
+// Conditions during one tick.
+type Environment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TimeOfDayHours float64                `protobuf:"fixed64,1,opt,name=time_of_day_hours,json=timeOfDayHours,proto3" json:"time_of_day_hours,omitempty"` // [0, 24)
+	Day            uint64                 `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`                                                  // simulated days since the start, from 0
+	// 0 (night) to 1 (clear midday)
+	LightLevel float64 `protobuf:"fixed64,3,opt,name=light_level,json=lightLevel,proto3" json:"light_level,omitempty"`
+	// too dark for sensor-based tasks
+	Dark    bool     `protobuf:"varint,4,opt,name=dark,proto3" json:"dark,omitempty"`
+	Weather *Weather `protobuf:"bytes,5,opt,name=weather,proto3" json:"weather,omitempty"`
+	// weather or time of day set through SetEnvironment
+	Overridden    bool `protobuf:"varint,6,opt,name=overridden,proto3" json:"overridden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Environment) Reset() {
+	*x = Environment{}
+	mi := &file_simulation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Environment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Environment) ProtoMessage() {}
+
+func (x *Environment) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Environment.ProtoReflect.Descriptor instead.
+func (*Environment) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Environment) GetTimeOfDayHours() float64 {
+	if x != nil {
+		return x.TimeOfDayHours
+	}
+	return 0
+}
+
+func (x *Environment) GetDay() uint64 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *Environment) GetLightLevel() float64 {
+	if x != nil {
+		return x.LightLevel
+	}
+	return 0
+}
+
+func (x *Environment) GetDark() bool {
+	if x != nil {
+		return x.Dark
+	}
+	return false
+}
+
+func (x *Environment) GetWeather() *Weather {
+	if x != nil {
+		return x.Weather
+	}
+	return nil
+}
+
+func (x *Environment) GetOverridden() bool {
+	if x != nil {
+		return x.Overridden
+	}
+	return false
+}
+
+// A scripted change to the weather and/or time of day, in force from at_ms
+// of simulated time until the next change.
+type EnvironmentChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	AtMs  uint64                 `protobuf:"varint,1,opt,name=at_ms,json=atMs,proto3" json:"at_ms,omitempty"`
+	// replaces the seeded weather when set
+	Weather *Weather `protobuf:"bytes,2,opt,name=weather,proto3" json:"weather,omitempty"`
+	// freezes the clock at time_of_day_hours when set
+	SetTimeOfDay   bool    `protobuf:"varint,3,opt,name=set_time_of_day,json=setTimeOfDay,proto3" json:"set_time_of_day,omitempty"`
+	TimeOfDayHours float64 `protobuf:"fixed64,4,opt,name=time_of_day_hours,json=timeOfDayHours,proto3" json:"time_of_day_hours,omitempty"`
+	// return to the seeded model
+	Clear         bool `protobuf:"varint,5,opt,name=clear,proto3" json:"clear,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnvironmentChange) Reset() {
+	*x = EnvironmentChange{}
+	mi := &file_simulation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvironmentChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentChange) ProtoMessage() {}
+
+func (x *EnvironmentChange) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentChange.ProtoReflect.Descriptor instead.
+func (*EnvironmentChange) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EnvironmentChange) GetAtMs() uint64 {
+	if x != nil {
+		return x.AtMs
+	}
+	return 0
+}
+
+func (x *EnvironmentChange) GetWeather() *Weather {
+	if x != nil {
+		return x.Weather
+	}
+	return nil
+}
+
+func (x *EnvironmentChange) GetSetTimeOfDay() bool {
+	if x != nil {
+		return x.SetTimeOfDay
+	}
+	return false
+}
+
+func (x *EnvironmentChange) GetTimeOfDayHours() float64 {
+	if x != nil {
+		return x.TimeOfDayHours
+	}
+	return 0
+}
+
+func (x *EnvironmentChange) GetClear() bool {
+	if x != nil {
+		return x.Clear
+	}
+	return false
+}
+
+// When a simulation completes on its own. Zero fields are not checked; the
+// first condition met ends the run.
+type Termination struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	MaxTicks     uint64                 `protobuf:"varint,1,opt,name=max_ticks,json=maxTicks,proto3" json:"max_ticks,omitempty"`
+	MaxSimTimeMs uint64                 `protobuf:"varint,2,opt,name=max_sim_time_ms,json=maxSimTimeMs,proto3" json:"max_sim_time_ms,omitempty"`
+	// every task is completed (only checked once tasks have been queued)
+	TasksComplete bool `protobuf:"varint,3,opt,name=tasks_complete,json=tasksComplete,proto3" json:"tasks_complete,omitempty"`
+	// total crop yield reached
+	TargetYield   float64 `protobuf:"fixed64,4,opt,name=target_yield,json=targetYield,proto3" json:"target_yield,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Termination) Reset() {
+	*x = Termination{}
+	mi := &file_simulation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Termination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Termination) ProtoMessage() {}
+
+func (x *Termination) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Termination.ProtoReflect.Descriptor instead.
+func (*Termination) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Termination) GetMaxTicks() uint64 {
+	if x != nil {
+		return x.MaxTicks
+	}
+	return 0
+}
+
+func (x *Termination) GetMaxSimTimeMs() uint64 {
+	if x != nil {
+		return x.MaxSimTimeMs
+	}
+	return 0
+}
+
+func (x *Termination) GetTasksComplete() bool {
+	if x != nil {
+		return x.TasksComplete
+	}
+	return false
+}
+
+func (x *Termination) GetTargetYield() float64 {
+	if x != nil {
+		return x.TargetYield
+	}
+	return 0
+}
+
+// Area planted with crops.
+type CropPatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinX          float64                `protobuf:"fixed64,1,opt,name=min_x,json=minX,proto3" json:"min_x,omitempty"`
+	MinY          float64                `protobuf:"fixed64,2,opt,name=min_y,json=minY,proto3" json:"min_y,omitempty"`
+	MaxX          float64                `protobuf:"fixed64,3,opt,name=max_x,json=maxX,proto3" json:"max_x,omitempty"`
+	MaxY          float64                `protobuf:"fixed64,4,opt,name=max_y,json=maxY,proto3" json:"max_y,omitempty"`
+	Label         string                 `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CropPatch) Reset() {
+	*x = CropPatch{}
+	mi := &file_simulation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CropPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CropPatch) ProtoMessage() {}
+
+func (x *CropPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CropPatch.ProtoReflect.Descriptor instead.
+func (*CropPatch) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CropPatch) GetMinX() float64 {
+	if x != nil {
+		return x.MinX
+	}
+	return 0
+}
+
+func (x *CropPatch) GetMinY() float64 {
+	if x != nil {
+		return x.MinY
+	}
+	return 0
+}
+
+func (x *CropPatch) GetMaxX() float64 {
+	if x != nil {
+		return x.MaxX
+	}
+	return 0
+}
+
+func (x *CropPatch) GetMaxY() float64 {
+	if x != nil {
+		return x.MaxY
+	}
+	return 0
+}
+
+func (x *CropPatch) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// Crop growth settings. Durations are in simulated days (see
+// EnvironmentConfig.day_length_ms); zero fields use the defaults.
+type CropConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// planted areas; empty plants the whole world when scenario_type is
+	// "harvest"
+	Fields []*CropPatch `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	// side of one crop cell in world units; 0 means 5
+	CellSize float64 `protobuf:"fixed64,2,opt,name=cell_size,json=cellSize,proto3" json:"cell_size,omitempty"`
+	// days from planting until ripe; 0 means 2
+	DaysToRipe float64 `protobuf:"fixed64,3,opt,name=days_to_ripe,json=daysToRipe,proto3" json:"days_to_ripe,omitempty"`
+	// days a cell stays at full yield once ripe; 0 means 1
+	RipeDays float64 `protobuf:"fixed64,4,opt,name=ripe_days,json=ripeDays,proto3" json:"ripe_days,omitempty"`
+	// days over which an overripe cell's yield falls to nothing; 0 means 1
+	SpoilDays float64 `protobuf:"fixed64,5,opt,name=spoil_days,json=spoilDays,proto3" json:"spoil_days,omitempty"`
+	// yield of one cell harvested while ripe; 0 means 100
+	YieldPerCell float64 `protobuf:"fixed64,6,opt,name=yield_per_cell,json=yieldPerCell,proto3" json:"yield_per_cell,omitempty"`
+	// don't queue harvest tasks for cells as they ripen
+	ManualHarvest bool `protobuf:"varint,7,opt,name=manual_harvest,json=manualHarvest,proto3" json:"manual_harvest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CropConfig) Reset() {
+	*x = CropConfig{}
+	mi := &file_simulation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CropConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CropConfig) ProtoMessage() {}
+
+func (x *CropConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CropConfig.ProtoReflect.Descriptor instead.
+func (*CropConfig) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CropConfig) GetFields() []*CropPatch {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *CropConfig) GetCellSize() float64 {
+	if x != nil {
+		return x.CellSize
+	}
+	return 0
+}
+
+func (x *CropConfig) GetDaysToRipe() float64 {
+	if x != nil {
+		return x.DaysToRipe
+	}
+	return 0
+}
+
+func (x *CropConfig) GetRipeDays() float64 {
+	if x != nil {
+		return x.RipeDays
+	}
+	return 0
+}
+
+func (x *CropConfig) GetSpoilDays() float64 {
+	if x != nil {
+		return x.SpoilDays
+	}
+	return 0
+}
+
+func (x *CropConfig) GetYieldPerCell() float64 {
+	if x != nil {
+		return x.YieldPerCell
+	}
+	return 0
+}
+
+func (x *CropConfig) GetManualHarvest() bool {
+	if x != nil {
+		return x.ManualHarvest
+	}
+	return false
+}
+
+type CropCell struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Col   uint32                 `protobuf:"varint,1,opt,name=col,proto3" json:"col,omitempty"`
+	Row   uint32                 `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	// centre of the cell
+	X     float64   `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y     float64   `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	Stage CropStage `protobuf:"varint,5,opt,name=stage,proto3,enum=autofarm.simulation.CropStage" json:"stage,omitempty"`
+	// what was harvested, or what harvesting now would yield
+	Yield         float64 `protobuf:"fixed64,6,opt,name=yield,proto3" json:"yield,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CropCell) Reset() {
+	*x = CropCell{}
+	mi := &file_simulation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CropCell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CropCell) ProtoMessage() {}
+
+func (x *CropCell) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CropCell.ProtoReflect.Descriptor instead.
+func (*CropCell) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CropCell) GetCol() uint32 {
+	if x != nil {
+		return x.Col
+	}
+	return 0
+}
+
+func (x *CropCell) GetRow() uint32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *CropCell) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *CropCell) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *CropCell) GetStage() CropStage {
+	if x != nil {
+		return x.Stage
+	}
+	return CropStage_CROP_STAGE_UNSPECIFIED
+}
+
+func (x *CropCell) GetYield() float64 {
+	if x != nil {
+		return x.Yield
+	}
+	return 0
+}
+
+// Crop totals after a tick.
+type CropSummary struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TotalYield     float64                `protobuf:"fixed64,1,opt,name=total_yield,json=totalYield,proto3" json:"total_yield,omitempty"`
+	PlantedCells   uint32                 `protobuf:"varint,2,opt,name=planted_cells,json=plantedCells,proto3" json:"planted_cells,omitempty"`
+	HarvestedCells uint32                 `protobuf:"varint,3,opt,name=harvested_cells,json=harvestedCells,proto3" json:"harvested_cells,omitempty"`
+	RipeCells      uint32                 `protobuf:"varint,4,opt,name=ripe_cells,json=ripeCells,proto3" json:"ripe_cells,omitempty"`
+	SpoiledCells   uint32                 `protobuf:"varint,5,opt,name=spoiled_cells,json=spoiledCells,proto3" json:"spoiled_cells,omitempty"`
+	// neither harvested nor spoiled
+	HarvestableCells uint32 `protobuf:"varint,6,opt,name=harvestable_cells,json=harvestableCells,proto3" json:"harvestable_cells,omitempty"`
+	// area of the harvestable cells, in world units squared
+	HarvestableArea float64 `protobuf:"fixed64,7,opt,name=harvestable_area,json=harvestableArea,proto3" json:"harvestable_area,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CropSummary) Reset() {
+	*x = CropSummary{}
+	mi := &file_simulation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CropSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CropSummary) ProtoMessage() {}
+
+func (x *CropSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CropSummary.ProtoReflect.Descriptor instead.
+func (*CropSummary) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CropSummary) GetTotalYield() float64 {
+	if x != nil {
+		return x.TotalYield
+	}
+	return 0
+}
+
+func (x *CropSummary) GetPlantedCells() uint32 {
+	if x != nil {
+		return x.PlantedCells
+	}
+	return 0
+}
+
+func (x *CropSummary) GetHarvestedCells() uint32 {
+	if x != nil {
+		return x.HarvestedCells
+	}
+	return 0
+}
+
+func (x *CropSummary) GetRipeCells() uint32 {
+	if x != nil {
+		return x.RipeCells
+	}
+	return 0
+}
+
+func (x *CropSummary) GetSpoiledCells() uint32 {
+	if x != nil {
+		return x.SpoiledCells
+	}
+	return 0
+}
+
+func (x *CropSummary) GetHarvestableCells() uint32 {
+	if x != nil {
+		return x.HarvestableCells
+	}
+	return 0
+}
+
+func (x *CropSummary) GetHarvestableArea() float64 {
+	if x != nil {
+		return x.HarvestableArea
+	}
+	return 0
+}
+
 type SimulationConfig struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	EntityCount  uint32                 `protobuf:"varint,2,opt,name=entity_count,json=entityCount,proto3" json:"entity_count,omitempty"`   // number of robots/agents
+	TickRateMs   uint32                 `protobuf:"varint,3,opt,name=tick_rate_ms,json=tickRateMs,proto3" json:"tick_rate_ms,omitempty"`    // tick interval in milliseconds
+	ScenarioType string                 `protobuf:"bytes,4,opt,name=scenario_type,json=scenarioType,proto3" json:"scenario_type,omitempty"` // e.g. "harvest", "patrol", etc.
+	// per-tick deadline measured from the scheduled time; 0 means tick_rate_ms
+	TickDeadlineMs uint32            `protobuf:"varint,5,opt,name=tick_deadline_ms,json=tickDeadlineMs,proto3" json:"tick_deadline_ms,omitempty"`
+	OverrunPolicy  TickOverrunPolicy `protobuf:"varint,6,opt,name=overrun_policy,json=overrunPolicy,proto3,enum=autofarm.simulation.TickOverrunPolicy" json:"overrun_policy,omitempty"`
+	// number of received ticks allowed to wait for aggregation/broadcast while
+	// the next tick is already dispatched; 0 runs ticks synchronously
+	PipelineDepth uint32 `protobuf:"varint,7,opt,name=pipeline_depth,json=pipelineDepth,proto3" json:"pipeline_depth,omitempty"`
+	// fleet mix; entity ids are assigned in group order and entity_count is
+	// the sum of the group counts. Empty means entity_count generic robots.
+	Fleet []*FleetGroup `protobuf:"bytes,8,rep,name=fleet,proto3" json:"fleet,omitempty"`
+	// per-type parameters; the orchestrator fills in defaults for every type
+	// in the fleet that is not listed
+	EntityTypes    []*EntityTypeParams    `protobuf:"bytes,9,rep,name=entity_types,json=entityTypes,proto3" json:"entity_types,omitempty"`
+	TaskAllocation TaskAllocationStrategy `protobuf:"varint,10,opt,name=task_allocation,json=taskAllocation,proto3,enum=autofarm.simulation.TaskAllocationStrategy" json:"task_allocation,omitempty"`
+	World          *WorldDefinition       `protobuf:"bytes,11,opt,name=world,proto3" json:"world,omitempty"`
+	// drives weather; 0 picks a random seed at creation, which is then stored
+	// here so the run can be reproduced
+	Seed        uint64             `protobuf:"varint,12,opt,name=seed,proto3" json:"seed,omitempty"`
+	Environment *EnvironmentConfig `protobuf:"bytes,13,opt,name=environment,proto3" json:"environment,omitempty"`
+	Crops       *CropConfig        `protobuf:"bytes,14,opt,name=crops,proto3" json:"crops,omitempty"`
+	// tasks queued when the simulation is created
+	Tasks []*Task `protobuf:"bytes,15,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// weather and time-of-day changes, in at_ms order
+	WeatherScript []*EnvironmentChange `protobuf:"bytes,16,rep,name=weather_script,json=weatherScript,proto3" json:"weather_script,omitempty"`
+	Termination   *Termination         `protobuf:"bytes,17,opt,name=termination,proto3" json:"termination,omitempty"`
+	// how often a running simulation keeps a snapshot of its state for
+	// forking; 0 means every 20 ticks
+	SnapshotIntervalTicks uint32 `protobuf:"varint,18,opt,name=snapshot_interval_ticks,json=snapshotIntervalTicks,proto3" json:"snapshot_interval_ticks,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *SimulationConfig) Reset() {
+	*x = SimulationConfig{}
+	mi := &file_simulation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationConfig) ProtoMessage() {}
+
+func (x *SimulationConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationConfig.ProtoReflect.Descriptor instead.
+func (*SimulationConfig) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SimulationConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SimulationConfig) GetEntityCount() uint32 {
+	if x != nil {
+		return x.EntityCount
+	}
+	return 0
+}
+
+func (x *SimulationConfig) GetTickRateMs() uint32 {
+	if x != nil {
+		return x.TickRateMs
+	}
+	return 0
+}
+
+func (x *SimulationConfig) GetScenarioType() string {
+	if x != nil {
+		return x.ScenarioType
+	}
+	return ""
+}
+
+func (x *SimulationConfig) GetTickDeadlineMs() uint32 {
+	if x != nil {
+		return x.TickDeadlineMs
+	}
+	return 0
+}
+
+func (x *SimulationConfig) GetOverrunPolicy() TickOverrunPolicy {
+	if x != nil {
+		return x.OverrunPolicy
+	}
+	return TickOverrunPolicy_TICK_OVERRUN_POLICY_UNSPECIFIED
+}
+
+func (x *SimulationConfig) GetPipelineDepth() uint32 {
+	if x != nil {
+		return x.PipelineDepth
+	}
+	return 0
+}
+
+func (x *SimulationConfig) GetFleet() []*FleetGroup {
+	if x != nil {
+		return x.Fleet
+	}
+	return nil
+}
+
+func (x *SimulationConfig) GetEntityTypes() []*EntityTypeParams {
+	if x != nil {
+		return x.EntityTypes
+	}
+	return nil
+}
+
+func (x *SimulationConfig) GetTaskAllocation() TaskAllocationStrategy {
+	if x != nil {
+		return x.TaskAllocation
+	}
+	return TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+func (x *SimulationConfig) GetWorld() *WorldDefinition {
+	if x != nil {
+		return x.World
+	}
+	return nil
+}
+
+func (x *SimulationConfig) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *SimulationConfig) GetEnvironment() *EnvironmentConfig {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+func (x *SimulationConfig) GetCrops() *CropConfig {
+	if x != nil {
+		return x.Crops
+	}
+	return nil
+}
+
+func (x *SimulationConfig) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *SimulationConfig) GetWeatherScript() []*EnvironmentChange {
+	if x != nil {
+		return x.WeatherScript
+	}
+	return nil
+}
+
+func (x *SimulationConfig) GetTermination() *Termination {
+	if x != nil {
+		return x.Termination
+	}
+	return nil
+}
+
+func (x *SimulationConfig) GetSnapshotIntervalTicks() uint32 {
+	if x != nil {
+		return x.SnapshotIntervalTicks
+	}
+	return 0
+}
+
+type Simulation struct {
+	state     protoimpl.MessageState    `protogen:"open.v1"`
+	Id        *commonpb.SimulationId    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Config    *SimulationConfig         `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	Status    commonpb.SimulationStatus `protobuf:"varint,3,opt,name=status,proto3,enum=autofarm.common.SimulationStatus" json:"status,omitempty"`
+	CreatedAt *timestamppb.Timestamp    `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt *timestamppb.Timestamp    `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt   *timestamppb.Timestamp    `protobuf:"bytes,6,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	// wall-clock speed relative to tick_rate_ms (1 = real time)
+	SpeedMultiplier float64 `protobuf:"fixed64,7,opt,name=speed_multiplier,json=speedMultiplier,proto3" json:"speed_multiplier,omitempty"`
+	// run ticks back-to-back, ignoring tick_rate_ms and speed_multiplier
+	FastForward bool `protobuf:"varint,8,opt,name=fast_forward,json=fastForward,proto3" json:"fast_forward,omitempty"`
+	// why a COMPLETED simulation ended, e.g. "max_ticks"
+	EndReason string `protobuf:"bytes,9,opt,name=end_reason,json=endReason,proto3" json:"end_reason,omitempty"`
+	// set on clones and forks: the simulation this one was created from, and
+	// for forks the tick of the snapshot it started from
+	SourceSimulationId string `protobuf:"bytes,10,opt,name=source_simulation_id,json=sourceSimulationId,proto3" json:"source_simulation_id,omitempty"`
+	ForkedFromTick     uint64 `protobuf:"varint,11,opt,name=forked_from_tick,json=forkedFromTick,proto3" json:"forked_from_tick,omitempty"`
+	// set when created from a template
+	TemplateName string `protobuf:"bytes,12,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	// set on the runs of an experiment
+	ExperimentId string `protobuf:"bytes,13,opt,name=experiment_id,json=experimentId,proto3" json:"experiment_id,omitempty"`
+	// the tenant (project) that owns the simulation; only its calls see it
+	Tenant        string `protobuf:"bytes,14,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Simulation) Reset() {
+	*x = Simulation{}
+	mi := &file_simulation_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Simulation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Simulation) ProtoMessage() {}
+
+func (x *Simulation) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Simulation.ProtoReflect.Descriptor instead.
+func (*Simulation) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Simulation) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Simulation) GetConfig() *SimulationConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Simulation) GetStatus() commonpb.SimulationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return commonpb.SimulationStatus(0)
+}
+
+func (x *Simulation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Simulation) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Simulation) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *Simulation) GetSpeedMultiplier() float64 {
+	if x != nil {
+		return x.SpeedMultiplier
+	}
+	return 0
+}
+
+func (x *Simulation) GetFastForward() bool {
+	if x != nil {
+		return x.FastForward
+	}
+	return false
+}
+
+func (x *Simulation) GetEndReason() string {
+	if x != nil {
+		return x.EndReason
+	}
+	return ""
+}
+
+func (x *Simulation) GetSourceSimulationId() string {
+	if x != nil {
+		return x.SourceSimulationId
+	}
+	return ""
+}
+
+func (x *Simulation) GetForkedFromTick() uint64 {
+	if x != nil {
+		return x.ForkedFromTick
+	}
+	return 0
+}
+
+func (x *Simulation) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *Simulation) GetExperimentId() string {
+	if x != nil {
+		return x.ExperimentId
+	}
+	return ""
+}
+
+func (x *Simulation) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// Changes applied to a config taken from a template, scenario or another
+// simulation. Zero fields keep the original value.
+type ConfigOverrides struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// seed, or pick a new random one with randomize_seed
+	Seed          uint64 `protobuf:"varint,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	RandomizeSeed bool   `protobuf:"varint,3,opt,name=randomize_seed,json=randomizeSeed,proto3" json:"randomize_seed,omitempty"`
+	// resizes a config with at most one fleet group
+	EntityCount uint32 `protobuf:"varint,4,opt,name=entity_count,json=entityCount,proto3" json:"entity_count,omitempty"`
+	// replaces the fleet mix
+	Fleet          []*FleetGroup          `protobuf:"bytes,5,rep,name=fleet,proto3" json:"fleet,omitempty"`
+	TickRateMs     uint32                 `protobuf:"varint,6,opt,name=tick_rate_ms,json=tickRateMs,proto3" json:"tick_rate_ms,omitempty"`
+	ScenarioType   string                 `protobuf:"bytes,7,opt,name=scenario_type,json=scenarioType,proto3" json:"scenario_type,omitempty"`
+	TaskAllocation TaskAllocationStrategy `protobuf:"varint,8,opt,name=task_allocation,json=taskAllocation,proto3,enum=autofarm.simulation.TaskAllocationStrategy" json:"task_allocation,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConfigOverrides) Reset() {
+	*x = ConfigOverrides{}
+	mi := &file_simulation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigOverrides) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigOverrides) ProtoMessage() {}
+
+func (x *ConfigOverrides) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigOverrides.ProtoReflect.Descriptor instead.
+func (*ConfigOverrides) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConfigOverrides) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConfigOverrides) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *ConfigOverrides) GetRandomizeSeed() bool {
+	if x != nil {
+		return x.RandomizeSeed
+	}
+	return false
+}
+
+func (x *ConfigOverrides) GetEntityCount() uint32 {
+	if x != nil {
+		return x.EntityCount
+	}
+	return 0
+}
+
+func (x *ConfigOverrides) GetFleet() []*FleetGroup {
+	if x != nil {
+		return x.Fleet
+	}
+	return nil
+}
+
+func (x *ConfigOverrides) GetTickRateMs() uint32 {
+	if x != nil {
+		return x.TickRateMs
+	}
+	return 0
+}
+
+func (x *ConfigOverrides) GetScenarioType() string {
+	if x != nil {
+		return x.ScenarioType
+	}
+	return ""
+}
+
+func (x *ConfigOverrides) GetTaskAllocation() TaskAllocationStrategy {
+	if x != nil {
+		return x.TaskAllocation
+	}
+	return TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_UNSPECIFIED
+}
+
+// Request to create a simulation (from API to Orchestrator). Exactly one of
+// config, scenario_document and scenario_name is set.
+type CreateSimulationRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Config *SimulationConfig      `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	// scenario document in YAML or JSON
+	ScenarioDocument []byte `protobuf:"bytes,2,opt,name=scenario_document,json=scenarioDocument,proto3" json:"scenario_document,omitempty"`
+	// name of a scenario file in the orchestrator's scenario library
+	ScenarioName string `protobuf:"bytes,3,opt,name=scenario_name,json=scenarioName,proto3" json:"scenario_name,omitempty"`
+	// name of a stored template
+	TemplateName  string           `protobuf:"bytes,4,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Overrides     *ConfigOverrides `protobuf:"bytes,5,opt,name=overrides,proto3" json:"overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSimulationRequest) Reset() {
+	*x = CreateSimulationRequest{}
+	mi := &file_simulation_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSimulationRequest) ProtoMessage() {}
+
+func (x *CreateSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSimulationRequest.ProtoReflect.Descriptor instead.
+func (*CreateSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateSimulationRequest) GetConfig() *SimulationConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CreateSimulationRequest) GetScenarioDocument() []byte {
+	if x != nil {
+		return x.ScenarioDocument
+	}
+	return nil
+}
+
+func (x *CreateSimulationRequest) GetScenarioName() string {
+	if x != nil {
+		return x.ScenarioName
+	}
+	return ""
+}
+
+func (x *CreateSimulationRequest) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *CreateSimulationRequest) GetOverrides() *ConfigOverrides {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+// Creates a simulation from another one's config, or forks it: the new
+// simulation starts from a snapshot of the source's state.
+type CloneSimulationRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Overrides *ConfigOverrides       `protobuf:"bytes,2,opt,name=overrides,proto3" json:"overrides,omitempty"`
+	// start from the latest snapshot at or before fork_tick; only name and
+	// seed may be overridden
+	Fork          bool   `protobuf:"varint,3,opt,name=fork,proto3" json:"fork,omitempty"`
+	ForkTick      uint64 `protobuf:"varint,4,opt,name=fork_tick,json=forkTick,proto3" json:"fork_tick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneSimulationRequest) Reset() {
+	*x = CloneSimulationRequest{}
+	mi := &file_simulation_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneSimulationRequest) ProtoMessage() {}
+
+func (x *CloneSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneSimulationRequest.ProtoReflect.Descriptor instead.
+func (*CloneSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CloneSimulationRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *CloneSimulationRequest) GetOverrides() *ConfigOverrides {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+func (x *CloneSimulationRequest) GetFork() bool {
+	if x != nil {
+		return x.Fork
+	}
+	return false
+}
+
+func (x *CloneSimulationRequest) GetForkTick() uint64 {
+	if x != nil {
+		return x.ForkTick
+	}
+	return 0
+}
+
+type CloneSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloneSimulationResponse) Reset() {
+	*x = CloneSimulationResponse{}
+	mi := &file_simulation_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneSimulationResponse) ProtoMessage() {}
+
+func (x *CloneSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneSimulationResponse.ProtoReflect.Descriptor instead.
+func (*CloneSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CloneSimulationResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+// A named, reusable simulation config.
+type SimulationTemplate struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Config      *SimulationConfig      `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// the tenant that owns the template; names are unique per tenant
+	Tenant        string `protobuf:"bytes,6,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulationTemplate) Reset() {
+	*x = SimulationTemplate{}
+	mi := &file_simulation_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulationTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulationTemplate) ProtoMessage() {}
+
+func (x *SimulationTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulationTemplate.ProtoReflect.Descriptor instead.
+func (*SimulationTemplate) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SimulationTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SimulationTemplate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SimulationTemplate) GetConfig() *SimulationConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *SimulationTemplate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SimulationTemplate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *SimulationTemplate) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// Creates a template. The config may come as a scenario document instead,
+// whose name and description are used when the template leaves them empty.
+type CreateTemplateRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Template         *SimulationTemplate    `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	ScenarioDocument []byte                 `protobuf:"bytes,2,opt,name=scenario_document,json=scenarioDocument,proto3" json:"scenario_document,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateTemplateRequest) Reset() {
+	*x = CreateTemplateRequest{}
+	mi := &file_simulation_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateRequest) ProtoMessage() {}
+
+func (x *CreateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateTemplateRequest) GetTemplate() *SimulationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *CreateTemplateRequest) GetScenarioDocument() []byte {
+	if x != nil {
+		return x.ScenarioDocument
+	}
+	return nil
+}
+
+type CreateTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *SimulationTemplate    `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTemplateResponse) Reset() {
+	*x = CreateTemplateResponse{}
+	mi := &file_simulation_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateResponse) ProtoMessage() {}
+
+func (x *CreateTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateTemplateResponse) GetTemplate() *SimulationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type GetTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateRequest) Reset() {
+	*x = GetTemplateRequest{}
+	mi := &file_simulation_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateRequest) ProtoMessage() {}
+
+func (x *GetTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *SimulationTemplate    `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateResponse) Reset() {
+	*x = GetTemplateResponse{}
+	mi := &file_simulation_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateResponse) ProtoMessage() {}
+
+func (x *GetTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateResponse.ProtoReflect.Descriptor instead.
+func (*GetTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTemplateResponse) GetTemplate() *SimulationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_simulation_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{26}
+}
+
+type ListTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*SimulationTemplate  `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	mi := &file_simulation_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*SimulationTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// Replaces the description and config of an existing template.
+type UpdateTemplateRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Template         *SimulationTemplate    `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	ScenarioDocument []byte                 `protobuf:"bytes,2,opt,name=scenario_document,json=scenarioDocument,proto3" json:"scenario_document,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateTemplateRequest) Reset() {
+	*x = UpdateTemplateRequest{}
+	mi := &file_simulation_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpdateTemplateRequest) GetTemplate() *SimulationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *UpdateTemplateRequest) GetScenarioDocument() []byte {
+	if x != nil {
+		return x.ScenarioDocument
+	}
+	return nil
+}
+
+type UpdateTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *SimulationTemplate    `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTemplateResponse) Reset() {
+	*x = UpdateTemplateResponse{}
+	mi := &file_simulation_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTemplateResponse) ProtoMessage() {}
+
+func (x *UpdateTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTemplateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateTemplateResponse) GetTemplate() *SimulationTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type DeleteTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateRequest) Reset() {
+	*x = DeleteTemplateRequest{}
+	mi := &file_simulation_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateResponse) Reset() {
+	*x = DeleteTemplateResponse{}
+	mi := &file_simulation_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{31}
+}
+
+type CreateSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSimulationResponse) Reset() {
+	*x = CreateSimulationResponse{}
+	mi := &file_simulation_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSimulationResponse) ProtoMessage() {}
+
+func (x *CreateSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSimulationResponse.ProtoReflect.Descriptor instead.
+func (*CreateSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateSimulationResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+// Lifecycle control
+type StartSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSimulationRequest) Reset() {
+	*x = StartSimulationRequest{}
+	mi := &file_simulation_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSimulationRequest) ProtoMessage() {}
+
+func (x *StartSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSimulationRequest.ProtoReflect.Descriptor instead.
+func (*StartSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *StartSimulationRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type StartSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartSimulationResponse) Reset() {
+	*x = StartSimulationResponse{}
+	mi := &file_simulation_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartSimulationResponse) ProtoMessage() {}
+
+func (x *StartSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartSimulationResponse.ProtoReflect.Descriptor instead.
+func (*StartSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StartSimulationResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+type StreamAggregatedTicksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamAggregatedTicksRequest) Reset() {
+	*x = StreamAggregatedTicksRequest{}
+	mi := &file_simulation_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamAggregatedTicksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAggregatedTicksRequest) ProtoMessage() {}
+
+func (x *StreamAggregatedTicksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAggregatedTicksRequest.ProtoReflect.Descriptor instead.
+func (*StreamAggregatedTicksRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *StreamAggregatedTicksRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type PauseSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseSimulationRequest) Reset() {
+	*x = PauseSimulationRequest{}
+	mi := &file_simulation_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseSimulationRequest) ProtoMessage() {}
+
+func (x *PauseSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseSimulationRequest.ProtoReflect.Descriptor instead.
+func (*PauseSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PauseSimulationRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type PauseSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseSimulationResponse) Reset() {
+	*x = PauseSimulationResponse{}
+	mi := &file_simulation_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseSimulationResponse) ProtoMessage() {}
+
+func (x *PauseSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseSimulationResponse.ProtoReflect.Descriptor instead.
+func (*PauseSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PauseSimulationResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+type StopSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopSimulationRequest) Reset() {
+	*x = StopSimulationRequest{}
+	mi := &file_simulation_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopSimulationRequest) ProtoMessage() {}
+
+func (x *StopSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopSimulationRequest.ProtoReflect.Descriptor instead.
+func (*StopSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StopSimulationRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type StopSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopSimulationResponse) Reset() {
+	*x = StopSimulationResponse{}
+	mi := &file_simulation_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopSimulationResponse) ProtoMessage() {}
+
+func (x *StopSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopSimulationResponse.ProtoReflect.Descriptor instead.
+func (*StopSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StopSimulationResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+// DeleteSimulationRequest stops the simulation if it is still active and
+// forgets it; streams of its ticks end.
+type DeleteSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSimulationRequest) Reset() {
+	*x = DeleteSimulationRequest{}
+	mi := &file_simulation_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSimulationRequest) ProtoMessage() {}
+
+func (x *DeleteSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSimulationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteSimulationRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type DeleteSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSimulationResponse) Reset() {
+	*x = DeleteSimulationResponse{}
+	mi := &file_simulation_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSimulationResponse) ProtoMessage() {}
+
+func (x *DeleteSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSimulationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{41}
+}
+
+type SetSimulationSpeedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// e.g. 0.5, 2, 10; ignored when fast_forward is set
+	Multiplier    float64 `protobuf:"fixed64,2,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	FastForward   bool    `protobuf:"varint,3,opt,name=fast_forward,json=fastForward,proto3" json:"fast_forward,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSimulationSpeedRequest) Reset() {
+	*x = SetSimulationSpeedRequest{}
+	mi := &file_simulation_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSimulationSpeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSimulationSpeedRequest) ProtoMessage() {}
+
+func (x *SetSimulationSpeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSimulationSpeedRequest.ProtoReflect.Descriptor instead.
+func (*SetSimulationSpeedRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetSimulationSpeedRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SetSimulationSpeedRequest) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *SetSimulationSpeedRequest) GetFastForward() bool {
+	if x != nil {
+		return x.FastForward
+	}
+	return false
+}
+
+type SetSimulationSpeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSimulationSpeedResponse) Reset() {
+	*x = SetSimulationSpeedResponse{}
+	mi := &file_simulation_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSimulationSpeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSimulationSpeedResponse) ProtoMessage() {}
+
+func (x *SetSimulationSpeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSimulationSpeedResponse.ProtoReflect.Descriptor instead.
+func (*SetSimulationSpeedResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetSimulationSpeedResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+type StepSimulationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// number of ticks to advance; 0 means 1
+	Ticks         uint32 `protobuf:"varint,2,opt,name=ticks,proto3" json:"ticks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepSimulationRequest) Reset() {
+	*x = StepSimulationRequest{}
+	mi := &file_simulation_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepSimulationRequest) ProtoMessage() {}
+
+func (x *StepSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepSimulationRequest.ProtoReflect.Descriptor instead.
+func (*StepSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *StepSimulationRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *StepSimulationRequest) GetTicks() uint32 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+type StepSimulationResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Simulation *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	// one aggregate per stepped tick, in order
+	Ticks         []*AggregatedTick `protobuf:"bytes,2,rep,name=ticks,proto3" json:"ticks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepSimulationResponse) Reset() {
+	*x = StepSimulationResponse{}
+	mi := &file_simulation_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepSimulationResponse) ProtoMessage() {}
+
+func (x *StepSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepSimulationResponse.ProtoReflect.Descriptor instead.
+func (*StepSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *StepSimulationResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+func (x *StepSimulationResponse) GetTicks() []*AggregatedTick {
+	if x != nil {
+		return x.Ticks
+	}
+	return nil
+}
+
+// Overrides the simulation's weather and/or time of day for what-if
+// testing, until cleared.
+type SetEnvironmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// replaces the seeded weather when set
+	Weather *Weather `protobuf:"bytes,2,opt,name=weather,proto3" json:"weather,omitempty"`
+	// freezes the clock at time_of_day_hours when set
+	SetTimeOfDay   bool    `protobuf:"varint,3,opt,name=set_time_of_day,json=setTimeOfDay,proto3" json:"set_time_of_day,omitempty"`
+	TimeOfDayHours float64 `protobuf:"fixed64,4,opt,name=time_of_day_hours,json=timeOfDayHours,proto3" json:"time_of_day_hours,omitempty"`
+	// drop all overrides and return to the seeded model
+	Clear         bool `protobuf:"varint,5,opt,name=clear,proto3" json:"clear,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEnvironmentRequest) Reset() {
+	*x = SetEnvironmentRequest{}
+	mi := &file_simulation_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEnvironmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEnvironmentRequest) ProtoMessage() {}
+
+func (x *SetEnvironmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEnvironmentRequest.ProtoReflect.Descriptor instead.
+func (*SetEnvironmentRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SetEnvironmentRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SetEnvironmentRequest) GetWeather() *Weather {
+	if x != nil {
+		return x.Weather
+	}
+	return nil
+}
+
+func (x *SetEnvironmentRequest) GetSetTimeOfDay() bool {
+	if x != nil {
+		return x.SetTimeOfDay
+	}
+	return false
+}
+
+func (x *SetEnvironmentRequest) GetTimeOfDayHours() float64 {
+	if x != nil {
+		return x.TimeOfDayHours
+	}
+	return 0
+}
+
+func (x *SetEnvironmentRequest) GetClear() bool {
+	if x != nil {
+		return x.Clear
+	}
+	return false
+}
+
+type SetEnvironmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// conditions for the next tick with the override applied
+	Environment   *Environment `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetEnvironmentResponse) Reset() {
+	*x = SetEnvironmentResponse{}
+	mi := &file_simulation_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetEnvironmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetEnvironmentResponse) ProtoMessage() {}
+
+func (x *SetEnvironmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetEnvironmentResponse.ProtoReflect.Descriptor instead.
+func (*SetEnvironmentResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SetEnvironmentResponse) GetEnvironment() *Environment {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+type GetCropsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCropsRequest) Reset() {
+	*x = GetCropsRequest{}
+	mi := &file_simulation_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCropsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCropsRequest) ProtoMessage() {}
+
+func (x *GetCropsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCropsRequest.ProtoReflect.Descriptor instead.
+func (*GetCropsRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetCropsRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type GetCropsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       *CropSummary           `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	CellSize      float64                `protobuf:"fixed64,2,opt,name=cell_size,json=cellSize,proto3" json:"cell_size,omitempty"`
+	Cells         []*CropCell            `protobuf:"bytes,3,rep,name=cells,proto3" json:"cells,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCropsResponse) Reset() {
+	*x = GetCropsResponse{}
+	mi := &file_simulation_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCropsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCropsResponse) ProtoMessage() {}
+
+func (x *GetCropsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCropsResponse.ProtoReflect.Descriptor instead.
+func (*GetCropsResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetCropsResponse) GetSummary() *CropSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *GetCropsResponse) GetCellSize() float64 {
+	if x != nil {
+		return x.CellSize
+	}
+	return 0
+}
+
+func (x *GetCropsResponse) GetCells() []*CropCell {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+type ListScenariosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScenariosRequest) Reset() {
+	*x = ListScenariosRequest{}
+	mi := &file_simulation_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScenariosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScenariosRequest) ProtoMessage() {}
+
+func (x *ListScenariosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScenariosRequest.ProtoReflect.Descriptor instead.
+func (*ListScenariosRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{50}
+}
+
+// A scenario file in the orchestrator's library.
+type ScenarioInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// set when the file does not validate
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScenarioInfo) Reset() {
+	*x = ScenarioInfo{}
+	mi := &file_simulation_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScenarioInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScenarioInfo) ProtoMessage() {}
+
+func (x *ScenarioInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScenarioInfo.ProtoReflect.Descriptor instead.
+func (*ScenarioInfo) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ScenarioInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScenarioInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ScenarioInfo) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListScenariosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scenarios     []*ScenarioInfo        `protobuf:"bytes,1,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScenariosResponse) Reset() {
+	*x = ListScenariosResponse{}
+	mi := &file_simulation_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScenariosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScenariosResponse) ProtoMessage() {}
+
+func (x *ListScenariosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScenariosResponse.ProtoReflect.Descriptor instead.
+func (*ListScenariosResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListScenariosResponse) GetScenarios() []*ScenarioInfo {
+	if x != nil {
+		return x.Scenarios
+	}
+	return nil
+}
+
+// One parameter an experiment varies.
+type ExperimentParameter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "entities", "fleet.<type>.count", "entity_types.<type>.<field>" (max_speed,
+	// battery_capacity, drain_rate, payload_capacity), "seed",
+	// "crops.days_to_ripe", "crops.yield_per_cell", "environment.start_hour"
+	// or "task_allocation"
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the values to take; without them, a grid steps from min to max and
+	// random sampling draws from [min, max]
+	Values []float64 `protobuf:"fixed64,2,rep,packed,name=values,proto3" json:"values,omitempty"`
+	Min    float64   `protobuf:"fixed64,3,opt,name=min,proto3" json:"min,omitempty"`
+	Max    float64   `protobuf:"fixed64,4,opt,name=max,proto3" json:"max,omitempty"`
+	Step   float64   `protobuf:"fixed64,5,opt,name=step,proto3" json:"step,omitempty"`
+	// the values of task_allocation, e.g. "auction"
+	Choices       []string `protobuf:"bytes,6,rep,name=choices,proto3" json:"choices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExperimentParameter) Reset() {
+	*x = ExperimentParameter{}
+	mi := &file_simulation_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExperimentParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentParameter) ProtoMessage() {}
+
+func (x *ExperimentParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentParameter.ProtoReflect.Descriptor instead.
+func (*ExperimentParameter) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ExperimentParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExperimentParameter) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ExperimentParameter) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *ExperimentParameter) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *ExperimentParameter) GetStep() float64 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *ExperimentParameter) GetChoices() []string {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+type ExperimentSpec struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the config every run starts from, given directly or by template name;
+	// it needs termination.max_ticks or termination.max_sim_time_ms so every
+	// run ends
+	BaseConfig   *SimulationConfig      `protobuf:"bytes,2,opt,name=base_config,json=baseConfig,proto3" json:"base_config,omitempty"`
+	BaseTemplate string                 `protobuf:"bytes,3,opt,name=base_template,json=baseTemplate,proto3" json:"base_template,omitempty"`
+	Parameters   []*ExperimentParameter `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Sampling     ExperimentSampling     `protobuf:"varint,5,opt,name=sampling,proto3,enum=autofarm.simulation.ExperimentSampling" json:"sampling,omitempty"`
+	Samples      uint32                 `protobuf:"varint,6,opt,name=samples,proto3" json:"samples,omitempty"`                               // random sampling only
+	SamplingSeed uint64                 `protobuf:"varint,7,opt,name=sampling_seed,json=samplingSeed,proto3" json:"sampling_seed,omitempty"` // random sampling only; 0 picks one
+	// how many runs may execute at once; 0 means 2
+	Concurrency   uint32 `protobuf:"varint,8,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExperimentSpec) Reset() {
+	*x = ExperimentSpec{}
+	mi := &file_simulation_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExperimentSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentSpec) ProtoMessage() {}
+
+func (x *ExperimentSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentSpec.ProtoReflect.Descriptor instead.
+func (*ExperimentSpec) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ExperimentSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExperimentSpec) GetBaseConfig() *SimulationConfig {
+	if x != nil {
+		return x.BaseConfig
+	}
+	return nil
+}
+
+func (x *ExperimentSpec) GetBaseTemplate() string {
+	if x != nil {
+		return x.BaseTemplate
+	}
+	return ""
+}
+
+func (x *ExperimentSpec) GetParameters() []*ExperimentParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *ExperimentSpec) GetSampling() ExperimentSampling {
+	if x != nil {
+		return x.Sampling
+	}
+	return ExperimentSampling_EXPERIMENT_SAMPLING_UNSPECIFIED
+}
+
+func (x *ExperimentSpec) GetSamples() uint32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *ExperimentSpec) GetSamplingSeed() uint64 {
+	if x != nil {
+		return x.SamplingSeed
+	}
+	return 0
+}
+
+func (x *ExperimentSpec) GetConcurrency() uint32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+// What a finished run achieved.
+type ExperimentRunSummary struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Ticks           uint64                 `protobuf:"varint,1,opt,name=ticks,proto3" json:"ticks,omitempty"`
+	SimTimeMs       uint64                 `protobuf:"varint,2,opt,name=sim_time_ms,json=simTimeMs,proto3" json:"sim_time_ms,omitempty"`
+	EndReason       string                 `protobuf:"bytes,3,opt,name=end_reason,json=endReason,proto3" json:"end_reason,omitempty"`
+	TotalYield      float64                `protobuf:"fixed64,4,opt,name=total_yield,json=totalYield,proto3" json:"total_yield,omitempty"`
+	HarvestedCells  uint32                 `protobuf:"varint,5,opt,name=harvested_cells,json=harvestedCells,proto3" json:"harvested_cells,omitempty"`
+	SpoiledCells    uint32                 `protobuf:"varint,6,opt,name=spoiled_cells,json=spoiledCells,proto3" json:"spoiled_cells,omitempty"`
+	TasksTotal      uint32                 `protobuf:"varint,7,opt,name=tasks_total,json=tasksTotal,proto3" json:"tasks_total,omitempty"`
+	TasksCompleted  uint32                 `protobuf:"varint,8,opt,name=tasks_completed,json=tasksCompleted,proto3" json:"tasks_completed,omitempty"`
+	Entities        uint32                 `protobuf:"varint,9,opt,name=entities,proto3" json:"entities,omitempty"`
+	AvgBattery      float64                `protobuf:"fixed64,10,opt,name=avg_battery,json=avgBattery,proto3" json:"avg_battery,omitempty"`
+	OfflineEntities uint32                 `protobuf:"varint,11,opt,name=offline_entities,json=offlineEntities,proto3" json:"offline_entities,omitempty"`
+	WallTimeMs      float64                `protobuf:"fixed64,12,opt,name=wall_time_ms,json=wallTimeMs,proto3" json:"wall_time_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExperimentRunSummary) Reset() {
+	*x = ExperimentRunSummary{}
+	mi := &file_simulation_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExperimentRunSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentRunSummary) ProtoMessage() {}
+
+func (x *ExperimentRunSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentRunSummary.ProtoReflect.Descriptor instead.
+func (*ExperimentRunSummary) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ExperimentRunSummary) GetTicks() uint64 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetSimTimeMs() uint64 {
+	if x != nil {
+		return x.SimTimeMs
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetEndReason() string {
+	if x != nil {
+		return x.EndReason
+	}
+	return ""
+}
+
+func (x *ExperimentRunSummary) GetTotalYield() float64 {
+	if x != nil {
+		return x.TotalYield
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetHarvestedCells() uint32 {
+	if x != nil {
+		return x.HarvestedCells
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetSpoiledCells() uint32 {
+	if x != nil {
+		return x.SpoiledCells
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetTasksTotal() uint32 {
+	if x != nil {
+		return x.TasksTotal
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetTasksCompleted() uint32 {
+	if x != nil {
+		return x.TasksCompleted
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetEntities() uint32 {
+	if x != nil {
+		return x.Entities
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetAvgBattery() float64 {
+	if x != nil {
+		return x.AvgBattery
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetOfflineEntities() uint32 {
+	if x != nil {
+		return x.OfflineEntities
+	}
+	return 0
+}
+
+func (x *ExperimentRunSummary) GetWallTimeMs() float64 {
+	if x != nil {
+		return x.WallTimeMs
+	}
+	return 0
+}
+
+type ExperimentRun struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Index uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// the run's parameter values: numbers, and choices for task_allocation
+	Values        map[string]float64    `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Choices       map[string]string     `protobuf:"bytes,3,rep,name=choices,proto3" json:"choices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	State         ExperimentRunState    `protobuf:"varint,4,opt,name=state,proto3,enum=autofarm.simulation.ExperimentRunState" json:"state,omitempty"`
+	SimulationId  string                `protobuf:"bytes,5,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Error         string                `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Summary       *ExperimentRunSummary `protobuf:"bytes,7,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExperimentRun) Reset() {
+	*x = ExperimentRun{}
+	mi := &file_simulation_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExperimentRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentRun) ProtoMessage() {}
+
+func (x *ExperimentRun) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentRun.ProtoReflect.Descriptor instead.
+func (*ExperimentRun) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ExperimentRun) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ExperimentRun) GetValues() map[string]float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ExperimentRun) GetChoices() map[string]string {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+func (x *ExperimentRun) GetState() ExperimentRunState {
+	if x != nil {
+		return x.State
+	}
+	return ExperimentRunState_EXPERIMENT_RUN_STATE_UNSPECIFIED
+}
+
+func (x *ExperimentRun) GetSimulationId() string {
+	if x != nil {
+		return x.SimulationId
+	}
+	return ""
+}
+
+func (x *ExperimentRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExperimentRun) GetSummary() *ExperimentRunSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type Experiment struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Spec      *ExperimentSpec        `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	State     ExperimentState        `protobuf:"varint,3,opt,name=state,proto3,enum=autofarm.simulation.ExperimentState" json:"state,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	EndedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	// progress: runs by state
+	TotalRuns     uint32 `protobuf:"varint,6,opt,name=total_runs,json=totalRuns,proto3" json:"total_runs,omitempty"`
+	QueuedRuns    uint32 `protobuf:"varint,7,opt,name=queued_runs,json=queuedRuns,proto3" json:"queued_runs,omitempty"`
+	RunningRuns   uint32 `protobuf:"varint,8,opt,name=running_runs,json=runningRuns,proto3" json:"running_runs,omitempty"`
+	CompletedRuns uint32 `protobuf:"varint,9,opt,name=completed_runs,json=completedRuns,proto3" json:"completed_runs,omitempty"`
+	FailedRuns    uint32 `protobuf:"varint,10,opt,name=failed_runs,json=failedRuns,proto3" json:"failed_runs,omitempty"`
+	CanceledRuns  uint32 `protobuf:"varint,11,opt,name=canceled_runs,json=canceledRuns,proto3" json:"canceled_runs,omitempty"`
+	// left out of ListExperiments
+	Runs []*ExperimentRun `protobuf:"bytes,12,rep,name=runs,proto3" json:"runs,omitempty"`
+	// the tenant that owns the experiment and its runs' simulations
+	Tenant        string `protobuf:"bytes,13,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Experiment) Reset() {
+	*x = Experiment{}
+	mi := &file_simulation_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Experiment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Experiment) ProtoMessage() {}
+
+func (x *Experiment) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Experiment.ProtoReflect.Descriptor instead.
+func (*Experiment) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Experiment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Experiment) GetSpec() *ExperimentSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *Experiment) GetState() ExperimentState {
+	if x != nil {
+		return x.State
+	}
+	return ExperimentState_EXPERIMENT_STATE_UNSPECIFIED
+}
+
+func (x *Experiment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Experiment) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *Experiment) GetTotalRuns() uint32 {
+	if x != nil {
+		return x.TotalRuns
+	}
+	return 0
+}
+
+func (x *Experiment) GetQueuedRuns() uint32 {
+	if x != nil {
+		return x.QueuedRuns
+	}
+	return 0
+}
+
+func (x *Experiment) GetRunningRuns() uint32 {
+	if x != nil {
+		return x.RunningRuns
+	}
+	return 0
+}
+
+func (x *Experiment) GetCompletedRuns() uint32 {
+	if x != nil {
+		return x.CompletedRuns
+	}
+	return 0
+}
+
+func (x *Experiment) GetFailedRuns() uint32 {
+	if x != nil {
+		return x.FailedRuns
+	}
+	return 0
+}
+
+func (x *Experiment) GetCanceledRuns() uint32 {
+	if x != nil {
+		return x.CanceledRuns
+	}
+	return 0
+}
+
+func (x *Experiment) GetRuns() []*ExperimentRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+func (x *Experiment) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type CreateExperimentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spec          *ExperimentSpec        `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExperimentRequest) Reset() {
+	*x = CreateExperimentRequest{}
+	mi := &file_simulation_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExperimentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExperimentRequest) ProtoMessage() {}
+
+func (x *CreateExperimentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExperimentRequest.ProtoReflect.Descriptor instead.
+func (*CreateExperimentRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CreateExperimentRequest) GetSpec() *ExperimentSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+type CreateExperimentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Experiment    *Experiment            `protobuf:"bytes,1,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExperimentResponse) Reset() {
+	*x = CreateExperimentResponse{}
+	mi := &file_simulation_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExperimentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExperimentResponse) ProtoMessage() {}
+
+func (x *CreateExperimentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExperimentResponse.ProtoReflect.Descriptor instead.
+func (*CreateExperimentResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateExperimentResponse) GetExperiment() *Experiment {
+	if x != nil {
+		return x.Experiment
+	}
+	return nil
+}
+
+type GetExperimentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExperimentRequest) Reset() {
+	*x = GetExperimentRequest{}
+	mi := &file_simulation_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExperimentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExperimentRequest) ProtoMessage() {}
+
+func (x *GetExperimentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExperimentRequest.ProtoReflect.Descriptor instead.
+func (*GetExperimentRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetExperimentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetExperimentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Experiment    *Experiment            `protobuf:"bytes,1,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExperimentResponse) Reset() {
+	*x = GetExperimentResponse{}
+	mi := &file_simulation_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExperimentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExperimentResponse) ProtoMessage() {}
+
+func (x *GetExperimentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExperimentResponse.ProtoReflect.Descriptor instead.
+func (*GetExperimentResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetExperimentResponse) GetExperiment() *Experiment {
+	if x != nil {
+		return x.Experiment
+	}
+	return nil
+}
+
+type ListExperimentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExperimentsRequest) Reset() {
+	*x = ListExperimentsRequest{}
+	mi := &file_simulation_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExperimentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExperimentsRequest) ProtoMessage() {}
+
+func (x *ListExperimentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExperimentsRequest.ProtoReflect.Descriptor instead.
+func (*ListExperimentsRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{62}
+}
+
+type ListExperimentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Experiments   []*Experiment          `protobuf:"bytes,1,rep,name=experiments,proto3" json:"experiments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExperimentsResponse) Reset() {
+	*x = ListExperimentsResponse{}
+	mi := &file_simulation_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExperimentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExperimentsResponse) ProtoMessage() {}
+
+func (x *ListExperimentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExperimentsResponse.ProtoReflect.Descriptor instead.
+func (*ListExperimentsResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListExperimentsResponse) GetExperiments() []*Experiment {
+	if x != nil {
+		return x.Experiments
+	}
+	return nil
+}
+
+// Stops an experiment's running runs and drops its queued ones.
+type CancelExperimentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelExperimentRequest) Reset() {
+	*x = CancelExperimentRequest{}
+	mi := &file_simulation_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelExperimentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelExperimentRequest) ProtoMessage() {}
+
+func (x *CancelExperimentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelExperimentRequest.ProtoReflect.Descriptor instead.
+func (*CancelExperimentRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *CancelExperimentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelExperimentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Experiment    *Experiment            `protobuf:"bytes,1,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelExperimentResponse) Reset() {
+	*x = CancelExperimentResponse{}
+	mi := &file_simulation_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelExperimentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelExperimentResponse) ProtoMessage() {}
+
+func (x *CancelExperimentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelExperimentResponse.ProtoReflect.Descriptor instead.
+func (*CancelExperimentResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CancelExperimentResponse) GetExperiment() *Experiment {
+	if x != nil {
+		return x.Experiment
+	}
+	return nil
+}
+
+type GetSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSimulationRequest) Reset() {
+	*x = GetSimulationRequest{}
+	mi := &file_simulation_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimulationRequest) ProtoMessage() {}
+
+func (x *GetSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimulationRequest.ProtoReflect.Descriptor instead.
+func (*GetSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *GetSimulationRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type GetSimulationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSimulationResponse) Reset() {
+	*x = GetSimulationResponse{}
+	mi := &file_simulation_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimulationResponse) ProtoMessage() {}
+
+func (x *GetSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimulationResponse.ProtoReflect.Descriptor instead.
+func (*GetSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetSimulationResponse) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
+	}
+	return nil
+}
+
+// Lists simulations, oldest first, optionally only those in one status.
+type ListSimulationsRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Status        commonpb.SimulationStatus `protobuf:"varint,1,opt,name=status,proto3,enum=autofarm.common.SimulationStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSimulationsRequest) Reset() {
+	*x = ListSimulationsRequest{}
+	mi := &file_simulation_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSimulationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSimulationsRequest) ProtoMessage() {}
+
+func (x *ListSimulationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSimulationsRequest.ProtoReflect.Descriptor instead.
+func (*ListSimulationsRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListSimulationsRequest) GetStatus() commonpb.SimulationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return commonpb.SimulationStatus(0)
+}
+
+type ListSimulationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Simulations   []*Simulation          `protobuf:"bytes,1,rep,name=simulations,proto3" json:"simulations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSimulationsResponse) Reset() {
+	*x = ListSimulationsResponse{}
+	mi := &file_simulation_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSimulationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSimulationsResponse) ProtoMessage() {}
+
+func (x *ListSimulationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSimulationsResponse.ProtoReflect.Descriptor instead.
+func (*ListSimulationsResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListSimulationsResponse) GetSimulations() []*Simulation {
+	if x != nil {
+		return x.Simulations
+	}
+	return nil
+}
+
+type EntityState struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	EntityId uint64                 `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// position
+	X float64 `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y float64 `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
+	// velocity
+	Vx float64 `protobuf:"fixed64,4,opt,name=vx,proto3" json:"vx,omitempty"`
+	Vy float64 `protobuf:"fixed64,5,opt,name=vy,proto3" json:"vy,omitempty"`
+	// battery/energy percentage (0–100)
+	Battery float64 `protobuf:"fixed64,6,opt,name=battery,proto3" json:"battery,omitempty"`
+	// opaque state, e.g. "idle", "moving", "working"
+	Status string     `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Type   EntityType `protobuf:"varint,8,opt,name=type,proto3,enum=autofarm.simulation.EntityType" json:"type,omitempty"`
+	// task the entity is working on, 0 when idle
+	TaskId        uint64 `protobuf:"varint,9,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityState) Reset() {
+	*x = EntityState{}
+	mi := &file_simulation_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityState) ProtoMessage() {}
+
+func (x *EntityState) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityState.ProtoReflect.Descriptor instead.
+func (*EntityState) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *EntityState) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *EntityState) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *EntityState) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *EntityState) GetVx() float64 {
+	if x != nil {
+		return x.Vx
+	}
+	return 0
+}
+
+func (x *EntityState) GetVy() float64 {
+	if x != nil {
+		return x.Vy
+	}
+	return 0
+}
+
+func (x *EntityState) GetBattery() float64 {
+	if x != nil {
+		return x.Battery
+	}
+	return 0
+}
+
+func (x *EntityState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EntityState) GetType() EntityType {
+	if x != nil {
+		return x.Type
+	}
+	return EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+func (x *EntityState) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             float64                `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_simulation_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *Point) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type Task struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// assigned by the orchestrator
+	TaskId    uint64    `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Type      TaskType  `protobuf:"varint,2,opt,name=type,proto3,enum=autofarm.simulation.TaskType" json:"type,omitempty"`
+	State     TaskState `protobuf:"varint,3,opt,name=state,proto3,enum=autofarm.simulation.TaskState" json:"state,omitempty"`
+	Waypoints []*Point  `protobuf:"bytes,4,rep,name=waypoints,proto3" json:"waypoints,omitempty"`
+	WorkTicks uint32    `protobuf:"varint,5,opt,name=work_ticks,json=workTicks,proto3" json:"work_ticks,omitempty"` // HARVEST
+	Load      float64   `protobuf:"fixed64,6,opt,name=load,proto3" json:"load,omitempty"`                           // TRANSPORT, must fit the entity's payload capacity
+	// higher runs first
+	Priority int32 `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	// entity types allowed to take the task; empty means the defaults for the
+	// task type
+	EligibleTypes    []EntityType `protobuf:"varint,8,rep,packed,name=eligible_types,json=eligibleTypes,proto3,enum=autofarm.simulation.EntityType" json:"eligible_types,omitempty"`
+	AssignedEntityId uint64       `protobuf:"varint,9,opt,name=assigned_entity_id,json=assignedEntityId,proto3" json:"assigned_entity_id,omitempty"`
+	CreatedTick      uint64       `protobuf:"varint,10,opt,name=created_tick,json=createdTick,proto3" json:"created_tick,omitempty"`
+	AssignedTick     uint64       `protobuf:"varint,11,opt,name=assigned_tick,json=assignedTick,proto3" json:"assigned_tick,omitempty"`
+	CompletedTick    uint64       `protobuf:"varint,12,opt,name=completed_tick,json=completedTick,proto3" json:"completed_tick,omitempty"`
+	Attempts         uint32       `protobuf:"varint,13,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_simulation_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *Task) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Task) GetType() TaskType {
+	if x != nil {
+		return x.Type
+	}
+	return TaskType_TASK_TYPE_UNSPECIFIED
+}
+
+func (x *Task) GetState() TaskState {
+	if x != nil {
+		return x.State
+	}
+	return TaskState_TASK_STATE_UNSPECIFIED
+}
+
+func (x *Task) GetWaypoints() []*Point {
+	if x != nil {
+		return x.Waypoints
+	}
+	return nil
+}
+
+func (x *Task) GetWorkTicks() uint32 {
+	if x != nil {
+		return x.WorkTicks
+	}
+	return 0
+}
+
+func (x *Task) GetLoad() float64 {
+	if x != nil {
+		return x.Load
+	}
+	return 0
+}
+
+func (x *Task) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Task) GetEligibleTypes() []EntityType {
+	if x != nil {
+		return x.EligibleTypes
+	}
+	return nil
+}
+
+func (x *Task) GetAssignedEntityId() uint64 {
+	if x != nil {
+		return x.AssignedEntityId
+	}
+	return 0
+}
+
+func (x *Task) GetCreatedTick() uint64 {
+	if x != nil {
+		return x.CreatedTick
+	}
+	return 0
+}
+
+func (x *Task) GetAssignedTick() uint64 {
+	if x != nil {
+		return x.AssignedTick
+	}
+	return 0
+}
+
+func (x *Task) GetCompletedTick() uint64 {
+	if x != nil {
+		return x.CompletedTick
+	}
+	return 0
+}
+
+func (x *Task) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+// A task changing state, streamed alongside entity state.
+type TaskEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        uint64                 `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	EntityId      uint64                 `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	State         TaskState              `protobuf:"varint,3,opt,name=state,proto3,enum=autofarm.simulation.TaskState" json:"state,omitempty"`
+	Tick          uint64                 `protobuf:"varint,4,opt,name=tick,proto3" json:"tick,omitempty"`
+	Detail        string                 `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_simulation_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *TaskEvent) GetTaskId() uint64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetState() TaskState {
+	if x != nil {
+		return x.State
+	}
+	return TaskState_TASK_STATE_UNSPECIFIED
+}
+
+func (x *TaskEvent) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type SubmitTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	EntityCount   uint32                 `protobuf:"varint,2,opt,name=entity_count,json=entityCount,proto3" json:"entity_count,omitempty"`   // number of robots/agents
-	TickRateMs    uint32                 `protobuf:"varint,3,opt,name=tick_rate_ms,json=tickRateMs,proto3" json:"tick_rate_ms,omitempty"`    // tick interval in milliseconds
-	ScenarioType  string                 `protobuf:"bytes,4,opt,name=scenario_type,json=scenarioType,proto3" json:"scenario_type,omitempty"` // e.g. "harvest", "patrol", etc.
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tasks         []*Task                `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimulationConfig) Reset() {
-	*x = SimulationConfig{}
-	mi := &file_simulation_proto_msgTypes[0]
+func (x *SubmitTasksRequest) Reset() {
+	*x = SubmitTasksRequest{}
+	mi := &file_simulation_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SimulationConfig) String() string {
+func (x *SubmitTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimulationConfig) ProtoMessage() {}
+func (*SubmitTasksRequest) ProtoMessage() {}
 
-func (x *SimulationConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[0]
+func (x *SubmitTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -60,66 +5565,362 @@ func (x *SimulationConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimulationConfig.ProtoReflect.Descriptor instead.
-func (*SimulationConfig) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use SubmitTasksRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTasksRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *SimulationConfig) GetName() string {
+func (x *SubmitTasksRequest) GetId() *commonpb.SimulationId {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
-	return ""
+	return nil
+}
+
+func (x *SubmitTasksRequest) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type SubmitTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the queued tasks with task_id and state filled in
+	Tasks         []*Task `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitTasksResponse) Reset() {
+	*x = SubmitTasksResponse{}
+	mi := &file_simulation_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTasksResponse) ProtoMessage() {}
+
+func (x *SubmitTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTasksResponse.ProtoReflect.Descriptor instead.
+func (*SubmitTasksResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SubmitTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// only tasks in this state; UNSPECIFIED lists all
+	State         TaskState `protobuf:"varint,2,opt,name=state,proto3,enum=autofarm.simulation.TaskState" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_simulation_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListTasksRequest) GetId() *commonpb.SimulationId {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetState() TaskState {
+	if x != nil {
+		return x.State
+	}
+	return TaskState_TASK_STATE_UNSPECIFIED
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_simulation_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type EntityCommand struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// assigned by the orchestrator; commands for a tick are applied in id order
+	CommandId uint64            `protobuf:"varint,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	EntityId  uint64            `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Type      EntityCommandType `protobuf:"varint,3,opt,name=type,proto3,enum=autofarm.simulation.EntityCommandType" json:"type,omitempty"`
+	// MOVE_TO target
+	X float64 `protobuf:"fixed64,4,opt,name=x,proto3" json:"x,omitempty"`
+	Y float64 `protobuf:"fixed64,5,opt,name=y,proto3" json:"y,omitempty"`
+	// SET_VELOCITY
+	Vx            float64 `protobuf:"fixed64,6,opt,name=vx,proto3" json:"vx,omitempty"`
+	Vy            float64 `protobuf:"fixed64,7,opt,name=vy,proto3" json:"vy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityCommand) Reset() {
+	*x = EntityCommand{}
+	mi := &file_simulation_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityCommand) ProtoMessage() {}
+
+func (x *EntityCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityCommand.ProtoReflect.Descriptor instead.
+func (*EntityCommand) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *EntityCommand) GetCommandId() uint64 {
+	if x != nil {
+		return x.CommandId
+	}
+	return 0
+}
+
+func (x *EntityCommand) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *EntityCommand) GetType() EntityCommandType {
+	if x != nil {
+		return x.Type
+	}
+	return EntityCommandType_ENTITY_COMMAND_TYPE_UNSPECIFIED
+}
+
+func (x *EntityCommand) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *EntityCommand) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *EntityCommand) GetVx() float64 {
+	if x != nil {
+		return x.Vx
+	}
+	return 0
+}
+
+func (x *EntityCommand) GetVy() float64 {
+	if x != nil {
+		return x.Vy
+	}
+	return 0
+}
+
+// Worker acknowledgement that a command was applied (or rejected) at a tick.
+type EntityCommandAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommandId     uint64                 `protobuf:"varint,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	EntityId      uint64                 `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Tick          uint64                 `protobuf:"varint,3,opt,name=tick,proto3" json:"tick,omitempty"`
+	Applied       bool                   `protobuf:"varint,4,opt,name=applied,proto3" json:"applied,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityCommandAck) Reset() {
+	*x = EntityCommandAck{}
+	mi := &file_simulation_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityCommandAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityCommandAck) ProtoMessage() {}
+
+func (x *EntityCommandAck) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityCommandAck.ProtoReflect.Descriptor instead.
+func (*EntityCommandAck) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *EntityCommandAck) GetCommandId() uint64 {
+	if x != nil {
+		return x.CommandId
+	}
+	return 0
+}
+
+func (x *EntityCommandAck) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
 }
 
-func (x *SimulationConfig) GetEntityCount() uint32 {
+func (x *EntityCommandAck) GetTick() uint64 {
 	if x != nil {
-		return x.EntityCount
+		return x.Tick
 	}
 	return 0
 }
 
-func (x *SimulationConfig) GetTickRateMs() uint32 {
+func (x *EntityCommandAck) GetApplied() bool {
 	if x != nil {
-		return x.TickRateMs
+		return x.Applied
 	}
-	return 0
+	return false
 }
 
-func (x *SimulationConfig) GetScenarioType() string {
+func (x *EntityCommandAck) GetError() string {
 	if x != nil {
-		return x.ScenarioType
+		return x.Error
 	}
 	return ""
 }
 
-type Simulation struct {
-	state         protoimpl.MessageState    `protogen:"open.v1"`
-	Id            *commonpb.SimulationId    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Config        *SimulationConfig         `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
-	Status        commonpb.SimulationStatus `protobuf:"varint,3,opt,name=status,proto3,enum=autofarm.common.SimulationStatus" json:"status,omitempty"`
-	CreatedAt     *timestamppb.Timestamp    `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	StartedAt     *timestamppb.Timestamp    `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	EndedAt       *timestamppb.Timestamp    `protobuf:"bytes,6,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+type SendEntityCommandRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Command       *EntityCommand         `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Simulation) Reset() {
-	*x = Simulation{}
-	mi := &file_simulation_proto_msgTypes[1]
+func (x *SendEntityCommandRequest) Reset() {
+	*x = SendEntityCommandRequest{}
+	mi := &file_simulation_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Simulation) String() string {
+func (x *SendEntityCommandRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Simulation) ProtoMessage() {}
+func (*SendEntityCommandRequest) ProtoMessage() {}
 
-func (x *Simulation) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[1]
+func (x *SendEntityCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -130,76 +5931,101 @@ func (x *Simulation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Simulation.ProtoReflect.Descriptor instead.
-func (*Simulation) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SendEntityCommandRequest.ProtoReflect.Descriptor instead.
+func (*SendEntityCommandRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *Simulation) GetId() *commonpb.SimulationId {
+func (x *SendEntityCommandRequest) GetId() *commonpb.SimulationId {
 	if x != nil {
 		return x.Id
 	}
 	return nil
 }
 
-func (x *Simulation) GetConfig() *SimulationConfig {
+func (x *SendEntityCommandRequest) GetCommand() *EntityCommand {
 	if x != nil {
-		return x.Config
+		return x.Command
 	}
 	return nil
 }
 
-func (x *Simulation) GetStatus() commonpb.SimulationStatus {
-	if x != nil {
-		return x.Status
-	}
-	return commonpb.SimulationStatus(0)
+type SendEntityCommandResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the queued command with its command_id filled in
+	Command       *EntityCommand `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Simulation) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+func (x *SendEntityCommandResponse) Reset() {
+	*x = SendEntityCommandResponse{}
+	mi := &file_simulation_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Simulation) GetStartedAt() *timestamppb.Timestamp {
+func (x *SendEntityCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendEntityCommandResponse) ProtoMessage() {}
+
+func (x *SendEntityCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[81]
 	if x != nil {
-		return x.StartedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Simulation) GetEndedAt() *timestamppb.Timestamp {
+// Deprecated: Use SendEntityCommandResponse.ProtoReflect.Descriptor instead.
+func (*SendEntityCommandResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *SendEntityCommandResponse) GetCommand() *EntityCommand {
 	if x != nil {
-		return x.EndedAt
+		return x.Command
 	}
 	return nil
 }
 
-// Request to create a simulation (from API to Orchestrator)
-type CreateSimulationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Config        *SimulationConfig      `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+// A new entity joining a running simulation.
+type EntitySpawn struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// assigned by the orchestrator
+	EntityId uint64 `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// starting state (entity_id inside is ignored); when unset the worker
+	// spawns the entity at a random position
+	InitialState *EntityState `protobuf:"bytes,2,opt,name=initial_state,json=initialState,proto3" json:"initial_state,omitempty"`
+	Type         EntityType   `protobuf:"varint,3,opt,name=type,proto3,enum=autofarm.simulation.EntityType" json:"type,omitempty"`
+	// initial_state comes from a snapshot and is taken as is: an empty
+	// battery stays empty and a disabled entity stays disabled
+	Restored      bool `protobuf:"varint,4,opt,name=restored,proto3" json:"restored,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateSimulationRequest) Reset() {
-	*x = CreateSimulationRequest{}
-	mi := &file_simulation_proto_msgTypes[2]
+func (x *EntitySpawn) Reset() {
+	*x = EntitySpawn{}
+	mi := &file_simulation_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSimulationRequest) String() string {
+func (x *EntitySpawn) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSimulationRequest) ProtoMessage() {}
+func (*EntitySpawn) ProtoMessage() {}
 
-func (x *CreateSimulationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[2]
+func (x *EntitySpawn) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,40 +6036,69 @@ func (x *CreateSimulationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSimulationRequest.ProtoReflect.Descriptor instead.
-func (*CreateSimulationRequest) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use EntitySpawn.ProtoReflect.Descriptor instead.
+func (*EntitySpawn) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *CreateSimulationRequest) GetConfig() *SimulationConfig {
+func (x *EntitySpawn) GetEntityId() uint64 {
 	if x != nil {
-		return x.Config
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *EntitySpawn) GetInitialState() *EntityState {
+	if x != nil {
+		return x.InitialState
 	}
 	return nil
 }
 
-type CreateSimulationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+func (x *EntitySpawn) GetType() EntityType {
+	if x != nil {
+		return x.Type
+	}
+	return EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+func (x *EntitySpawn) GetRestored() bool {
+	if x != nil {
+		return x.Restored
+	}
+	return false
+}
+
+type ScaleEntitiesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// entities to add with an explicit starting state
+	Spawn []*EntityState `protobuf:"bytes,2,rep,name=spawn,proto3" json:"spawn,omitempty"`
+	// additional entities to add with worker-chosen starting state
+	SpawnCount uint32 `protobuf:"varint,3,opt,name=spawn_count,json=spawnCount,proto3" json:"spawn_count,omitempty"`
+	// entities to remove
+	Retire []uint64 `protobuf:"varint,4,rep,packed,name=retire,proto3" json:"retire,omitempty"`
+	// type of the spawn_count entities
+	SpawnType     EntityType `protobuf:"varint,5,opt,name=spawn_type,json=spawnType,proto3,enum=autofarm.simulation.EntityType" json:"spawn_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateSimulationResponse) Reset() {
-	*x = CreateSimulationResponse{}
-	mi := &file_simulation_proto_msgTypes[3]
+func (x *ScaleEntitiesRequest) Reset() {
+	*x = ScaleEntitiesRequest{}
+	mi := &file_simulation_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSimulationResponse) String() string {
+func (x *ScaleEntitiesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSimulationResponse) ProtoMessage() {}
+func (*ScaleEntitiesRequest) ProtoMessage() {}
 
-func (x *CreateSimulationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[3]
+func (x *ScaleEntitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,41 +6109,70 @@ func (x *CreateSimulationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSimulationResponse.ProtoReflect.Descriptor instead.
-func (*CreateSimulationResponse) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ScaleEntitiesRequest.ProtoReflect.Descriptor instead.
+func (*ScaleEntitiesRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *CreateSimulationResponse) GetSimulation() *Simulation {
+func (x *ScaleEntitiesRequest) GetId() *commonpb.SimulationId {
 	if x != nil {
-		return x.Simulation
+		return x.Id
 	}
 	return nil
 }
 
-// Lifecycle control
-type StartSimulationRequest struct {
+func (x *ScaleEntitiesRequest) GetSpawn() []*EntityState {
+	if x != nil {
+		return x.Spawn
+	}
+	return nil
+}
+
+func (x *ScaleEntitiesRequest) GetSpawnCount() uint32 {
+	if x != nil {
+		return x.SpawnCount
+	}
+	return 0
+}
+
+func (x *ScaleEntitiesRequest) GetRetire() []uint64 {
+	if x != nil {
+		return x.Retire
+	}
+	return nil
+}
+
+func (x *ScaleEntitiesRequest) GetSpawnType() EntityType {
+	if x != nil {
+		return x.SpawnType
+	}
+	return EntityType_ENTITY_TYPE_UNSPECIFIED
+}
+
+type ScaleEntitiesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
+	SpawnedIds    []uint64               `protobuf:"varint,2,rep,packed,name=spawned_ids,json=spawnedIds,proto3" json:"spawned_ids,omitempty"`
+	RetiredIds    []uint64               `protobuf:"varint,3,rep,packed,name=retired_ids,json=retiredIds,proto3" json:"retired_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartSimulationRequest) Reset() {
-	*x = StartSimulationRequest{}
-	mi := &file_simulation_proto_msgTypes[4]
+func (x *ScaleEntitiesResponse) Reset() {
+	*x = ScaleEntitiesResponse{}
+	mi := &file_simulation_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartSimulationRequest) String() string {
+func (x *ScaleEntitiesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartSimulationRequest) ProtoMessage() {}
+func (*ScaleEntitiesResponse) ProtoMessage() {}
 
-func (x *StartSimulationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[4]
+func (x *ScaleEntitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -299,40 +6183,70 @@ func (x *StartSimulationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartSimulationRequest.ProtoReflect.Descriptor instead.
-func (*StartSimulationRequest) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ScaleEntitiesResponse.ProtoReflect.Descriptor instead.
+func (*ScaleEntitiesResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *StartSimulationRequest) GetId() *commonpb.SimulationId {
+func (x *ScaleEntitiesResponse) GetSimulation() *Simulation {
 	if x != nil {
-		return x.Id
+		return x.Simulation
 	}
 	return nil
 }
 
-type StartSimulationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ScaleEntitiesResponse) GetSpawnedIds() []uint64 {
+	if x != nil {
+		return x.SpawnedIds
+	}
+	return nil
 }
 
-func (x *StartSimulationResponse) Reset() {
-	*x = StartSimulationResponse{}
-	mi := &file_simulation_proto_msgTypes[5]
+func (x *ScaleEntitiesResponse) GetRetiredIds() []uint64 {
+	if x != nil {
+		return x.RetiredIds
+	}
+	return nil
+}
+
+// What the orchestrator sends to workers per tick
+type SimulationTickRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId *commonpb.SimulationId `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	// monotonically increasing tick index
+	Tick uint64 `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
+	// subset of entity ids this worker should process
+	EntityIds []uint64 `protobuf:"varint,3,rep,packed,name=entity_ids,json=entityIds,proto3" json:"entity_ids,omitempty"`
+	// config snapshot for this simulation (duplicated for stateless workers)
+	Config      *SimulationConfig      `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	ScheduledAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	// entity commands to apply at the start of this tick
+	Commands []*EntityCommand `protobuf:"bytes,6,rep,name=commands,proto3" json:"commands,omitempty"`
+	// roster changes since the previous tick; entity_ids already reflects them
+	Spawns           []*EntitySpawn `protobuf:"bytes,7,rep,name=spawns,proto3" json:"spawns,omitempty"`
+	RetiredEntityIds []uint64       `protobuf:"varint,8,rep,packed,name=retired_entity_ids,json=retiredEntityIds,proto3" json:"retired_entity_ids,omitempty"`
+	// tasks newly assigned to entities; assigned_entity_id is set
+	TaskAssignments []*Task      `protobuf:"bytes,9,rep,name=task_assignments,json=taskAssignments,proto3" json:"task_assignments,omitempty"`
+	Environment     *Environment `protobuf:"bytes,10,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SimulationTickRequest) Reset() {
+	*x = SimulationTickRequest{}
+	mi := &file_simulation_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartSimulationResponse) String() string {
+func (x *SimulationTickRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartSimulationResponse) ProtoMessage() {}
+func (*SimulationTickRequest) ProtoMessage() {}
 
-func (x *StartSimulationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[5]
+func (x *SimulationTickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -343,40 +6257,109 @@ func (x *StartSimulationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartSimulationResponse.ProtoReflect.Descriptor instead.
-func (*StartSimulationResponse) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SimulationTickRequest.ProtoReflect.Descriptor instead.
+func (*SimulationTickRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *StartSimulationResponse) GetSimulation() *Simulation {
+func (x *SimulationTickRequest) GetSimulationId() *commonpb.SimulationId {
 	if x != nil {
-		return x.Simulation
+		return x.SimulationId
 	}
 	return nil
 }
 
-type StreamAggregatedTicksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *SimulationTickRequest) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *SimulationTickRequest) GetEntityIds() []uint64 {
+	if x != nil {
+		return x.EntityIds
+	}
+	return nil
+}
+
+func (x *SimulationTickRequest) GetConfig() *SimulationConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *SimulationTickRequest) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+func (x *SimulationTickRequest) GetCommands() []*EntityCommand {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *SimulationTickRequest) GetSpawns() []*EntitySpawn {
+	if x != nil {
+		return x.Spawns
+	}
+	return nil
+}
+
+func (x *SimulationTickRequest) GetRetiredEntityIds() []uint64 {
+	if x != nil {
+		return x.RetiredEntityIds
+	}
+	return nil
+}
+
+func (x *SimulationTickRequest) GetTaskAssignments() []*Task {
+	if x != nil {
+		return x.TaskAssignments
+	}
+	return nil
+}
+
+func (x *SimulationTickRequest) GetEnvironment() *Environment {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
+}
+
+// What workers send back to orchestrator
+type SimulationTickResult struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId *commonpb.SimulationId `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Tick         uint64                 `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
+	// fully updated entity state for this worker's partition
+	Entities []*EntityState `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	// per-worker metrics
+	ComputeMs     float64 `protobuf:"fixed64,4,opt,name=compute_ms,json=computeMs,proto3" json:"compute_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamAggregatedTicksRequest) Reset() {
-	*x = StreamAggregatedTicksRequest{}
-	mi := &file_simulation_proto_msgTypes[6]
+func (x *SimulationTickResult) Reset() {
+	*x = SimulationTickResult{}
+	mi := &file_simulation_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamAggregatedTicksRequest) String() string {
+func (x *SimulationTickResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamAggregatedTicksRequest) ProtoMessage() {}
+func (*SimulationTickResult) ProtoMessage() {}
 
-func (x *StreamAggregatedTicksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[6]
+func (x *SimulationTickResult) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -387,40 +6370,92 @@ func (x *StreamAggregatedTicksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamAggregatedTicksRequest.ProtoReflect.Descriptor instead.
-func (*StreamAggregatedTicksRequest) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SimulationTickResult.ProtoReflect.Descriptor instead.
+func (*SimulationTickResult) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *StreamAggregatedTicksRequest) GetId() *commonpb.SimulationId {
+func (x *SimulationTickResult) GetSimulationId() *commonpb.SimulationId {
 	if x != nil {
-		return x.Id
+		return x.SimulationId
 	}
 	return nil
 }
 
-type PauseSimulationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *SimulationTickResult) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *SimulationTickResult) GetEntities() []*EntityState {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *SimulationTickResult) GetComputeMs() float64 {
+	if x != nil {
+		return x.ComputeMs
+	}
+	return 0
+}
+
+// Aggregated state that the orchestrator pushes toward the dashboard layer
+type AggregatedTick struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SimulationId *commonpb.SimulationId `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	Tick         uint64                 `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
+	Entities     []*EntityState         `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	// simple metrics for the whole simulation at this tick
+	AvgComputeMs float64                `protobuf:"fixed64,4,opt,name=avg_compute_ms,json=avgComputeMs,proto3" json:"avg_compute_ms,omitempty"`
+	WorkerCount  uint32                 `protobuf:"varint,5,opt,name=worker_count,json=workerCount,proto3" json:"worker_count,omitempty"`
+	CompletedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// tick timing: when the tick was due to start and when it had to finish
+	ScheduledAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	Deadline    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// set when completed_at is past the deadline
+	Late      bool    `protobuf:"varint,9,opt,name=late,proto3" json:"late,omitempty"`
+	OverrunMs float64 `protobuf:"fixed64,10,opt,name=overrun_ms,json=overrunMs,proto3" json:"overrun_ms,omitempty"`
+	// number of tick slots dropped before this tick (SKIP policy only)
+	SkippedTicks uint64 `protobuf:"varint,11,opt,name=skipped_ticks,json=skippedTicks,proto3" json:"skipped_ticks,omitempty"`
+	// simulated time at the end of this tick (tick * tick_rate_ms)
+	SimTimeMs uint64 `protobuf:"varint,12,opt,name=sim_time_ms,json=simTimeMs,proto3" json:"sim_time_ms,omitempty"`
+	// speed the tick ran at; subscribers may decimate fast-forwarded ticks
+	SpeedMultiplier float64 `protobuf:"fixed64,13,opt,name=speed_multiplier,json=speedMultiplier,proto3" json:"speed_multiplier,omitempty"`
+	FastForward     bool    `protobuf:"varint,14,opt,name=fast_forward,json=fastForward,proto3" json:"fast_forward,omitempty"`
+	// entity commands applied at the start of this tick
+	CommandAcks []*EntityCommandAck `protobuf:"bytes,15,rep,name=command_acks,json=commandAcks,proto3" json:"command_acks,omitempty"`
+	// task assignments, completions and failures during this tick
+	TaskEvents []*TaskEvent `protobuf:"bytes,16,rep,name=task_events,json=taskEvents,proto3" json:"task_events,omitempty"`
+	// where avg_compute_ms went, per phase (e.g. "planning", "step"),
+	// averaged across workers
+	ComputeBreakdownMs map[string]float64 `protobuf:"bytes,17,rep,name=compute_breakdown_ms,json=computeBreakdownMs,proto3" json:"compute_breakdown_ms,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// time of day and weather during this tick
+	Environment *Environment `protobuf:"bytes,18,opt,name=environment,proto3" json:"environment,omitempty"`
+	// set when the simulation grows crops
+	Crops         *CropSummary `protobuf:"bytes,19,opt,name=crops,proto3" json:"crops,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PauseSimulationRequest) Reset() {
-	*x = PauseSimulationRequest{}
-	mi := &file_simulation_proto_msgTypes[7]
+func (x *AggregatedTick) Reset() {
+	*x = AggregatedTick{}
+	mi := &file_simulation_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PauseSimulationRequest) String() string {
+func (x *AggregatedTick) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PauseSimulationRequest) ProtoMessage() {}
+func (*AggregatedTick) ProtoMessage() {}
 
-func (x *PauseSimulationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[7]
+func (x *AggregatedTick) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -431,172 +6466,185 @@ func (x *PauseSimulationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PauseSimulationRequest.ProtoReflect.Descriptor instead.
-func (*PauseSimulationRequest) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AggregatedTick.ProtoReflect.Descriptor instead.
+func (*AggregatedTick) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *PauseSimulationRequest) GetId() *commonpb.SimulationId {
+func (x *AggregatedTick) GetSimulationId() *commonpb.SimulationId {
 	if x != nil {
-		return x.Id
+		return x.SimulationId
 	}
 	return nil
 }
 
-type PauseSimulationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AggregatedTick) GetTick() uint64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
 }
 
-func (x *PauseSimulationResponse) Reset() {
-	*x = PauseSimulationResponse{}
-	mi := &file_simulation_proto_msgTypes[8]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AggregatedTick) GetEntities() []*EntityState {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
 }
 
-func (x *PauseSimulationResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AggregatedTick) GetAvgComputeMs() float64 {
+	if x != nil {
+		return x.AvgComputeMs
+	}
+	return 0
 }
 
-func (*PauseSimulationResponse) ProtoMessage() {}
-
-func (x *PauseSimulationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[8]
+func (x *AggregatedTick) GetWorkerCount() uint32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.WorkerCount
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use PauseSimulationResponse.ProtoReflect.Descriptor instead.
-func (*PauseSimulationResponse) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{8}
+func (x *AggregatedTick) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
 }
 
-func (x *PauseSimulationResponse) GetSimulation() *Simulation {
+func (x *AggregatedTick) GetScheduledAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Simulation
+		return x.ScheduledAt
 	}
 	return nil
 }
 
-type StopSimulationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AggregatedTick) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
 }
 
-func (x *StopSimulationRequest) Reset() {
-	*x = StopSimulationRequest{}
-	mi := &file_simulation_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AggregatedTick) GetLate() bool {
+	if x != nil {
+		return x.Late
+	}
+	return false
 }
 
-func (x *StopSimulationRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AggregatedTick) GetOverrunMs() float64 {
+	if x != nil {
+		return x.OverrunMs
+	}
+	return 0
 }
 
-func (*StopSimulationRequest) ProtoMessage() {}
-
-func (x *StopSimulationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[9]
+func (x *AggregatedTick) GetSkippedTicks() uint64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SkippedTicks
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use StopSimulationRequest.ProtoReflect.Descriptor instead.
-func (*StopSimulationRequest) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{9}
+func (x *AggregatedTick) GetSimTimeMs() uint64 {
+	if x != nil {
+		return x.SimTimeMs
+	}
+	return 0
 }
 
-func (x *StopSimulationRequest) GetId() *commonpb.SimulationId {
+func (x *AggregatedTick) GetSpeedMultiplier() float64 {
 	if x != nil {
-		return x.Id
+		return x.SpeedMultiplier
 	}
-	return nil
+	return 0
 }
 
-type StopSimulationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AggregatedTick) GetFastForward() bool {
+	if x != nil {
+		return x.FastForward
+	}
+	return false
 }
 
-func (x *StopSimulationResponse) Reset() {
-	*x = StopSimulationResponse{}
-	mi := &file_simulation_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AggregatedTick) GetCommandAcks() []*EntityCommandAck {
+	if x != nil {
+		return x.CommandAcks
+	}
+	return nil
 }
 
-func (x *StopSimulationResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AggregatedTick) GetTaskEvents() []*TaskEvent {
+	if x != nil {
+		return x.TaskEvents
+	}
+	return nil
 }
 
-func (*StopSimulationResponse) ProtoMessage() {}
-
-func (x *StopSimulationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[10]
+func (x *AggregatedTick) GetComputeBreakdownMs() map[string]float64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ComputeBreakdownMs
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use StopSimulationResponse.ProtoReflect.Descriptor instead.
-func (*StopSimulationResponse) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{10}
+func (x *AggregatedTick) GetEnvironment() *Environment {
+	if x != nil {
+		return x.Environment
+	}
+	return nil
 }
 
-func (x *StopSimulationResponse) GetSimulation() *Simulation {
+func (x *AggregatedTick) GetCrops() *CropSummary {
 	if x != nil {
-		return x.Simulation
+		return x.Crops
 	}
 	return nil
 }
 
-type GetSimulationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+// AuditEvent is an entry of the audit trail: a change a caller made to a
+// simulation. Events are never changed or removed, and outlive the
+// simulation they are about.
+type AuditEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// increases with every event recorded
+	Id           uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Tenant       string                 `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	SimulationId *commonpb.SimulationId `protobuf:"bytes,4,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
+	// "create", "clone", "start", "pause", "stop", "delete", "set_speed" or
+	// "entity_command"
+	Action string `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	// who made the change: the subject of the caller's API key or token,
+	// empty for calls that name no caller
+	Actor        string                    `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	SourceIp     string                    `protobuf:"bytes,7,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	RequestId    string                    `protobuf:"bytes,8,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	StatusBefore commonpb.SimulationStatus `protobuf:"varint,9,opt,name=status_before,json=statusBefore,proto3,enum=autofarm.common.SimulationStatus" json:"status_before,omitempty"`
+	StatusAfter  commonpb.SimulationStatus `protobuf:"varint,10,opt,name=status_after,json=statusAfter,proto3,enum=autofarm.common.SimulationStatus" json:"status_after,omitempty"`
+	// what changed, e.g. "multiplier 2" or "entity 3: move_to"
+	Detail        string `protobuf:"bytes,11,opt,name=detail,proto3" json:"detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSimulationRequest) Reset() {
-	*x = GetSimulationRequest{}
-	mi := &file_simulation_proto_msgTypes[11]
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_simulation_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSimulationRequest) String() string {
+func (x *AuditEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSimulationRequest) ProtoMessage() {}
+func (*AuditEvent) ProtoMessage() {}
 
-func (x *GetSimulationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[11]
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -607,94 +6655,117 @@ func (x *GetSimulationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSimulationRequest.ProtoReflect.Descriptor instead.
-func (*GetSimulationRequest) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *GetSimulationRequest) GetId() *commonpb.SimulationId {
+func (x *AuditEvent) GetId() uint64 {
 	if x != nil {
 		return x.Id
 	}
+	return 0
+}
+
+func (x *AuditEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
 	return nil
 }
 
-type GetSimulationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Simulation    *Simulation            `protobuf:"bytes,1,opt,name=simulation,proto3" json:"simulation,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AuditEvent) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
 }
 
-func (x *GetSimulationResponse) Reset() {
-	*x = GetSimulationResponse{}
-	mi := &file_simulation_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AuditEvent) GetSimulationId() *commonpb.SimulationId {
+	if x != nil {
+		return x.SimulationId
+	}
+	return nil
 }
 
-func (x *GetSimulationResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
 }
 
-func (*GetSimulationResponse) ProtoMessage() {}
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
 
-func (x *GetSimulationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[12]
+func (x *AuditEvent) GetSourceIp() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SourceIp
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetStatusBefore() commonpb.SimulationStatus {
+	if x != nil {
+		return x.StatusBefore
 	}
-	return mi.MessageOf(x)
+	return commonpb.SimulationStatus(0)
 }
 
-// Deprecated: Use GetSimulationResponse.ProtoReflect.Descriptor instead.
-func (*GetSimulationResponse) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{12}
+func (x *AuditEvent) GetStatusAfter() commonpb.SimulationStatus {
+	if x != nil {
+		return x.StatusAfter
+	}
+	return commonpb.SimulationStatus(0)
 }
 
-func (x *GetSimulationResponse) GetSimulation() *Simulation {
+func (x *AuditEvent) GetDetail() string {
 	if x != nil {
-		return x.Simulation
+		return x.Detail
 	}
-	return nil
+	return ""
 }
 
-type EntityState struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	EntityId uint64                 `protobuf:"varint,1,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
-	// position
-	X float64 `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
-	Y float64 `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
-	// velocity
-	Vx float64 `protobuf:"fixed64,4,opt,name=vx,proto3" json:"vx,omitempty"`
-	Vy float64 `protobuf:"fixed64,5,opt,name=vy,proto3" json:"vy,omitempty"`
-	// battery/energy percentage (0–100)
-	Battery float64 `protobuf:"fixed64,6,opt,name=battery,proto3" json:"battery,omitempty"`
-	// opaque state, e.g. "idle", "moving", "working"
-	Status        string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+// ListSimulationEventsRequest selects the audit events of one simulation,
+// deleted ones included. Unset times do not limit the events.
+type ListSimulationEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// since is inclusive, until exclusive
+	Since *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Until *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	// keep the latest limit events; 0 means 100, at most 1000
+	Limit         uint32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EntityState) Reset() {
-	*x = EntityState{}
-	mi := &file_simulation_proto_msgTypes[13]
+func (x *ListSimulationEventsRequest) Reset() {
+	*x = ListSimulationEventsRequest{}
+	mi := &file_simulation_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EntityState) String() string {
+func (x *ListSimulationEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EntityState) ProtoMessage() {}
+func (*ListSimulationEventsRequest) ProtoMessage() {}
 
-func (x *EntityState) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[13]
+func (x *ListSimulationEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,90 +6776,113 @@ func (x *EntityState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EntityState.ProtoReflect.Descriptor instead.
-func (*EntityState) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListSimulationEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListSimulationEventsRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *EntityState) GetEntityId() uint64 {
+func (x *ListSimulationEventsRequest) GetId() *commonpb.SimulationId {
 	if x != nil {
-		return x.EntityId
+		return x.Id
 	}
-	return 0
+	return nil
 }
 
-func (x *EntityState) GetX() float64 {
+func (x *ListSimulationEventsRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.X
+		return x.Since
 	}
-	return 0
+	return nil
 }
 
-func (x *EntityState) GetY() float64 {
+func (x *ListSimulationEventsRequest) GetUntil() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Y
+		return x.Until
 	}
-	return 0
+	return nil
 }
 
-func (x *EntityState) GetVx() float64 {
+func (x *ListSimulationEventsRequest) GetLimit() uint32 {
 	if x != nil {
-		return x.Vx
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *EntityState) GetVy() float64 {
-	if x != nil {
-		return x.Vy
-	}
-	return 0
+type ListSimulationEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// oldest first
+	Events        []*AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EntityState) GetBattery() float64 {
+func (x *ListSimulationEventsResponse) Reset() {
+	*x = ListSimulationEventsResponse{}
+	mi := &file_simulation_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSimulationEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSimulationEventsResponse) ProtoMessage() {}
+
+func (x *ListSimulationEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[90]
 	if x != nil {
-		return x.Battery
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *EntityState) GetStatus() string {
+// Deprecated: Use ListSimulationEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListSimulationEventsResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ListSimulationEventsResponse) GetEvents() []*AuditEvent {
 	if x != nil {
-		return x.Status
+		return x.Events
 	}
-	return ""
+	return nil
 }
 
-// What the orchestrator sends to workers per tick
-type SimulationTickRequest struct {
-	state        protoimpl.MessageState `protogen:"open.v1"`
-	SimulationId *commonpb.SimulationId `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
-	// monotonically increasing tick index
-	Tick uint64 `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
-	// subset of entity ids this worker should process
-	EntityIds []uint64 `protobuf:"varint,3,rep,packed,name=entity_ids,json=entityIds,proto3" json:"entity_ids,omitempty"`
-	// config snapshot for this simulation (duplicated for stateless workers)
-	Config        *SimulationConfig      `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
-	ScheduledAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+// ListAuditEventsRequest selects the audit events of the caller's tenant.
+type ListAuditEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Since *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	// only events of this action or actor, when set
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Actor  string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// as in ListSimulationEventsRequest
+	Limit         uint32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimulationTickRequest) Reset() {
-	*x = SimulationTickRequest{}
-	mi := &file_simulation_proto_msgTypes[14]
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_simulation_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SimulationTickRequest) String() string {
+func (x *ListAuditEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimulationTickRequest) ProtoMessage() {}
+func (*ListAuditEventsRequest) ProtoMessage() {}
 
-func (x *SimulationTickRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[14]
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -799,74 +6893,68 @@ func (x *SimulationTickRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimulationTickRequest.ProtoReflect.Descriptor instead.
-func (*SimulationTickRequest) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *SimulationTickRequest) GetSimulationId() *commonpb.SimulationId {
+func (x *ListAuditEventsRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.SimulationId
+		return x.Since
 	}
 	return nil
 }
 
-func (x *SimulationTickRequest) GetTick() uint64 {
+func (x *ListAuditEventsRequest) GetUntil() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Tick
+		return x.Until
 	}
-	return 0
+	return nil
 }
 
-func (x *SimulationTickRequest) GetEntityIds() []uint64 {
+func (x *ListAuditEventsRequest) GetAction() string {
 	if x != nil {
-		return x.EntityIds
+		return x.Action
 	}
-	return nil
+	return ""
 }
 
-func (x *SimulationTickRequest) GetConfig() *SimulationConfig {
+func (x *ListAuditEventsRequest) GetActor() string {
 	if x != nil {
-		return x.Config
+		return x.Actor
 	}
-	return nil
+	return ""
 }
 
-func (x *SimulationTickRequest) GetScheduledAt() *timestamppb.Timestamp {
+func (x *ListAuditEventsRequest) GetLimit() uint32 {
 	if x != nil {
-		return x.ScheduledAt
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-// What workers send back to orchestrator
-type SimulationTickResult struct {
-	state        protoimpl.MessageState `protogen:"open.v1"`
-	SimulationId *commonpb.SimulationId `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
-	Tick         uint64                 `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
-	// fully updated entity state for this worker's partition
-	Entities []*EntityState `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
-	// per-worker metrics
-	ComputeMs     float64 `protobuf:"fixed64,4,opt,name=compute_ms,json=computeMs,proto3" json:"compute_ms,omitempty"`
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimulationTickResult) Reset() {
-	*x = SimulationTickResult{}
-	mi := &file_simulation_proto_msgTypes[15]
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_simulation_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SimulationTickResult) String() string {
+func (x *ListAuditEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SimulationTickResult) ProtoMessage() {}
+func (*ListAuditEventsResponse) ProtoMessage() {}
 
-func (x *SimulationTickResult) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[15]
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,68 +6965,96 @@ func (x *SimulationTickResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SimulationTickResult.ProtoReflect.Descriptor instead.
-func (*SimulationTickResult) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *SimulationTickResult) GetSimulationId() *commonpb.SimulationId {
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
 	if x != nil {
-		return x.SimulationId
+		return x.Events
 	}
 	return nil
 }
 
-func (x *SimulationTickResult) GetTick() uint64 {
-	if x != nil {
-		return x.Tick
-	}
-	return 0
+type WatchSimulationStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *commonpb.SimulationId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SimulationTickResult) GetEntities() []*EntityState {
+func (x *WatchSimulationStatusRequest) Reset() {
+	*x = WatchSimulationStatusRequest{}
+	mi := &file_simulation_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSimulationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSimulationStatusRequest) ProtoMessage() {}
+
+func (x *WatchSimulationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[93]
 	if x != nil {
-		return x.Entities
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *SimulationTickResult) GetComputeMs() float64 {
+// Deprecated: Use WatchSimulationStatusRequest.ProtoReflect.Descriptor instead.
+func (*WatchSimulationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *WatchSimulationStatusRequest) GetId() *commonpb.SimulationId {
 	if x != nil {
-		return x.ComputeMs
+		return x.Id
 	}
-	return 0
+	return nil
 }
 
-// Aggregated state that the orchestrator pushes toward the dashboard layer
-type AggregatedTick struct {
-	state        protoimpl.MessageState `protogen:"open.v1"`
-	SimulationId *commonpb.SimulationId `protobuf:"bytes,1,opt,name=simulation_id,json=simulationId,proto3" json:"simulation_id,omitempty"`
-	Tick         uint64                 `protobuf:"varint,2,opt,name=tick,proto3" json:"tick,omitempty"`
-	Entities     []*EntityState         `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
-	// simple metrics for the whole simulation at this tick
-	AvgComputeMs  float64                `protobuf:"fixed64,4,opt,name=avg_compute_ms,json=avgComputeMs,proto3" json:"avg_compute_ms,omitempty"`
-	WorkerCount   uint32                 `protobuf:"varint,5,opt,name=worker_count,json=workerCount,proto3" json:"worker_count,omitempty"`
-	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+// SimulationStatusEvent is a change of a simulation's status. The first
+// event of a watch is the status the simulation had when it began, with no
+// previous status or action.
+type SimulationStatusEvent struct {
+	state          protoimpl.MessageState    `protogen:"open.v1"`
+	Id             *commonpb.SimulationId    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time           *timestamppb.Timestamp    `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	PreviousStatus commonpb.SimulationStatus `protobuf:"varint,3,opt,name=previous_status,json=previousStatus,proto3,enum=autofarm.common.SimulationStatus" json:"previous_status,omitempty"`
+	Status         commonpb.SimulationStatus `protobuf:"varint,4,opt,name=status,proto3,enum=autofarm.common.SimulationStatus" json:"status,omitempty"`
+	// "start", "pause", "stop", "delete", "complete" or "fail"
+	Action string `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	// why the simulation completed or failed
+	Reason string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	// the simulation right after the change
+	Simulation    *Simulation `protobuf:"bytes,7,opt,name=simulation,proto3" json:"simulation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AggregatedTick) Reset() {
-	*x = AggregatedTick{}
-	mi := &file_simulation_proto_msgTypes[16]
+func (x *SimulationStatusEvent) Reset() {
+	*x = SimulationStatusEvent{}
+	mi := &file_simulation_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AggregatedTick) String() string {
+func (x *SimulationStatusEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AggregatedTick) ProtoMessage() {}
+func (*SimulationStatusEvent) ProtoMessage() {}
 
-func (x *AggregatedTick) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_proto_msgTypes[16]
+func (x *SimulationStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -949,49 +7065,56 @@ func (x *AggregatedTick) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AggregatedTick.ProtoReflect.Descriptor instead.
-func (*AggregatedTick) Descriptor() ([]byte, []int) {
-	return file_simulation_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use SimulationStatusEvent.ProtoReflect.Descriptor instead.
+func (*SimulationStatusEvent) Descriptor() ([]byte, []int) {
+	return file_simulation_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *AggregatedTick) GetSimulationId() *commonpb.SimulationId {
+func (x *SimulationStatusEvent) GetId() *commonpb.SimulationId {
 	if x != nil {
-		return x.SimulationId
+		return x.Id
 	}
 	return nil
 }
 
-func (x *AggregatedTick) GetTick() uint64 {
+func (x *SimulationStatusEvent) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Tick
+		return x.Time
 	}
-	return 0
+	return nil
 }
 
-func (x *AggregatedTick) GetEntities() []*EntityState {
+func (x *SimulationStatusEvent) GetPreviousStatus() commonpb.SimulationStatus {
 	if x != nil {
-		return x.Entities
+		return x.PreviousStatus
 	}
-	return nil
+	return commonpb.SimulationStatus(0)
 }
 
-func (x *AggregatedTick) GetAvgComputeMs() float64 {
+func (x *SimulationStatusEvent) GetStatus() commonpb.SimulationStatus {
 	if x != nil {
-		return x.AvgComputeMs
+		return x.Status
 	}
-	return 0
+	return commonpb.SimulationStatus(0)
 }
 
-func (x *AggregatedTick) GetWorkerCount() uint32 {
+func (x *SimulationStatusEvent) GetAction() string {
 	if x != nil {
-		return x.WorkerCount
+		return x.Action
 	}
-	return 0
+	return ""
 }
 
-func (x *AggregatedTick) GetCompletedAt() *timestamppb.Timestamp {
+func (x *SimulationStatusEvent) GetReason() string {
 	if x != nil {
-		return x.CompletedAt
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SimulationStatusEvent) GetSimulation() *Simulation {
+	if x != nil {
+		return x.Simulation
 	}
 	return nil
 }
@@ -1000,13 +7123,132 @@ var File_simulation_proto protoreflect.FileDescriptor
 
 const file_simulation_proto_rawDesc = "" +
 	"\n" +
-	"\x10simulation.proto\x12\x13autofarm.simulation\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\x90\x01\n" +
+	"\x10simulation.proto\x12\x13autofarm.simulation\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\x93\x02\n" +
+	"\x10EntityTypeParams\x123\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1f.autofarm.simulation.EntityTypeR\x04type\x12\x1b\n" +
+	"\tmax_speed\x18\x02 \x01(\x01R\bmaxSpeed\x12)\n" +
+	"\x10battery_capacity\x18\x03 \x01(\x01R\x0fbatteryCapacity\x12\x1d\n" +
+	"\n" +
+	"drain_rate\x18\x04 \x01(\x01R\tdrainRate\x12)\n" +
+	"\x10payload_capacity\x18\x05 \x01(\x01R\x0fpayloadCapacity\x128\n" +
+	"\x18ignores_ground_obstacles\x18\x06 \x01(\bR\x16ignoresGroundObstacles\"G\n" +
+	"\x0fEntityPlacement\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\x12\x18\n" +
+	"\abattery\x18\x03 \x01(\x01R\abattery\"\x9d\x01\n" +
+	"\n" +
+	"FleetGroup\x123\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1f.autofarm.simulation.EntityTypeR\x04type\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x12D\n" +
+	"\n" +
+	"placements\x18\x03 \x03(\v2$.autofarm.simulation.EntityPlacementR\n" +
+	"placements\"t\n" +
+	"\bObstacle\x12\x13\n" +
+	"\x05min_x\x18\x01 \x01(\x01R\x04minX\x12\x13\n" +
+	"\x05min_y\x18\x02 \x01(\x01R\x04minY\x12\x13\n" +
+	"\x05max_x\x18\x03 \x01(\x01R\x04maxX\x12\x13\n" +
+	"\x05max_y\x18\x04 \x01(\x01R\x04maxY\x12\x14\n" +
+	"\x05label\x18\x05 \x01(\tR\x05label\"\x8c\x01\n" +
+	"\fTerrainPatch\x12\x13\n" +
+	"\x05min_x\x18\x01 \x01(\x01R\x04minX\x12\x13\n" +
+	"\x05min_y\x18\x02 \x01(\x01R\x04minY\x12\x13\n" +
+	"\x05max_x\x18\x03 \x01(\x01R\x04maxX\x12\x13\n" +
+	"\x05max_y\x18\x04 \x01(\x01R\x04maxY\x12\x12\n" +
+	"\x04cost\x18\x05 \x01(\x01R\x04cost\x12\x14\n" +
+	"\x05label\x18\x06 \x01(\tR\x05label\"\xf3\x01\n" +
+	"\x0fWorldDefinition\x12\x1b\n" +
+	"\tcell_size\x18\x01 \x01(\x01R\bcellSize\x12;\n" +
+	"\tobstacles\x18\x02 \x03(\v2\x1d.autofarm.simulation.ObstacleR\tobstacles\x12I\n" +
+	"\x0epath_algorithm\x18\x03 \x01(\x0e2\".autofarm.simulation.PathAlgorithmR\rpathAlgorithm\x12;\n" +
+	"\aterrain\x18\x04 \x03(\v2!.autofarm.simulation.TerrainPatchR\aterrain\"V\n" +
+	"\x11EnvironmentConfig\x12\x1d\n" +
+	"\n" +
+	"start_hour\x18\x01 \x01(\x01R\tstartHour\x12\"\n" +
+	"\rday_length_ms\x18\x02 \x01(\x04R\vdayLengthMs\"\xe3\x01\n" +
+	"\aWeather\x12C\n" +
+	"\tcondition\x18\x01 \x01(\x0e2%.autofarm.simulation.WeatherConditionR\tcondition\x12\x1f\n" +
+	"\vcloud_cover\x18\x02 \x01(\x01R\n" +
+	"cloudCover\x12%\n" +
+	"\x0erain_intensity\x18\x03 \x01(\x01R\rrainIntensity\x12\x1d\n" +
+	"\n" +
+	"wind_speed\x18\x04 \x01(\x01R\twindSpeed\x12,\n" +
+	"\x12wind_direction_deg\x18\x05 \x01(\x01R\x10windDirectionDeg\"\xd7\x01\n" +
+	"\vEnvironment\x12)\n" +
+	"\x11time_of_day_hours\x18\x01 \x01(\x01R\x0etimeOfDayHours\x12\x10\n" +
+	"\x03day\x18\x02 \x01(\x04R\x03day\x12\x1f\n" +
+	"\vlight_level\x18\x03 \x01(\x01R\n" +
+	"lightLevel\x12\x12\n" +
+	"\x04dark\x18\x04 \x01(\bR\x04dark\x126\n" +
+	"\aweather\x18\x05 \x01(\v2\x1c.autofarm.simulation.WeatherR\aweather\x12\x1e\n" +
+	"\n" +
+	"overridden\x18\x06 \x01(\bR\n" +
+	"overridden\"\xc8\x01\n" +
+	"\x11EnvironmentChange\x12\x13\n" +
+	"\x05at_ms\x18\x01 \x01(\x04R\x04atMs\x126\n" +
+	"\aweather\x18\x02 \x01(\v2\x1c.autofarm.simulation.WeatherR\aweather\x12%\n" +
+	"\x0fset_time_of_day\x18\x03 \x01(\bR\fsetTimeOfDay\x12)\n" +
+	"\x11time_of_day_hours\x18\x04 \x01(\x01R\x0etimeOfDayHours\x12\x14\n" +
+	"\x05clear\x18\x05 \x01(\bR\x05clear\"\x9b\x01\n" +
+	"\vTermination\x12\x1b\n" +
+	"\tmax_ticks\x18\x01 \x01(\x04R\bmaxTicks\x12%\n" +
+	"\x0fmax_sim_time_ms\x18\x02 \x01(\x04R\fmaxSimTimeMs\x12%\n" +
+	"\x0etasks_complete\x18\x03 \x01(\bR\rtasksComplete\x12!\n" +
+	"\ftarget_yield\x18\x04 \x01(\x01R\vtargetYield\"u\n" +
+	"\tCropPatch\x12\x13\n" +
+	"\x05min_x\x18\x01 \x01(\x01R\x04minX\x12\x13\n" +
+	"\x05min_y\x18\x02 \x01(\x01R\x04minY\x12\x13\n" +
+	"\x05max_x\x18\x03 \x01(\x01R\x04maxX\x12\x13\n" +
+	"\x05max_y\x18\x04 \x01(\x01R\x04maxY\x12\x14\n" +
+	"\x05label\x18\x05 \x01(\tR\x05label\"\x8c\x02\n" +
+	"\n" +
+	"CropConfig\x126\n" +
+	"\x06fields\x18\x01 \x03(\v2\x1e.autofarm.simulation.CropPatchR\x06fields\x12\x1b\n" +
+	"\tcell_size\x18\x02 \x01(\x01R\bcellSize\x12 \n" +
+	"\fdays_to_ripe\x18\x03 \x01(\x01R\n" +
+	"daysToRipe\x12\x1b\n" +
+	"\tripe_days\x18\x04 \x01(\x01R\bripeDays\x12\x1d\n" +
+	"\n" +
+	"spoil_days\x18\x05 \x01(\x01R\tspoilDays\x12$\n" +
+	"\x0eyield_per_cell\x18\x06 \x01(\x01R\fyieldPerCell\x12%\n" +
+	"\x0emanual_harvest\x18\a \x01(\bR\rmanualHarvest\"\x96\x01\n" +
+	"\bCropCell\x12\x10\n" +
+	"\x03col\x18\x01 \x01(\rR\x03col\x12\x10\n" +
+	"\x03row\x18\x02 \x01(\rR\x03row\x12\f\n" +
+	"\x01x\x18\x03 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x04 \x01(\x01R\x01y\x124\n" +
+	"\x05stage\x18\x05 \x01(\x0e2\x1e.autofarm.simulation.CropStageR\x05stage\x12\x14\n" +
+	"\x05yield\x18\x06 \x01(\x01R\x05yield\"\x98\x02\n" +
+	"\vCropSummary\x12\x1f\n" +
+	"\vtotal_yield\x18\x01 \x01(\x01R\n" +
+	"totalYield\x12#\n" +
+	"\rplanted_cells\x18\x02 \x01(\rR\fplantedCells\x12'\n" +
+	"\x0fharvested_cells\x18\x03 \x01(\rR\x0eharvestedCells\x12\x1d\n" +
+	"\n" +
+	"ripe_cells\x18\x04 \x01(\rR\tripeCells\x12#\n" +
+	"\rspoiled_cells\x18\x05 \x01(\rR\fspoiledCells\x12+\n" +
+	"\x11harvestable_cells\x18\x06 \x01(\rR\x10harvestableCells\x12)\n" +
+	"\x10harvestable_area\x18\a \x01(\x01R\x0fharvestableArea\"\xd4\a\n" +
 	"\x10SimulationConfig\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
 	"\fentity_count\x18\x02 \x01(\rR\ventityCount\x12 \n" +
 	"\ftick_rate_ms\x18\x03 \x01(\rR\n" +
 	"tickRateMs\x12#\n" +
-	"\rscenario_type\x18\x04 \x01(\tR\fscenarioType\"\xe2\x02\n" +
+	"\rscenario_type\x18\x04 \x01(\tR\fscenarioType\x12(\n" +
+	"\x10tick_deadline_ms\x18\x05 \x01(\rR\x0etickDeadlineMs\x12M\n" +
+	"\x0eoverrun_policy\x18\x06 \x01(\x0e2&.autofarm.simulation.TickOverrunPolicyR\roverrunPolicy\x12%\n" +
+	"\x0epipeline_depth\x18\a \x01(\rR\rpipelineDepth\x125\n" +
+	"\x05fleet\x18\b \x03(\v2\x1f.autofarm.simulation.FleetGroupR\x05fleet\x12H\n" +
+	"\fentity_types\x18\t \x03(\v2%.autofarm.simulation.EntityTypeParamsR\ventityTypes\x12T\n" +
+	"\x0ftask_allocation\x18\n" +
+	" \x01(\x0e2+.autofarm.simulation.TaskAllocationStrategyR\x0etaskAllocation\x12:\n" +
+	"\x05world\x18\v \x01(\v2$.autofarm.simulation.WorldDefinitionR\x05world\x12\x12\n" +
+	"\x04seed\x18\f \x01(\x04R\x04seed\x12H\n" +
+	"\venvironment\x18\r \x01(\v2&.autofarm.simulation.EnvironmentConfigR\venvironment\x125\n" +
+	"\x05crops\x18\x0e \x01(\v2\x1f.autofarm.simulation.CropConfigR\x05crops\x12/\n" +
+	"\x05tasks\x18\x0f \x03(\v2\x19.autofarm.simulation.TaskR\x05tasks\x12M\n" +
+	"\x0eweather_script\x18\x10 \x03(\v2&.autofarm.simulation.EnvironmentChangeR\rweatherScript\x12B\n" +
+	"\vtermination\x18\x11 \x01(\v2 .autofarm.simulation.TerminationR\vtermination\x126\n" +
+	"\x17snapshot_interval_ticks\x18\x12 \x01(\rR\x15snapshotIntervalTicks\"\x8d\x05\n" +
 	"\n" +
 	"Simulation\x12-\n" +
 	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x12=\n" +
@@ -1016,9 +7258,71 @@ const file_simulation_proto_rawDesc = "" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
-	"\bended_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\"X\n" +
+	"\bended_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12)\n" +
+	"\x10speed_multiplier\x18\a \x01(\x01R\x0fspeedMultiplier\x12!\n" +
+	"\ffast_forward\x18\b \x01(\bR\vfastForward\x12\x1d\n" +
+	"\n" +
+	"end_reason\x18\t \x01(\tR\tendReason\x120\n" +
+	"\x14source_simulation_id\x18\n" +
+	" \x01(\tR\x12sourceSimulationId\x12(\n" +
+	"\x10forked_from_tick\x18\v \x01(\x04R\x0eforkedFromTick\x12#\n" +
+	"\rtemplate_name\x18\f \x01(\tR\ftemplateName\x12#\n" +
+	"\rexperiment_id\x18\r \x01(\tR\fexperimentId\x12\x16\n" +
+	"\x06tenant\x18\x0e \x01(\tR\x06tenant\"\xd7\x02\n" +
+	"\x0fConfigOverrides\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04seed\x18\x02 \x01(\x04R\x04seed\x12%\n" +
+	"\x0erandomize_seed\x18\x03 \x01(\bR\rrandomizeSeed\x12!\n" +
+	"\fentity_count\x18\x04 \x01(\rR\ventityCount\x125\n" +
+	"\x05fleet\x18\x05 \x03(\v2\x1f.autofarm.simulation.FleetGroupR\x05fleet\x12 \n" +
+	"\ftick_rate_ms\x18\x06 \x01(\rR\n" +
+	"tickRateMs\x12#\n" +
+	"\rscenario_type\x18\a \x01(\tR\fscenarioType\x12T\n" +
+	"\x0ftask_allocation\x18\b \x01(\x0e2+.autofarm.simulation.TaskAllocationStrategyR\x0etaskAllocation\"\x93\x02\n" +
 	"\x17CreateSimulationRequest\x12=\n" +
-	"\x06config\x18\x01 \x01(\v2%.autofarm.simulation.SimulationConfigR\x06config\"[\n" +
+	"\x06config\x18\x01 \x01(\v2%.autofarm.simulation.SimulationConfigR\x06config\x12+\n" +
+	"\x11scenario_document\x18\x02 \x01(\fR\x10scenarioDocument\x12#\n" +
+	"\rscenario_name\x18\x03 \x01(\tR\fscenarioName\x12#\n" +
+	"\rtemplate_name\x18\x04 \x01(\tR\ftemplateName\x12B\n" +
+	"\toverrides\x18\x05 \x01(\v2$.autofarm.simulation.ConfigOverridesR\toverrides\"\xbc\x01\n" +
+	"\x16CloneSimulationRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x12B\n" +
+	"\toverrides\x18\x02 \x01(\v2$.autofarm.simulation.ConfigOverridesR\toverrides\x12\x12\n" +
+	"\x04fork\x18\x03 \x01(\bR\x04fork\x12\x1b\n" +
+	"\tfork_tick\x18\x04 \x01(\x04R\bforkTick\"Z\n" +
+	"\x17CloneSimulationResponse\x12?\n" +
+	"\n" +
+	"simulation\x18\x01 \x01(\v2\x1f.autofarm.simulation.SimulationR\n" +
+	"simulation\"\x97\x02\n" +
+	"\x12SimulationTemplate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12=\n" +
+	"\x06config\x18\x03 \x01(\v2%.autofarm.simulation.SimulationConfigR\x06config\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x16\n" +
+	"\x06tenant\x18\x06 \x01(\tR\x06tenant\"\x89\x01\n" +
+	"\x15CreateTemplateRequest\x12C\n" +
+	"\btemplate\x18\x01 \x01(\v2'.autofarm.simulation.SimulationTemplateR\btemplate\x12+\n" +
+	"\x11scenario_document\x18\x02 \x01(\fR\x10scenarioDocument\"]\n" +
+	"\x16CreateTemplateResponse\x12C\n" +
+	"\btemplate\x18\x01 \x01(\v2'.autofarm.simulation.SimulationTemplateR\btemplate\"(\n" +
+	"\x12GetTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"Z\n" +
+	"\x13GetTemplateResponse\x12C\n" +
+	"\btemplate\x18\x01 \x01(\v2'.autofarm.simulation.SimulationTemplateR\btemplate\"\x16\n" +
+	"\x14ListTemplatesRequest\"^\n" +
+	"\x15ListTemplatesResponse\x12E\n" +
+	"\ttemplates\x18\x01 \x03(\v2'.autofarm.simulation.SimulationTemplateR\ttemplates\"\x89\x01\n" +
+	"\x15UpdateTemplateRequest\x12C\n" +
+	"\btemplate\x18\x01 \x01(\v2'.autofarm.simulation.SimulationTemplateR\btemplate\x12+\n" +
+	"\x11scenario_document\x18\x02 \x01(\fR\x10scenarioDocument\"]\n" +
+	"\x16UpdateTemplateResponse\x12C\n" +
+	"\btemplate\x18\x01 \x01(\v2'.autofarm.simulation.SimulationTemplateR\btemplate\"+\n" +
+	"\x15DeleteTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x18\n" +
+	"\x16DeleteTemplateResponse\"[\n" +
 	"\x18CreateSimulationResponse\x12?\n" +
 	"\n" +
 	"simulation\x18\x01 \x01(\v2\x1f.autofarm.simulation.SimulationR\n" +
@@ -1042,13 +7346,152 @@ const file_simulation_proto_rawDesc = "" +
 	"\x16StopSimulationResponse\x12?\n" +
 	"\n" +
 	"simulation\x18\x01 \x01(\v2\x1f.autofarm.simulation.SimulationR\n" +
-	"simulation\"E\n" +
+	"simulation\"H\n" +
+	"\x17DeleteSimulationRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\"\x1a\n" +
+	"\x18DeleteSimulationResponse\"\x8d\x01\n" +
+	"\x19SetSimulationSpeedRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x02 \x01(\x01R\n" +
+	"multiplier\x12!\n" +
+	"\ffast_forward\x18\x03 \x01(\bR\vfastForward\"]\n" +
+	"\x1aSetSimulationSpeedResponse\x12?\n" +
+	"\n" +
+	"simulation\x18\x01 \x01(\v2\x1f.autofarm.simulation.SimulationR\n" +
+	"simulation\"\\\n" +
+	"\x15StepSimulationRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x12\x14\n" +
+	"\x05ticks\x18\x02 \x01(\rR\x05ticks\"\x94\x01\n" +
+	"\x16StepSimulationResponse\x12?\n" +
+	"\n" +
+	"simulation\x18\x01 \x01(\v2\x1f.autofarm.simulation.SimulationR\n" +
+	"simulation\x129\n" +
+	"\x05ticks\x18\x02 \x03(\v2#.autofarm.simulation.AggregatedTickR\x05ticks\"\xe6\x01\n" +
+	"\x15SetEnvironmentRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x126\n" +
+	"\aweather\x18\x02 \x01(\v2\x1c.autofarm.simulation.WeatherR\aweather\x12%\n" +
+	"\x0fset_time_of_day\x18\x03 \x01(\bR\fsetTimeOfDay\x12)\n" +
+	"\x11time_of_day_hours\x18\x04 \x01(\x01R\x0etimeOfDayHours\x12\x14\n" +
+	"\x05clear\x18\x05 \x01(\bR\x05clear\"\\\n" +
+	"\x16SetEnvironmentResponse\x12B\n" +
+	"\venvironment\x18\x01 \x01(\v2 .autofarm.simulation.EnvironmentR\venvironment\"@\n" +
+	"\x0fGetCropsRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\"\xa0\x01\n" +
+	"\x10GetCropsResponse\x12:\n" +
+	"\asummary\x18\x01 \x01(\v2 .autofarm.simulation.CropSummaryR\asummary\x12\x1b\n" +
+	"\tcell_size\x18\x02 \x01(\x01R\bcellSize\x123\n" +
+	"\x05cells\x18\x03 \x03(\v2\x1d.autofarm.simulation.CropCellR\x05cells\"\x16\n" +
+	"\x14ListScenariosRequest\"Z\n" +
+	"\fScenarioInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"X\n" +
+	"\x15ListScenariosResponse\x12?\n" +
+	"\tscenarios\x18\x01 \x03(\v2!.autofarm.simulation.ScenarioInfoR\tscenarios\"\x93\x01\n" +
+	"\x13ExperimentParameter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\x01R\x06values\x12\x10\n" +
+	"\x03min\x18\x03 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x04 \x01(\x01R\x03max\x12\x12\n" +
+	"\x04step\x18\x05 \x01(\x01R\x04step\x12\x18\n" +
+	"\achoices\x18\x06 \x03(\tR\achoices\"\x81\x03\n" +
+	"\x0eExperimentSpec\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12F\n" +
+	"\vbase_config\x18\x02 \x01(\v2%.autofarm.simulation.SimulationConfigR\n" +
+	"baseConfig\x12#\n" +
+	"\rbase_template\x18\x03 \x01(\tR\fbaseTemplate\x12H\n" +
+	"\n" +
+	"parameters\x18\x04 \x03(\v2(.autofarm.simulation.ExperimentParameterR\n" +
+	"parameters\x12C\n" +
+	"\bsampling\x18\x05 \x01(\x0e2'.autofarm.simulation.ExperimentSamplingR\bsampling\x12\x18\n" +
+	"\asamples\x18\x06 \x01(\rR\asamples\x12#\n" +
+	"\rsampling_seed\x18\a \x01(\x04R\fsamplingSeed\x12 \n" +
+	"\vconcurrency\x18\b \x01(\rR\vconcurrency\"\xae\x03\n" +
+	"\x14ExperimentRunSummary\x12\x14\n" +
+	"\x05ticks\x18\x01 \x01(\x04R\x05ticks\x12\x1e\n" +
+	"\vsim_time_ms\x18\x02 \x01(\x04R\tsimTimeMs\x12\x1d\n" +
+	"\n" +
+	"end_reason\x18\x03 \x01(\tR\tendReason\x12\x1f\n" +
+	"\vtotal_yield\x18\x04 \x01(\x01R\n" +
+	"totalYield\x12'\n" +
+	"\x0fharvested_cells\x18\x05 \x01(\rR\x0eharvestedCells\x12#\n" +
+	"\rspoiled_cells\x18\x06 \x01(\rR\fspoiledCells\x12\x1f\n" +
+	"\vtasks_total\x18\a \x01(\rR\n" +
+	"tasksTotal\x12'\n" +
+	"\x0ftasks_completed\x18\b \x01(\rR\x0etasksCompleted\x12\x1a\n" +
+	"\bentities\x18\t \x01(\rR\bentities\x12\x1f\n" +
+	"\vavg_battery\x18\n" +
+	" \x01(\x01R\n" +
+	"avgBattery\x12)\n" +
+	"\x10offline_entities\x18\v \x01(\rR\x0fofflineEntities\x12 \n" +
+	"\fwall_time_ms\x18\f \x01(\x01R\n" +
+	"wallTimeMs\"\xee\x03\n" +
+	"\rExperimentRun\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12F\n" +
+	"\x06values\x18\x02 \x03(\v2..autofarm.simulation.ExperimentRun.ValuesEntryR\x06values\x12I\n" +
+	"\achoices\x18\x03 \x03(\v2/.autofarm.simulation.ExperimentRun.ChoicesEntryR\achoices\x12=\n" +
+	"\x05state\x18\x04 \x01(\x0e2'.autofarm.simulation.ExperimentRunStateR\x05state\x12#\n" +
+	"\rsimulation_id\x18\x05 \x01(\tR\fsimulationId\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12C\n" +
+	"\asummary\x18\a \x01(\v2).autofarm.simulation.ExperimentRunSummaryR\asummary\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a:\n" +
+	"\fChoicesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa3\x04\n" +
+	"\n" +
+	"Experiment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\x04spec\x18\x02 \x01(\v2#.autofarm.simulation.ExperimentSpecR\x04spec\x12:\n" +
+	"\x05state\x18\x03 \x01(\x0e2$.autofarm.simulation.ExperimentStateR\x05state\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x125\n" +
+	"\bended_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12\x1d\n" +
+	"\n" +
+	"total_runs\x18\x06 \x01(\rR\ttotalRuns\x12\x1f\n" +
+	"\vqueued_runs\x18\a \x01(\rR\n" +
+	"queuedRuns\x12!\n" +
+	"\frunning_runs\x18\b \x01(\rR\vrunningRuns\x12%\n" +
+	"\x0ecompleted_runs\x18\t \x01(\rR\rcompletedRuns\x12\x1f\n" +
+	"\vfailed_runs\x18\n" +
+	" \x01(\rR\n" +
+	"failedRuns\x12#\n" +
+	"\rcanceled_runs\x18\v \x01(\rR\fcanceledRuns\x126\n" +
+	"\x04runs\x18\f \x03(\v2\".autofarm.simulation.ExperimentRunR\x04runs\x12\x16\n" +
+	"\x06tenant\x18\r \x01(\tR\x06tenant\"R\n" +
+	"\x17CreateExperimentRequest\x127\n" +
+	"\x04spec\x18\x01 \x01(\v2#.autofarm.simulation.ExperimentSpecR\x04spec\"[\n" +
+	"\x18CreateExperimentResponse\x12?\n" +
+	"\n" +
+	"experiment\x18\x01 \x01(\v2\x1f.autofarm.simulation.ExperimentR\n" +
+	"experiment\"&\n" +
+	"\x14GetExperimentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"X\n" +
+	"\x15GetExperimentResponse\x12?\n" +
+	"\n" +
+	"experiment\x18\x01 \x01(\v2\x1f.autofarm.simulation.ExperimentR\n" +
+	"experiment\"\x18\n" +
+	"\x16ListExperimentsRequest\"\\\n" +
+	"\x17ListExperimentsResponse\x12A\n" +
+	"\vexperiments\x18\x01 \x03(\v2\x1f.autofarm.simulation.ExperimentR\vexperiments\")\n" +
+	"\x17CancelExperimentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"[\n" +
+	"\x18CancelExperimentResponse\x12?\n" +
+	"\n" +
+	"experiment\x18\x01 \x01(\v2\x1f.autofarm.simulation.ExperimentR\n" +
+	"experiment\"E\n" +
 	"\x14GetSimulationRequest\x12-\n" +
 	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\"X\n" +
 	"\x15GetSimulationResponse\x12?\n" +
 	"\n" +
 	"simulation\x18\x01 \x01(\v2\x1f.autofarm.simulation.SimulationR\n" +
-	"simulation\"\x98\x01\n" +
+	"simulation\"S\n" +
+	"\x16ListSimulationsRequest\x129\n" +
+	"\x06status\x18\x01 \x01(\x0e2!.autofarm.common.SimulationStatusR\x06status\"\\\n" +
+	"\x17ListSimulationsResponse\x12A\n" +
+	"\vsimulations\x18\x01 \x03(\v2\x1f.autofarm.simulation.SimulationR\vsimulations\"\xe6\x01\n" +
 	"\vEntityState\x12\x1b\n" +
 	"\tentity_id\x18\x01 \x01(\x04R\bentityId\x12\f\n" +
 	"\x01x\x18\x02 \x01(\x01R\x01x\x12\f\n" +
@@ -1056,34 +7499,273 @@ const file_simulation_proto_rawDesc = "" +
 	"\x02vx\x18\x04 \x01(\x01R\x02vx\x12\x0e\n" +
 	"\x02vy\x18\x05 \x01(\x01R\x02vy\x12\x18\n" +
 	"\abattery\x18\x06 \x01(\x01R\abattery\x12\x16\n" +
-	"\x06status\x18\a \x01(\tR\x06status\"\x8c\x02\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x123\n" +
+	"\x04type\x18\b \x01(\x0e2\x1f.autofarm.simulation.EntityTypeR\x04type\x12\x17\n" +
+	"\atask_id\x18\t \x01(\x04R\x06taskId\"#\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\"\x92\x04\n" +
+	"\x04Task\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x121\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1d.autofarm.simulation.TaskTypeR\x04type\x124\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1e.autofarm.simulation.TaskStateR\x05state\x128\n" +
+	"\twaypoints\x18\x04 \x03(\v2\x1a.autofarm.simulation.PointR\twaypoints\x12\x1d\n" +
+	"\n" +
+	"work_ticks\x18\x05 \x01(\rR\tworkTicks\x12\x12\n" +
+	"\x04load\x18\x06 \x01(\x01R\x04load\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\x05R\bpriority\x12F\n" +
+	"\x0eeligible_types\x18\b \x03(\x0e2\x1f.autofarm.simulation.EntityTypeR\religibleTypes\x12,\n" +
+	"\x12assigned_entity_id\x18\t \x01(\x04R\x10assignedEntityId\x12!\n" +
+	"\fcreated_tick\x18\n" +
+	" \x01(\x04R\vcreatedTick\x12#\n" +
+	"\rassigned_tick\x18\v \x01(\x04R\fassignedTick\x12%\n" +
+	"\x0ecompleted_tick\x18\f \x01(\x04R\rcompletedTick\x12\x1a\n" +
+	"\battempts\x18\r \x01(\rR\battempts\"\xa3\x01\n" +
+	"\tTaskEvent\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x04R\x06taskId\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\x04R\bentityId\x124\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1e.autofarm.simulation.TaskStateR\x05state\x12\x12\n" +
+	"\x04tick\x18\x04 \x01(\x04R\x04tick\x12\x16\n" +
+	"\x06detail\x18\x05 \x01(\tR\x06detail\"t\n" +
+	"\x12SubmitTasksRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x12/\n" +
+	"\x05tasks\x18\x02 \x03(\v2\x19.autofarm.simulation.TaskR\x05tasks\"F\n" +
+	"\x13SubmitTasksResponse\x12/\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x19.autofarm.simulation.TaskR\x05tasks\"w\n" +
+	"\x10ListTasksRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x124\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x1e.autofarm.simulation.TaskStateR\x05state\"D\n" +
+	"\x11ListTasksResponse\x12/\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x19.autofarm.simulation.TaskR\x05tasks\"\xc3\x01\n" +
+	"\rEntityCommand\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\x04R\tcommandId\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\x04R\bentityId\x12:\n" +
+	"\x04type\x18\x03 \x01(\x0e2&.autofarm.simulation.EntityCommandTypeR\x04type\x12\f\n" +
+	"\x01x\x18\x04 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x05 \x01(\x01R\x01y\x12\x0e\n" +
+	"\x02vx\x18\x06 \x01(\x01R\x02vx\x12\x0e\n" +
+	"\x02vy\x18\a \x01(\x01R\x02vy\"\x92\x01\n" +
+	"\x10EntityCommandAck\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\x04R\tcommandId\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\x04R\bentityId\x12\x12\n" +
+	"\x04tick\x18\x03 \x01(\x04R\x04tick\x12\x18\n" +
+	"\aapplied\x18\x04 \x01(\bR\aapplied\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\x87\x01\n" +
+	"\x18SendEntityCommandRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x12<\n" +
+	"\acommand\x18\x02 \x01(\v2\".autofarm.simulation.EntityCommandR\acommand\"Y\n" +
+	"\x19SendEntityCommandResponse\x12<\n" +
+	"\acommand\x18\x01 \x01(\v2\".autofarm.simulation.EntityCommandR\acommand\"\xc2\x01\n" +
+	"\vEntitySpawn\x12\x1b\n" +
+	"\tentity_id\x18\x01 \x01(\x04R\bentityId\x12E\n" +
+	"\rinitial_state\x18\x02 \x01(\v2 .autofarm.simulation.EntityStateR\finitialState\x123\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1f.autofarm.simulation.EntityTypeR\x04type\x12\x1a\n" +
+	"\brestored\x18\x04 \x01(\bR\brestored\"\xf6\x01\n" +
+	"\x14ScaleEntitiesRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x126\n" +
+	"\x05spawn\x18\x02 \x03(\v2 .autofarm.simulation.EntityStateR\x05spawn\x12\x1f\n" +
+	"\vspawn_count\x18\x03 \x01(\rR\n" +
+	"spawnCount\x12\x16\n" +
+	"\x06retire\x18\x04 \x03(\x04R\x06retire\x12>\n" +
+	"\n" +
+	"spawn_type\x18\x05 \x01(\x0e2\x1f.autofarm.simulation.EntityTypeR\tspawnType\"\x9a\x01\n" +
+	"\x15ScaleEntitiesResponse\x12?\n" +
+	"\n" +
+	"simulation\x18\x01 \x01(\v2\x1f.autofarm.simulation.SimulationR\n" +
+	"simulation\x12\x1f\n" +
+	"\vspawned_ids\x18\x02 \x03(\x04R\n" +
+	"spawnedIds\x12\x1f\n" +
+	"\vretired_ids\x18\x03 \x03(\x04R\n" +
+	"retiredIds\"\xbe\x04\n" +
 	"\x15SimulationTickRequest\x12B\n" +
 	"\rsimulation_id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\fsimulationId\x12\x12\n" +
 	"\x04tick\x18\x02 \x01(\x04R\x04tick\x12\x1d\n" +
 	"\n" +
 	"entity_ids\x18\x03 \x03(\x04R\tentityIds\x12=\n" +
 	"\x06config\x18\x04 \x01(\v2%.autofarm.simulation.SimulationConfigR\x06config\x12=\n" +
-	"\fscheduled_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\"\xcb\x01\n" +
+	"\fscheduled_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\x12>\n" +
+	"\bcommands\x18\x06 \x03(\v2\".autofarm.simulation.EntityCommandR\bcommands\x128\n" +
+	"\x06spawns\x18\a \x03(\v2 .autofarm.simulation.EntitySpawnR\x06spawns\x12,\n" +
+	"\x12retired_entity_ids\x18\b \x03(\x04R\x10retiredEntityIds\x12D\n" +
+	"\x10task_assignments\x18\t \x03(\v2\x19.autofarm.simulation.TaskR\x0ftaskAssignments\x12B\n" +
+	"\venvironment\x18\n" +
+	" \x01(\v2 .autofarm.simulation.EnvironmentR\venvironment\"\xcb\x01\n" +
 	"\x14SimulationTickResult\x12B\n" +
 	"\rsimulation_id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\fsimulationId\x12\x12\n" +
 	"\x04tick\x18\x02 \x01(\x04R\x04tick\x12<\n" +
 	"\bentities\x18\x03 \x03(\v2 .autofarm.simulation.EntityStateR\bentities\x12\x1d\n" +
 	"\n" +
-	"compute_ms\x18\x04 \x01(\x01R\tcomputeMs\"\xae\x02\n" +
+	"compute_ms\x18\x04 \x01(\x01R\tcomputeMs\"\xa8\b\n" +
 	"\x0eAggregatedTick\x12B\n" +
 	"\rsimulation_id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\fsimulationId\x12\x12\n" +
 	"\x04tick\x18\x02 \x01(\x04R\x04tick\x12<\n" +
 	"\bentities\x18\x03 \x03(\v2 .autofarm.simulation.EntityStateR\bentities\x12$\n" +
 	"\x0eavg_compute_ms\x18\x04 \x01(\x01R\favgComputeMs\x12!\n" +
 	"\fworker_count\x18\x05 \x01(\rR\vworkerCount\x12=\n" +
-	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt2\xa6\x05\n" +
+	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12=\n" +
+	"\fscheduled_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\x126\n" +
+	"\bdeadline\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12\x12\n" +
+	"\x04late\x18\t \x01(\bR\x04late\x12\x1d\n" +
+	"\n" +
+	"overrun_ms\x18\n" +
+	" \x01(\x01R\toverrunMs\x12#\n" +
+	"\rskipped_ticks\x18\v \x01(\x04R\fskippedTicks\x12\x1e\n" +
+	"\vsim_time_ms\x18\f \x01(\x04R\tsimTimeMs\x12)\n" +
+	"\x10speed_multiplier\x18\r \x01(\x01R\x0fspeedMultiplier\x12!\n" +
+	"\ffast_forward\x18\x0e \x01(\bR\vfastForward\x12H\n" +
+	"\fcommand_acks\x18\x0f \x03(\v2%.autofarm.simulation.EntityCommandAckR\vcommandAcks\x12?\n" +
+	"\vtask_events\x18\x10 \x03(\v2\x1e.autofarm.simulation.TaskEventR\n" +
+	"taskEvents\x12m\n" +
+	"\x14compute_breakdown_ms\x18\x11 \x03(\v2;.autofarm.simulation.AggregatedTick.ComputeBreakdownMsEntryR\x12computeBreakdownMs\x12B\n" +
+	"\venvironment\x18\x12 \x01(\v2 .autofarm.simulation.EnvironmentR\venvironment\x126\n" +
+	"\x05crops\x18\x13 \x01(\v2 .autofarm.simulation.CropSummaryR\x05crops\x1aE\n" +
+	"\x17ComputeBreakdownMsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xb8\x03\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x06tenant\x18\x03 \x01(\tR\x06tenant\x12B\n" +
+	"\rsimulation_id\x18\x04 \x01(\v2\x1d.autofarm.common.SimulationIdR\fsimulationId\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\x12\x1b\n" +
+	"\tsource_ip\x18\a \x01(\tR\bsourceIp\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\b \x01(\tR\trequestId\x12F\n" +
+	"\rstatus_before\x18\t \x01(\x0e2!.autofarm.common.SimulationStatusR\fstatusBefore\x12D\n" +
+	"\fstatus_after\x18\n" +
+	" \x01(\x0e2!.autofarm.common.SimulationStatusR\vstatusAfter\x12\x16\n" +
+	"\x06detail\x18\v \x01(\tR\x06detail\"\xc6\x01\n" +
+	"\x1bListSimulationEventsRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\rR\x05limit\"W\n" +
+	"\x1cListSimulationEventsResponse\x127\n" +
+	"\x06events\x18\x01 \x03(\v2\x1f.autofarm.simulation.AuditEventR\x06events\"\xc0\x01\n" +
+	"\x16ListAuditEventsRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\rR\x05limit\"R\n" +
+	"\x17ListAuditEventsResponse\x127\n" +
+	"\x06events\x18\x01 \x03(\v2\x1f.autofarm.simulation.AuditEventR\x06events\"M\n" +
+	"\x1cWatchSimulationStatusRequest\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\"\xee\x02\n" +
+	"\x15SimulationStatusEvent\x12-\n" +
+	"\x02id\x18\x01 \x01(\v2\x1d.autofarm.common.SimulationIdR\x02id\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12J\n" +
+	"\x0fprevious_status\x18\x03 \x01(\x0e2!.autofarm.common.SimulationStatusR\x0epreviousStatus\x129\n" +
+	"\x06status\x18\x04 \x01(\x0e2!.autofarm.common.SimulationStatusR\x06status\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12?\n" +
+	"\n" +
+	"simulation\x18\a \x01(\v2\x1f.autofarm.simulation.SimulationR\n" +
+	"simulation*\x9a\x01\n" +
+	"\x11TickOverrunPolicy\x12#\n" +
+	"\x1fTICK_OVERRUN_POLICY_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dTICK_OVERRUN_POLICY_MARK_LATE\x10\x01\x12\x1c\n" +
+	"\x18TICK_OVERRUN_POLICY_SKIP\x10\x02\x12\x1f\n" +
+	"\x1bTICK_OVERRUN_POLICY_STRETCH\x10\x03*t\n" +
+	"\n" +
+	"EntityType\x12\x1b\n" +
+	"\x17ENTITY_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13ENTITY_TYPE_TRACTOR\x10\x01\x12\x15\n" +
+	"\x11ENTITY_TYPE_DRONE\x10\x02\x12\x19\n" +
+	"\x15ENTITY_TYPE_HARVESTER\x10\x03*\xbd\x01\n" +
+	"\x16TaskAllocationStrategy\x12(\n" +
+	"$TASK_ALLOCATION_STRATEGY_UNSPECIFIED\x10\x00\x12+\n" +
+	"'TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST\x10\x01\x12$\n" +
+	" TASK_ALLOCATION_STRATEGY_AUCTION\x10\x02\x12&\n" +
+	"\"TASK_ALLOCATION_STRATEGY_HUNGARIAN\x10\x03*a\n" +
+	"\rPathAlgorithm\x12\x1e\n" +
+	"\x1aPATH_ALGORITHM_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12PATH_ALGORITHM_JPS\x10\x01\x12\x18\n" +
+	"\x14PATH_ALGORITHM_ASTAR\x10\x02*\xa9\x01\n" +
+	"\x10WeatherCondition\x12!\n" +
+	"\x1dWEATHER_CONDITION_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17WEATHER_CONDITION_CLEAR\x10\x01\x12\x1c\n" +
+	"\x18WEATHER_CONDITION_CLOUDY\x10\x02\x12\x1a\n" +
+	"\x16WEATHER_CONDITION_RAIN\x10\x03\x12\x1b\n" +
+	"\x17WEATHER_CONDITION_STORM\x10\x04*\xd5\x01\n" +
+	"\tCropStage\x12\x1a\n" +
+	"\x16CROP_STAGE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CROP_STAGE_SEEDLING\x10\x01\x12\x19\n" +
+	"\x15CROP_STAGE_VEGETATIVE\x10\x02\x12\x18\n" +
+	"\x14CROP_STAGE_FLOWERING\x10\x03\x12\x13\n" +
+	"\x0fCROP_STAGE_RIPE\x10\x04\x12\x17\n" +
+	"\x13CROP_STAGE_OVERRIPE\x10\x05\x12\x16\n" +
+	"\x12CROP_STAGE_SPOILED\x10\x06\x12\x18\n" +
+	"\x14CROP_STAGE_HARVESTED\x10\a*w\n" +
+	"\x12ExperimentSampling\x12#\n" +
+	"\x1fEXPERIMENT_SAMPLING_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18EXPERIMENT_SAMPLING_GRID\x10\x01\x12\x1e\n" +
+	"\x1aEXPERIMENT_SAMPLING_RANDOM\x10\x02*\x90\x01\n" +
+	"\x0fExperimentState\x12 \n" +
+	"\x1cEXPERIMENT_STATE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18EXPERIMENT_STATE_RUNNING\x10\x01\x12\x1e\n" +
+	"\x1aEXPERIMENT_STATE_COMPLETED\x10\x02\x12\x1d\n" +
+	"\x19EXPERIMENT_STATE_CANCELED\x10\x03*\xe5\x01\n" +
+	"\x12ExperimentRunState\x12$\n" +
+	" EXPERIMENT_RUN_STATE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bEXPERIMENT_RUN_STATE_QUEUED\x10\x01\x12 \n" +
+	"\x1cEXPERIMENT_RUN_STATE_RUNNING\x10\x02\x12\"\n" +
+	"\x1eEXPERIMENT_RUN_STATE_COMPLETED\x10\x03\x12\x1f\n" +
+	"\x1bEXPERIMENT_RUN_STATE_FAILED\x10\x04\x12!\n" +
+	"\x1dEXPERIMENT_RUN_STATE_CANCELED\x10\x05*k\n" +
+	"\bTaskType\x12\x19\n" +
+	"\x15TASK_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TASK_TYPE_HARVEST\x10\x01\x12\x14\n" +
+	"\x10TASK_TYPE_PATROL\x10\x02\x12\x17\n" +
+	"\x13TASK_TYPE_TRANSPORT\x10\x03*\x89\x01\n" +
+	"\tTaskState\x12\x1a\n" +
+	"\x16TASK_STATE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12TASK_STATE_PENDING\x10\x01\x12\x17\n" +
+	"\x13TASK_STATE_ASSIGNED\x10\x02\x12\x18\n" +
+	"\x14TASK_STATE_COMPLETED\x10\x03\x12\x15\n" +
+	"\x11TASK_STATE_FAILED\x10\x04*\xe8\x01\n" +
+	"\x11EntityCommandType\x12#\n" +
+	"\x1fENTITY_COMMAND_TYPE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bENTITY_COMMAND_TYPE_MOVE_TO\x10\x01\x12$\n" +
+	" ENTITY_COMMAND_TYPE_SET_VELOCITY\x10\x02\x12&\n" +
+	"\"ENTITY_COMMAND_TYPE_RETURN_TO_BASE\x10\x03\x12\x1f\n" +
+	"\x1bENTITY_COMMAND_TYPE_DISABLE\x10\x04\x12\x1e\n" +
+	"\x1aENTITY_COMMAND_TYPE_ENABLE\x10\x052\xc3\x19\n" +
 	"\x11SimulationService\x12o\n" +
 	"\x10CreateSimulation\x12,.autofarm.simulation.CreateSimulationRequest\x1a-.autofarm.simulation.CreateSimulationResponse\x12l\n" +
 	"\x0fStartSimulation\x12+.autofarm.simulation.StartSimulationRequest\x1a,.autofarm.simulation.StartSimulationResponse\x12l\n" +
 	"\x0fPauseSimulation\x12+.autofarm.simulation.PauseSimulationRequest\x1a,.autofarm.simulation.PauseSimulationResponse\x12i\n" +
-	"\x0eStopSimulation\x12*.autofarm.simulation.StopSimulationRequest\x1a+.autofarm.simulation.StopSimulationResponse\x12f\n" +
-	"\rGetSimulation\x12).autofarm.simulation.GetSimulationRequest\x1a*.autofarm.simulation.GetSimulationResponse\x12q\n" +
-	"\x15StreamAggregatedTicks\x121.autofarm.simulation.StreamAggregatedTicksRequest\x1a#.autofarm.simulation.AggregatedTick0\x01B=Z;github.com/stevenmed26/AutoFarm/internal/proto/simulationpbb\x06proto3"
+	"\x0eStopSimulation\x12*.autofarm.simulation.StopSimulationRequest\x1a+.autofarm.simulation.StopSimulationResponse\x12o\n" +
+	"\x10DeleteSimulation\x12,.autofarm.simulation.DeleteSimulationRequest\x1a-.autofarm.simulation.DeleteSimulationResponse\x12f\n" +
+	"\rGetSimulation\x12).autofarm.simulation.GetSimulationRequest\x1a*.autofarm.simulation.GetSimulationResponse\x12l\n" +
+	"\x0fListSimulations\x12+.autofarm.simulation.ListSimulationsRequest\x1a,.autofarm.simulation.ListSimulationsResponse\x12u\n" +
+	"\x12SetSimulationSpeed\x12..autofarm.simulation.SetSimulationSpeedRequest\x1a/.autofarm.simulation.SetSimulationSpeedResponse\x12i\n" +
+	"\x0eStepSimulation\x12*.autofarm.simulation.StepSimulationRequest\x1a+.autofarm.simulation.StepSimulationResponse\x12r\n" +
+	"\x11SendEntityCommand\x12-.autofarm.simulation.SendEntityCommandRequest\x1a..autofarm.simulation.SendEntityCommandResponse\x12f\n" +
+	"\rScaleEntities\x12).autofarm.simulation.ScaleEntitiesRequest\x1a*.autofarm.simulation.ScaleEntitiesResponse\x12`\n" +
+	"\vSubmitTasks\x12'.autofarm.simulation.SubmitTasksRequest\x1a(.autofarm.simulation.SubmitTasksResponse\x12Z\n" +
+	"\tListTasks\x12%.autofarm.simulation.ListTasksRequest\x1a&.autofarm.simulation.ListTasksResponse\x12i\n" +
+	"\x0eSetEnvironment\x12*.autofarm.simulation.SetEnvironmentRequest\x1a+.autofarm.simulation.SetEnvironmentResponse\x12W\n" +
+	"\bGetCrops\x12$.autofarm.simulation.GetCropsRequest\x1a%.autofarm.simulation.GetCropsResponse\x12f\n" +
+	"\rListScenarios\x12).autofarm.simulation.ListScenariosRequest\x1a*.autofarm.simulation.ListScenariosResponse\x12l\n" +
+	"\x0fCloneSimulation\x12+.autofarm.simulation.CloneSimulationRequest\x1a,.autofarm.simulation.CloneSimulationResponse\x12i\n" +
+	"\x0eCreateTemplate\x12*.autofarm.simulation.CreateTemplateRequest\x1a+.autofarm.simulation.CreateTemplateResponse\x12`\n" +
+	"\vGetTemplate\x12'.autofarm.simulation.GetTemplateRequest\x1a(.autofarm.simulation.GetTemplateResponse\x12f\n" +
+	"\rListTemplates\x12).autofarm.simulation.ListTemplatesRequest\x1a*.autofarm.simulation.ListTemplatesResponse\x12i\n" +
+	"\x0eUpdateTemplate\x12*.autofarm.simulation.UpdateTemplateRequest\x1a+.autofarm.simulation.UpdateTemplateResponse\x12i\n" +
+	"\x0eDeleteTemplate\x12*.autofarm.simulation.DeleteTemplateRequest\x1a+.autofarm.simulation.DeleteTemplateResponse\x12o\n" +
+	"\x10CreateExperiment\x12,.autofarm.simulation.CreateExperimentRequest\x1a-.autofarm.simulation.CreateExperimentResponse\x12f\n" +
+	"\rGetExperiment\x12).autofarm.simulation.GetExperimentRequest\x1a*.autofarm.simulation.GetExperimentResponse\x12l\n" +
+	"\x0fListExperiments\x12+.autofarm.simulation.ListExperimentsRequest\x1a,.autofarm.simulation.ListExperimentsResponse\x12o\n" +
+	"\x10CancelExperiment\x12,.autofarm.simulation.CancelExperimentRequest\x1a-.autofarm.simulation.CancelExperimentResponse\x12{\n" +
+	"\x14ListSimulationEvents\x120.autofarm.simulation.ListSimulationEventsRequest\x1a1.autofarm.simulation.ListSimulationEventsResponse\x12l\n" +
+	"\x0fListAuditEvents\x12+.autofarm.simulation.ListAuditEventsRequest\x1a,.autofarm.simulation.ListAuditEventsResponse\x12q\n" +
+	"\x15StreamAggregatedTicks\x121.autofarm.simulation.StreamAggregatedTicksRequest\x1a#.autofarm.simulation.AggregatedTick0\x01\x12x\n" +
+	"\x15WatchSimulationStatus\x121.autofarm.simulation.WatchSimulationStatusRequest\x1a*.autofarm.simulation.SimulationStatusEvent0\x01B=Z;github.com/stevenmed26/AutoFarm/internal/proto/simulationpbb\x06proto3"
 
 var (
 	file_simulation_proto_rawDescOnce sync.Once
@@ -1097,72 +7779,332 @@ func file_simulation_proto_rawDescGZIP() []byte {
 	return file_simulation_proto_rawDescData
 }
 
-var file_simulation_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_simulation_proto_enumTypes = make([]protoimpl.EnumInfo, 12)
+var file_simulation_proto_msgTypes = make([]protoimpl.MessageInfo, 98)
 var file_simulation_proto_goTypes = []any{
-	(*SimulationConfig)(nil),             // 0: autofarm.simulation.SimulationConfig
-	(*Simulation)(nil),                   // 1: autofarm.simulation.Simulation
-	(*CreateSimulationRequest)(nil),      // 2: autofarm.simulation.CreateSimulationRequest
-	(*CreateSimulationResponse)(nil),     // 3: autofarm.simulation.CreateSimulationResponse
-	(*StartSimulationRequest)(nil),       // 4: autofarm.simulation.StartSimulationRequest
-	(*StartSimulationResponse)(nil),      // 5: autofarm.simulation.StartSimulationResponse
-	(*StreamAggregatedTicksRequest)(nil), // 6: autofarm.simulation.StreamAggregatedTicksRequest
-	(*PauseSimulationRequest)(nil),       // 7: autofarm.simulation.PauseSimulationRequest
-	(*PauseSimulationResponse)(nil),      // 8: autofarm.simulation.PauseSimulationResponse
-	(*StopSimulationRequest)(nil),        // 9: autofarm.simulation.StopSimulationRequest
-	(*StopSimulationResponse)(nil),       // 10: autofarm.simulation.StopSimulationResponse
-	(*GetSimulationRequest)(nil),         // 11: autofarm.simulation.GetSimulationRequest
-	(*GetSimulationResponse)(nil),        // 12: autofarm.simulation.GetSimulationResponse
-	(*EntityState)(nil),                  // 13: autofarm.simulation.EntityState
-	(*SimulationTickRequest)(nil),        // 14: autofarm.simulation.SimulationTickRequest
-	(*SimulationTickResult)(nil),         // 15: autofarm.simulation.SimulationTickResult
-	(*AggregatedTick)(nil),               // 16: autofarm.simulation.AggregatedTick
-	(*commonpb.SimulationId)(nil),        // 17: autofarm.common.SimulationId
-	(commonpb.SimulationStatus)(0),       // 18: autofarm.common.SimulationStatus
-	(*timestamppb.Timestamp)(nil),        // 19: google.protobuf.Timestamp
+	(TickOverrunPolicy)(0),               // 0: autofarm.simulation.TickOverrunPolicy
+	(EntityType)(0),                      // 1: autofarm.simulation.EntityType
+	(TaskAllocationStrategy)(0),          // 2: autofarm.simulation.TaskAllocationStrategy
+	(PathAlgorithm)(0),                   // 3: autofarm.simulation.PathAlgorithm
+	(WeatherCondition)(0),                // 4: autofarm.simulation.WeatherCondition
+	(CropStage)(0),                       // 5: autofarm.simulation.CropStage
+	(ExperimentSampling)(0),              // 6: autofarm.simulation.ExperimentSampling
+	(ExperimentState)(0),                 // 7: autofarm.simulation.ExperimentState
+	(ExperimentRunState)(0),              // 8: autofarm.simulation.ExperimentRunState
+	(TaskType)(0),                        // 9: autofarm.simulation.TaskType
+	(TaskState)(0),                       // 10: autofarm.simulation.TaskState
+	(EntityCommandType)(0),               // 11: autofarm.simulation.EntityCommandType
+	(*EntityTypeParams)(nil),             // 12: autofarm.simulation.EntityTypeParams
+	(*EntityPlacement)(nil),              // 13: autofarm.simulation.EntityPlacement
+	(*FleetGroup)(nil),                   // 14: autofarm.simulation.FleetGroup
+	(*Obstacle)(nil),                     // 15: autofarm.simulation.Obstacle
+	(*TerrainPatch)(nil),                 // 16: autofarm.simulation.TerrainPatch
+	(*WorldDefinition)(nil),              // 17: autofarm.simulation.WorldDefinition
+	(*EnvironmentConfig)(nil),            // 18: autofarm.simulation.EnvironmentConfig
+	(*Weather)(nil),                      // 19: autofarm.simulation.Weather
+	(*Environment)(nil),                  // 20: autofarm.simulation.Environment
+	(*EnvironmentChange)(nil),            // 21: autofarm.simulation.EnvironmentChange
+	(*Termination)(nil),                  // 22: autofarm.simulation.Termination
+	(*CropPatch)(nil),                    // 23: autofarm.simulation.CropPatch
+	(*CropConfig)(nil),                   // 24: autofarm.simulation.CropConfig
+	(*CropCell)(nil),                     // 25: autofarm.simulation.CropCell
+	(*CropSummary)(nil),                  // 26: autofarm.simulation.CropSummary
+	(*SimulationConfig)(nil),             // 27: autofarm.simulation.SimulationConfig
+	(*Simulation)(nil),                   // 28: autofarm.simulation.Simulation
+	(*ConfigOverrides)(nil),              // 29: autofarm.simulation.ConfigOverrides
+	(*CreateSimulationRequest)(nil),      // 30: autofarm.simulation.CreateSimulationRequest
+	(*CloneSimulationRequest)(nil),       // 31: autofarm.simulation.CloneSimulationRequest
+	(*CloneSimulationResponse)(nil),      // 32: autofarm.simulation.CloneSimulationResponse
+	(*SimulationTemplate)(nil),           // 33: autofarm.simulation.SimulationTemplate
+	(*CreateTemplateRequest)(nil),        // 34: autofarm.simulation.CreateTemplateRequest
+	(*CreateTemplateResponse)(nil),       // 35: autofarm.simulation.CreateTemplateResponse
+	(*GetTemplateRequest)(nil),           // 36: autofarm.simulation.GetTemplateRequest
+	(*GetTemplateResponse)(nil),          // 37: autofarm.simulation.GetTemplateResponse
+	(*ListTemplatesRequest)(nil),         // 38: autofarm.simulation.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),        // 39: autofarm.simulation.ListTemplatesResponse
+	(*UpdateTemplateRequest)(nil),        // 40: autofarm.simulation.UpdateTemplateRequest
+	(*UpdateTemplateResponse)(nil),       // 41: autofarm.simulation.UpdateTemplateResponse
+	(*DeleteTemplateRequest)(nil),        // 42: autofarm.simulation.DeleteTemplateRequest
+	(*DeleteTemplateResponse)(nil),       // 43: autofarm.simulation.DeleteTemplateResponse
+	(*CreateSimulationResponse)(nil),     // 44: autofarm.simulation.CreateSimulationResponse
+	(*StartSimulationRequest)(nil),       // 45: autofarm.simulation.StartSimulationRequest
+	(*StartSimulationResponse)(nil),      // 46: autofarm.simulation.StartSimulationResponse
+	(*StreamAggregatedTicksRequest)(nil), // 47: autofarm.simulation.StreamAggregatedTicksRequest
+	(*PauseSimulationRequest)(nil),       // 48: autofarm.simulation.PauseSimulationRequest
+	(*PauseSimulationResponse)(nil),      // 49: autofarm.simulation.PauseSimulationResponse
+	(*StopSimulationRequest)(nil),        // 50: autofarm.simulation.StopSimulationRequest
+	(*StopSimulationResponse)(nil),       // 51: autofarm.simulation.StopSimulationResponse
+	(*DeleteSimulationRequest)(nil),      // 52: autofarm.simulation.DeleteSimulationRequest
+	(*DeleteSimulationResponse)(nil),     // 53: autofarm.simulation.DeleteSimulationResponse
+	(*SetSimulationSpeedRequest)(nil),    // 54: autofarm.simulation.SetSimulationSpeedRequest
+	(*SetSimulationSpeedResponse)(nil),   // 55: autofarm.simulation.SetSimulationSpeedResponse
+	(*StepSimulationRequest)(nil),        // 56: autofarm.simulation.StepSimulationRequest
+	(*StepSimulationResponse)(nil),       // 57: autofarm.simulation.StepSimulationResponse
+	(*SetEnvironmentRequest)(nil),        // 58: autofarm.simulation.SetEnvironmentRequest
+	(*SetEnvironmentResponse)(nil),       // 59: autofarm.simulation.SetEnvironmentResponse
+	(*GetCropsRequest)(nil),              // 60: autofarm.simulation.GetCropsRequest
+	(*GetCropsResponse)(nil),             // 61: autofarm.simulation.GetCropsResponse
+	(*ListScenariosRequest)(nil),         // 62: autofarm.simulation.ListScenariosRequest
+	(*ScenarioInfo)(nil),                 // 63: autofarm.simulation.ScenarioInfo
+	(*ListScenariosResponse)(nil),        // 64: autofarm.simulation.ListScenariosResponse
+	(*ExperimentParameter)(nil),          // 65: autofarm.simulation.ExperimentParameter
+	(*ExperimentSpec)(nil),               // 66: autofarm.simulation.ExperimentSpec
+	(*ExperimentRunSummary)(nil),         // 67: autofarm.simulation.ExperimentRunSummary
+	(*ExperimentRun)(nil),                // 68: autofarm.simulation.ExperimentRun
+	(*Experiment)(nil),                   // 69: autofarm.simulation.Experiment
+	(*CreateExperimentRequest)(nil),      // 70: autofarm.simulation.CreateExperimentRequest
+	(*CreateExperimentResponse)(nil),     // 71: autofarm.simulation.CreateExperimentResponse
+	(*GetExperimentRequest)(nil),         // 72: autofarm.simulation.GetExperimentRequest
+	(*GetExperimentResponse)(nil),        // 73: autofarm.simulation.GetExperimentResponse
+	(*ListExperimentsRequest)(nil),       // 74: autofarm.simulation.ListExperimentsRequest
+	(*ListExperimentsResponse)(nil),      // 75: autofarm.simulation.ListExperimentsResponse
+	(*CancelExperimentRequest)(nil),      // 76: autofarm.simulation.CancelExperimentRequest
+	(*CancelExperimentResponse)(nil),     // 77: autofarm.simulation.CancelExperimentResponse
+	(*GetSimulationRequest)(nil),         // 78: autofarm.simulation.GetSimulationRequest
+	(*GetSimulationResponse)(nil),        // 79: autofarm.simulation.GetSimulationResponse
+	(*ListSimulationsRequest)(nil),       // 80: autofarm.simulation.ListSimulationsRequest
+	(*ListSimulationsResponse)(nil),      // 81: autofarm.simulation.ListSimulationsResponse
+	(*EntityState)(nil),                  // 82: autofarm.simulation.EntityState
+	(*Point)(nil),                        // 83: autofarm.simulation.Point
+	(*Task)(nil),                         // 84: autofarm.simulation.Task
+	(*TaskEvent)(nil),                    // 85: autofarm.simulation.TaskEvent
+	(*SubmitTasksRequest)(nil),           // 86: autofarm.simulation.SubmitTasksRequest
+	(*SubmitTasksResponse)(nil),          // 87: autofarm.simulation.SubmitTasksResponse
+	(*ListTasksRequest)(nil),             // 88: autofarm.simulation.ListTasksRequest
+	(*ListTasksResponse)(nil),            // 89: autofarm.simulation.ListTasksResponse
+	(*EntityCommand)(nil),                // 90: autofarm.simulation.EntityCommand
+	(*EntityCommandAck)(nil),             // 91: autofarm.simulation.EntityCommandAck
+	(*SendEntityCommandRequest)(nil),     // 92: autofarm.simulation.SendEntityCommandRequest
+	(*SendEntityCommandResponse)(nil),    // 93: autofarm.simulation.SendEntityCommandResponse
+	(*EntitySpawn)(nil),                  // 94: autofarm.simulation.EntitySpawn
+	(*ScaleEntitiesRequest)(nil),         // 95: autofarm.simulation.ScaleEntitiesRequest
+	(*ScaleEntitiesResponse)(nil),        // 96: autofarm.simulation.ScaleEntitiesResponse
+	(*SimulationTickRequest)(nil),        // 97: autofarm.simulation.SimulationTickRequest
+	(*SimulationTickResult)(nil),         // 98: autofarm.simulation.SimulationTickResult
+	(*AggregatedTick)(nil),               // 99: autofarm.simulation.AggregatedTick
+	(*AuditEvent)(nil),                   // 100: autofarm.simulation.AuditEvent
+	(*ListSimulationEventsRequest)(nil),  // 101: autofarm.simulation.ListSimulationEventsRequest
+	(*ListSimulationEventsResponse)(nil), // 102: autofarm.simulation.ListSimulationEventsResponse
+	(*ListAuditEventsRequest)(nil),       // 103: autofarm.simulation.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),      // 104: autofarm.simulation.ListAuditEventsResponse
+	(*WatchSimulationStatusRequest)(nil), // 105: autofarm.simulation.WatchSimulationStatusRequest
+	(*SimulationStatusEvent)(nil),        // 106: autofarm.simulation.SimulationStatusEvent
+	nil,                                  // 107: autofarm.simulation.ExperimentRun.ValuesEntry
+	nil,                                  // 108: autofarm.simulation.ExperimentRun.ChoicesEntry
+	nil,                                  // 109: autofarm.simulation.AggregatedTick.ComputeBreakdownMsEntry
+	(*commonpb.SimulationId)(nil),        // 110: autofarm.common.SimulationId
+	(commonpb.SimulationStatus)(0),       // 111: autofarm.common.SimulationStatus
+	(*timestamppb.Timestamp)(nil),        // 112: google.protobuf.Timestamp
 }
 var file_simulation_proto_depIdxs = []int32{
-	17, // 0: autofarm.simulation.Simulation.id:type_name -> autofarm.common.SimulationId
-	0,  // 1: autofarm.simulation.Simulation.config:type_name -> autofarm.simulation.SimulationConfig
-	18, // 2: autofarm.simulation.Simulation.status:type_name -> autofarm.common.SimulationStatus
-	19, // 3: autofarm.simulation.Simulation.created_at:type_name -> google.protobuf.Timestamp
-	19, // 4: autofarm.simulation.Simulation.started_at:type_name -> google.protobuf.Timestamp
-	19, // 5: autofarm.simulation.Simulation.ended_at:type_name -> google.protobuf.Timestamp
-	0,  // 6: autofarm.simulation.CreateSimulationRequest.config:type_name -> autofarm.simulation.SimulationConfig
-	1,  // 7: autofarm.simulation.CreateSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
-	17, // 8: autofarm.simulation.StartSimulationRequest.id:type_name -> autofarm.common.SimulationId
-	1,  // 9: autofarm.simulation.StartSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
-	17, // 10: autofarm.simulation.StreamAggregatedTicksRequest.id:type_name -> autofarm.common.SimulationId
-	17, // 11: autofarm.simulation.PauseSimulationRequest.id:type_name -> autofarm.common.SimulationId
-	1,  // 12: autofarm.simulation.PauseSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
-	17, // 13: autofarm.simulation.StopSimulationRequest.id:type_name -> autofarm.common.SimulationId
-	1,  // 14: autofarm.simulation.StopSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
-	17, // 15: autofarm.simulation.GetSimulationRequest.id:type_name -> autofarm.common.SimulationId
-	1,  // 16: autofarm.simulation.GetSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
-	17, // 17: autofarm.simulation.SimulationTickRequest.simulation_id:type_name -> autofarm.common.SimulationId
-	0,  // 18: autofarm.simulation.SimulationTickRequest.config:type_name -> autofarm.simulation.SimulationConfig
-	19, // 19: autofarm.simulation.SimulationTickRequest.scheduled_at:type_name -> google.protobuf.Timestamp
-	17, // 20: autofarm.simulation.SimulationTickResult.simulation_id:type_name -> autofarm.common.SimulationId
-	13, // 21: autofarm.simulation.SimulationTickResult.entities:type_name -> autofarm.simulation.EntityState
-	17, // 22: autofarm.simulation.AggregatedTick.simulation_id:type_name -> autofarm.common.SimulationId
-	13, // 23: autofarm.simulation.AggregatedTick.entities:type_name -> autofarm.simulation.EntityState
-	19, // 24: autofarm.simulation.AggregatedTick.completed_at:type_name -> google.protobuf.Timestamp
-	2,  // 25: autofarm.simulation.SimulationService.CreateSimulation:input_type -> autofarm.simulation.CreateSimulationRequest
-	4,  // 26: autofarm.simulation.SimulationService.StartSimulation:input_type -> autofarm.simulation.StartSimulationRequest
-	7,  // 27: autofarm.simulation.SimulationService.PauseSimulation:input_type -> autofarm.simulation.PauseSimulationRequest
-	9,  // 28: autofarm.simulation.SimulationService.StopSimulation:input_type -> autofarm.simulation.StopSimulationRequest
-	11, // 29: autofarm.simulation.SimulationService.GetSimulation:input_type -> autofarm.simulation.GetSimulationRequest
-	6,  // 30: autofarm.simulation.SimulationService.StreamAggregatedTicks:input_type -> autofarm.simulation.StreamAggregatedTicksRequest
-	3,  // 31: autofarm.simulation.SimulationService.CreateSimulation:output_type -> autofarm.simulation.CreateSimulationResponse
-	5,  // 32: autofarm.simulation.SimulationService.StartSimulation:output_type -> autofarm.simulation.StartSimulationResponse
-	8,  // 33: autofarm.simulation.SimulationService.PauseSimulation:output_type -> autofarm.simulation.PauseSimulationResponse
-	10, // 34: autofarm.simulation.SimulationService.StopSimulation:output_type -> autofarm.simulation.StopSimulationResponse
-	12, // 35: autofarm.simulation.SimulationService.GetSimulation:output_type -> autofarm.simulation.GetSimulationResponse
-	16, // 36: autofarm.simulation.SimulationService.StreamAggregatedTicks:output_type -> autofarm.simulation.AggregatedTick
-	31, // [31:37] is the sub-list for method output_type
-	25, // [25:31] is the sub-list for method input_type
-	25, // [25:25] is the sub-list for extension type_name
-	25, // [25:25] is the sub-list for extension extendee
-	0,  // [0:25] is the sub-list for field type_name
+	1,   // 0: autofarm.simulation.EntityTypeParams.type:type_name -> autofarm.simulation.EntityType
+	1,   // 1: autofarm.simulation.FleetGroup.type:type_name -> autofarm.simulation.EntityType
+	13,  // 2: autofarm.simulation.FleetGroup.placements:type_name -> autofarm.simulation.EntityPlacement
+	15,  // 3: autofarm.simulation.WorldDefinition.obstacles:type_name -> autofarm.simulation.Obstacle
+	3,   // 4: autofarm.simulation.WorldDefinition.path_algorithm:type_name -> autofarm.simulation.PathAlgorithm
+	16,  // 5: autofarm.simulation.WorldDefinition.terrain:type_name -> autofarm.simulation.TerrainPatch
+	4,   // 6: autofarm.simulation.Weather.condition:type_name -> autofarm.simulation.WeatherCondition
+	19,  // 7: autofarm.simulation.Environment.weather:type_name -> autofarm.simulation.Weather
+	19,  // 8: autofarm.simulation.EnvironmentChange.weather:type_name -> autofarm.simulation.Weather
+	23,  // 9: autofarm.simulation.CropConfig.fields:type_name -> autofarm.simulation.CropPatch
+	5,   // 10: autofarm.simulation.CropCell.stage:type_name -> autofarm.simulation.CropStage
+	0,   // 11: autofarm.simulation.SimulationConfig.overrun_policy:type_name -> autofarm.simulation.TickOverrunPolicy
+	14,  // 12: autofarm.simulation.SimulationConfig.fleet:type_name -> autofarm.simulation.FleetGroup
+	12,  // 13: autofarm.simulation.SimulationConfig.entity_types:type_name -> autofarm.simulation.EntityTypeParams
+	2,   // 14: autofarm.simulation.SimulationConfig.task_allocation:type_name -> autofarm.simulation.TaskAllocationStrategy
+	17,  // 15: autofarm.simulation.SimulationConfig.world:type_name -> autofarm.simulation.WorldDefinition
+	18,  // 16: autofarm.simulation.SimulationConfig.environment:type_name -> autofarm.simulation.EnvironmentConfig
+	24,  // 17: autofarm.simulation.SimulationConfig.crops:type_name -> autofarm.simulation.CropConfig
+	84,  // 18: autofarm.simulation.SimulationConfig.tasks:type_name -> autofarm.simulation.Task
+	21,  // 19: autofarm.simulation.SimulationConfig.weather_script:type_name -> autofarm.simulation.EnvironmentChange
+	22,  // 20: autofarm.simulation.SimulationConfig.termination:type_name -> autofarm.simulation.Termination
+	110, // 21: autofarm.simulation.Simulation.id:type_name -> autofarm.common.SimulationId
+	27,  // 22: autofarm.simulation.Simulation.config:type_name -> autofarm.simulation.SimulationConfig
+	111, // 23: autofarm.simulation.Simulation.status:type_name -> autofarm.common.SimulationStatus
+	112, // 24: autofarm.simulation.Simulation.created_at:type_name -> google.protobuf.Timestamp
+	112, // 25: autofarm.simulation.Simulation.started_at:type_name -> google.protobuf.Timestamp
+	112, // 26: autofarm.simulation.Simulation.ended_at:type_name -> google.protobuf.Timestamp
+	14,  // 27: autofarm.simulation.ConfigOverrides.fleet:type_name -> autofarm.simulation.FleetGroup
+	2,   // 28: autofarm.simulation.ConfigOverrides.task_allocation:type_name -> autofarm.simulation.TaskAllocationStrategy
+	27,  // 29: autofarm.simulation.CreateSimulationRequest.config:type_name -> autofarm.simulation.SimulationConfig
+	29,  // 30: autofarm.simulation.CreateSimulationRequest.overrides:type_name -> autofarm.simulation.ConfigOverrides
+	110, // 31: autofarm.simulation.CloneSimulationRequest.id:type_name -> autofarm.common.SimulationId
+	29,  // 32: autofarm.simulation.CloneSimulationRequest.overrides:type_name -> autofarm.simulation.ConfigOverrides
+	28,  // 33: autofarm.simulation.CloneSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
+	27,  // 34: autofarm.simulation.SimulationTemplate.config:type_name -> autofarm.simulation.SimulationConfig
+	112, // 35: autofarm.simulation.SimulationTemplate.created_at:type_name -> google.protobuf.Timestamp
+	112, // 36: autofarm.simulation.SimulationTemplate.updated_at:type_name -> google.protobuf.Timestamp
+	33,  // 37: autofarm.simulation.CreateTemplateRequest.template:type_name -> autofarm.simulation.SimulationTemplate
+	33,  // 38: autofarm.simulation.CreateTemplateResponse.template:type_name -> autofarm.simulation.SimulationTemplate
+	33,  // 39: autofarm.simulation.GetTemplateResponse.template:type_name -> autofarm.simulation.SimulationTemplate
+	33,  // 40: autofarm.simulation.ListTemplatesResponse.templates:type_name -> autofarm.simulation.SimulationTemplate
+	33,  // 41: autofarm.simulation.UpdateTemplateRequest.template:type_name -> autofarm.simulation.SimulationTemplate
+	33,  // 42: autofarm.simulation.UpdateTemplateResponse.template:type_name -> autofarm.simulation.SimulationTemplate
+	28,  // 43: autofarm.simulation.CreateSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
+	110, // 44: autofarm.simulation.StartSimulationRequest.id:type_name -> autofarm.common.SimulationId
+	28,  // 45: autofarm.simulation.StartSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
+	110, // 46: autofarm.simulation.StreamAggregatedTicksRequest.id:type_name -> autofarm.common.SimulationId
+	110, // 47: autofarm.simulation.PauseSimulationRequest.id:type_name -> autofarm.common.SimulationId
+	28,  // 48: autofarm.simulation.PauseSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
+	110, // 49: autofarm.simulation.StopSimulationRequest.id:type_name -> autofarm.common.SimulationId
+	28,  // 50: autofarm.simulation.StopSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
+	110, // 51: autofarm.simulation.DeleteSimulationRequest.id:type_name -> autofarm.common.SimulationId
+	110, // 52: autofarm.simulation.SetSimulationSpeedRequest.id:type_name -> autofarm.common.SimulationId
+	28,  // 53: autofarm.simulation.SetSimulationSpeedResponse.simulation:type_name -> autofarm.simulation.Simulation
+	110, // 54: autofarm.simulation.StepSimulationRequest.id:type_name -> autofarm.common.SimulationId
+	28,  // 55: autofarm.simulation.StepSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
+	99,  // 56: autofarm.simulation.StepSimulationResponse.ticks:type_name -> autofarm.simulation.AggregatedTick
+	110, // 57: autofarm.simulation.SetEnvironmentRequest.id:type_name -> autofarm.common.SimulationId
+	19,  // 58: autofarm.simulation.SetEnvironmentRequest.weather:type_name -> autofarm.simulation.Weather
+	20,  // 59: autofarm.simulation.SetEnvironmentResponse.environment:type_name -> autofarm.simulation.Environment
+	110, // 60: autofarm.simulation.GetCropsRequest.id:type_name -> autofarm.common.SimulationId
+	26,  // 61: autofarm.simulation.GetCropsResponse.summary:type_name -> autofarm.simulation.CropSummary
+	25,  // 62: autofarm.simulation.GetCropsResponse.cells:type_name -> autofarm.simulation.CropCell
+	63,  // 63: autofarm.simulation.ListScenariosResponse.scenarios:type_name -> autofarm.simulation.ScenarioInfo
+	27,  // 64: autofarm.simulation.ExperimentSpec.base_config:type_name -> autofarm.simulation.SimulationConfig
+	65,  // 65: autofarm.simulation.ExperimentSpec.parameters:type_name -> autofarm.simulation.ExperimentParameter
+	6,   // 66: autofarm.simulation.ExperimentSpec.sampling:type_name -> autofarm.simulation.ExperimentSampling
+	107, // 67: autofarm.simulation.ExperimentRun.values:type_name -> autofarm.simulation.ExperimentRun.ValuesEntry
+	108, // 68: autofarm.simulation.ExperimentRun.choices:type_name -> autofarm.simulation.ExperimentRun.ChoicesEntry
+	8,   // 69: autofarm.simulation.ExperimentRun.state:type_name -> autofarm.simulation.ExperimentRunState
+	67,  // 70: autofarm.simulation.ExperimentRun.summary:type_name -> autofarm.simulation.ExperimentRunSummary
+	66,  // 71: autofarm.simulation.Experiment.spec:type_name -> autofarm.simulation.ExperimentSpec
+	7,   // 72: autofarm.simulation.Experiment.state:type_name -> autofarm.simulation.ExperimentState
+	112, // 73: autofarm.simulation.Experiment.created_at:type_name -> google.protobuf.Timestamp
+	112, // 74: autofarm.simulation.Experiment.ended_at:type_name -> google.protobuf.Timestamp
+	68,  // 75: autofarm.simulation.Experiment.runs:type_name -> autofarm.simulation.ExperimentRun
+	66,  // 76: autofarm.simulation.CreateExperimentRequest.spec:type_name -> autofarm.simulation.ExperimentSpec
+	69,  // 77: autofarm.simulation.CreateExperimentResponse.experiment:type_name -> autofarm.simulation.Experiment
+	69,  // 78: autofarm.simulation.GetExperimentResponse.experiment:type_name -> autofarm.simulation.Experiment
+	69,  // 79: autofarm.simulation.ListExperimentsResponse.experiments:type_name -> autofarm.simulation.Experiment
+	69,  // 80: autofarm.simulation.CancelExperimentResponse.experiment:type_name -> autofarm.simulation.Experiment
+	110, // 81: autofarm.simulation.GetSimulationRequest.id:type_name -> autofarm.common.SimulationId
+	28,  // 82: autofarm.simulation.GetSimulationResponse.simulation:type_name -> autofarm.simulation.Simulation
+	111, // 83: autofarm.simulation.ListSimulationsRequest.status:type_name -> autofarm.common.SimulationStatus
+	28,  // 84: autofarm.simulation.ListSimulationsResponse.simulations:type_name -> autofarm.simulation.Simulation
+	1,   // 85: autofarm.simulation.EntityState.type:type_name -> autofarm.simulation.EntityType
+	9,   // 86: autofarm.simulation.Task.type:type_name -> autofarm.simulation.TaskType
+	10,  // 87: autofarm.simulation.Task.state:type_name -> autofarm.simulation.TaskState
+	83,  // 88: autofarm.simulation.Task.waypoints:type_name -> autofarm.simulation.Point
+	1,   // 89: autofarm.simulation.Task.eligible_types:type_name -> autofarm.simulation.EntityType
+	10,  // 90: autofarm.simulation.TaskEvent.state:type_name -> autofarm.simulation.TaskState
+	110, // 91: autofarm.simulation.SubmitTasksRequest.id:type_name -> autofarm.common.SimulationId
+	84,  // 92: autofarm.simulation.SubmitTasksRequest.tasks:type_name -> autofarm.simulation.Task
+	84,  // 93: autofarm.simulation.SubmitTasksResponse.tasks:type_name -> autofarm.simulation.Task
+	110, // 94: autofarm.simulation.ListTasksRequest.id:type_name -> autofarm.common.SimulationId
+	10,  // 95: autofarm.simulation.ListTasksRequest.state:type_name -> autofarm.simulation.TaskState
+	84,  // 96: autofarm.simulation.ListTasksResponse.tasks:type_name -> autofarm.simulation.Task
+	11,  // 97: autofarm.simulation.EntityCommand.type:type_name -> autofarm.simulation.EntityCommandType
+	110, // 98: autofarm.simulation.SendEntityCommandRequest.id:type_name -> autofarm.common.SimulationId
+	90,  // 99: autofarm.simulation.SendEntityCommandRequest.command:type_name -> autofarm.simulation.EntityCommand
+	90,  // 100: autofarm.simulation.SendEntityCommandResponse.command:type_name -> autofarm.simulation.EntityCommand
+	82,  // 101: autofarm.simulation.EntitySpawn.initial_state:type_name -> autofarm.simulation.EntityState
+	1,   // 102: autofarm.simulation.EntitySpawn.type:type_name -> autofarm.simulation.EntityType
+	110, // 103: autofarm.simulation.ScaleEntitiesRequest.id:type_name -> autofarm.common.SimulationId
+	82,  // 104: autofarm.simulation.ScaleEntitiesRequest.spawn:type_name -> autofarm.simulation.EntityState
+	1,   // 105: autofarm.simulation.ScaleEntitiesRequest.spawn_type:type_name -> autofarm.simulation.EntityType
+	28,  // 106: autofarm.simulation.ScaleEntitiesResponse.simulation:type_name -> autofarm.simulation.Simulation
+	110, // 107: autofarm.simulation.SimulationTickRequest.simulation_id:type_name -> autofarm.common.SimulationId
+	27,  // 108: autofarm.simulation.SimulationTickRequest.config:type_name -> autofarm.simulation.SimulationConfig
+	112, // 109: autofarm.simulation.SimulationTickRequest.scheduled_at:type_name -> google.protobuf.Timestamp
+	90,  // 110: autofarm.simulation.SimulationTickRequest.commands:type_name -> autofarm.simulation.EntityCommand
+	94,  // 111: autofarm.simulation.SimulationTickRequest.spawns:type_name -> autofarm.simulation.EntitySpawn
+	84,  // 112: autofarm.simulation.SimulationTickRequest.task_assignments:type_name -> autofarm.simulation.Task
+	20,  // 113: autofarm.simulation.SimulationTickRequest.environment:type_name -> autofarm.simulation.Environment
+	110, // 114: autofarm.simulation.SimulationTickResult.simulation_id:type_name -> autofarm.common.SimulationId
+	82,  // 115: autofarm.simulation.SimulationTickResult.entities:type_name -> autofarm.simulation.EntityState
+	110, // 116: autofarm.simulation.AggregatedTick.simulation_id:type_name -> autofarm.common.SimulationId
+	82,  // 117: autofarm.simulation.AggregatedTick.entities:type_name -> autofarm.simulation.EntityState
+	112, // 118: autofarm.simulation.AggregatedTick.completed_at:type_name -> google.protobuf.Timestamp
+	112, // 119: autofarm.simulation.AggregatedTick.scheduled_at:type_name -> google.protobuf.Timestamp
+	112, // 120: autofarm.simulation.AggregatedTick.deadline:type_name -> google.protobuf.Timestamp
+	91,  // 121: autofarm.simulation.AggregatedTick.command_acks:type_name -> autofarm.simulation.EntityCommandAck
+	85,  // 122: autofarm.simulation.AggregatedTick.task_events:type_name -> autofarm.simulation.TaskEvent
+	109, // 123: autofarm.simulation.AggregatedTick.compute_breakdown_ms:type_name -> autofarm.simulation.AggregatedTick.ComputeBreakdownMsEntry
+	20,  // 124: autofarm.simulation.AggregatedTick.environment:type_name -> autofarm.simulation.Environment
+	26,  // 125: autofarm.simulation.AggregatedTick.crops:type_name -> autofarm.simulation.CropSummary
+	112, // 126: autofarm.simulation.AuditEvent.time:type_name -> google.protobuf.Timestamp
+	110, // 127: autofarm.simulation.AuditEvent.simulation_id:type_name -> autofarm.common.SimulationId
+	111, // 128: autofarm.simulation.AuditEvent.status_before:type_name -> autofarm.common.SimulationStatus
+	111, // 129: autofarm.simulation.AuditEvent.status_after:type_name -> autofarm.common.SimulationStatus
+	110, // 130: autofarm.simulation.ListSimulationEventsRequest.id:type_name -> autofarm.common.SimulationId
+	112, // 131: autofarm.simulation.ListSimulationEventsRequest.since:type_name -> google.protobuf.Timestamp
+	112, // 132: autofarm.simulation.ListSimulationEventsRequest.until:type_name -> google.protobuf.Timestamp
+	100, // 133: autofarm.simulation.ListSimulationEventsResponse.events:type_name -> autofarm.simulation.AuditEvent
+	112, // 134: autofarm.simulation.ListAuditEventsRequest.since:type_name -> google.protobuf.Timestamp
+	112, // 135: autofarm.simulation.ListAuditEventsRequest.until:type_name -> google.protobuf.Timestamp
+	100, // 136: autofarm.simulation.ListAuditEventsResponse.events:type_name -> autofarm.simulation.AuditEvent
+	110, // 137: autofarm.simulation.WatchSimulationStatusRequest.id:type_name -> autofarm.common.SimulationId
+	110, // 138: autofarm.simulation.SimulationStatusEvent.id:type_name -> autofarm.common.SimulationId
+	112, // 139: autofarm.simulation.SimulationStatusEvent.time:type_name -> google.protobuf.Timestamp
+	111, // 140: autofarm.simulation.SimulationStatusEvent.previous_status:type_name -> autofarm.common.SimulationStatus
+	111, // 141: autofarm.simulation.SimulationStatusEvent.status:type_name -> autofarm.common.SimulationStatus
+	28,  // 142: autofarm.simulation.SimulationStatusEvent.simulation:type_name -> autofarm.simulation.Simulation
+	30,  // 143: autofarm.simulation.SimulationService.CreateSimulation:input_type -> autofarm.simulation.CreateSimulationRequest
+	45,  // 144: autofarm.simulation.SimulationService.StartSimulation:input_type -> autofarm.simulation.StartSimulationRequest
+	48,  // 145: autofarm.simulation.SimulationService.PauseSimulation:input_type -> autofarm.simulation.PauseSimulationRequest
+	50,  // 146: autofarm.simulation.SimulationService.StopSimulation:input_type -> autofarm.simulation.StopSimulationRequest
+	52,  // 147: autofarm.simulation.SimulationService.DeleteSimulation:input_type -> autofarm.simulation.DeleteSimulationRequest
+	78,  // 148: autofarm.simulation.SimulationService.GetSimulation:input_type -> autofarm.simulation.GetSimulationRequest
+	80,  // 149: autofarm.simulation.SimulationService.ListSimulations:input_type -> autofarm.simulation.ListSimulationsRequest
+	54,  // 150: autofarm.simulation.SimulationService.SetSimulationSpeed:input_type -> autofarm.simulation.SetSimulationSpeedRequest
+	56,  // 151: autofarm.simulation.SimulationService.StepSimulation:input_type -> autofarm.simulation.StepSimulationRequest
+	92,  // 152: autofarm.simulation.SimulationService.SendEntityCommand:input_type -> autofarm.simulation.SendEntityCommandRequest
+	95,  // 153: autofarm.simulation.SimulationService.ScaleEntities:input_type -> autofarm.simulation.ScaleEntitiesRequest
+	86,  // 154: autofarm.simulation.SimulationService.SubmitTasks:input_type -> autofarm.simulation.SubmitTasksRequest
+	88,  // 155: autofarm.simulation.SimulationService.ListTasks:input_type -> autofarm.simulation.ListTasksRequest
+	58,  // 156: autofarm.simulation.SimulationService.SetEnvironment:input_type -> autofarm.simulation.SetEnvironmentRequest
+	60,  // 157: autofarm.simulation.SimulationService.GetCrops:input_type -> autofarm.simulation.GetCropsRequest
+	62,  // 158: autofarm.simulation.SimulationService.ListScenarios:input_type -> autofarm.simulation.ListScenariosRequest
+	31,  // 159: autofarm.simulation.SimulationService.CloneSimulation:input_type -> autofarm.simulation.CloneSimulationRequest
+	34,  // 160: autofarm.simulation.SimulationService.CreateTemplate:input_type -> autofarm.simulation.CreateTemplateRequest
+	36,  // 161: autofarm.simulation.SimulationService.GetTemplate:input_type -> autofarm.simulation.GetTemplateRequest
+	38,  // 162: autofarm.simulation.SimulationService.ListTemplates:input_type -> autofarm.simulation.ListTemplatesRequest
+	40,  // 163: autofarm.simulation.SimulationService.UpdateTemplate:input_type -> autofarm.simulation.UpdateTemplateRequest
+	42,  // 164: autofarm.simulation.SimulationService.DeleteTemplate:input_type -> autofarm.simulation.DeleteTemplateRequest
+	70,  // 165: autofarm.simulation.SimulationService.CreateExperiment:input_type -> autofarm.simulation.CreateExperimentRequest
+	72,  // 166: autofarm.simulation.SimulationService.GetExperiment:input_type -> autofarm.simulation.GetExperimentRequest
+	74,  // 167: autofarm.simulation.SimulationService.ListExperiments:input_type -> autofarm.simulation.ListExperimentsRequest
+	76,  // 168: autofarm.simulation.SimulationService.CancelExperiment:input_type -> autofarm.simulation.CancelExperimentRequest
+	101, // 169: autofarm.simulation.SimulationService.ListSimulationEvents:input_type -> autofarm.simulation.ListSimulationEventsRequest
+	103, // 170: autofarm.simulation.SimulationService.ListAuditEvents:input_type -> autofarm.simulation.ListAuditEventsRequest
+	47,  // 171: autofarm.simulation.SimulationService.StreamAggregatedTicks:input_type -> autofarm.simulation.StreamAggregatedTicksRequest
+	105, // 172: autofarm.simulation.SimulationService.WatchSimulationStatus:input_type -> autofarm.simulation.WatchSimulationStatusRequest
+	44,  // 173: autofarm.simulation.SimulationService.CreateSimulation:output_type -> autofarm.simulation.CreateSimulationResponse
+	46,  // 174: autofarm.simulation.SimulationService.StartSimulation:output_type -> autofarm.simulation.StartSimulationResponse
+	49,  // 175: autofarm.simulation.SimulationService.PauseSimulation:output_type -> autofarm.simulation.PauseSimulationResponse
+	51,  // 176: autofarm.simulation.SimulationService.StopSimulation:output_type -> autofarm.simulation.StopSimulationResponse
+	53,  // 177: autofarm.simulation.SimulationService.DeleteSimulation:output_type -> autofarm.simulation.DeleteSimulationResponse
+	79,  // 178: autofarm.simulation.SimulationService.GetSimulation:output_type -> autofarm.simulation.GetSimulationResponse
+	81,  // 179: autofarm.simulation.SimulationService.ListSimulations:output_type -> autofarm.simulation.ListSimulationsResponse
+	55,  // 180: autofarm.simulation.SimulationService.SetSimulationSpeed:output_type -> autofarm.simulation.SetSimulationSpeedResponse
+	57,  // 181: autofarm.simulation.SimulationService.StepSimulation:output_type -> autofarm.simulation.StepSimulationResponse
+	93,  // 182: autofarm.simulation.SimulationService.SendEntityCommand:output_type -> autofarm.simulation.SendEntityCommandResponse
+	96,  // 183: autofarm.simulation.SimulationService.ScaleEntities:output_type -> autofarm.simulation.ScaleEntitiesResponse
+	87,  // 184: autofarm.simulation.SimulationService.SubmitTasks:output_type -> autofarm.simulation.SubmitTasksResponse
+	89,  // 185: autofarm.simulation.SimulationService.ListTasks:output_type -> autofarm.simulation.ListTasksResponse
+	59,  // 186: autofarm.simulation.SimulationService.SetEnvironment:output_type -> autofarm.simulation.SetEnvironmentResponse
+	61,  // 187: autofarm.simulation.SimulationService.GetCrops:output_type -> autofarm.simulation.GetCropsResponse
+	64,  // 188: autofarm.simulation.SimulationService.ListScenarios:output_type -> autofarm.simulation.ListScenariosResponse
+	32,  // 189: autofarm.simulation.SimulationService.CloneSimulation:output_type -> autofarm.simulation.CloneSimulationResponse
+	35,  // 190: autofarm.simulation.SimulationService.CreateTemplate:output_type -> autofarm.simulation.CreateTemplateResponse
+	37,  // 191: autofarm.simulation.SimulationService.GetTemplate:output_type -> autofarm.simulation.GetTemplateResponse
+	39,  // 192: autofarm.simulation.SimulationService.ListTemplates:output_type -> autofarm.simulation.ListTemplatesResponse
+	41,  // 193: autofarm.simulation.SimulationService.UpdateTemplate:output_type -> autofarm.simulation.UpdateTemplateResponse
+	43,  // 194: autofarm.simulation.SimulationService.DeleteTemplate:output_type -> autofarm.simulation.DeleteTemplateResponse
+	71,  // 195: autofarm.simulation.SimulationService.CreateExperiment:output_type -> autofarm.simulation.CreateExperimentResponse
+	73,  // 196: autofarm.simulation.SimulationService.GetExperiment:output_type -> autofarm.simulation.GetExperimentResponse
+	75,  // 197: autofarm.simulation.SimulationService.ListExperiments:output_type -> autofarm.simulation.ListExperimentsResponse
+	77,  // 198: autofarm.simulation.SimulationService.CancelExperiment:output_type -> autofarm.simulation.CancelExperimentResponse
+	102, // 199: autofarm.simulation.SimulationService.ListSimulationEvents:output_type -> autofarm.simulation.ListSimulationEventsResponse
+	104, // 200: autofarm.simulation.SimulationService.ListAuditEvents:output_type -> autofarm.simulation.ListAuditEventsResponse
+	99,  // 201: autofarm.simulation.SimulationService.StreamAggregatedTicks:output_type -> autofarm.simulation.AggregatedTick
+	106, // 202: autofarm.simulation.SimulationService.WatchSimulationStatus:output_type -> autofarm.simulation.SimulationStatusEvent
+	173, // [173:203] is the sub-list for method output_type
+	143, // [143:173] is the sub-list for method input_type
+	143, // [143:143] is the sub-list for extension type_name
+	143, // [143:143] is the sub-list for extension extendee
+	0,   // [0:143] is the sub-list for field type_name
 }
 
 func init() { file_simulation_proto_init() }
@@ -1175,13 +8117,14 @@ func file_simulation_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_simulation_proto_rawDesc), len(file_simulation_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   17,
+			NumEnums:      12,
+			NumMessages:   98,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_simulation_proto_goTypes,
 		DependencyIndexes: file_simulation_proto_depIdxs,
+		EnumInfos:         file_simulation_proto_enumTypes,
 		MessageInfos:      file_simulation_proto_msgTypes,
 	}.Build()
 	File_simulation_proto = out.File
@@ -25,8 +25,32 @@ const (
 	SimulationService_StartSimulation_FullMethodName       = "/autofarm.simulation.SimulationService/StartSimulation"
 	SimulationService_PauseSimulation_FullMethodName       = "/autofarm.simulation.SimulationService/PauseSimulation"
 	SimulationService_StopSimulation_FullMethodName        = "/autofarm.simulation.SimulationService/StopSimulation"
+	SimulationService_DeleteSimulation_FullMethodName      = "/autofarm.simulation.SimulationService/DeleteSimulation"
 	SimulationService_GetSimulation_FullMethodName         = "/autofarm.simulation.SimulationService/GetSimulation"
+	SimulationService_ListSimulations_FullMethodName       = "/autofarm.simulation.SimulationService/ListSimulations"
+	SimulationService_SetSimulationSpeed_FullMethodName    = "/autofarm.simulation.SimulationService/SetSimulationSpeed"
+	SimulationService_StepSimulation_FullMethodName        = "/autofarm.simulation.SimulationService/StepSimulation"
+	SimulationService_SendEntityCommand_FullMethodName     = "/autofarm.simulation.SimulationService/SendEntityCommand"
+	SimulationService_ScaleEntities_FullMethodName         = "/autofarm.simulation.SimulationService/ScaleEntities"
+	SimulationService_SubmitTasks_FullMethodName           = "/autofarm.simulation.SimulationService/SubmitTasks"
+	SimulationService_ListTasks_FullMethodName             = "/autofarm.simulation.SimulationService/ListTasks"
+	SimulationService_SetEnvironment_FullMethodName        = "/autofarm.simulation.SimulationService/SetEnvironment"
+	SimulationService_GetCrops_FullMethodName              = "/autofarm.simulation.SimulationService/GetCrops"
+	SimulationService_ListScenarios_FullMethodName         = "/autofarm.simulation.SimulationService/ListScenarios"
+	SimulationService_CloneSimulation_FullMethodName       = "/autofarm.simulation.SimulationService/CloneSimulation"
+	SimulationService_CreateTemplate_FullMethodName        = "/autofarm.simulation.SimulationService/CreateTemplate"
+	SimulationService_GetTemplate_FullMethodName           = "/autofarm.simulation.SimulationService/GetTemplate"
+	SimulationService_ListTemplates_FullMethodName         = "/autofarm.simulation.SimulationService/ListTemplates"
+	SimulationService_UpdateTemplate_FullMethodName        = "/autofarm.simulation.SimulationService/UpdateTemplate"
+	SimulationService_DeleteTemplate_FullMethodName        = "/autofarm.simulation.SimulationService/DeleteTemplate"
+	SimulationService_CreateExperiment_FullMethodName      = "/autofarm.simulation.SimulationService/CreateExperiment"
+	SimulationService_GetExperiment_FullMethodName         = "/autofarm.simulation.SimulationService/GetExperiment"
+	SimulationService_ListExperiments_FullMethodName       = "/autofarm.simulation.SimulationService/ListExperiments"
+	SimulationService_CancelExperiment_FullMethodName      = "/autofarm.simulation.SimulationService/CancelExperiment"
+	SimulationService_ListSimulationEvents_FullMethodName  = "/autofarm.simulation.SimulationService/ListSimulationEvents"
+	SimulationService_ListAuditEvents_FullMethodName       = "/autofarm.simulation.SimulationService/ListAuditEvents"
 	SimulationService_StreamAggregatedTicks_FullMethodName = "/autofarm.simulation.SimulationService/StreamAggregatedTicks"
+	SimulationService_WatchSimulationStatus_FullMethodName = "/autofarm.simulation.SimulationService/WatchSimulationStatus"
 )
 
 // SimulationServiceClient is the client API for SimulationService service.
@@ -39,8 +63,35 @@ type SimulationServiceClient interface {
 	StartSimulation(ctx context.Context, in *StartSimulationRequest, opts ...grpc.CallOption) (*StartSimulationResponse, error)
 	PauseSimulation(ctx context.Context, in *PauseSimulationRequest, opts ...grpc.CallOption) (*PauseSimulationResponse, error)
 	StopSimulation(ctx context.Context, in *StopSimulationRequest, opts ...grpc.CallOption) (*StopSimulationResponse, error)
+	DeleteSimulation(ctx context.Context, in *DeleteSimulationRequest, opts ...grpc.CallOption) (*DeleteSimulationResponse, error)
 	GetSimulation(ctx context.Context, in *GetSimulationRequest, opts ...grpc.CallOption) (*GetSimulationResponse, error)
+	ListSimulations(ctx context.Context, in *ListSimulationsRequest, opts ...grpc.CallOption) (*ListSimulationsResponse, error)
+	SetSimulationSpeed(ctx context.Context, in *SetSimulationSpeedRequest, opts ...grpc.CallOption) (*SetSimulationSpeedResponse, error)
+	StepSimulation(ctx context.Context, in *StepSimulationRequest, opts ...grpc.CallOption) (*StepSimulationResponse, error)
+	SendEntityCommand(ctx context.Context, in *SendEntityCommandRequest, opts ...grpc.CallOption) (*SendEntityCommandResponse, error)
+	ScaleEntities(ctx context.Context, in *ScaleEntitiesRequest, opts ...grpc.CallOption) (*ScaleEntitiesResponse, error)
+	SubmitTasks(ctx context.Context, in *SubmitTasksRequest, opts ...grpc.CallOption) (*SubmitTasksResponse, error)
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	SetEnvironment(ctx context.Context, in *SetEnvironmentRequest, opts ...grpc.CallOption) (*SetEnvironmentResponse, error)
+	GetCrops(ctx context.Context, in *GetCropsRequest, opts ...grpc.CallOption) (*GetCropsResponse, error)
+	ListScenarios(ctx context.Context, in *ListScenariosRequest, opts ...grpc.CallOption) (*ListScenariosResponse, error)
+	CloneSimulation(ctx context.Context, in *CloneSimulationRequest, opts ...grpc.CallOption) (*CloneSimulationResponse, error)
+	CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*CreateTemplateResponse, error)
+	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*UpdateTemplateResponse, error)
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
+	CreateExperiment(ctx context.Context, in *CreateExperimentRequest, opts ...grpc.CallOption) (*CreateExperimentResponse, error)
+	GetExperiment(ctx context.Context, in *GetExperimentRequest, opts ...grpc.CallOption) (*GetExperimentResponse, error)
+	ListExperiments(ctx context.Context, in *ListExperimentsRequest, opts ...grpc.CallOption) (*ListExperimentsResponse, error)
+	CancelExperiment(ctx context.Context, in *CancelExperimentRequest, opts ...grpc.CallOption) (*CancelExperimentResponse, error)
+	ListSimulationEvents(ctx context.Context, in *ListSimulationEventsRequest, opts ...grpc.CallOption) (*ListSimulationEventsResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 	StreamAggregatedTicks(ctx context.Context, in *StreamAggregatedTicksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AggregatedTick], error)
+	// WatchSimulationStatus streams a simulation's status changes. The
+	// stream ends after the simulation's final status: COMPLETED, STOPPED
+	// or FAILED.
+	WatchSimulationStatus(ctx context.Context, in *WatchSimulationStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SimulationStatusEvent], error)
 }
 
 type simulationServiceClient struct {
@@ -91,6 +142,16 @@ func (c *simulationServiceClient) StopSimulation(ctx context.Context, in *StopSi
 	return out, nil
 }
 
+func (c *simulationServiceClient) DeleteSimulation(ctx context.Context, in *DeleteSimulationRequest, opts ...grpc.CallOption) (*DeleteSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSimulationResponse)
+	err := c.cc.Invoke(ctx, SimulationService_DeleteSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *simulationServiceClient) GetSimulation(ctx context.Context, in *GetSimulationRequest, opts ...grpc.CallOption) (*GetSimulationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetSimulationResponse)
@@ -101,6 +162,226 @@ func (c *simulationServiceClient) GetSimulation(ctx context.Context, in *GetSimu
 	return out, nil
 }
 
+func (c *simulationServiceClient) ListSimulations(ctx context.Context, in *ListSimulationsRequest, opts ...grpc.CallOption) (*ListSimulationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSimulationsResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListSimulations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) SetSimulationSpeed(ctx context.Context, in *SetSimulationSpeedRequest, opts ...grpc.CallOption) (*SetSimulationSpeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetSimulationSpeedResponse)
+	err := c.cc.Invoke(ctx, SimulationService_SetSimulationSpeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) StepSimulation(ctx context.Context, in *StepSimulationRequest, opts ...grpc.CallOption) (*StepSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StepSimulationResponse)
+	err := c.cc.Invoke(ctx, SimulationService_StepSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) SendEntityCommand(ctx context.Context, in *SendEntityCommandRequest, opts ...grpc.CallOption) (*SendEntityCommandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendEntityCommandResponse)
+	err := c.cc.Invoke(ctx, SimulationService_SendEntityCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ScaleEntities(ctx context.Context, in *ScaleEntitiesRequest, opts ...grpc.CallOption) (*ScaleEntitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScaleEntitiesResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ScaleEntities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) SubmitTasks(ctx context.Context, in *SubmitTasksRequest, opts ...grpc.CallOption) (*SubmitTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitTasksResponse)
+	err := c.cc.Invoke(ctx, SimulationService_SubmitTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) SetEnvironment(ctx context.Context, in *SetEnvironmentRequest, opts ...grpc.CallOption) (*SetEnvironmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetEnvironmentResponse)
+	err := c.cc.Invoke(ctx, SimulationService_SetEnvironment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) GetCrops(ctx context.Context, in *GetCropsRequest, opts ...grpc.CallOption) (*GetCropsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCropsResponse)
+	err := c.cc.Invoke(ctx, SimulationService_GetCrops_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ListScenarios(ctx context.Context, in *ListScenariosRequest, opts ...grpc.CallOption) (*ListScenariosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScenariosResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListScenarios_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) CloneSimulation(ctx context.Context, in *CloneSimulationRequest, opts ...grpc.CallOption) (*CloneSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloneSimulationResponse)
+	err := c.cc.Invoke(ctx, SimulationService_CloneSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*CreateTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTemplateResponse)
+	err := c.cc.Invoke(ctx, SimulationService_CreateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTemplateResponse)
+	err := c.cc.Invoke(ctx, SimulationService_GetTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTemplatesResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*UpdateTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTemplateResponse)
+	err := c.cc.Invoke(ctx, SimulationService_UpdateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTemplateResponse)
+	err := c.cc.Invoke(ctx, SimulationService_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) CreateExperiment(ctx context.Context, in *CreateExperimentRequest, opts ...grpc.CallOption) (*CreateExperimentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateExperimentResponse)
+	err := c.cc.Invoke(ctx, SimulationService_CreateExperiment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) GetExperiment(ctx context.Context, in *GetExperimentRequest, opts ...grpc.CallOption) (*GetExperimentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExperimentResponse)
+	err := c.cc.Invoke(ctx, SimulationService_GetExperiment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ListExperiments(ctx context.Context, in *ListExperimentsRequest, opts ...grpc.CallOption) (*ListExperimentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExperimentsResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListExperiments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) CancelExperiment(ctx context.Context, in *CancelExperimentRequest, opts ...grpc.CallOption) (*CancelExperimentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelExperimentResponse)
+	err := c.cc.Invoke(ctx, SimulationService_CancelExperiment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ListSimulationEvents(ctx context.Context, in *ListSimulationEventsRequest, opts ...grpc.CallOption) (*ListSimulationEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSimulationEventsResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListSimulationEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, SimulationService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *simulationServiceClient) StreamAggregatedTicks(ctx context.Context, in *StreamAggregatedTicksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AggregatedTick], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &SimulationService_ServiceDesc.Streams[0], SimulationService_StreamAggregatedTicks_FullMethodName, cOpts...)
@@ -120,6 +401,25 @@ func (c *simulationServiceClient) StreamAggregatedTicks(ctx context.Context, in
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SimulationService_StreamAggregatedTicksClient = grpc.ServerStreamingClient[AggregatedTick]
 
+func (c *simulationServiceClient) WatchSimulationStatus(ctx context.Context, in *WatchSimulationStatusRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SimulationStatusEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SimulationService_ServiceDesc.Streams[1], SimulationService_WatchSimulationStatus_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchSimulationStatusRequest, SimulationStatusEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_WatchSimulationStatusClient = grpc.ServerStreamingClient[SimulationStatusEvent]
+
 // SimulationServiceServer is the server API for SimulationService service.
 // All implementations must embed UnimplementedSimulationServiceServer
 // for forward compatibility.
@@ -130,8 +430,35 @@ type SimulationServiceServer interface {
 	StartSimulation(context.Context, *StartSimulationRequest) (*StartSimulationResponse, error)
 	PauseSimulation(context.Context, *PauseSimulationRequest) (*PauseSimulationResponse, error)
 	StopSimulation(context.Context, *StopSimulationRequest) (*StopSimulationResponse, error)
+	DeleteSimulation(context.Context, *DeleteSimulationRequest) (*DeleteSimulationResponse, error)
 	GetSimulation(context.Context, *GetSimulationRequest) (*GetSimulationResponse, error)
+	ListSimulations(context.Context, *ListSimulationsRequest) (*ListSimulationsResponse, error)
+	SetSimulationSpeed(context.Context, *SetSimulationSpeedRequest) (*SetSimulationSpeedResponse, error)
+	StepSimulation(context.Context, *StepSimulationRequest) (*StepSimulationResponse, error)
+	SendEntityCommand(context.Context, *SendEntityCommandRequest) (*SendEntityCommandResponse, error)
+	ScaleEntities(context.Context, *ScaleEntitiesRequest) (*ScaleEntitiesResponse, error)
+	SubmitTasks(context.Context, *SubmitTasksRequest) (*SubmitTasksResponse, error)
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	SetEnvironment(context.Context, *SetEnvironmentRequest) (*SetEnvironmentResponse, error)
+	GetCrops(context.Context, *GetCropsRequest) (*GetCropsResponse, error)
+	ListScenarios(context.Context, *ListScenariosRequest) (*ListScenariosResponse, error)
+	CloneSimulation(context.Context, *CloneSimulationRequest) (*CloneSimulationResponse, error)
+	CreateTemplate(context.Context, *CreateTemplateRequest) (*CreateTemplateResponse, error)
+	GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error)
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+	UpdateTemplate(context.Context, *UpdateTemplateRequest) (*UpdateTemplateResponse, error)
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+	CreateExperiment(context.Context, *CreateExperimentRequest) (*CreateExperimentResponse, error)
+	GetExperiment(context.Context, *GetExperimentRequest) (*GetExperimentResponse, error)
+	ListExperiments(context.Context, *ListExperimentsRequest) (*ListExperimentsResponse, error)
+	CancelExperiment(context.Context, *CancelExperimentRequest) (*CancelExperimentResponse, error)
+	ListSimulationEvents(context.Context, *ListSimulationEventsRequest) (*ListSimulationEventsResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
 	StreamAggregatedTicks(*StreamAggregatedTicksRequest, grpc.ServerStreamingServer[AggregatedTick]) error
+	// WatchSimulationStatus streams a simulation's status changes. The
+	// stream ends after the simulation's final status: COMPLETED, STOPPED
+	// or FAILED.
+	WatchSimulationStatus(*WatchSimulationStatusRequest, grpc.ServerStreamingServer[SimulationStatusEvent]) error
 	mustEmbedUnimplementedSimulationServiceServer()
 }
 
@@ -154,12 +481,84 @@ func (UnimplementedSimulationServiceServer) PauseSimulation(context.Context, *Pa
 func (UnimplementedSimulationServiceServer) StopSimulation(context.Context, *StopSimulationRequest) (*StopSimulationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopSimulation not implemented")
 }
+func (UnimplementedSimulationServiceServer) DeleteSimulation(context.Context, *DeleteSimulationRequest) (*DeleteSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSimulation not implemented")
+}
 func (UnimplementedSimulationServiceServer) GetSimulation(context.Context, *GetSimulationRequest) (*GetSimulationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSimulation not implemented")
 }
+func (UnimplementedSimulationServiceServer) ListSimulations(context.Context, *ListSimulationsRequest) (*ListSimulationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSimulations not implemented")
+}
+func (UnimplementedSimulationServiceServer) SetSimulationSpeed(context.Context, *SetSimulationSpeedRequest) (*SetSimulationSpeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSimulationSpeed not implemented")
+}
+func (UnimplementedSimulationServiceServer) StepSimulation(context.Context, *StepSimulationRequest) (*StepSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StepSimulation not implemented")
+}
+func (UnimplementedSimulationServiceServer) SendEntityCommand(context.Context, *SendEntityCommandRequest) (*SendEntityCommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendEntityCommand not implemented")
+}
+func (UnimplementedSimulationServiceServer) ScaleEntities(context.Context, *ScaleEntitiesRequest) (*ScaleEntitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScaleEntities not implemented")
+}
+func (UnimplementedSimulationServiceServer) SubmitTasks(context.Context, *SubmitTasksRequest) (*SubmitTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitTasks not implemented")
+}
+func (UnimplementedSimulationServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedSimulationServiceServer) SetEnvironment(context.Context, *SetEnvironmentRequest) (*SetEnvironmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetEnvironment not implemented")
+}
+func (UnimplementedSimulationServiceServer) GetCrops(context.Context, *GetCropsRequest) (*GetCropsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCrops not implemented")
+}
+func (UnimplementedSimulationServiceServer) ListScenarios(context.Context, *ListScenariosRequest) (*ListScenariosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScenarios not implemented")
+}
+func (UnimplementedSimulationServiceServer) CloneSimulation(context.Context, *CloneSimulationRequest) (*CloneSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneSimulation not implemented")
+}
+func (UnimplementedSimulationServiceServer) CreateTemplate(context.Context, *CreateTemplateRequest) (*CreateTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTemplate not implemented")
+}
+func (UnimplementedSimulationServiceServer) GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplate not implemented")
+}
+func (UnimplementedSimulationServiceServer) ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedSimulationServiceServer) UpdateTemplate(context.Context, *UpdateTemplateRequest) (*UpdateTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTemplate not implemented")
+}
+func (UnimplementedSimulationServiceServer) DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedSimulationServiceServer) CreateExperiment(context.Context, *CreateExperimentRequest) (*CreateExperimentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExperiment not implemented")
+}
+func (UnimplementedSimulationServiceServer) GetExperiment(context.Context, *GetExperimentRequest) (*GetExperimentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExperiment not implemented")
+}
+func (UnimplementedSimulationServiceServer) ListExperiments(context.Context, *ListExperimentsRequest) (*ListExperimentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExperiments not implemented")
+}
+func (UnimplementedSimulationServiceServer) CancelExperiment(context.Context, *CancelExperimentRequest) (*CancelExperimentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelExperiment not implemented")
+}
+func (UnimplementedSimulationServiceServer) ListSimulationEvents(context.Context, *ListSimulationEventsRequest) (*ListSimulationEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSimulationEvents not implemented")
+}
+func (UnimplementedSimulationServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedSimulationServiceServer) StreamAggregatedTicks(*StreamAggregatedTicksRequest, grpc.ServerStreamingServer[AggregatedTick]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamAggregatedTicks not implemented")
 }
+func (UnimplementedSimulationServiceServer) WatchSimulationStatus(*WatchSimulationStatusRequest, grpc.ServerStreamingServer[SimulationStatusEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSimulationStatus not implemented")
+}
 func (UnimplementedSimulationServiceServer) mustEmbedUnimplementedSimulationServiceServer() {}
 func (UnimplementedSimulationServiceServer) testEmbeddedByValue()                           {}
 
@@ -253,6 +652,24 @@ func _SimulationService_StopSimulation_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SimulationService_DeleteSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).DeleteSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_DeleteSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).DeleteSimulation(ctx, req.(*DeleteSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SimulationService_GetSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetSimulationRequest)
 	if err := dec(in); err != nil {
@@ -271,31 +688,438 @@ func _SimulationService_GetSimulation_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SimulationService_StreamAggregatedTicks_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(StreamAggregatedTicksRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _SimulationService_ListSimulations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSimulationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(SimulationServiceServer).StreamAggregatedTicks(m, &grpc.GenericServerStream[StreamAggregatedTicksRequest, AggregatedTick]{ServerStream: stream})
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListSimulations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListSimulations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListSimulations(ctx, req.(*ListSimulationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type SimulationService_StreamAggregatedTicksServer = grpc.ServerStreamingServer[AggregatedTick]
+func _SimulationService_SetSimulationSpeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSimulationSpeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).SetSimulationSpeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_SetSimulationSpeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).SetSimulationSpeed(ctx, req.(*SetSimulationSpeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// SimulationService_ServiceDesc is the grpc.ServiceDesc for SimulationService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var SimulationService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "autofarm.simulation.SimulationService",
-	HandlerType: (*SimulationServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateSimulation",
-			Handler:    _SimulationService_CreateSimulation_Handler,
-		},
-		{
-			MethodName: "StartSimulation",
-			Handler:    _SimulationService_StartSimulation_Handler,
+func _SimulationService_StepSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).StepSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_StepSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).StepSimulation(ctx, req.(*StepSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_SendEntityCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendEntityCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).SendEntityCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_SendEntityCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).SendEntityCommand(ctx, req.(*SendEntityCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ScaleEntities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleEntitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ScaleEntities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ScaleEntities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ScaleEntities(ctx, req.(*ScaleEntitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_SubmitTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).SubmitTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_SubmitTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).SubmitTasks(ctx, req.(*SubmitTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_SetEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnvironmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).SetEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_SetEnvironment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).SetEnvironment(ctx, req.(*SetEnvironmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_GetCrops_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCropsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).GetCrops(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_GetCrops_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).GetCrops(ctx, req.(*GetCropsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ListScenarios_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScenariosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListScenarios(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListScenarios_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListScenarios(ctx, req.(*ListScenariosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_CloneSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).CloneSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_CloneSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).CloneSimulation(ctx, req.(*CloneSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).CreateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_CreateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).CreateTemplate(ctx, req.(*CreateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_GetTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).GetTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_GetTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).GetTemplate(ctx, req.(*GetTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListTemplates(ctx, req.(*ListTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_UpdateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).UpdateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_UpdateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).UpdateTemplate(ctx, req.(*UpdateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).DeleteTemplate(ctx, req.(*DeleteTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_CreateExperiment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExperimentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).CreateExperiment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_CreateExperiment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).CreateExperiment(ctx, req.(*CreateExperimentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_GetExperiment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExperimentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).GetExperiment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_GetExperiment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).GetExperiment(ctx, req.(*GetExperimentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ListExperiments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExperimentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListExperiments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListExperiments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListExperiments(ctx, req.(*ListExperimentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_CancelExperiment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelExperimentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).CancelExperiment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_CancelExperiment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).CancelExperiment(ctx, req.(*CancelExperimentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ListSimulationEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSimulationEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListSimulationEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListSimulationEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListSimulationEvents(ctx, req.(*ListSimulationEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_StreamAggregatedTicks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAggregatedTicksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulationServiceServer).StreamAggregatedTicks(m, &grpc.GenericServerStream[StreamAggregatedTicksRequest, AggregatedTick]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_StreamAggregatedTicksServer = grpc.ServerStreamingServer[AggregatedTick]
+
+func _SimulationService_WatchSimulationStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSimulationStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulationServiceServer).WatchSimulationStatus(m, &grpc.GenericServerStream[WatchSimulationStatusRequest, SimulationStatusEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_WatchSimulationStatusServer = grpc.ServerStreamingServer[SimulationStatusEvent]
+
+// SimulationService_ServiceDesc is the grpc.ServiceDesc for SimulationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimulationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "autofarm.simulation.SimulationService",
+	HandlerType: (*SimulationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateSimulation",
+			Handler:    _SimulationService_CreateSimulation_Handler,
+		},
+		{
+			MethodName: "StartSimulation",
+			Handler:    _SimulationService_StartSimulation_Handler,
 		},
 		{
 			MethodName: "PauseSimulation",
@@ -305,10 +1129,102 @@ var SimulationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StopSimulation",
 			Handler:    _SimulationService_StopSimulation_Handler,
 		},
+		{
+			MethodName: "DeleteSimulation",
+			Handler:    _SimulationService_DeleteSimulation_Handler,
+		},
 		{
 			MethodName: "GetSimulation",
 			Handler:    _SimulationService_GetSimulation_Handler,
 		},
+		{
+			MethodName: "ListSimulations",
+			Handler:    _SimulationService_ListSimulations_Handler,
+		},
+		{
+			MethodName: "SetSimulationSpeed",
+			Handler:    _SimulationService_SetSimulationSpeed_Handler,
+		},
+		{
+			MethodName: "StepSimulation",
+			Handler:    _SimulationService_StepSimulation_Handler,
+		},
+		{
+			MethodName: "SendEntityCommand",
+			Handler:    _SimulationService_SendEntityCommand_Handler,
+		},
+		{
+			MethodName: "ScaleEntities",
+			Handler:    _SimulationService_ScaleEntities_Handler,
+		},
+		{
+			MethodName: "SubmitTasks",
+			Handler:    _SimulationService_SubmitTasks_Handler,
+		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _SimulationService_ListTasks_Handler,
+		},
+		{
+			MethodName: "SetEnvironment",
+			Handler:    _SimulationService_SetEnvironment_Handler,
+		},
+		{
+			MethodName: "GetCrops",
+			Handler:    _SimulationService_GetCrops_Handler,
+		},
+		{
+			MethodName: "ListScenarios",
+			Handler:    _SimulationService_ListScenarios_Handler,
+		},
+		{
+			MethodName: "CloneSimulation",
+			Handler:    _SimulationService_CloneSimulation_Handler,
+		},
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _SimulationService_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplate",
+			Handler:    _SimulationService_GetTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _SimulationService_ListTemplates_Handler,
+		},
+		{
+			MethodName: "UpdateTemplate",
+			Handler:    _SimulationService_UpdateTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _SimulationService_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "CreateExperiment",
+			Handler:    _SimulationService_CreateExperiment_Handler,
+		},
+		{
+			MethodName: "GetExperiment",
+			Handler:    _SimulationService_GetExperiment_Handler,
+		},
+		{
+			MethodName: "ListExperiments",
+			Handler:    _SimulationService_ListExperiments_Handler,
+		},
+		{
+			MethodName: "CancelExperiment",
+			Handler:    _SimulationService_CancelExperiment_Handler,
+		},
+		{
+			MethodName: "ListSimulationEvents",
+			Handler:    _SimulationService_ListSimulationEvents_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _SimulationService_ListAuditEvents_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -316,6 +1232,11 @@ var SimulationService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SimulationService_StreamAggregatedTicks_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchSimulationStatus",
+			Handler:       _SimulationService_WatchSimulationStatus_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "simulation.proto",
 }
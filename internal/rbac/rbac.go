@@ -0,0 +1,82 @@
+// Package rbac holds the roles API keys and tokens grant, and passes the
+// caller's role from the API gateway to the orchestrator.
+//
+// Roles are ordered: each one may do everything the roles below it may.
+// Viewers may read simulations and watch their streams; operators may
+// also create, start, pause, stop and drive them; admins may also delete
+// them, manage templates and manage API keys.
+package rbac
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Role is what a caller is allowed to do.
+type Role string
+
+const (
+	Viewer   Role = "viewer"
+	Operator Role = "operator"
+	Admin    Role = "admin"
+)
+
+var ranks = map[Role]int{Viewer: 1, Operator: 2, Admin: 3}
+
+// Metadata keys of the gRPC calls the API gateway makes for its callers.
+const (
+	RoleMetadataKey    = "x-autofarm-role"
+	SubjectMetadataKey = "x-autofarm-subject"
+)
+
+// Parse returns the role named s.
+func Parse(s string) (Role, error) {
+	r := Role(s)
+	if _, ok := ranks[r]; !ok {
+		return "", fmt.Errorf("unknown role %q: use viewer, operator or admin", s)
+	}
+	return r, nil
+}
+
+// Allows reports whether r may do what needs role need.
+func (r Role) Allows(need Role) bool {
+	rank, ok := ranks[r]
+	return ok && rank >= ranks[need]
+}
+
+// Caller is who makes a gRPC call, and the role they hold.
+type Caller struct {
+	Subject string
+	Role    Role
+}
+
+// NewOutgoingContext returns ctx with c as the caller of the gRPC calls
+// made with it.
+func NewOutgoingContext(ctx context.Context, c Caller) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RoleMetadataKey, string(c.Role), SubjectMetadataKey, c.Subject)
+}
+
+// FromIncomingContext returns the caller of a gRPC call. ok is false if
+// the call names no role.
+func FromIncomingContext(ctx context.Context) (c Caller, ok bool, err error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	roles := md.Get(RoleMetadataKey)
+	switch len(roles) {
+	case 0:
+		return Caller{}, false, nil
+	case 1:
+	default:
+		return Caller{}, false, fmt.Errorf("%d roles given; want one", len(roles))
+	}
+	role, err := Parse(roles[0])
+	if err != nil {
+		return Caller{}, false, err
+	}
+	c = Caller{Role: role}
+	if subjects := md.Get(SubjectMetadataKey); len(subjects) > 0 {
+		c.Subject = subjects[0]
+	}
+	return c, true, nil
+}
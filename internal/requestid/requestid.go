@@ -0,0 +1,68 @@
+// Package requestid carries the ID of a request from the API gateway to
+// the services it calls, so what each of them records about the request
+// can be tied together.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// Header is the HTTP header a request's ID comes in and goes back out in.
+const Header = "X-Request-ID"
+
+// MetadataKey is the gRPC metadata key carrying the ID.
+const MetadataKey = "x-request-id"
+
+// maxLen bounds the IDs taken from clients.
+const maxLen = 128
+
+type ctxKey struct{}
+
+// New returns a new random ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether id, e.g. from a client's header, is fit to use:
+// non-empty, at most 128 printable ASCII characters.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// NewContext returns ctx carrying id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the ID ctx carries, or "".
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// NewOutgoingContext returns ctx with id as the request ID of the gRPC calls
+// made with it.
+func NewOutgoingContext(ctx context.Context, id string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+}
+
+// FromIncomingContext returns the request ID of a gRPC call, or "" if it
+// has none or an unfit one.
+func FromIncomingContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(MetadataKey); len(ids) > 0 && Valid(ids[0]) {
+		return ids[0]
+	}
+	return ""
+}
@@ -0,0 +1,219 @@
+package scenario
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stevenmed26/AutoFarm/internal/fleet"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// Names of enum values in documents, as in the REST API.
+var (
+	overrunPolicies = map[string]simulationpb.TickOverrunPolicy{
+		"":          simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_UNSPECIFIED,
+		"mark_late": simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_MARK_LATE,
+		"skip":      simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_SKIP,
+		"stretch":   simulationpb.TickOverrunPolicy_TICK_OVERRUN_POLICY_STRETCH,
+	}
+	taskAllocations = map[string]simulationpb.TaskAllocationStrategy{
+		"":               simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_UNSPECIFIED,
+		"greedy_nearest": simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST,
+		"auction":        simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_AUCTION,
+		"hungarian":      simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_HUNGARIAN,
+	}
+	pathAlgorithms = map[string]simulationpb.PathAlgorithm{
+		"":      simulationpb.PathAlgorithm_PATH_ALGORITHM_UNSPECIFIED,
+		"jps":   simulationpb.PathAlgorithm_PATH_ALGORITHM_JPS,
+		"astar": simulationpb.PathAlgorithm_PATH_ALGORITHM_ASTAR,
+	}
+	weatherConditions = map[string]simulationpb.WeatherCondition{
+		"":       simulationpb.WeatherCondition_WEATHER_CONDITION_UNSPECIFIED,
+		"clear":  simulationpb.WeatherCondition_WEATHER_CONDITION_CLEAR,
+		"cloudy": simulationpb.WeatherCondition_WEATHER_CONDITION_CLOUDY,
+		"rain":   simulationpb.WeatherCondition_WEATHER_CONDITION_RAIN,
+		"storm":  simulationpb.WeatherCondition_WEATHER_CONDITION_STORM,
+	}
+	taskTypes = map[string]simulationpb.TaskType{
+		"harvest":   simulationpb.TaskType_TASK_TYPE_HARVEST,
+		"patrol":    simulationpb.TaskType_TASK_TYPE_PATROL,
+		"transport": simulationpb.TaskType_TASK_TYPE_TRANSPORT,
+	}
+)
+
+// Load parses a scenario document and converts it to a simulation config.
+// The config still needs the orchestrator's usual validation.
+func Load(data []byte) (*simulationpb.SimulationConfig, error) {
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Config()
+}
+
+// Config converts d to a simulation config, resolving enum names. Unknown
+// names are reported with their field paths.
+func (d *Document) Config() (*simulationpb.SimulationConfig, error) {
+	var errs errorList
+
+	cfg := &simulationpb.SimulationConfig{
+		Name:           d.Name,
+		EntityCount:    d.EntityCount,
+		TickRateMs:     d.TickRateMs,
+		ScenarioType:   d.ScenarioType,
+		TickDeadlineMs: d.TickDeadlineMs,
+		OverrunPolicy:  lookup(overrunPolicies, d.OverrunPolicy, "overrun_policy", &errs),
+		PipelineDepth:  d.PipelineDepth,
+		TaskAllocation: lookup(taskAllocations, d.TaskAllocation, "task_allocation", &errs),
+		Seed:           d.Seed,
+	}
+
+	if w := d.World; w != nil {
+		cfg.World = &simulationpb.WorldDefinition{
+			CellSize:      w.CellSize,
+			PathAlgorithm: lookup(pathAlgorithms, w.PathAlgorithm, "world.path_algorithm", &errs),
+		}
+		for _, o := range w.Obstacles {
+			cfg.World.Obstacles = append(cfg.World.Obstacles, &simulationpb.Obstacle{
+				MinX: o.MinX, MinY: o.MinY, MaxX: o.MaxX, MaxY: o.MaxY, Label: o.Label,
+			})
+		}
+		for _, p := range w.Terrain {
+			cfg.World.Terrain = append(cfg.World.Terrain, &simulationpb.TerrainPatch{
+				MinX: p.MinX, MinY: p.MinY, MaxX: p.MaxX, MaxY: p.MaxY, Cost: p.Cost, Label: p.Label,
+			})
+		}
+	}
+
+	for i, g := range d.Fleet {
+		group := &simulationpb.FleetGroup{
+			Type:  entityType(g.Type, fmt.Sprintf("fleet[%d].type", i), &errs),
+			Count: g.Count,
+		}
+		for _, p := range g.Placements {
+			group.Placements = append(group.Placements, &simulationpb.EntityPlacement{X: p.X, Y: p.Y, Battery: p.Battery})
+		}
+		cfg.Fleet = append(cfg.Fleet, group)
+	}
+
+	for i, et := range d.EntityTypes {
+		cfg.EntityTypes = append(cfg.EntityTypes, &simulationpb.EntityTypeParams{
+			Type:                   entityType(et.Type, fmt.Sprintf("entity_types[%d].type", i), &errs),
+			MaxSpeed:               et.MaxSpeed,
+			BatteryCapacity:        et.BatteryCapacity,
+			DrainRate:              et.DrainRate,
+			PayloadCapacity:        et.PayloadCapacity,
+			IgnoresGroundObstacles: et.IgnoresGroundObstacles,
+		})
+	}
+
+	if e := d.Environment; e != nil {
+		cfg.Environment = &simulationpb.EnvironmentConfig{
+			StartHour:   e.StartHour,
+			DayLengthMs: e.DayLengthMs,
+		}
+	}
+
+	for i, c := range d.WeatherScript {
+		change := &simulationpb.EnvironmentChange{
+			AtMs:  c.AtMs,
+			Clear: c.Clear,
+		}
+		if w := c.Weather; w != nil {
+			change.Weather = &simulationpb.Weather{
+				Condition:        lookup(weatherConditions, w.Condition, fmt.Sprintf("weather_script[%d].weather.condition", i), &errs),
+				CloudCover:       w.CloudCover,
+				RainIntensity:    w.RainIntensity,
+				WindSpeed:        w.WindSpeed,
+				WindDirectionDeg: w.WindDirectionDeg,
+			}
+		}
+		if c.TimeOfDayHours != nil {
+			change.SetTimeOfDay = true
+			change.TimeOfDayHours = *c.TimeOfDayHours
+		}
+		cfg.WeatherScript = append(cfg.WeatherScript, change)
+	}
+
+	if c := d.Crops; c != nil {
+		cfg.Crops = &simulationpb.CropConfig{
+			CellSize:      c.CellSize,
+			DaysToRipe:    c.DaysToRipe,
+			RipeDays:      c.RipeDays,
+			SpoilDays:     c.SpoilDays,
+			YieldPerCell:  c.YieldPerCell,
+			ManualHarvest: c.ManualHarvest,
+		}
+		for _, f := range c.Fields {
+			cfg.Crops.Fields = append(cfg.Crops.Fields, &simulationpb.CropPatch{
+				MinX: f.MinX, MinY: f.MinY, MaxX: f.MaxX, MaxY: f.MaxY, Label: f.Label,
+			})
+		}
+	}
+
+	for i, t := range d.Tasks {
+		path := fmt.Sprintf("tasks[%d]", i)
+		task := &simulationpb.Task{
+			WorkTicks: t.WorkTicks,
+			Load:      t.Load,
+			Priority:  t.Priority,
+		}
+		if tt, ok := taskTypes[strings.ToLower(t.Type)]; ok {
+			task.Type = tt
+		} else {
+			errs.add(path+".type", nil, "unknown task type %q (want harvest, patrol or transport)", t.Type)
+		}
+		for _, p := range t.Waypoints {
+			task.Waypoints = append(task.Waypoints, &simulationpb.Point{X: p.X, Y: p.Y})
+		}
+		for j, name := range t.EligibleTypes {
+			task.EligibleTypes = append(task.EligibleTypes, entityType(name, fmt.Sprintf("%s.eligible_types[%d]", path, j), &errs))
+		}
+		cfg.Tasks = append(cfg.Tasks, task)
+	}
+
+	if t := d.Termination; t != nil {
+		cfg.Termination = &simulationpb.Termination{
+			MaxTicks:      t.MaxTicks,
+			MaxSimTimeMs:  t.MaxSimTimeMs,
+			TasksComplete: t.TasksComplete,
+			TargetYield:   t.TargetYield,
+		}
+	}
+
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// lookup resolves an enum name, recording an error at path when it is
+// unknown.
+func lookup[T ~int32](names map[string]T, name, path string, errs *errorList) T {
+	v, ok := names[strings.ToLower(name)]
+	if !ok {
+		errs.add(path, nil, "unknown value %q (want one of %s)", name, choices(names))
+	}
+	return v
+}
+
+func entityType(name, path string, errs *errorList) simulationpb.EntityType {
+	t, err := fleet.ParseType(name)
+	if err != nil {
+		errs.add(path, nil, "%v", err)
+	}
+	return t
+}
+
+// choices lists the non-empty names of an enum.
+func choices[T ~int32](names map[string]T) string {
+	out := make([]string, 0, len(names))
+	for name := range names {
+		if name != "" {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,142 @@
+// Package scenario reads scenario documents: YAML (or JSON) files that
+// describe a complete simulation setup, from the world layout and fleet to
+// the tasks, weather script and termination conditions. Documents are
+// checked against their schema before they are converted, and every problem
+// is reported with the path of the offending field.
+package scenario
+
+// Version is the scenario document format this package reads.
+const Version = 1
+
+// Document is a scenario document. Field names follow the REST API, and
+// zero values mean the same defaults as there.
+type Document struct {
+	Version     int    `yaml:"version"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+
+	// Seed drives the weather and crop planting; 0 picks one at creation.
+	Seed uint64 `yaml:"seed"`
+
+	// EntityCount is the number of generic robots when fleet is empty.
+	EntityCount    uint32 `yaml:"entities"`
+	TickRateMs     uint32 `yaml:"tick_rate_ms"`
+	TickDeadlineMs uint32 `yaml:"tick_deadline_ms"`
+	OverrunPolicy  string `yaml:"overrun_policy"`
+	PipelineDepth  uint32 `yaml:"pipeline_depth"`
+	TaskAllocation string `yaml:"task_allocation"`
+	ScenarioType   string `yaml:"scenario_type"`
+
+	World         *World          `yaml:"world"`
+	Fleet         []FleetGroup    `yaml:"fleet"`
+	EntityTypes   []EntityType    `yaml:"entity_types"`
+	Environment   *Environment    `yaml:"environment"`
+	WeatherScript []WeatherChange `yaml:"weather_script"`
+	Crops         *Crops          `yaml:"crops"`
+	Tasks         []Task          `yaml:"tasks"`
+	Termination   *Termination    `yaml:"termination"`
+}
+
+// Area is an axis-aligned rectangle in world units.
+type Area struct {
+	MinX  float64 `yaml:"min_x"`
+	MinY  float64 `yaml:"min_y"`
+	MaxX  float64 `yaml:"max_x"`
+	MaxY  float64 `yaml:"max_y"`
+	Label string  `yaml:"label"`
+}
+
+// TerrainPatch is an area that is slower to cross.
+type TerrainPatch struct {
+	MinX  float64 `yaml:"min_x"`
+	MinY  float64 `yaml:"min_y"`
+	MaxX  float64 `yaml:"max_x"`
+	MaxY  float64 `yaml:"max_y"`
+	Cost  float64 `yaml:"cost"`
+	Label string  `yaml:"label"`
+}
+
+type World struct {
+	CellSize      float64        `yaml:"cell_size"`
+	PathAlgorithm string         `yaml:"path_algorithm"`
+	Obstacles     []Area         `yaml:"obstacles"`
+	Terrain       []TerrainPatch `yaml:"terrain"`
+}
+
+// FleetGroup is part of the fleet mix. Placements give the starting
+// positions of the group's first entities.
+type FleetGroup struct {
+	Type       string      `yaml:"type"`
+	Count      uint32      `yaml:"count"`
+	Placements []Placement `yaml:"placements"`
+}
+
+type Placement struct {
+	X       float64 `yaml:"x"`
+	Y       float64 `yaml:"y"`
+	Battery float64 `yaml:"battery"`
+}
+
+type EntityType struct {
+	Type                   string  `yaml:"type"`
+	MaxSpeed               float64 `yaml:"max_speed"`
+	BatteryCapacity        float64 `yaml:"battery_capacity"`
+	DrainRate              float64 `yaml:"drain_rate"`
+	PayloadCapacity        float64 `yaml:"payload_capacity"`
+	IgnoresGroundObstacles bool    `yaml:"ignores_ground_obstacles"`
+}
+
+type Environment struct {
+	StartHour   float64 `yaml:"start_hour"`
+	DayLengthMs uint64  `yaml:"day_length_ms"`
+}
+
+// WeatherChange sets the weather and/or time of day from AtMs of simulated
+// time until the next change.
+type WeatherChange struct {
+	AtMs           uint64   `yaml:"at_ms"`
+	Weather        *Weather `yaml:"weather"`
+	TimeOfDayHours *float64 `yaml:"time_of_day_hours"`
+	Clear          bool     `yaml:"clear"`
+}
+
+type Weather struct {
+	Condition        string  `yaml:"condition"`
+	CloudCover       float64 `yaml:"cloud_cover"`
+	RainIntensity    float64 `yaml:"rain_intensity"`
+	WindSpeed        float64 `yaml:"wind_speed"`
+	WindDirectionDeg float64 `yaml:"wind_direction_deg"`
+}
+
+type Crops struct {
+	Fields        []Area  `yaml:"fields"`
+	CellSize      float64 `yaml:"cell_size"`
+	DaysToRipe    float64 `yaml:"days_to_ripe"`
+	RipeDays      float64 `yaml:"ripe_days"`
+	SpoilDays     float64 `yaml:"spoil_days"`
+	YieldPerCell  float64 `yaml:"yield_per_cell"`
+	ManualHarvest bool    `yaml:"manual_harvest"`
+}
+
+// Task is queued when the simulation is created.
+type Task struct {
+	Type          string   `yaml:"type"`
+	Waypoints     []Point  `yaml:"waypoints"`
+	WorkTicks     uint32   `yaml:"work_ticks"`
+	Load          float64  `yaml:"load"`
+	Priority      int32    `yaml:"priority"`
+	EligibleTypes []string `yaml:"eligible_types"`
+}
+
+type Point struct {
+	X float64 `yaml:"x"`
+	Y float64 `yaml:"y"`
+}
+
+// Termination ends the run once any of its non-zero conditions is met.
+type Termination struct {
+	MaxTicks      uint64  `yaml:"max_ticks"`
+	MaxSimTimeMs  uint64  `yaml:"max_sim_time_ms"`
+	TasksComplete bool    `yaml:"tasks_complete"`
+	TargetYield   float64 `yaml:"target_yield"`
+}
@@ -0,0 +1,107 @@
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is returned for scenario names the library does not hold.
+var ErrNotFound = errors.New("scenario not found")
+
+// extensions are tried in order when a name is looked up.
+var extensions = []string{".yaml", ".yml", ".json"}
+
+// validName keeps names inside the library directory.
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Library is a directory of scenario files, referred to by file name
+// without the extension.
+type Library struct {
+	dir string
+}
+
+// NewLibrary returns the library in dir. The directory need not exist; an
+// absent library is empty.
+func NewLibrary(dir string) *Library {
+	return &Library{dir: dir}
+}
+
+// Read returns the document called name.
+func (l *Library) Read(name string) ([]byte, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid scenario name %q", name)
+	}
+	for _, ext := range extensions {
+		data, err := readFile(filepath.Join(l.dir, name+ext))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("scenario %s: %w", name, err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// Names lists the scenarios in the library, sorted. A name present with
+// more than one extension is listed once.
+func (l *Library) Names() ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(e.Name())
+		name := strings.TrimSuffix(e.Name(), ext)
+		if !isScenarioExt(ext) || !validName.MatchString(name) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func isScenarioExt(ext string) bool {
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// readFile reads at most MaxDocumentSize bytes, failing on larger files.
+func readFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, MaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxDocumentSize {
+		return nil, fmt.Errorf("larger than %d bytes", MaxDocumentSize)
+	}
+	return data, nil
+}
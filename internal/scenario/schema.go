@@ -0,0 +1,239 @@
+package scenario
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxDocumentSize bounds how large a scenario document may be.
+const MaxDocumentSize = 1 << 20
+
+// FieldError is a problem with one field of a document.
+type FieldError struct {
+	// Path locates the field, e.g. "fleet[1].count"; empty for the
+	// document as a whole.
+	Path string
+
+	// Line is the 1-based line of the field in the document, 0 when it is
+	// not known.
+	Line int
+
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	msg := e.Message
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	if e.Line > 0 {
+		msg += fmt.Sprintf(" (line %d)", e.Line)
+	}
+	return msg
+}
+
+// ValidationError lists every problem found in a document.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "invalid scenario: " + strings.Join(msgs, "; ")
+}
+
+// errorList collects field errors while a document is checked.
+type errorList []*FieldError
+
+func (l *errorList) add(path string, node *yaml.Node, format string, args ...any) {
+	fe := &FieldError{Path: path, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		fe.Line = node.Line
+	}
+	*l = append(*l, fe)
+}
+
+func (l errorList) err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: l}
+}
+
+// Parse reads a YAML or JSON scenario document and checks it against the
+// schema: unknown fields, values of the wrong type and a missing or
+// unsupported version are all reported, not just the first.
+func Parse(data []byte) (*Document, error) {
+	if len(data) > MaxDocumentSize {
+		return nil, fmt.Errorf("scenario document is larger than %d bytes", MaxDocumentSize)
+	}
+
+	var root yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&root); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, &ValidationError{Errors: []*FieldError{{Message: "empty document"}}}
+		}
+		return nil, &ValidationError{Errors: []*FieldError{{Message: err.Error()}}}
+	}
+
+	var errs errorList
+	doc := root.Content[0]
+
+	if v := field(doc, "version"); v == nil {
+		errs.add("version", doc, "is required (want %d)", Version)
+	} else if v.Kind == yaml.ScalarNode && v.Value != fmt.Sprint(Version) {
+		errs.add("version", v, "unsupported version %q (want %d)", v.Value, Version)
+	}
+	checkNode(doc, reflect.TypeOf(Document{}), "", &errs)
+
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var d Document
+	if err := doc.Decode(&d); err != nil {
+		return nil, &ValidationError{Errors: []*FieldError{{Message: err.Error()}}}
+	}
+	return &d, nil
+}
+
+// checkNode checks node against the Go type t it will be decoded into.
+// Null values are allowed anywhere and leave the field at its zero value.
+func checkNode(node *yaml.Node, t reflect.Type, path string, errs *errorList) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			errs.add(path, node, "must be an object")
+			return
+		}
+		fields := yamlFields(t)
+		seen := make(map[string]bool, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			name := key.Value
+			fieldPath := joinPath(path, name)
+			ft, ok := fields[name]
+			switch {
+			case key.Kind != yaml.ScalarNode:
+				errs.add(path, key, "keys must be strings")
+			case !ok:
+				errs.add(fieldPath, key, "unknown field")
+			case seen[name]:
+				errs.add(fieldPath, key, "listed twice")
+			default:
+				seen[name] = true
+				checkNode(value, ft, fieldPath, errs)
+			}
+		}
+
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			errs.add(path, node, "must be a list")
+			return
+		}
+		for i, item := range node.Content {
+			checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+
+	default:
+		if node.Kind != yaml.ScalarNode {
+			errs.add(path, node, "must be %s", describe(t))
+			return
+		}
+		if !scalarMatches(node, t) {
+			errs.add(path, node, "must be %s, got %q", describe(t), node.Value)
+			return
+		}
+		// Decoding catches what the tag does not: overflow and negative
+		// values for unsigned fields.
+		if err := node.Decode(reflect.New(t).Interface()); err != nil {
+			errs.add(path, node, "%q is out of range for %s", node.Value, describe(t))
+		}
+	}
+}
+
+// scalarMatches reports whether node's resolved YAML type fits t. Integers
+// are accepted where floats are expected.
+func scalarMatches(node *yaml.Node, t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String:
+		return node.Tag == "!!str"
+	case reflect.Bool:
+		return node.Tag == "!!bool"
+	case reflect.Float32, reflect.Float64:
+		return node.Tag == "!!int" || node.Tag == "!!float"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return node.Tag == "!!int"
+	}
+	return false
+}
+
+func describe(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a non-negative integer"
+	}
+	return t.String()
+}
+
+// yamlFields maps the yaml names of t's fields to their types.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	out := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		out[name] = f.Type
+	}
+	return out
+}
+
+// field returns the value of key in a mapping node, or nil.
+func field(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,87 @@
+package scenario
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadInvalid(t *testing.T) {
+	// Every document has one problem, reported at path, with a message
+	// containing msg.
+	tests := []struct {
+		name string
+		doc  string
+		path string
+		msg  string
+		line int
+	}{
+		{"empty", "", "", "empty document", 0},
+		{"not yaml", "version: [1", "", "did not find expected", 0},
+		{"no version", "name: x", "version", "is required (want 1)", 1},
+		{"unsupported version", "version: 2", "version", `unsupported version "2" (want 1)`, 1},
+		{"unknown field", "version: 1\nentitys: 5", "entitys", "unknown field", 2},
+		{"listed twice", "version: 1\nseed: 1\nseed: 2", "seed", "listed twice", 3},
+		{"wrong type", "version: 1\ntick_rate_ms: fast", "tick_rate_ms", `must be a non-negative integer, got "fast"`, 2},
+		{"negative unsigned", "version: 1\nentities: -3", "entities", `"-3" is out of range for a non-negative integer`, 2},
+		{"float for integer", "version: 1\nseed: 1.5", "seed", `must be a non-negative integer, got "1.5"`, 2},
+		{"object for scalar", "version: 1\nname: {a: 1}", "name", "must be a string", 2},
+		{"scalar for object", "version: 1\nworld: flat", "world", "must be an object", 2},
+		{"scalar for list", "version: 1\nfleet: drone", "fleet", "must be a list", 2},
+		{"nested unknown field", "version: 1\nfleet:\n  - type: drone\n    speed: 3", "fleet[0].speed", "unknown field", 4},
+		{"nested wrong type", "version: 1\nworld:\n  obstacles:\n    - {min_x: a}", "world.obstacles[0].min_x", `must be a number, got "a"`, 4},
+		{"bool", "version: 1\ncrops: {manual_harvest: yes please}", "crops.manual_harvest", "must be true or false", 2},
+		{"unknown overrun policy", "version: 1\noverrun_policy: drop", "overrun_policy", `unknown value "drop" (want one of mark_late, skip, stretch)`, 0},
+		{"unknown path algorithm", "version: 1\nworld: {path_algorithm: bfs}", "world.path_algorithm", `unknown value "bfs" (want one of astar, jps)`, 0},
+		{"unknown fleet type", "version: 1\nfleet: [{type: blimp}]", "fleet[0].type", `unknown entity type "blimp"`, 0},
+		{"unknown weather", "version: 1\nweather_script: [{weather: {condition: hail}}]", "weather_script[0].weather.condition", `unknown value "hail"`, 0},
+		{"unknown task type", "version: 1\ntasks: [{type: mow}]", "tasks[0].type", `unknown task type "mow" (want harvest, patrol or transport)`, 0},
+		{"unknown eligible type", "version: 1\ntasks: [{type: patrol, eligible_types: [drone, blimp]}]", "tasks[0].eligible_types[1]", `unknown entity type "blimp"`, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load([]byte(tt.doc))
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("Load() = %v, %v; want a *ValidationError", cfg, err)
+			}
+			if len(ve.Errors) != 1 {
+				t.Fatalf("%d errors, want 1: %v", len(ve.Errors), err)
+			}
+			fe := ve.Errors[0]
+			if fe.Path != tt.path || !strings.Contains(fe.Message, tt.msg) || fe.Line != tt.line {
+				t.Errorf("error at %q line %d: %q; want %q line %d: %q", fe.Path, fe.Line, fe.Message, tt.path, tt.line, tt.msg)
+			}
+		})
+	}
+}
+
+// TestLoadReportsEveryError checks a document's problems are all reported,
+// in document order, not just the first.
+func TestLoadReportsEveryError(t *testing.T) {
+	doc := `
+version: 3
+tick_rate_ms: -1
+fleet:
+  - type: drone
+    count: many
+  - colour: red
+`
+	_, err := Load([]byte(doc))
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("Load() error %v, want a *ValidationError", err)
+	}
+	want := []string{"version", "tick_rate_ms", "fleet[0].count", "fleet[1].colour"}
+	var got []string
+	for _, fe := range ve.Errors {
+		got = append(got, fe.Path)
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("errors at %v, want %v", got, want)
+	}
+	if !strings.HasPrefix(err.Error(), "invalid scenario: version: ") {
+		t.Errorf("error %q", err)
+	}
+}
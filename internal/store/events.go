@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// EventFilter selects audit events. Zero fields select everything.
+type EventFilter struct {
+	Tenant       string
+	SimulationID string
+	Action       string
+	Actor        string
+
+	// Since is inclusive, Until exclusive.
+	Since time.Time
+	Until time.Time
+
+	// Limit keeps only the latest Limit events.
+	Limit int
+}
+
+func (f *EventFilter) match(e *simulationpb.AuditEvent) bool {
+	t := e.GetTime().AsTime()
+	switch {
+	case f.Tenant != "" && e.GetTenant() != f.Tenant,
+		f.SimulationID != "" && e.GetSimulationId().GetValue() != f.SimulationID,
+		f.Action != "" && e.GetAction() != f.Action,
+		f.Actor != "" && e.GetActor() != f.Actor,
+		!f.Since.IsZero() && t.Before(f.Since),
+		!f.Until.IsZero() && !t.Before(f.Until):
+		return false
+	}
+	return true
+}
+
+// EventStore keeps the audit trail. It is append-only: events are never
+// changed or removed. Implementations hand out copies.
+type EventStore interface {
+	// AppendEvent adds e, setting its ID and, when unset, its time.
+	AppendEvent(ctx context.Context, e *simulationpb.AuditEvent) (*simulationpb.AuditEvent, error)
+	// ListEvents returns the events f selects, oldest first.
+	ListEvents(ctx context.Context, f EventFilter) ([]*simulationpb.AuditEvent, error)
+}
+
+var _ EventStore = (*MemoryStore)(nil)
+
+func (m *MemoryStore) AppendEvent(ctx context.Context, e *simulationpb.AuditEvent) (*simulationpb.AuditEvent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored := proto.Clone(e).(*simulationpb.AuditEvent)
+	stored.Id = uint64(len(m.events)) + 1
+	if stored.Time == nil {
+		stored.Time = timestamppb.Now()
+	}
+	m.events = append(m.events, stored)
+	return proto.Clone(stored).(*simulationpb.AuditEvent), nil
+}
+
+func (m *MemoryStore) ListEvents(ctx context.Context, f EventFilter) ([]*simulationpb.AuditEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Walk back from the latest event, so a limit stops the walk early.
+	var out []*simulationpb.AuditEvent
+	for i := len(m.events) - 1; i >= 0; i-- {
+		if f.Limit > 0 && len(out) == f.Limit {
+			break
+		}
+		if e := m.events[i]; f.match(e) {
+			out = append(out, proto.Clone(e).(*simulationpb.AuditEvent))
+		}
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out, nil
+}
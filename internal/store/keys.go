@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// APIKey is a credential of the API gateway. Only the SHA-256 hash of the
+// secret is kept; the secret itself is shown once, when the key is created.
+type APIKey struct {
+	ID   string
+	Name string
+
+	// Subject identifies who uses the key, e.g. a team or a CI pipeline.
+	Subject string
+
+	// Tenant is the tenant (project) the key acts for.
+	Tenant string
+
+	// Role is the RBAC role the key grants: "viewer", "operator" or
+	// "admin".
+	Role string
+
+	// Hash is the hex-encoded SHA-256 of the secret.
+	Hash string
+
+	CreatedAt time.Time
+
+	// ExpiresAt is zero for keys that do not expire.
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the key can no longer be used at now.
+func (k *APIKey) Expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// KeyStore keeps API keys. GetKey, ListKeys and DeleteKey only see the
+// keys of tenant; another tenant's key is not found. Implementations set
+// created_at when it is zero and hand out copies.
+type KeyStore interface {
+	CreateKey(ctx context.Context, k *APIKey) (*APIKey, error)
+	GetKey(ctx context.Context, tenant, id string) (*APIKey, error)
+	// GetKeyByHash finds the key whose secret hashes to hash, whatever its
+	// tenant.
+	GetKeyByHash(ctx context.Context, hash string) (*APIKey, error)
+	ListKeys(ctx context.Context, tenant string) ([]*APIKey, error)
+	DeleteKey(ctx context.Context, tenant, id string) error
+}
+
+var _ KeyStore = (*MemoryStore)(nil)
+
+// CreateKey adds a key. Both its ID and its hash must be new.
+func (m *MemoryStore) CreateKey(ctx context.Context, k *APIKey) (*APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.keys[k.ID]; ok {
+		return nil, fmt.Errorf("key %q: %w", k.ID, ErrExists)
+	}
+	if _, ok := m.keyIDs[k.Hash]; ok {
+		return nil, fmt.Errorf("key %q: secret: %w", k.ID, ErrExists)
+	}
+
+	stored := *k
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = time.Now().UTC()
+	}
+	m.keys[stored.ID] = &stored
+	m.keyIDs[stored.Hash] = stored.ID
+	out := stored
+	return &out, nil
+}
+
+func (m *MemoryStore) GetKey(ctx context.Context, tenant, id string) (*APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	k, ok := m.keys[id]
+	if !ok || k.Tenant != tenant {
+		return nil, fmt.Errorf("key %q: %w", id, ErrNotFound)
+	}
+	out := *k
+	return &out, nil
+}
+
+func (m *MemoryStore) GetKeyByHash(ctx context.Context, hash string) (*APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	id, ok := m.keyIDs[hash]
+	if !ok {
+		return nil, fmt.Errorf("key: %w", ErrNotFound)
+	}
+	out := *m.keys[id]
+	return &out, nil
+}
+
+// ListKeys returns every key of the tenant, ordered by ID.
+func (m *MemoryStore) ListKeys(ctx context.Context, tenant string) ([]*APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]*APIKey, 0)
+	for _, k := range m.keys {
+		if k.Tenant != tenant {
+			continue
+		}
+		c := *k
+		out = append(out, &c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (m *MemoryStore) DeleteKey(ctx context.Context, tenant, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k, ok := m.keys[id]
+	if !ok || k.Tenant != tenant {
+		return fmt.Errorf("key %q: %w", id, ErrNotFound)
+	}
+	delete(m.keyIDs, k.Hash)
+	delete(m.keys, id)
+	return nil
+}
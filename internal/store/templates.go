@@ -0,0 +1,141 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// Errors returned by TemplateStore and KeyStore implementations, wrapped
+// with the template name or key ID.
+var (
+	ErrNotFound = errors.New("not found")
+	ErrExists   = errors.New("already exists")
+)
+
+// TemplateStore keeps named simulation templates. Each tenant has its own
+// names; a template's tenant is its tenant field. Implementations set the
+// created_at and updated_at timestamps and hand out copies, so callers may
+// modify what they pass in and get back.
+type TemplateStore interface {
+	CreateTemplate(ctx context.Context, t *simulationpb.SimulationTemplate) (*simulationpb.SimulationTemplate, error)
+	GetTemplate(ctx context.Context, tenant, name string) (*simulationpb.SimulationTemplate, error)
+	ListTemplates(ctx context.Context, tenant string) ([]*simulationpb.SimulationTemplate, error)
+	UpdateTemplate(ctx context.Context, t *simulationpb.SimulationTemplate) (*simulationpb.SimulationTemplate, error)
+	DeleteTemplate(ctx context.Context, tenant, name string) error
+}
+
+// templateKey identifies a template in a MemoryStore.
+type templateKey struct {
+	tenant, name string
+}
+
+func keyOf(t *simulationpb.SimulationTemplate) templateKey {
+	return templateKey{tenant: t.GetTenant(), name: t.GetName()}
+}
+
+// MemoryStore is an in-memory TemplateStore, KeyStore and EventStore.
+// Everything is lost when the process exits.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	templates map[templateKey]*simulationpb.SimulationTemplate
+
+	// keys by ID; keyIDs maps a key's hash to its ID
+	keys   map[string]*APIKey
+	keyIDs map[string]string
+
+	// events is the audit trail, in the order the events were appended
+	events []*simulationpb.AuditEvent
+}
+
+var _ TemplateStore = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		templates: make(map[templateKey]*simulationpb.SimulationTemplate),
+		keys:      make(map[string]*APIKey),
+		keyIDs:    make(map[string]string),
+	}
+}
+
+func (m *MemoryStore) CreateTemplate(ctx context.Context, t *simulationpb.SimulationTemplate) (*simulationpb.SimulationTemplate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.templates[keyOf(t)]; ok {
+		return nil, fmt.Errorf("template %q: %w", t.GetName(), ErrExists)
+	}
+
+	stored := cloneTemplate(t)
+	stored.CreatedAt = timestamppb.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	m.templates[keyOf(stored)] = stored
+	return cloneTemplate(stored), nil
+}
+
+func (m *MemoryStore) GetTemplate(ctx context.Context, tenant, name string) (*simulationpb.SimulationTemplate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.templates[templateKey{tenant: tenant, name: name}]
+	if !ok {
+		return nil, fmt.Errorf("template %q: %w", name, ErrNotFound)
+	}
+	return cloneTemplate(t), nil
+}
+
+// ListTemplates returns every template of the tenant, ordered by name.
+func (m *MemoryStore) ListTemplates(ctx context.Context, tenant string) ([]*simulationpb.SimulationTemplate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := make([]*simulationpb.SimulationTemplate, 0)
+	for key, t := range m.templates {
+		if key.tenant == tenant {
+			out = append(out, cloneTemplate(t))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// UpdateTemplate replaces an existing template, keeping its created_at.
+func (m *MemoryStore) UpdateTemplate(ctx context.Context, t *simulationpb.SimulationTemplate) (*simulationpb.SimulationTemplate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.templates[keyOf(t)]
+	if !ok {
+		return nil, fmt.Errorf("template %q: %w", t.GetName(), ErrNotFound)
+	}
+
+	stored := cloneTemplate(t)
+	stored.CreatedAt = old.CreatedAt
+	stored.UpdatedAt = timestamppb.Now()
+	m.templates[keyOf(stored)] = stored
+	return cloneTemplate(stored), nil
+}
+
+func (m *MemoryStore) DeleteTemplate(ctx context.Context, tenant, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := templateKey{tenant: tenant, name: name}
+	if _, ok := m.templates[key]; !ok {
+		return fmt.Errorf("template %q: %w", name, ErrNotFound)
+	}
+	delete(m.templates, key)
+	return nil
+}
+
+func cloneTemplate(t *simulationpb.SimulationTemplate) *simulationpb.SimulationTemplate {
+	return proto.Clone(t).(*simulationpb.SimulationTemplate)
+}
@@ -0,0 +1,200 @@
+package tasks
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// Candidate is an idle entity that can be given a task.
+type Candidate struct {
+	EntityID uint64
+	Type     simulationpb.EntityType
+	X, Y     float64
+	Battery  float64
+
+	MaxSpeed        float64
+	PayloadCapacity float64
+}
+
+// Assignment pairs a task with a candidate, by index into the slices passed
+// to Allocate.
+type Assignment struct {
+	Task      int
+	Candidate int
+}
+
+// Allocator matches pending tasks to idle candidates. Each task and each
+// candidate appears in at most one assignment. Tasks are passed in
+// submission order; allocators serve higher priorities first.
+type Allocator interface {
+	Allocate(tasks []*simulationpb.Task, candidates []Candidate) []Assignment
+}
+
+// NewAllocator returns the allocator for strategy.
+func NewAllocator(strategy simulationpb.TaskAllocationStrategy) (Allocator, error) {
+	switch strategy {
+	case simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_UNSPECIFIED,
+		simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_GREEDY_NEAREST:
+		return GreedyNearest{}, nil
+	case simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_AUCTION:
+		return Auction{}, nil
+	case simulationpb.TaskAllocationStrategy_TASK_ALLOCATION_STRATEGY_HUNGARIAN:
+		return Hungarian{}, nil
+	default:
+		return nil, fmt.Errorf("unknown task_allocation %d", strategy)
+	}
+}
+
+// Cost is the travel time in ticks for c to reach the start of t. ok is
+// false if c cannot take t at all.
+func Cost(t *simulationpb.Task, c Candidate) (cost float64, ok bool) {
+	eligible := false
+	for _, et := range EligibleTypes(t) {
+		if et == c.Type {
+			eligible = true
+			break
+		}
+	}
+	if !eligible || c.MaxSpeed <= 0 {
+		return 0, false
+	}
+	if t.GetType() == simulationpb.TaskType_TASK_TYPE_TRANSPORT && t.GetLoad() > c.PayloadCapacity {
+		return 0, false
+	}
+
+	start := t.GetWaypoints()[0]
+	return math.Hypot(start.GetX()-c.X, start.GetY()-c.Y) / c.MaxSpeed, true
+}
+
+// GreedyNearest walks the tasks by priority and gives each one the nearest
+// (fastest to arrive) remaining candidate. It is cheap but can leave a later
+// task with a poor match.
+type GreedyNearest struct{}
+
+func (GreedyNearest) Allocate(tasks []*simulationpb.Task, candidates []Candidate) []Assignment {
+	taken := make([]bool, len(candidates))
+	var out []Assignment
+
+	for _, ti := range byPriority(tasks) {
+		best, bestCost := -1, math.Inf(1)
+		for ci, c := range candidates {
+			if taken[ci] {
+				continue
+			}
+			if cost, ok := Cost(tasks[ti], c); ok && cost < bestCost {
+				best, bestCost = ci, cost
+			}
+		}
+		if best >= 0 {
+			taken[best] = true
+			out = append(out, Assignment{Task: ti, Candidate: best})
+		}
+	}
+	return out
+}
+
+// Auction runs a contract-net style auction per priority level. Every open
+// task is announced; each unassigned candidate bids on the task it can do
+// most cheaply, and each task is awarded to its lowest bidder. Losing
+// candidates bid again on the tasks left, until no award is made. Bids
+// include a battery penalty so well-charged entities win close contests.
+type Auction struct{}
+
+func (Auction) Allocate(tasks []*simulationpb.Task, candidates []Candidate) []Assignment {
+	taken := make([]bool, len(candidates))
+	var out []Assignment
+
+	for _, level := range priorityLevels(tasks) {
+		open := make(map[int]bool, len(level))
+		for _, ti := range level {
+			open[ti] = true
+		}
+
+		for len(open) > 0 {
+			// Collect each free candidate's best bid.
+			type bid struct {
+				candidate int
+				amount    float64
+			}
+			best := make(map[int]bid)
+			for ci, c := range candidates {
+				if taken[ci] {
+					continue
+				}
+				target, amount := -1, math.Inf(1)
+				for _, ti := range level {
+					if !open[ti] {
+						continue
+					}
+					if cost, ok := Cost(tasks[ti], c); ok {
+						if b := bidAmount(cost, c); b < amount {
+							target, amount = ti, b
+						}
+					}
+				}
+				if target < 0 {
+					continue
+				}
+				if cur, ok := best[target]; !ok || amount < cur.amount {
+					best[target] = bid{candidate: ci, amount: amount}
+				}
+			}
+			if len(best) == 0 {
+				break
+			}
+
+			// Award in task order so the outcome is deterministic.
+			for _, ti := range level {
+				if b, ok := best[ti]; ok {
+					taken[b.candidate] = true
+					delete(open, ti)
+					out = append(out, Assignment{Task: ti, Candidate: b.candidate})
+				}
+			}
+		}
+	}
+	return out
+}
+
+// bidAmount scales the travel cost up as battery runs down, doubling it for
+// an entity at the minimum battery level.
+func bidAmount(cost float64, c Candidate) float64 {
+	spare := (c.Battery - minBattery) / (100 - minBattery)
+	if spare < 0 {
+		spare = 0
+	}
+	if spare > 1 {
+		spare = 1
+	}
+	return cost * (2 - spare)
+}
+
+// byPriority returns task indexes ordered by descending priority, keeping
+// submission order within a priority.
+func byPriority(tasks []*simulationpb.Task) []int {
+	idx := make([]int, len(tasks))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return tasks[idx[i]].GetPriority() > tasks[idx[j]].GetPriority()
+	})
+	return idx
+}
+
+// priorityLevels groups byPriority into runs of equal priority.
+func priorityLevels(tasks []*simulationpb.Task) [][]int {
+	var levels [][]int
+	for _, ti := range byPriority(tasks) {
+		n := len(levels)
+		if n > 0 && tasks[levels[n-1][0]].GetPriority() == tasks[ti].GetPriority() {
+			levels[n-1] = append(levels[n-1], ti)
+		} else {
+			levels = append(levels, []int{ti})
+		}
+	}
+	return levels
+}
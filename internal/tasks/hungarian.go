@@ -0,0 +1,155 @@
+package tasks
+
+import (
+	"math"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+// maxHungarianSize caps each side of the cost matrix. The solver is cubic,
+// so larger levels are trimmed: later tasks wait for the next allocation and
+// candidates beyond the cap sit this one out.
+const maxHungarianSize = 256
+
+// infeasible stands in for pairs that cannot be assigned. It is large enough
+// to never be preferred but small enough to keep the potentials finite.
+const infeasible = 1e12
+
+// Hungarian assigns each priority level so that the total travel cost is as
+// low as possible, using the Hungarian (Kuhn-Munkres) algorithm.
+type Hungarian struct{}
+
+func (Hungarian) Allocate(tasks []*simulationpb.Task, candidates []Candidate) []Assignment {
+	taken := make([]bool, len(candidates))
+	var out []Assignment
+
+	for _, level := range priorityLevels(tasks) {
+		var free []int
+		for ci := range candidates {
+			if !taken[ci] {
+				free = append(free, ci)
+			}
+		}
+		if len(free) == 0 {
+			break
+		}
+		if len(level) > maxHungarianSize {
+			level = level[:maxHungarianSize]
+		}
+		if len(free) > maxHungarianSize {
+			free = free[:maxHungarianSize]
+		}
+
+		cost := make([][]float64, len(level))
+		for r, ti := range level {
+			cost[r] = make([]float64, len(free))
+			for c, ci := range free {
+				if v, ok := Cost(tasks[ti], candidates[ci]); ok {
+					cost[r][c] = v
+				} else {
+					cost[r][c] = infeasible
+				}
+			}
+		}
+
+		for r, c := range solveAssignment(cost) {
+			if c < 0 || cost[r][c] >= infeasible {
+				continue
+			}
+			taken[free[c]] = true
+			out = append(out, Assignment{Task: level[r], Candidate: free[c]})
+		}
+	}
+	return out
+}
+
+// solveAssignment returns, for each row of the rectangular cost matrix, the
+// column it is assigned to (or -1), minimising the total cost.
+func solveAssignment(cost [][]float64) []int {
+	rows := len(cost)
+	if rows == 0 {
+		return nil
+	}
+	cols := len(cost[0])
+
+	// The solver below needs rows <= cols; transpose otherwise.
+	if rows > cols {
+		t := make([][]float64, cols)
+		for c := range t {
+			t[c] = make([]float64, rows)
+			for r := range cost {
+				t[c][r] = cost[r][c]
+			}
+		}
+		byCol := solveAssignment(t)
+		out := make([]int, rows)
+		for r := range out {
+			out[r] = -1
+		}
+		for c, r := range byCol {
+			if r >= 0 {
+				out[r] = c
+			}
+		}
+		return out
+	}
+
+	// Potentials u (rows) and v (columns), 1-indexed with a sentinel
+	// column 0; match[c] is the row matched to column c.
+	u := make([]float64, rows+1)
+	v := make([]float64, cols+1)
+	match := make([]int, cols+1)
+	way := make([]int, cols+1)
+
+	for r := 1; r <= rows; r++ {
+		match[0] = r
+		col := 0
+		minv := make([]float64, cols+1)
+		used := make([]bool, cols+1)
+		for c := range minv {
+			minv[c] = math.Inf(1)
+		}
+
+		for match[col] != 0 {
+			used[col] = true
+			row, delta, next := match[col], math.Inf(1), 0
+			for c := 1; c <= cols; c++ {
+				if used[c] {
+					continue
+				}
+				if cur := cost[row-1][c-1] - u[row] - v[c]; cur < minv[c] {
+					minv[c], way[c] = cur, col
+				}
+				if minv[c] < delta {
+					delta, next = minv[c], c
+				}
+			}
+			for c := 0; c <= cols; c++ {
+				if used[c] {
+					u[match[c]] += delta
+					v[c] -= delta
+				} else {
+					minv[c] -= delta
+				}
+			}
+			col = next
+		}
+
+		for col != 0 {
+			prev := way[col]
+			match[col] = match[prev]
+			col = prev
+		}
+	}
+
+	out := make([]int, rows)
+	for r := range out {
+		out[r] = -1
+	}
+	for c := 1; c <= cols; c++ {
+		if match[c] != 0 {
+			out[match[c]-1] = c - 1
+		}
+	}
+	return out
+}
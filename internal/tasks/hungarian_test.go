@@ -0,0 +1,137 @@
+package tasks
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bruteForceCost returns the least total cost of assigning every row of
+// cost (rows <= cols) to a distinct column, trying every assignment.
+func bruteForceCost(cost [][]float64) float64 {
+	used := make([]bool, len(cost[0]))
+	var best = math.Inf(1)
+	var try func(r int, total float64)
+	try = func(r int, total float64) {
+		if r == len(cost) {
+			best = min(best, total)
+			return
+		}
+		for c := range used {
+			if !used[c] {
+				used[c] = true
+				try(r+1, total+cost[r][c])
+				used[c] = false
+			}
+		}
+	}
+	try(0, 0)
+	return best
+}
+
+func TestSolveAssignment(t *testing.T) {
+	tests := []struct {
+		name string
+		cost [][]float64
+		want []int
+	}{
+		{
+			name: "empty",
+			cost: nil,
+			want: nil,
+		},
+		{
+			name: "greedy is not optimal",
+			cost: [][]float64{
+				{1, 2},
+				{1, 10},
+			},
+			want: []int{1, 0},
+		},
+		{
+			name: "more columns than rows",
+			cost: [][]float64{
+				{5, 1, 9},
+			},
+			want: []int{1},
+		},
+		{
+			name: "more rows than columns",
+			cost: [][]float64{
+				{4},
+				{2},
+				{3},
+			},
+			want: []int{-1, 0, -1},
+		},
+		{
+			name: "infeasible pairs avoided",
+			cost: [][]float64{
+				{infeasible, 7},
+				{3, infeasible},
+			},
+			want: []int{1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := solveAssignment(tt.cost)
+			if len(got) != len(tt.want) {
+				t.Fatalf("solveAssignment() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("solveAssignment() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestSolveAssignmentOptimal checks random matrices against every possible
+// assignment.
+func TestSolveAssignmentOptimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		rows, cols := 1+rng.Intn(6), 1+rng.Intn(6)
+		cost := make([][]float64, rows)
+		for r := range cost {
+			cost[r] = make([]float64, cols)
+			for c := range cost[r] {
+				cost[r][c] = float64(rng.Intn(100))
+			}
+		}
+
+		got := solveAssignment(cost)
+		total, seen := 0.0, map[int]bool{}
+		for r, c := range got {
+			if c < 0 {
+				continue
+			}
+			if seen[c] {
+				t.Fatalf("%v: column %d assigned twice in %v", cost, c, got)
+			}
+			seen[c] = true
+			total += cost[r][c]
+		}
+		if len(seen) != min(rows, cols) {
+			t.Fatalf("%v: %d pairs assigned in %v, want %d", cost, len(seen), got, min(rows, cols))
+		}
+
+		// Brute force the smaller side against the larger.
+		square := cost
+		if rows > cols {
+			square = make([][]float64, cols)
+			for c := range square {
+				square[c] = make([]float64, rows)
+				for r := range cost {
+					square[c][r] = cost[r][c]
+				}
+			}
+		}
+		if want := bruteForceCost(square); total != want {
+			t.Fatalf("%v: total cost %v of %v, want %v", cost, total, got, want)
+		}
+	}
+}
@@ -0,0 +1,395 @@
+// Package tasks queues harvest, patrol and transport jobs for a simulation
+// and assigns them to idle entities with a pluggable allocation strategy.
+package tasks
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stevenmed26/AutoFarm/internal/fleet"
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+	"github.com/stevenmed26/AutoFarm/internal/world"
+)
+
+const (
+	// MaxSubmit bounds how many tasks one SubmitTasks call may queue.
+	MaxSubmit = 1000
+
+	// DefaultWorkTicks is how long a harvest task works its cell when the
+	// task does not say.
+	DefaultWorkTicks = 10
+
+	// minBattery is the battery level below which an entity gets no new work.
+	minBattery = 30
+)
+
+// defaultEligible lists the entity types that can take each task type when
+// the task does not restrict them itself.
+var defaultEligible = map[simulationpb.TaskType][]simulationpb.EntityType{
+	simulationpb.TaskType_TASK_TYPE_HARVEST: {
+		simulationpb.EntityType_ENTITY_TYPE_HARVESTER,
+		simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED,
+	},
+	simulationpb.TaskType_TASK_TYPE_PATROL: {
+		simulationpb.EntityType_ENTITY_TYPE_DRONE,
+		simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED,
+		simulationpb.EntityType_ENTITY_TYPE_TRACTOR,
+	},
+	simulationpb.TaskType_TASK_TYPE_TRANSPORT: {
+		simulationpb.EntityType_ENTITY_TYPE_TRACTOR,
+		simulationpb.EntityType_ENTITY_TYPE_DRONE,
+		simulationpb.EntityType_ENTITY_TYPE_HARVESTER,
+		simulationpb.EntityType_ENTITY_TYPE_UNSPECIFIED,
+	},
+}
+
+// Queue is the task queue of one simulation. Tasks move PENDING -> ASSIGNED
+// -> COMPLETED; a failed task goes back to PENDING for another entity.
+type Queue struct {
+	mu        sync.Mutex
+	allocator Allocator
+	lastID    uint64
+	tasks     map[uint64]*simulationpb.Task
+	pending   []uint64          // submission order
+	busy      map[uint64]uint64 // entity id -> task id
+	completed int
+
+	latest     []*simulationpb.EntityState
+	latestTick uint64
+}
+
+// NewQueue creates an empty queue that allocates with strategy.
+func NewQueue(strategy simulationpb.TaskAllocationStrategy) (*Queue, error) {
+	a, err := NewAllocator(strategy)
+	if err != nil {
+		return nil, err
+	}
+	return &Queue{
+		allocator: a,
+		tasks:     make(map[uint64]*simulationpb.Task),
+		busy:      make(map[uint64]uint64),
+	}, nil
+}
+
+// FieldError is a problem with one field of a task.
+type FieldError struct {
+	// Field is the path of the field: relative to the task, e.g.
+	// "waypoints[1]", from Validate, and relative to the request, e.g.
+	// "tasks[2].waypoints[1]", from Submit.
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Submit validates and queues copies of tasks, returning them with ids and
+// state filled in. Either every task is queued or none is; a *FieldError
+// names the task refused.
+func (q *Queue) Submit(tasks []*simulationpb.Task, tick uint64) ([]*simulationpb.Task, error) {
+	if len(tasks) == 0 {
+		return nil, &FieldError{Field: "tasks", Message: "no tasks"}
+	}
+	if len(tasks) > MaxSubmit {
+		return nil, &FieldError{Field: "tasks", Message: fmt.Sprintf("cannot submit more than %d tasks at once", MaxSubmit)}
+	}
+	for i, t := range tasks {
+		if err := Validate(t); err != nil {
+			return nil, &FieldError{Field: fmt.Sprintf("tasks[%d].%s", i, err.Field), Message: err.Message}
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	out := make([]*simulationpb.Task, 0, len(tasks))
+	for _, t := range tasks {
+		queued := proto.Clone(t).(*simulationpb.Task)
+		q.lastID++
+		queued.TaskId = q.lastID
+		queued.State = simulationpb.TaskState_TASK_STATE_PENDING
+		queued.AssignedEntityId = 0
+		queued.CreatedTick = tick
+		queued.AssignedTick = 0
+		queued.CompletedTick = 0
+		queued.Attempts = 0
+		if queued.Type == simulationpb.TaskType_TASK_TYPE_HARVEST && queued.WorkTicks == 0 {
+			queued.WorkTicks = DefaultWorkTicks
+		}
+
+		q.tasks[queued.TaskId] = queued
+		q.pending = append(q.pending, queued.TaskId)
+		out = append(out, proto.Clone(queued).(*simulationpb.Task))
+	}
+	return out, nil
+}
+
+// List returns copies of the tasks in state, or of every task when state is
+// UNSPECIFIED, ordered by id.
+func (q *Queue) List(state simulationpb.TaskState) []*simulationpb.Task {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	out := make([]*simulationpb.Task, 0, len(q.tasks))
+	for _, t := range q.tasks {
+		if state == simulationpb.TaskState_TASK_STATE_UNSPECIFIED || t.State == state {
+			out = append(out, proto.Clone(t).(*simulationpb.Task))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].TaskId < out[j].TaskId })
+	return out
+}
+
+// AllCompleted reports whether tasks have been queued and every one of them
+// is completed.
+func (q *Queue) AllCompleted() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.tasks) > 0 && q.completed == len(q.tasks)
+}
+
+// Restore replaces the queue's tasks with copies of tasks, e.g. from a
+// snapshot of another run, and takes states as the latest observed entity
+// states at tick. Assigned tasks go back to pending: the entities that held
+// them start over without a task.
+func (q *Queue) Restore(tasks []*simulationpb.Task, states []*simulationpb.EntityState, tick uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.tasks = make(map[uint64]*simulationpb.Task, len(tasks))
+	q.pending = nil
+	q.busy = make(map[uint64]uint64)
+	q.completed = 0
+	q.lastID = 0
+
+	sorted := make([]*simulationpb.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].TaskId < sorted[j].TaskId })
+
+	for _, t := range sorted {
+		restored := proto.Clone(t).(*simulationpb.Task)
+		switch restored.State {
+		case simulationpb.TaskState_TASK_STATE_COMPLETED:
+			q.completed++
+		default:
+			restored.State = simulationpb.TaskState_TASK_STATE_PENDING
+			restored.AssignedEntityId = 0
+			restored.AssignedTick = 0
+			q.pending = append(q.pending, restored.TaskId)
+		}
+		q.tasks[restored.TaskId] = restored
+		if restored.TaskId > q.lastID {
+			q.lastID = restored.TaskId
+		}
+	}
+
+	q.latest, q.latestTick = states, tick
+}
+
+// Observe records the entity states of an aggregated tick. They are used for
+// the next allocation, and to requeue tasks whose completion was lost with a
+// skipped tick: an entity that reports no task after its assignment was
+// delivered no longer holds it.
+func (q *Queue) Observe(tick uint64, states []*simulationpb.EntityState) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if tick < q.latestTick {
+		return
+	}
+	q.latest, q.latestTick = states, tick
+
+	for _, st := range states {
+		taskID, ok := q.busy[st.GetEntityId()]
+		if !ok || st.GetTaskId() == taskID {
+			continue
+		}
+		if t := q.tasks[taskID]; t.AssignedTick <= tick {
+			q.requeue(t)
+		}
+	}
+}
+
+// Apply applies task events reported by workers and returns copies of the
+// tasks they completed. Events for tasks the entity no longer holds are
+// ignored.
+func (q *Queue) Apply(events []*simulationpb.TaskEvent) []*simulationpb.Task {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var completed []*simulationpb.Task
+	for _, ev := range events {
+		t, ok := q.tasks[ev.GetTaskId()]
+		if !ok || t.State != simulationpb.TaskState_TASK_STATE_ASSIGNED || t.AssignedEntityId != ev.GetEntityId() {
+			continue
+		}
+		switch ev.GetState() {
+		case simulationpb.TaskState_TASK_STATE_COMPLETED:
+			t.State = simulationpb.TaskState_TASK_STATE_COMPLETED
+			t.CompletedTick = ev.GetTick()
+			delete(q.busy, t.AssignedEntityId)
+			q.completed++
+			completed = append(completed, proto.Clone(t).(*simulationpb.Task))
+		case simulationpb.TaskState_TASK_STATE_FAILED:
+			q.requeue(t)
+		}
+	}
+	return completed
+}
+
+// Release returns the tasks held by entityIDs to the queue, e.g. when the
+// entities are retired.
+func (q *Queue) Release(entityIDs []uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, id := range entityIDs {
+		if taskID, ok := q.busy[id]; ok {
+			q.requeue(q.tasks[taskID])
+		}
+	}
+}
+
+// Allocate assigns pending tasks to idle entities among live, using the
+// latest observed entity states and the entity parameters in cfg. It returns
+// copies of the newly assigned tasks.
+func (q *Queue) Allocate(cfg *simulationpb.SimulationConfig, live []uint64, tick uint64) []*simulationpb.Task {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 || len(q.latest) == 0 {
+		return nil
+	}
+
+	var candidates []Candidate
+	for _, st := range q.latest {
+		id := st.GetEntityId()
+		if _, busy := q.busy[id]; busy || !contains(live, id) || !available(st) {
+			continue
+		}
+		p := fleet.Params(cfg, st.GetType())
+		candidates = append(candidates, Candidate{
+			EntityID:        id,
+			Type:            st.GetType(),
+			X:               st.GetX(),
+			Y:               st.GetY(),
+			Battery:         st.GetBattery(),
+			MaxSpeed:        p.GetMaxSpeed(),
+			PayloadCapacity: p.GetPayloadCapacity(),
+		})
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	pending := make([]*simulationpb.Task, len(q.pending))
+	for i, id := range q.pending {
+		pending[i] = q.tasks[id]
+	}
+
+	assignments := q.allocator.Allocate(pending, candidates)
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	assigned := make(map[uint64]bool, len(assignments))
+	out := make([]*simulationpb.Task, 0, len(assignments))
+	for _, a := range assignments {
+		t, c := pending[a.Task], candidates[a.Candidate]
+		t.State = simulationpb.TaskState_TASK_STATE_ASSIGNED
+		t.AssignedEntityId = c.EntityID
+		t.AssignedTick = tick
+		t.Attempts++
+		q.busy[c.EntityID] = t.TaskId
+		assigned[t.TaskId] = true
+		out = append(out, proto.Clone(t).(*simulationpb.Task))
+	}
+
+	remaining := q.pending[:0]
+	for _, id := range q.pending {
+		if !assigned[id] {
+			remaining = append(remaining, id)
+		}
+	}
+	q.pending = remaining
+
+	sort.Slice(out, func(i, j int) bool { return out[i].TaskId < out[j].TaskId })
+	return out
+}
+
+// requeue puts an assigned task back at the end of the pending list.
+func (q *Queue) requeue(t *simulationpb.Task) {
+	delete(q.busy, t.AssignedEntityId)
+	t.State = simulationpb.TaskState_TASK_STATE_PENDING
+	t.AssignedEntityId = 0
+	t.AssignedTick = 0
+	q.pending = append(q.pending, t.TaskId)
+}
+
+// Validate checks that t is a well-formed task, naming the field at fault.
+func Validate(t *simulationpb.Task) *FieldError {
+	n := len(t.GetWaypoints())
+	switch t.GetType() {
+	case simulationpb.TaskType_TASK_TYPE_HARVEST:
+		if n != 1 {
+			return &FieldError{Field: "waypoints", Message: "harvest task needs exactly one waypoint (the cell)"}
+		}
+	case simulationpb.TaskType_TASK_TYPE_PATROL:
+		if n == 0 {
+			return &FieldError{Field: "waypoints", Message: "patrol task needs at least one waypoint"}
+		}
+	case simulationpb.TaskType_TASK_TYPE_TRANSPORT:
+		if n != 2 {
+			return &FieldError{Field: "waypoints", Message: "transport task needs exactly two waypoints (pickup and depot)"}
+		}
+		if t.GetLoad() < 0 || math.IsNaN(t.GetLoad()) || math.IsInf(t.GetLoad(), 0) {
+			return &FieldError{Field: "load", Message: "must be a finite number >= 0"}
+		}
+	default:
+		return &FieldError{Field: "type", Message: fmt.Sprintf("unknown task type %s", t.GetType())}
+	}
+
+	for i, p := range t.GetWaypoints() {
+		if !inWorld(p.GetX()) || !inWorld(p.GetY()) {
+			return &FieldError{Field: fmt.Sprintf("waypoints[%d]", i), Message: fmt.Sprintf("must lie within [0, %d]", world.Size)}
+		}
+	}
+	for i, et := range t.GetEligibleTypes() {
+		if _, ok := simulationpb.EntityType_name[int32(et)]; !ok {
+			return &FieldError{Field: fmt.Sprintf("eligible_types[%d]", i), Message: fmt.Sprintf("unknown entity type %d", et)}
+		}
+	}
+	return nil
+}
+
+// EligibleTypes returns the entity types that may take t.
+func EligibleTypes(t *simulationpb.Task) []simulationpb.EntityType {
+	if len(t.GetEligibleTypes()) > 0 {
+		return t.GetEligibleTypes()
+	}
+	return defaultEligible[t.GetType()]
+}
+
+// available reports whether an entity in state st can take new work.
+func available(st *simulationpb.EntityState) bool {
+	switch st.GetStatus() {
+	case "disabled", "offline", "low_battery":
+		return false
+	}
+	return st.GetBattery() >= minBattery
+}
+
+func inWorld(v float64) bool {
+	return v >= 0 && v <= world.Size
+}
+
+// contains reports whether the sorted ids contain id.
+func contains(ids []uint64, id uint64) bool {
+	i := sort.Search(len(ids), func(i int) bool { return ids[i] >= id })
+	return i < len(ids) && ids[i] == id
+}
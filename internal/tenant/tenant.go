@@ -0,0 +1,129 @@
+// Package tenant identifies the tenant (project) a call acts for, and holds
+// the quotas tenants are kept to.
+//
+// The API gateway passes the caller's tenant to the orchestrator in gRPC
+// metadata; the orchestrator scopes every call to it.
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Default is the tenant of calls that name none, e.g. when the API gateway
+// runs without authentication.
+const Default = "default"
+
+// MetadataKey is the gRPC metadata key carrying the tenant.
+const MetadataKey = "x-autofarm-tenant"
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,62}$`)
+
+// Validate checks that name can be used as a tenant.
+func Validate(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid tenant %q: use up to 63 lowercase letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// NewOutgoingContext returns ctx with name as the tenant of the gRPC calls
+// made with it.
+func NewOutgoingContext(ctx context.Context, name string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, name)
+}
+
+// FromIncomingContext returns the tenant of a gRPC call, or Default if the
+// call names none. Servers must remove the tenant of calls from clients
+// they do not trust first.
+func FromIncomingContext(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MetadataKey)
+	switch len(values) {
+	case 0:
+		return Default, nil
+	case 1:
+		if err := Validate(values[0]); err != nil {
+			return "", err
+		}
+		return values[0], nil
+	}
+	return "", fmt.Errorf("%d tenants given; want one", len(values))
+}
+
+// Quota limits what a tenant may use. Zero fields are unlimited.
+type Quota struct {
+	// MaxRunningSimulations caps the tenant's running simulations,
+	// experiment runs included.
+	MaxRunningSimulations int `json:"max_running_simulations"`
+
+	// MaxEntities caps the entities of the tenant's created, running and
+	// paused simulations together.
+	MaxEntities int `json:"max_entities"`
+
+	// MinTickRateMs is the shortest tick interval the tenant may run at,
+	// speed multipliers included.
+	MinTickRateMs uint32 `json:"min_tick_rate_ms"`
+}
+
+// Quotas are the quotas of every tenant.
+type Quotas struct {
+	// Default applies to tenants without their own entry.
+	Default Quota `json:"default"`
+
+	// Tenants replace Default for the tenants they name.
+	Tenants map[string]Quota `json:"tenants"`
+}
+
+// LoadQuotas reads a quota file:
+//
+//	{
+//	  "default": {"max_running_simulations": 4, "max_entities": 5000, "min_tick_rate_ms": 20},
+//	  "tenants": {"research": {"max_running_simulations": 16, "max_entities": 50000}}
+//	}
+func LoadQuotas(path string) (*Quotas, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var q Quotas
+	if err := json.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := q.Default.validate(); err != nil {
+		return nil, fmt.Errorf("%s: default: %w", path, err)
+	}
+	for name, quota := range q.Tenants {
+		if err := Validate(name); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if err := quota.validate(); err != nil {
+			return nil, fmt.Errorf("%s: tenants.%s: %w", path, name, err)
+		}
+	}
+	return &q, nil
+}
+
+// For returns the quota of the named tenant. A nil Quotas is unlimited.
+func (q *Quotas) For(name string) Quota {
+	if q == nil {
+		return Quota{}
+	}
+	if quota, ok := q.Tenants[name]; ok {
+		return quota
+	}
+	return q.Default
+}
+
+func (q Quota) validate() error {
+	if q.MaxRunningSimulations < 0 || q.MaxEntities < 0 {
+		return errors.New("limits must be >= 0")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Package tracing sets up the OpenTelemetry traces of the services. Spans
+// go to an OTLP collector, or as JSON lines to stdout or a file for use
+// offline.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Environment variables Setup reads. The OTLP exporter and the sampler
+// also take the standard OTEL_EXPORTER_OTLP_* and OTEL_TRACES_SAMPLER*
+// variables.
+const (
+	// ExporterEnv picks the exporter: otlp, stdout or none (the default).
+	ExporterEnv = "OTEL_TRACES_EXPORTER"
+
+	// FileEnv makes the stdout exporter append to a file instead.
+	FileEnv = "TRACES_FILE"
+)
+
+// Setup installs the global tracer provider and W3C trace context
+// propagation for service. The returned function flushes and stops the
+// exporter. Without an exporter, spans are not recorded, but trace
+// context still passes through.
+func Setup(ctx context.Context, service string) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, err := newExporter(ctx, strings.ToLower(os.Getenv(ExporterEnv)))
+	if err != nil || exporter == nil {
+		return func(context.Context) error { return nil }, err
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", service)))
+	if err != nil {
+		return nil, fmt.Errorf("trace resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// newExporter returns the exporter named by name, or nil for none.
+func newExporter(ctx context.Context, name string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "otlp":
+		exp, err := otlptracegrpc.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("OTLP trace exporter: %w", err)
+		}
+		return exp, nil
+	case "stdout", "console":
+		var w io.Writer = os.Stdout
+		if path := os.Getenv(FileEnv); path != "" {
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", FileEnv, err)
+			}
+			w = f
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(w))
+	default:
+		return nil, fmt.Errorf("%s: unknown exporter %q: want otlp, stdout or none", ExporterEnv, name)
+	}
+}
+
+// Inject returns the trace context of ctx as a map, for messages that
+// carry it themselves, such as the ticks sent down a long-lived stream.
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns ctx carrying the trace context in m, from Inject.
+func Extract(ctx context.Context, m map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(m))
+}
@@ -0,0 +1,226 @@
+// Package world turns a simulation's world definition into the occupancy
+// grid entities navigate on.
+package world
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	simulationpb "github.com/stevenmed26/AutoFarm/internal/proto/simulationpb"
+)
+
+const (
+	// Size is the side of the square world, in world units.
+	Size = 100
+
+	// DefaultCellSize is used when a world definition leaves cell_size at 0.
+	DefaultCellSize = 1.0
+
+	// MinCellSize bounds the grid to 200x200 cells.
+	MinCellSize = 0.5
+
+	// MaxObstacles bounds how many obstacles a world definition may list.
+	MaxObstacles = 1000
+
+	// MaxTerrainPatches bounds how many terrain patches a world may list.
+	MaxTerrainPatches = 1000
+
+	// MaxTerrainCost is the highest movement cost a terrain patch may have.
+	MaxTerrainCost = 10.0
+)
+
+// Cell identifies a grid cell by column and row.
+type Cell struct {
+	Col, Row int
+}
+
+// Grid is the occupancy grid of a world, with the movement cost of each cell.
+// It is immutable once built and safe for concurrent use.
+type Grid struct {
+	cellSize   float64
+	cols, rows int
+	blocked    []bool
+	obstacles  int
+	costs      []float64 // nil when every cell costs 1
+}
+
+// Validate checks a world definition. A nil definition is an empty world.
+func Validate(def *simulationpb.WorldDefinition) error {
+	if def == nil {
+		return nil
+	}
+	if cs := def.GetCellSize(); cs != 0 && (cs < MinCellSize || cs > Size || math.IsNaN(cs)) {
+		return fmt.Errorf("world.cell_size must be between %g and %d", MinCellSize, Size)
+	}
+	if _, ok := simulationpb.PathAlgorithm_name[int32(def.GetPathAlgorithm())]; !ok {
+		return fmt.Errorf("unknown world.path_algorithm %d", def.GetPathAlgorithm())
+	}
+	if len(def.GetObstacles()) > MaxObstacles {
+		return fmt.Errorf("world: at most %d obstacles", MaxObstacles)
+	}
+	for i, o := range def.GetObstacles() {
+		if !inWorld(o.GetMinX()) || !inWorld(o.GetMinY()) || !inWorld(o.GetMaxX()) || !inWorld(o.GetMaxY()) {
+			return fmt.Errorf("world.obstacles[%d] must lie within [0, %d]", i, Size)
+		}
+		if o.GetMinX() >= o.GetMaxX() || o.GetMinY() >= o.GetMaxY() {
+			return fmt.Errorf("world.obstacles[%d] must have min < max", i)
+		}
+	}
+	if len(def.GetTerrain()) > MaxTerrainPatches {
+		return fmt.Errorf("world: at most %d terrain patches", MaxTerrainPatches)
+	}
+	for i, t := range def.GetTerrain() {
+		if !inWorld(t.GetMinX()) || !inWorld(t.GetMinY()) || !inWorld(t.GetMaxX()) || !inWorld(t.GetMaxY()) {
+			return fmt.Errorf("world.terrain[%d] must lie within [0, %d]", i, Size)
+		}
+		if t.GetMinX() >= t.GetMaxX() || t.GetMinY() >= t.GetMaxY() {
+			return fmt.Errorf("world.terrain[%d] must have min < max", i)
+		}
+		if c := t.GetCost(); !(c >= 1 && c <= MaxTerrainCost) {
+			return fmt.Errorf("world.terrain[%d].cost must be between 1 and %g", i, MaxTerrainCost)
+		}
+	}
+	return nil
+}
+
+// NewGrid builds the occupancy grid for def, which must have passed
+// Validate. A cell is blocked if any obstacle overlaps its interior, and
+// costs as much as the most expensive terrain patch overlapping it.
+func NewGrid(def *simulationpb.WorldDefinition) *Grid {
+	cs := def.GetCellSize()
+	if cs == 0 {
+		cs = DefaultCellSize
+	}
+	n := int(math.Ceil(Size / cs))
+
+	g := &Grid{
+		cellSize:  cs,
+		cols:      n,
+		rows:      n,
+		blocked:   make([]bool, n*n),
+		obstacles: len(def.GetObstacles()),
+	}
+
+	for _, o := range def.GetObstacles() {
+		g.cover(o.GetMinX(), o.GetMinY(), o.GetMaxX(), o.GetMaxY(), func(i int) {
+			g.blocked[i] = true
+		})
+	}
+	for _, t := range def.GetTerrain() {
+		if t.GetCost() == 1 {
+			continue
+		}
+		if g.costs == nil {
+			g.costs = make([]float64, n*n)
+			for i := range g.costs {
+				g.costs[i] = 1
+			}
+		}
+		g.cover(t.GetMinX(), t.GetMinY(), t.GetMaxX(), t.GetMaxY(), func(i int) {
+			g.costs[i] = math.Max(g.costs[i], t.GetCost())
+		})
+	}
+	return g
+}
+
+// cover calls fn with the index of every cell whose interior the rectangle
+// overlaps. A rectangle ending exactly on a cell boundary does not touch the
+// next cell.
+func (g *Grid) cover(minX, minY, maxX, maxY float64, fn func(i int)) {
+	c0, r0 := g.index(minX), g.index(minY)
+	c1, r1 := g.index(math.Nextafter(maxX, 0)), g.index(math.Nextafter(maxY, 0))
+	for r := r0; r <= r1; r++ {
+		for c := c0; c <= c1; c++ {
+			fn(r*g.cols + c)
+		}
+	}
+}
+
+// Empty reports whether the grid has no obstacles.
+func (g *Grid) Empty() bool {
+	return g.obstacles == 0
+}
+
+// Uniform reports whether every cell has movement cost 1.
+func (g *Grid) Uniform() bool {
+	return g.costs == nil
+}
+
+// Dims returns the number of columns and rows.
+func (g *Grid) Dims() (cols, rows int) {
+	return g.cols, g.rows
+}
+
+// CellSize returns the side of one cell in world units.
+func (g *Grid) CellSize() float64 {
+	return g.cellSize
+}
+
+// CellAt returns the cell containing the world point (x, y), clamped to the
+// grid.
+func (g *Grid) CellAt(x, y float64) Cell {
+	return Cell{Col: g.index(x), Row: g.index(y)}
+}
+
+// Center returns the world coordinates of the middle of c.
+func (g *Grid) Center(c Cell) (x, y float64) {
+	return (float64(c.Col) + 0.5) * g.cellSize, (float64(c.Row) + 0.5) * g.cellSize
+}
+
+// InBounds reports whether c is on the grid.
+func (g *Grid) InBounds(c Cell) bool {
+	return c.Col >= 0 && c.Col < g.cols && c.Row >= 0 && c.Row < g.rows
+}
+
+// Walkable reports whether c is on the grid and not blocked.
+func (g *Grid) Walkable(c Cell) bool {
+	return g.InBounds(c) && !g.blocked[c.Row*g.cols+c.Col]
+}
+
+// Cost returns the movement cost of c: 1 for open ground, more for terrain
+// that is slow to cross.
+func (g *Grid) Cost(c Cell) float64 {
+	if g.costs == nil || !g.InBounds(c) {
+		return 1
+	}
+	return g.costs[c.Row*g.cols+c.Col]
+}
+
+// CostAt returns the movement cost at the world point (x, y).
+func (g *Grid) CostAt(x, y float64) float64 {
+	return g.Cost(g.CellAt(x, y))
+}
+
+// BlockedAt reports whether the world point (x, y) lies in a blocked cell.
+// Points outside the world are not blocked; the world edge is handled
+// separately.
+func (g *Grid) BlockedAt(x, y float64) bool {
+	if !inWorld(x) || !inWorld(y) {
+		return false
+	}
+	return !g.Walkable(g.CellAt(x, y))
+}
+
+// ValidatePoint rejects world points that lie in a blocked cell.
+func (g *Grid) ValidatePoint(x, y float64) error {
+	if g.BlockedAt(x, y) {
+		return errors.New("point lies inside an obstacle")
+	}
+	return nil
+}
+
+func (g *Grid) index(v float64) int {
+	i := int(math.Floor(v / g.cellSize))
+	if i < 0 {
+		return 0
+	}
+	if i >= g.cols {
+		return g.cols - 1
+	}
+	return i
+}
+
+func inWorld(v float64) bool {
+	return v >= 0 && v <= Size
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiClient calls the REST API and records every call's latency and
+// outcome by operation.
+type apiClient struct {
+	base string
+	key  string
+	http *http.Client
+
+	mu    sync.Mutex
+	calls map[string]*callStats
+}
+
+// callStats are the calls of one operation. Errors are transport failures
+// and non-2xx responses.
+type callStats struct {
+	count     int
+	errors    int
+	latencies []float64 // ms
+}
+
+func newAPIClient(base, key string) *apiClient {
+	return &apiClient{
+		base:  strings.TrimRight(base, "/"),
+		key:   key,
+		http:  &http.Client{Timeout: 10 * time.Second},
+		calls: make(map[string]*callStats),
+	}
+}
+
+func (c *apiClient) createSimulation(ctx context.Context, name string, entities, tickRateMs uint32) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"name":         name,
+		"entities":     entities,
+		"tick_rate_ms": tickRateMs,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var sim struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(ctx, "create", http.MethodPost, "/simulations", body, &sim); err != nil {
+		return "", err
+	}
+	return sim.ID, nil
+}
+
+func (c *apiClient) startSimulation(ctx context.Context, id string) error {
+	return c.do(ctx, "start", http.MethodPost, "/simulations/"+id+"/start", nil, nil)
+}
+
+func (c *apiClient) stopSimulation(ctx context.Context, id string) error {
+	return c.do(ctx, "stop", http.MethodPost, "/simulations/"+id+"/stop", nil, nil)
+}
+
+// pollStatus gets the simulation's status every interval until ctx ends,
+// the way a dashboard would.
+func (c *apiClient) pollStatus(ctx context.Context, id string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// Errors are counted; the load goes on.
+		_ = c.do(ctx, "get", http.MethodGet, "/simulations/"+id, nil, nil)
+	}
+}
+
+// do makes one call and decodes a JSON response into out, if given.
+func (c *apiClient) do(ctx context.Context, op, method, path string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.key != "" {
+		req.Header.Set("Authorization", "Bearer "+c.key)
+	}
+
+	start := time.Now()
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// A call cut short by the end of the run is not an API error.
+		if ctx.Err() == nil {
+			c.record(op, time.Since(start), false)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	elapsed := time.Since(start)
+	if err == nil && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		err = fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if err == nil && out != nil {
+		err = json.Unmarshal(data, out)
+	}
+	c.record(op, elapsed, err == nil)
+	return err
+}
+
+func (c *apiClient) record(op string, elapsed time.Duration, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, found := c.calls[op]
+	if !found {
+		s = &callStats{}
+		c.calls[op] = s
+	}
+	s.count++
+	if !ok {
+		s.errors++
+	}
+	s.latencies = append(s.latencies, ms(elapsed))
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -1,8 +1,194 @@
+// Command loadtest drives a running AutoFarm stack through its public API:
+// it creates simulations over REST, subscribes to each over WebSocket and
+// reports end-to-end tick latency, dropped and skipped ticks and API error
+// rates as percentiles.
+//
+//	go run ./scripts/loadtest -sims 8 -entities 100,1000 -tick-rate-ms 50 -subscribers 4 -duration 60s
 package main
 
-import "fmt"
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type options struct {
+	api         string
+	key         string
+	sims        int
+	entities    []uint32
+	tickRates   []uint32
+	subscribers int
+	duration    time.Duration
+	warmup      time.Duration
+	poll        time.Duration
+	keep        bool
+	jsonOut     bool
+
+	// thresholds; zero disables them
+	maxP99Ms     float64
+	maxErrorRate float64
+	maxDropRate  float64
+}
 
-// main is a placeholder entrypoint for load testing AutoFarm.
 func main() {
-	fmt.Println("AutoFarm load test stub")
+	var o options
+	var entities, tickRates string
+	flag.StringVar(&o.api, "api", "http://localhost:8080", "API gateway base URL")
+	flag.StringVar(&o.key, "key", os.Getenv("AUTOFARM_API_KEY"), "API key or bearer token, if the API requires one (default $AUTOFARM_API_KEY)")
+	flag.IntVar(&o.sims, "sims", 4, "number of simulations to create")
+	flag.StringVar(&entities, "entities", "100", "entities per simulation; a comma-separated list is cycled through")
+	flag.StringVar(&tickRates, "tick-rate-ms", "50", "tick interval per simulation; a comma-separated list is cycled through")
+	flag.IntVar(&o.subscribers, "subscribers", 2, "WebSocket subscribers per simulation")
+	flag.DurationVar(&o.duration, "duration", 30*time.Second, "how long to measure")
+	flag.DurationVar(&o.warmup, "warmup", 2*time.Second, "ticks received this soon after start are not measured")
+	flag.DurationVar(&o.poll, "poll", time.Second, "interval of status requests per simulation (0 disables)")
+	flag.BoolVar(&o.keep, "keep", false, "leave the simulations running instead of stopping them")
+	flag.BoolVar(&o.jsonOut, "json", false, "print the report as JSON")
+	flag.Float64Var(&o.maxP99Ms, "max-p99-ms", 0, "exit 1 if the p99 tick latency exceeds this")
+	flag.Float64Var(&o.maxErrorRate, "max-error-rate", 0, "exit 1 if the API error rate exceeds this fraction")
+	flag.Float64Var(&o.maxDropRate, "max-drop-rate", 0, "exit 1 if the fraction of ticks dropped before reaching subscribers exceeds this")
+	flag.Parse()
+
+	var err error
+	if o.entities, err = parseList(entities); err != nil {
+		log.Fatalf("-entities: %v", err)
+	}
+	if o.tickRates, err = parseList(tickRates); err != nil {
+		log.Fatalf("-tick-rate-ms: %v", err)
+	}
+	if o.sims < 1 || o.subscribers < 1 {
+		log.Fatal("-sims and -subscribers must be >= 1")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	rep, err := run(ctx, o)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if o.jsonOut {
+		err = rep.writeJSON(os.Stdout)
+	} else {
+		err = rep.writeText(os.Stdout)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if failures := rep.check(o); len(failures) > 0 {
+		for _, f := range failures {
+			fmt.Fprintf(os.Stderr, "FAIL: %s\n", f)
+		}
+		os.Exit(1)
+	}
+}
+
+// run creates the simulations, subscribes to them, starts them, measures
+// for the configured duration and tears everything down.
+func run(ctx context.Context, o options) (*report, error) {
+	api := newAPIClient(o.api, o.key)
+	rec := newRecorder()
+
+	log.Printf("creating %d simulations", o.sims)
+	var ids []string
+	for i := 0; i < o.sims; i++ {
+		id, err := api.createSimulation(ctx, fmt.Sprintf("loadtest-%d", i),
+			o.entities[i%len(o.entities)], o.tickRates[i%len(o.tickRates)])
+		if err != nil {
+			log.Printf("create simulation %d: %v", i, err)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no simulation could be created")
+	}
+	defer func() {
+		if o.keep {
+			log.Printf("leaving %d simulations running", len(ids))
+			return
+		}
+		// Stop even after an interrupt.
+		stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		for _, id := range ids {
+			if err := api.stopSimulation(stopCtx, id); err != nil {
+				log.Printf("stop simulation %s: %v", id, err)
+			}
+		}
+	}()
+
+	// Subscribe before starting so the first ticks are seen.
+	log.Printf("opening %d WebSocket subscribers", len(ids)*o.subscribers)
+	subCtx, cancelSubs := context.WithCancel(ctx)
+	defer cancelSubs()
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		for k := 0; k < o.subscribers; k++ {
+			sub, err := dial(subCtx, o.api, o.key, id)
+			if err != nil {
+				rec.connectFailed()
+				log.Printf("subscribe to %s: %v", id, err)
+				continue
+			}
+			rec.connected()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sub.read(subCtx, rec)
+			}()
+		}
+	}
+
+	for _, id := range ids {
+		if err := api.startSimulation(ctx, id); err != nil {
+			log.Printf("start simulation %s: %v", id, err)
+		}
+	}
+	started := time.Now()
+	rec.measureFrom(started.Add(o.warmup))
+	log.Printf("running for %s (warmup %s)", o.duration, o.warmup)
+
+	if o.poll > 0 {
+		for _, id := range ids {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				api.pollStatus(subCtx, id, o.poll)
+			}(id)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		log.Print("interrupted; reporting what was measured")
+	case <-time.After(o.warmup + o.duration):
+	}
+	measured := time.Since(started) - o.warmup
+	cancelSubs()
+	wg.Wait()
+
+	return newReport(o, len(ids), measured, rec, api), nil
+}
+
+func parseList(s string) ([]uint32, error) {
+	var out []uint32
+	for _, f := range strings.Split(s, ",") {
+		v, err := strconv.ParseUint(strings.TrimSpace(f), 10, 32)
+		if err != nil || v == 0 {
+			return nil, fmt.Errorf("%q is not a number > 0", f)
+		}
+		out = append(out, uint32(v))
+	}
+	return out, nil
 }
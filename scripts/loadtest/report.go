@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// report is the outcome of a run.
+type report struct {
+	Config    reportConfig    `json:"config"`
+	Ticks     tickReport      `json:"ticks"`
+	LatencyMs percentiles     `json:"latency_ms"`
+	WebSocket websocketReport `json:"websocket"`
+	API       []apiReport     `json:"api"`
+
+	// APIErrorRate is the fraction of all API calls that failed.
+	APIErrorRate float64 `json:"api_error_rate"`
+}
+
+type reportConfig struct {
+	Simulations int      `json:"simulations"`
+	Entities    []uint32 `json:"entities"`
+	TickRateMs  []uint32 `json:"tick_rate_ms"`
+	Subscribers int      `json:"subscribers_per_simulation"`
+	DurationS   float64  `json:"duration_s"`
+	WarmupS     float64  `json:"warmup_s"`
+}
+
+// tickReport counts measured ticks across all subscribers. A tick is
+// dropped when a subscriber never saw it although it saw a later one;
+// skipped ticks were never run by the orchestrator (SKIP overrun policy).
+type tickReport struct {
+	Received  uint64  `json:"received"`
+	Measured  uint64  `json:"measured"`
+	PerSecond float64 `json:"per_second"`
+	Dropped   uint64  `json:"dropped"`
+	DropRate  float64 `json:"drop_rate"`
+	Skipped   uint64  `json:"skipped"`
+	Late      uint64  `json:"late"`
+	LateRate  float64 `json:"late_rate"`
+}
+
+type websocketReport struct {
+	Connected   int    `json:"connected"`
+	Failed      int    `json:"failed"`
+	Disconnects int    `json:"disconnects"`
+	BadMessages int    `json:"bad_messages"`
+	LastError   string `json:"last_error,omitempty"`
+}
+
+type apiReport struct {
+	Operation string      `json:"operation"`
+	Calls     int         `json:"calls"`
+	Errors    int         `json:"errors"`
+	ErrorRate float64     `json:"error_rate"`
+	LatencyMs percentiles `json:"latency_ms"`
+}
+
+type percentiles struct {
+	Samples int     `json:"samples"`
+	Mean    float64 `json:"mean"`
+	P50     float64 `json:"p50"`
+	P90     float64 `json:"p90"`
+	P95     float64 `json:"p95"`
+	P99     float64 `json:"p99"`
+	P999    float64 `json:"p99_9"`
+	Max     float64 `json:"max"`
+}
+
+func newReport(o options, sims int, measured time.Duration, rec *recorder, api *apiClient) *report {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rep := &report{
+		Config: reportConfig{
+			Simulations: sims,
+			Entities:    o.entities,
+			TickRateMs:  o.tickRates,
+			Subscribers: o.subscribers,
+			DurationS:   measured.Seconds(),
+			WarmupS:     o.warmup.Seconds(),
+		},
+		Ticks: tickReport{
+			Received: rec.received,
+			Measured: rec.measured,
+			Dropped:  rec.dropped,
+			DropRate: ratio(float64(rec.dropped), float64(rec.measured+rec.dropped)),
+			Skipped:  rec.skipped,
+			Late:     rec.late,
+			LateRate: ratio(float64(rec.late), float64(rec.measured)),
+		},
+		LatencyMs: summarize(rec.latencies),
+		WebSocket: websocketReport{
+			Connected:   rec.connects,
+			Failed:      rec.failedConnects,
+			Disconnects: rec.disconnects,
+			BadMessages: rec.badMessages,
+		},
+	}
+	if measured > 0 {
+		rep.Ticks.PerSecond = float64(rec.measured) / measured.Seconds()
+	}
+	if rec.lastDisconnect != nil {
+		rep.WebSocket.LastError = rec.lastDisconnect.Error()
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	var calls, errors int
+	for op, s := range api.calls {
+		rep.API = append(rep.API, apiReport{
+			Operation: op,
+			Calls:     s.count,
+			Errors:    s.errors,
+			ErrorRate: ratio(float64(s.errors), float64(s.count)),
+			LatencyMs: summarize(s.latencies),
+		})
+		calls += s.count
+		errors += s.errors
+	}
+	sort.Slice(rep.API, func(i, j int) bool { return rep.API[i].Operation < rep.API[j].Operation })
+	rep.APIErrorRate = ratio(float64(errors), float64(calls))
+	return rep
+}
+
+// check returns the thresholds the report breaks.
+func (r *report) check(o options) []string {
+	var failures []string
+	if o.maxP99Ms > 0 && r.LatencyMs.P99 > o.maxP99Ms {
+		failures = append(failures, fmt.Sprintf("p99 tick latency %.1fms > %gms", r.LatencyMs.P99, o.maxP99Ms))
+	}
+	if o.maxErrorRate > 0 && r.APIErrorRate > o.maxErrorRate {
+		failures = append(failures, fmt.Sprintf("API error rate %.4f > %g", r.APIErrorRate, o.maxErrorRate))
+	}
+	if o.maxDropRate > 0 && r.Ticks.DropRate > o.maxDropRate {
+		failures = append(failures, fmt.Sprintf("tick drop rate %.4f > %g", r.Ticks.DropRate, o.maxDropRate))
+	}
+	if r.Ticks.Measured == 0 {
+		failures = append(failures, "no ticks were measured")
+	}
+	return failures
+}
+
+func (r *report) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func (r *report) writeText(w io.Writer) error {
+	c := r.Config
+	fmt.Fprintf(w, "AutoFarm load test: %d simulations (entities %v, tick_rate_ms %v), %d subscribers each, %.1fs measured after %.1fs warmup\n\n",
+		c.Simulations, c.Entities, c.TickRateMs, c.Subscribers, c.DurationS, c.WarmupS)
+
+	t := r.Ticks
+	fmt.Fprintf(w, "Ticks: %d received, %d measured (%.1f/s); dropped %d (%.2f%%), skipped %d, late %d (%.2f%%)\n",
+		t.Received, t.Measured, t.PerSecond, t.Dropped, 100*t.DropRate, t.Skipped, t.Late, 100*t.LateRate)
+	ws := r.WebSocket
+	fmt.Fprintf(w, "WebSocket: %d connected, %d failed, %d disconnects, %d bad messages\n\n",
+		ws.Connected, ws.Failed, ws.Disconnects, ws.BadMessages)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "latency (ms)\tsamples\tmean\tp50\tp90\tp95\tp99\tp99.9\tmax\t")
+	writeRow(tw, "tick end-to-end", r.LatencyMs)
+	for _, a := range r.API {
+		writeRow(tw, "api "+a.Operation, a.LatencyMs)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "api\tcalls\terrors\terror rate\t")
+	for _, a := range r.API {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f%%\t\n", a.Operation, a.Calls, a.Errors, 100*a.ErrorRate)
+	}
+	fmt.Fprintf(tw, "all\t\t\t%.2f%%\t\n", 100*r.APIErrorRate)
+	return tw.Flush()
+}
+
+func writeRow(w io.Writer, name string, p percentiles) {
+	fmt.Fprintf(w, "%s\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t\n",
+		name, p.Samples, p.Mean, p.P50, p.P90, p.P95, p.P99, p.P999, p.Max)
+}
+
+// summarize computes nearest-rank percentiles of samples.
+func summarize(samples []float64) percentiles {
+	if len(samples) == 0 {
+		return percentiles{}
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	at := func(p float64) float64 {
+		i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		return sorted[max(i, 0)]
+	}
+	return percentiles{
+		Samples: len(sorted),
+		Mean:    sum / float64(len(sorted)),
+		P50:     at(50),
+		P90:     at(90),
+		P95:     at(95),
+		P99:     at(99),
+		P999:    at(99.9),
+		Max:     sorted[len(sorted)-1],
+	}
+}
+
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// tickMessage is the part of a dashboard update the load test reads.
+type tickMessage struct {
+	Tick         uint64    `json:"tick"`
+	CompletedAt  time.Time `json:"completed_at"`
+	SkippedTicks uint64    `json:"skipped_ticks"`
+	Late         bool      `json:"late"`
+}
+
+// subscriber is one WebSocket connection to a simulation's tick stream.
+type subscriber struct {
+	simID string
+	conn  *websocket.Conn
+}
+
+// dial opens a subscriber to simulation id through the API at base,
+// authenticating with key if it is set.
+func dial(ctx context.Context, base, key, id string) (*subscriber, error) {
+	u := strings.TrimRight(base, "/") + "/ws/simulations/" + id
+	switch {
+	case strings.HasPrefix(u, "https://"):
+		u = "wss://" + strings.TrimPrefix(u, "https://")
+	case strings.HasPrefix(u, "http://"):
+		u = "ws://" + strings.TrimPrefix(u, "http://")
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var header http.Header
+	if key != "" {
+		header = http.Header{"Authorization": {"Bearer " + key}}
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(dialCtx, u, header)
+	if err != nil {
+		return nil, err
+	}
+	return &subscriber{simID: id, conn: conn}, nil
+}
+
+// read records every tick received until ctx ends or the connection
+// fails. Latency is the time from the tick's completed_at, stamped by the
+// orchestrator, to its receipt here, so the two clocks must agree.
+func (s *subscriber) read(ctx context.Context, rec *recorder) {
+	go func() {
+		<-ctx.Done()
+		_ = s.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		s.conn.Close()
+	}()
+
+	var last uint64
+	for {
+		_, data, err := s.conn.ReadMessage()
+		received := time.Now()
+		if err != nil {
+			if ctx.Err() == nil {
+				rec.disconnected(fmt.Errorf("simulation %s: %w", s.simID, err))
+			}
+			return
+		}
+
+		var msg tickMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			rec.badMessage()
+			continue
+		}
+
+		var gap uint64
+		if last != 0 && msg.Tick > last+1 {
+			gap = msg.Tick - last - 1
+		}
+		if msg.Tick > last {
+			last = msg.Tick
+		}
+		rec.tick(received, received.Sub(msg.CompletedAt), gap, msg.SkippedTicks, msg.Late)
+	}
+}
+
+// recorder collects what every subscriber observed.
+type recorder struct {
+	mu sync.Mutex
+
+	// ticks received before from are not measured
+	from time.Time
+
+	received  uint64
+	measured  uint64
+	latencies []float64 // ms
+	dropped   uint64    // tick numbers a subscriber never saw
+	skipped   uint64    // tick slots the orchestrator skipped
+	late      uint64
+
+	connects       int
+	failedConnects int
+	disconnects    int
+	lastDisconnect error
+	badMessages    int
+}
+
+func newRecorder() *recorder {
+	return &recorder{}
+}
+
+func (r *recorder) measureFrom(t time.Time) {
+	r.mu.Lock()
+	r.from = t
+	r.mu.Unlock()
+}
+
+func (r *recorder) tick(at time.Time, latency time.Duration, gap, skipped uint64, late bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.received++
+	if r.from.IsZero() || at.Before(r.from) {
+		return
+	}
+	r.measured++
+	r.latencies = append(r.latencies, ms(latency))
+	r.dropped += gap
+	r.skipped += skipped
+	if late {
+		r.late++
+	}
+}
+
+func (r *recorder) connected() {
+	r.mu.Lock()
+	r.connects++
+	r.mu.Unlock()
+}
+
+func (r *recorder) connectFailed() {
+	r.mu.Lock()
+	r.failedConnects++
+	r.mu.Unlock()
+}
+
+func (r *recorder) disconnected(err error) {
+	r.mu.Lock()
+	r.disconnects++
+	r.lastDisconnect = err
+	r.mu.Unlock()
+}
+
+func (r *recorder) badMessage() {
+	r.mu.Lock()
+	r.badMessages++
+	r.mu.Unlock()
+}
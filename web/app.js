@@ -12,6 +12,12 @@ const metricTick = document.getElementById("metric-tick");
 const metricLatency = document.getElementById("metric-latency");
 const metricCompute = document.getElementById("metric-compute");
 
+const apiKeyInput = document.getElementById("api-key");
+apiKeyInput.value = localStorage.getItem("autofarm-api-key") || "";
+apiKeyInput.addEventListener("change", () => {
+  localStorage.setItem("autofarm-api-key", apiKeyInput.value.trim());
+});
+
 const canvas = document.getElementById("canvas");
 const ctx = canvas.getContext("2d");
 
@@ -31,7 +37,7 @@ btnStart.addEventListener("click", async () => {
     // Create simulation via REST
     const res = await fetch("/simulations", {
       method: "POST",
-      headers: { "Content-Type": "application/json" },
+      headers: { "Content-Type": "application/json", ...authHeaders() },
       body: JSON.stringify({
         name,
         entities,
@@ -41,8 +47,7 @@ btnStart.addEventListener("click", async () => {
     });
 
     if (!res.ok) {
-      const text = await res.text();
-      setStatus("Failed to create simulation: " + text, "error");
+      setStatus("Failed to create simulation: " + (await errorMessage(res)), "error");
       return;
     }
 
@@ -56,11 +61,11 @@ btnStart.addEventListener("click", async () => {
     // Start simulation
     const startRes = await fetch(`/simulations/${currentSimId}/start`, {
       method: "POST",
+      headers: authHeaders(),
     });
 
     if (!startRes.ok) {
-      const text = await startRes.text();
-      setStatus("Failed to start simulation: " + text, "error");
+      setStatus("Failed to start simulation: " + (await errorMessage(startRes)), "error");
       return;
     }
 
@@ -82,7 +87,12 @@ function openWebSocket(simId) {
 
   const proto = window.location.protocol === "https:" ? "wss" : "ws";
   const host = window.location.host;
-  const url = `${proto}://${host}/ws/simulations/${encodeURIComponent(simId)}`;
+  let url = `${proto}://${host}/ws/simulations/${encodeURIComponent(simId)}`;
+  // Browsers cannot set headers on WebSockets, so the key goes in the query.
+  const key = apiKeyInput.value.trim();
+  if (key) {
+    url += `?access_token=${encodeURIComponent(key)}`;
+  }
 
   ws = new WebSocket(url);
   wsStatusEl.textContent = "Connecting...";
@@ -115,7 +125,7 @@ function openWebSocket(simId) {
 
     // update shape:
     // {
-    //   simulation_id, tick, entities: [{id,x,y,vx,vy,battery,status}],
+    //   simulation_id, tick, entities: [{id,x,y,vx,vy,battery,status,type}],
     //   avg_compute_ms, worker_count, completed_at
     // }
 
@@ -135,6 +145,22 @@ function openWebSocket(simId) {
   };
 }
 
+// authHeaders returns the Authorization header for the entered API key.
+function authHeaders() {
+  const key = apiKeyInput.value.trim();
+  return key ? { Authorization: `Bearer ${key}` } : {};
+}
+
+// errorMessage returns the message of a failed response's JSON error body.
+async function errorMessage(res) {
+  const text = await res.text();
+  try {
+    return JSON.parse(text).error.message;
+  } catch {
+    return text;
+  }
+}
+
 function setStatus(msg, type) {
   statusEl.textContent = msg;
   statusEl.style.color = {
@@ -175,12 +201,19 @@ function renderFrame(update) {
       color = "#22c55e"; // normal
     }
 
-    drawEntity(ctx, x, y, color);
+    drawEntity(ctx, x, y, color, ENTITY_RADIUS[e.type] ?? 6);
   }
 }
 
-function drawEntity(ctx, x, y, color) {
-  const r = 6;
+// Marker size per entity type, so mixed fleets are easy to tell apart.
+const ENTITY_RADIUS = {
+  drone: 4,
+  robot: 6,
+  tractor: 7,
+  harvester: 9,
+};
+
+function drawEntity(ctx, x, y, color, r) {
   ctx.beginPath();
   ctx.arc(x, y, r, 0, Math.PI * 2);
   ctx.fillStyle = color;